@@ -53,10 +53,13 @@ type BulkCommandFlags struct {
 	IncludeSubmodules bool
 	Include           string
 	Exclude           string
+	Select            string
+	Groups            []string
 	Format            string
 	Watch             bool
 	Interval          time.Duration
 	SkipFetch         bool
+	ViaDaemon         bool
 }
 
 // BulkFlagOptions allows customizing which bulk flags are registered.
@@ -82,6 +85,10 @@ func addBulkFlagsWithOpts(cmd *cobra.Command, flags *BulkCommandFlags, opts Bulk
 	}
 	if !opts.SkipInclude {
 		cmd.Flags().StringVar(&flags.Include, "include", "", "regex pattern to include repositories")
+		// --select and --group narrow the include pattern, so they come with it.
+		cmd.Flags().StringVar(&flags.Select, "select", "", "select repositories by label, name or path (e.g. 'label=backend,!archived')")
+		cmd.Flags().StringSliceVar(&flags.Groups, "group", nil, "select repositories in these groups of the workspace config")
+		addSelectionHook(cmd, flags)
 	}
 	if !opts.SkipExclude {
 		cmd.Flags().StringVar(&flags.Exclude, "exclude", "", "regex pattern to exclude repositories")
@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+// addSelectionHook resolves --select and --group before the command runs.
+// The selected repositories become an exact --include pattern, so every bulk
+// operation honors them without knowing about labels.
+func addSelectionHook(cmd *cobra.Command, flags *BulkCommandFlags) {
+	prev, prevE := cmd.PreRun, cmd.PreRunE
+	cmd.PreRun = nil
+	cmd.PreRunE = func(c *cobra.Command, args []string) error {
+		switch {
+		case prevE != nil:
+			if err := prevE(c, args); err != nil {
+				return err
+			}
+		case prev != nil:
+			prev(c, args)
+		}
+		return applyBulkSelection(c, args, flags)
+	}
+}
+
+// applyBulkSelection narrows flags.Include to the repositories under the
+// command's directory that match --select and are in a --group.
+func applyBulkSelection(cmd *cobra.Command, args []string, flags *BulkCommandFlags) error {
+	if flags.Select == "" && len(flags.Groups) == 0 {
+		return nil
+	}
+
+	var sel *config.Selector
+	if flags.Select != "" {
+		var err error
+		if sel, err = config.ParseSelector(flags.Select); err != nil {
+			return err
+		}
+	}
+
+	directory := selectionDirectory(cmd, args)
+	configPath, err := config.DetectConfigFile(directory)
+	if err != nil {
+		return fmt.Errorf("--select and --group use the labels and groups of a workspace config: %w", err)
+	}
+	selection, err := config.LoadSelection(configPath)
+	if err != nil {
+		return err
+	}
+
+	scan, err := repository.NewClient().ScanRepositories(cmd.Context(), repository.ScanOptions{
+		Directory:         directory,
+		MaxDepth:          flags.Depth,
+		IncludeSubmodules: flags.IncludeSubmodules,
+		IncludePattern:    flags.Include,
+		ExcludePattern:    flags.Exclude,
+	})
+	if err != nil {
+		return err
+	}
+	selected, err := selection.Filter(scan.Paths, sel, flags.Groups)
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		return fmt.Errorf("no repositories under %s match %s", directory, describeSelection(flags))
+	}
+
+	quoted := make([]string, len(selected))
+	for i, p := range selected {
+		quoted[i] = regexp.QuoteMeta(p)
+	}
+	flags.Include = "^(?:" + strings.Join(quoted, "|") + ")$"
+	return nil
+}
+
+// selectionDirectory is the directory a bulk command works on: its last
+// positional argument that is a directory (commands such as switch and
+// exec take other arguments first), else the current directory.
+func selectionDirectory(cmd *cobra.Command, args []string) string {
+	if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+		args = args[:dash]
+	}
+	for i := len(args) - 1; i >= 0; i-- {
+		if info, err := os.Stat(args[i]); err == nil && info.IsDir() {
+			return args[i]
+		}
+	}
+	return "."
+}
+
+func describeSelection(flags *BulkCommandFlags) string {
+	var parts []string
+	if flags.Select != "" {
+		parts = append(parts, fmt.Sprintf("--select %q", flags.Select))
+	}
+	if len(flags.Groups) > 0 {
+		parts = append(parts, "--group "+strings.Join(flags.Groups, ","))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestApplyBulkSelection(t *testing.T) {
+	parent := t.TempDir()
+	t.Setenv("HOME", parent)
+	for _, name := range []string{"api", "web", "legacy"} {
+		runGit(t, parent, "init", "--quiet", name)
+	}
+	cfg := `repositories:
+  - url: https://github.com/org/api.git
+    labels: [backend]
+  - url: https://github.com/org/web.git
+    labels: [frontend]
+  - url: https://github.com/org/legacy.git
+    labels: [backend, archived]
+groups:
+  core: [api, web]
+`
+	if err := os.WriteFile(filepath.Join(parent, ".gz-git.yaml"), []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(t.Context())
+	selected := func(flags BulkCommandFlags, args ...string) ([]string, error) {
+		t.Helper()
+		flags.Depth = 1
+		if err := applyBulkSelection(cmd, args, &flags); err != nil {
+			return nil, err
+		}
+		include := regexp.MustCompile(flags.Include)
+		var names []string
+		for _, name := range []string{"api", "web", "legacy"} {
+			if include.MatchString(filepath.Join(parent, name)) {
+				names = append(names, name)
+			}
+		}
+		return names, nil
+	}
+
+	for _, tc := range []struct {
+		flags BulkCommandFlags
+		want  string
+	}{
+		{BulkCommandFlags{Select: "label=backend,!archived"}, "api"},
+		{BulkCommandFlags{Groups: []string{"core"}}, "api web"},
+		{BulkCommandFlags{Groups: []string{"core"}, Exclude: "web"}, "api"},
+		{BulkCommandFlags{Select: "!frontend"}, "api legacy"},
+	} {
+		// The directory may follow other arguments, as in 'switch <branch> [dir]'.
+		got, err := selected(tc.flags, "main", parent)
+		if err != nil {
+			t.Errorf("%+v: %v", tc.flags, err)
+			continue
+		}
+		if strings.Join(got, " ") != tc.want {
+			t.Errorf("%+v selected %v, want %s", tc.flags, got, tc.want)
+		}
+	}
+
+	if _, err := selected(BulkCommandFlags{Select: "label=mobile"}, parent); err == nil || !strings.Contains(err.Error(), "no repositories under") {
+		t.Errorf("empty selection: err = %v", err)
+	}
+	if _, err := selected(BulkCommandFlags{Groups: []string{"nope"}}, parent); err == nil || !strings.Contains(err.Error(), `unknown group "nope"`) {
+		t.Errorf("unknown group: err = %v", err)
+	}
+
+	// Without --select or --group nothing is resolved.
+	flags := BulkCommandFlags{Include: "keep"}
+	if err := applyBulkSelection(cmd, nil, &flags); err != nil || flags.Include != "keep" {
+		t.Errorf("no selection: include = %q, err = %v", flags.Include, err)
+	}
+}
+
+func TestBulkCommandsTakeSelectAndGroup(t *testing.T) {
+	for _, c := range []*cobra.Command{statusCmd, fetchCmd, pullCmd, pushCmd, execCmd, switchCmd} {
+		if c.Flags().Lookup("select") == nil || c.Flags().Lookup("group") == nil {
+			t.Errorf("%s lacks --select/--group", c.Name())
+		}
+		if c.PreRunE == nil {
+			t.Errorf("%s does not resolve the selection before running", c.Name())
+		}
+	}
+	if cloneCmd.Flags().Lookup("select") != nil {
+		t.Error("clone scans nothing, so it should not take --select")
+	}
+}
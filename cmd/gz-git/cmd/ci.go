@@ -0,0 +1,24 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+)
+
+var ciCmd = &cobra.Command{
+	Use:   "ci",
+	Short: "Inspect forge CI pipelines across repositories",
+	Long: cliutil.QuickStartHelp(`  # Which repositories have a red default branch?
+  gz-git ci status -d 2 ~/work
+
+  # Agent-friendly output
+  gz-git ci status --format llm`),
+}
+
+func init() {
+	rootCmd.AddCommand(ciCmd)
+}
@@ -0,0 +1,428 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/gizzahub/gzh-cli-gitforge/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/ci"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/provider"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/reposynccli"
+)
+
+var (
+	ciStatusFlags    BulkCommandFlags
+	ciStatusRefs     []string
+	ciStatusProvider string
+	ciStatusToken    string
+)
+
+var ciStatusCmd = &cobra.Command{
+	Use:   "status [directory]",
+	Short: "Show CI status for HEAD, upstream and default branch",
+	Long: cliutil.QuickStartHelp(`  # Every repository one level down
+  gz-git ci status
+
+  # Only the default branches of a workspace
+  gz-git ci status -d 2 --refs default ~/work
+
+  # Machine-readable, one entry per repository
+  gz-git ci status --format json | jq '.repositories[] | select(.state == "failure")'
+
+Exit Codes:
+  0  no tracked branch is failing
+  1  at least one tracked branch is failing
+  2  the check could not run, or a forge could not be queried
+
+HEAD is looked up by commit SHA, so an unpushed commit reports "none". The
+upstream and default branch are looked up by name, so they reflect the tip on
+the forge rather than what this clone last fetched.`),
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCIStatus,
+}
+
+func init() {
+	ciCmd.AddCommand(ciStatusCmd)
+
+	addBulkFlagsWithOpts(ciStatusCmd, &ciStatusFlags, BulkFlagOptions{
+		SkipDryRun: true,
+		SkipFetch:  true,
+		SkipWatch:  true,
+	})
+	ciStatusCmd.Flags().StringSliceVar(&ciStatusRefs, "refs", ci.AllKinds, "refs to check: head, upstream, default")
+	ciStatusCmd.Flags().StringVar(&ciStatusProvider, "provider", "", "force provider: github, gitlab, or gitea")
+	ciStatusCmd.Flags().StringVar(&ciStatusToken, "token", "", "forge API token")
+}
+
+func runCIStatus(cmd *cobra.Command, args []string) error {
+	ctx := cmdContext(cmd)
+
+	directory, err := validateBulkDirectory(args)
+	if err != nil {
+		return cliutil.NewExitError(2, err)
+	}
+	if err := validateBulkDepth(cmd, ciStatusFlags.Depth); err != nil {
+		return cliutil.NewExitError(2, err)
+	}
+	if err := validateBulkFormat(ciStatusFlags.Format); err != nil {
+		return cliutil.NewExitError(2, err)
+	}
+	if err := validateCIKinds(ciStatusRefs); err != nil {
+		return cliutil.NewExitError(2, err)
+	}
+
+	opts := ciQueryOptions{
+		Kinds:    ciStatusRefs,
+		Provider: ciStatusProvider,
+		Token:    ciStatusToken,
+		Parallel: ciStatusFlags.Parallel,
+	}
+	if effective, _ := LoadEffectiveConfig(cmd, map[string]any{
+		"provider": ciStatusProvider,
+		"token":    ciStatusToken,
+	}); effective != nil {
+		opts.applyEffective(effective)
+		if !cmd.Flags().Changed("parallel") && effective.Parallel > 0 {
+			opts.Parallel = effective.Parallel
+		}
+	}
+
+	if shouldShowProgress(ciStatusFlags.Format, quiet) {
+		printScanningMessage(directory, ciStatusFlags.Depth, opts.Parallel, false)
+	}
+
+	client := repository.NewClient()
+	result, err := client.BulkStatus(ctx, repository.BulkStatusOptions{
+		Directory:         directory,
+		Parallel:          opts.Parallel,
+		MaxDepth:          ciStatusFlags.Depth,
+		IncludeSubmodules: ciStatusFlags.IncludeSubmodules,
+		IncludePattern:    ciStatusFlags.Include,
+		ExcludePattern:    ciStatusFlags.Exclude,
+		Verbose:           verbose,
+		Logger:            createBulkLogger(verbose),
+	})
+	if err != nil {
+		return cliutil.NewExitError(2, fmt.Errorf("scan failed: %w", err))
+	}
+
+	reports := collectCIReports(ctx, client, result.Repositories, nil, opts)
+	out := buildCIStatusOutput(result, reports)
+
+	switch ciStatusFlags.Format {
+	case "json", "llm":
+		writeBulkOutput(ciStatusFlags.Format, out)
+	default:
+		if !quiet {
+			renderCIStatus(cmd.OutOrStdout(), out, ciStatusFlags.Format == "compact")
+		}
+	}
+
+	switch {
+	case out.Summary.Failure > 0:
+		// The report above names the red branches; the error only carries
+		// the exit code.
+		return cliutil.NewExitError(1, fmt.Errorf("%d of %d repositories have failing CI", out.Summary.Failure, len(out.Repositories)))
+	case out.Summary.Errors > 0:
+		return cliutil.NewExitError(2, fmt.Errorf("%d of %d repositories could not be checked", out.Summary.Errors, len(out.Repositories)))
+	}
+	return nil
+}
+
+func validateCIKinds(kinds []string) error {
+	for _, k := range kinds {
+		switch k {
+		case ci.KindHead, ci.KindUpstream, ci.KindDefault:
+		default:
+			return fmt.Errorf("invalid ref kind %q (allowed: %s)", k, strings.Join(ci.AllKinds, ", "))
+		}
+	}
+	return nil
+}
+
+// ciQueryOptions is what collectCIReports needs from flags and config. It is
+// shared by `ci status` and `info --ci` so both route tokens the same way.
+type ciQueryOptions struct {
+	Kinds          []string
+	Provider       string
+	Token          string
+	FallbackToken  string
+	BaseURL        string
+	BaseCandidates []string
+	Parallel       int
+}
+
+func (o *ciQueryOptions) applyEffective(effective *config.EffectiveConfig) {
+	if o.Provider == "" {
+		o.Provider = effective.Provider
+	}
+	// The profile token is a fallback, not an override: a credential routed
+	// to the repository's host must still win over it.
+	o.FallbackToken = effective.Token
+	o.BaseURL = effective.BaseURL
+	if len(o.BaseCandidates) == 0 {
+		o.BaseCandidates = effective.Branch.DefaultBranch
+	}
+}
+
+// collectCIReports asks the forges about every scanned repository, keyed by
+// absolute path. bases maps a repository path to its already-resolved default
+// branch; repositories missing from it are resolved here.
+func collectCIReports(
+	ctx context.Context,
+	client repository.Client,
+	repos []repository.RepositoryStatusResult,
+	bases map[string]string,
+	opts ciQueryOptions,
+) map[string]ci.Report {
+	readers := newCIReaderCache(opts)
+	executor := gitcmd.NewExecutor()
+	reports := make([]ci.Report, len(repos))
+
+	parallel := opts.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(parallel)
+	for i := range repos {
+		i := i
+		g.Go(func() error {
+			reports[i] = checkRepoCI(gctx, client, executor, readers, repos[i], bases, opts)
+			return nil // the error lives in the report
+		})
+	}
+	_ = g.Wait()
+
+	byPath := make(map[string]ci.Report, len(repos))
+	for i, repo := range repos {
+		byPath[repo.Path] = reports[i]
+	}
+	return byPath
+}
+
+func checkRepoCI(
+	ctx context.Context,
+	client repository.Client,
+	executor *gitcmd.Executor,
+	readers *ciReaderCache,
+	status repository.RepositoryStatusResult,
+	bases map[string]string,
+	opts ciQueryOptions,
+) ci.Report {
+	report := ci.Report{Path: status.Path, RelativePath: status.RelativePath, State: provider.CIStateNone}
+	if report.RelativePath == "" {
+		report.RelativePath = status.Path
+	}
+	if status.Error != nil {
+		report.Error = status.Error.Error()
+		return report
+	}
+	if status.RemoteURL == "" {
+		// Nothing to ask; a repository without a remote has no CI to be red.
+		return report
+	}
+
+	base, ok := bases[status.Path]
+	if !ok {
+		repo, err := client.Open(ctx, status.Path)
+		if err != nil {
+			report.Error = err.Error()
+			return report
+		}
+		if info, err := client.ResolveBase(ctx, repo, opts.BaseCandidates); err == nil {
+			base = info.Name
+		}
+	}
+	// The scan keeps the abbreviated SHA for display; forges that filter by
+	// commit need the full object name.
+	headSHA, _ := executor.RunOutput(ctx, status.Path, "rev-parse", "--verify", "-q", "HEAD")
+
+	checked := ci.Check(ctx, readers.get, ci.Targets(status, strings.TrimSpace(headSHA), base, opts.Kinds))
+	checked.Path = report.Path
+	checked.RelativePath = report.RelativePath
+	return checked
+}
+
+// ciReaderCache builds one forge client per provider, base URL, and routed
+// credential, so a workspace of a hundred github.com repositories shares a
+// client while an enterprise host or an org with its own token gets another.
+type ciReaderCache struct {
+	route   forgeRouteOptions
+	mu      sync.Mutex
+	readers map[string]provider.CIStatusReader
+}
+
+func newCIReaderCache(opts ciQueryOptions) *ciReaderCache {
+	return &ciReaderCache{
+		route: forgeRouteOptions{
+			Provider:      opts.Provider,
+			BaseURL:       opts.BaseURL,
+			Token:         opts.Token,
+			FallbackToken: opts.FallbackToken,
+			Credentials:   config.LoadCredentials(),
+		},
+		readers: make(map[string]provider.CIStatusReader),
+	}
+}
+
+func (c *ciReaderCache) get(remote provider.ForgeRemote) (provider.CIStatusReader, error) {
+	route := routeForgeRemote(remote, c.route)
+	if route.Provider == "" {
+		return nil, fmt.Errorf("unknown forge host %s; pass --provider", remote.Host)
+	}
+	if route.TokenErr != nil {
+		return nil, route.TokenErr
+	}
+
+	key := route.Provider + "|" + route.BaseURL + "|" + route.TokenSource
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if r, ok := c.readers[key]; ok {
+		return r, nil
+	}
+	p, err := reposynccli.NewForgeProviderWithAuth(route.Provider, route.Token, route.BaseURL, 0)
+	if err != nil {
+		return nil, err
+	}
+	reader, ok := p.(provider.CIStatusReader)
+	if !ok {
+		return nil, fmt.Errorf("provider %s does not report CI status", route.Provider)
+	}
+	c.readers[key] = reader
+	return reader, nil
+}
+
+// CIStatusJSONOutput is the structured contract for `gz-git ci status`.
+type CIStatusJSONOutput struct {
+	TotalScanned int             `json:"total_scanned"`
+	DurationMs   int64           `json:"duration_ms"`
+	Summary      CIStatusSummary `json:"summary"`
+	Repositories []ci.Report     `json:"repositories"`
+}
+
+// CIStatusSummary counts repositories by their worst tracked state. Errors
+// are counted separately: a repository can be red and partially unchecked.
+type CIStatusSummary struct {
+	Success int `json:"success"`
+	Pending int `json:"pending"`
+	Failure int `json:"failure"`
+	None    int `json:"none"`
+	Errors  int `json:"errors"`
+}
+
+func buildCIStatusOutput(result *repository.BulkStatusResult, reports map[string]ci.Report) CIStatusJSONOutput {
+	out := CIStatusJSONOutput{
+		TotalScanned: result.TotalScanned,
+		DurationMs:   result.Duration.Milliseconds(),
+		Repositories: make([]ci.Report, 0, len(result.Repositories)),
+	}
+	for _, repo := range result.Repositories {
+		report := reports[repo.Path]
+		switch report.State {
+		case provider.CIStateSuccess:
+			out.Summary.Success++
+		case provider.CIStatePending:
+			out.Summary.Pending++
+		case provider.CIStateFailure:
+			out.Summary.Failure++
+		default:
+			out.Summary.None++
+		}
+		if report.Failed() {
+			out.Summary.Errors++
+		}
+		out.Repositories = append(out.Repositories, report)
+	}
+	return out
+}
+
+// renderCIStatus prints one line per repository with a cell per tracked ref.
+// compact keeps only the repositories that are not green.
+func renderCIStatus(w io.Writer, out CIStatusJSONOutput, compact bool) {
+	fmt.Fprintln(w)
+	width := len("REPOSITORY")
+	for _, r := range out.Repositories {
+		if len(r.RelativePath) > width {
+			width = len(r.RelativePath)
+		}
+	}
+
+	fmt.Fprintf(w, "  %s%-*s  %-10s %-10s %s%s\n", cliutil.ColorGray, width, "REPOSITORY", "HEAD", "UPSTREAM", "DEFAULT", cliutil.ColorReset)
+	for _, r := range out.Repositories {
+		if compact && r.State != provider.CIStateFailure && !r.Failed() {
+			continue
+		}
+		fmt.Fprintf(w, "%s %-*s  %s %s %s\n",
+			ciStateSymbol(r.State), width, r.RelativePath,
+			ciRefCell(r.Ref(ci.KindHead), 10), ciRefCell(r.Ref(ci.KindUpstream), 10), ciRefCell(r.Ref(ci.KindDefault), 0))
+		for _, ref := range r.Refs {
+			if len(ref.FailedChecks) > 0 {
+				fmt.Fprintf(w, "      %s%s %s: %s%s\n", cliutil.ColorGray, ref.Kind, ref.Ref, strings.Join(ref.FailedChecks, ", "), cliutil.ColorReset)
+			}
+			if ref.Error != "" {
+				fmt.Fprintf(w, "      %s%s: %s%s\n", cliutil.ColorGray, ref.Kind, ref.Error, cliutil.ColorReset)
+			}
+		}
+		if r.Error != "" {
+			fmt.Fprintf(w, "      %s%s%s\n", cliutil.ColorGray, r.Error, cliutil.ColorReset)
+		}
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "CI %d repos  [✓%d success  …%d pending  ✗%d failure  -%d none]\n",
+		len(out.Repositories), out.Summary.Success, out.Summary.Pending, out.Summary.Failure, out.Summary.None)
+}
+
+// ciRefCell renders one ref's state padded to width; a ref that was not
+// checked prints the same gray dash the info table uses for "nothing here".
+func ciRefCell(ref *ci.RefResult, width int) string {
+	text, color := cellNormal, cliutil.ColorGray
+	if ref != nil {
+		switch {
+		case ref.Error != "":
+			text, color = "error", cliutil.ColorRed
+		case ref.State == provider.CIStateNone:
+		default:
+			text, color = string(ref.State), ciStateColor(ref.State)
+		}
+	}
+	return infoCell{text: text, color: color}.render(width)
+}
+
+func ciStateSymbol(state provider.CIState) string {
+	switch state {
+	case provider.CIStateSuccess:
+		return cliutil.ColorGreen + "✓" + cliutil.ColorReset
+	case provider.CIStatePending:
+		return cliutil.ColorYellow + "…" + cliutil.ColorReset
+	case provider.CIStateFailure:
+		return cliutil.ColorRed + "✗" + cliutil.ColorReset
+	default:
+		return " "
+	}
+}
+
+func ciStateColor(state provider.CIState) string {
+	switch state {
+	case provider.CIStateSuccess:
+		return cliutil.ColorGreen
+	case provider.CIStatePending:
+		return cliutil.ColorYellow
+	case provider.CIStateFailure:
+		return cliutil.ColorRed
+	default:
+		return cliutil.ColorGray
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/ci"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/provider"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+func TestValidateCIKinds(t *testing.T) {
+	if err := validateCIKinds(ci.AllKinds); err != nil {
+		t.Fatalf("all kinds rejected: %v", err)
+	}
+	if err := validateCIKinds([]string{"head", "tip"}); err == nil {
+		t.Fatal("unknown kind accepted")
+	}
+}
+
+func TestBuildCIStatusOutputCountsWorstState(t *testing.T) {
+	result := bulkResult(
+		repository.RepositoryStatusResult{Path: "/w/red", RelativePath: "red"},
+		repository.RepositoryStatusResult{Path: "/w/green", RelativePath: "green"},
+		repository.RepositoryStatusResult{Path: "/w/broken", RelativePath: "broken"},
+	)
+	reports := map[string]ci.Report{
+		"/w/red": {RelativePath: "red", State: provider.CIStateFailure, Refs: []ci.RefResult{
+			{Kind: ci.KindDefault, Ref: "main", State: provider.CIStateFailure, FailedChecks: []string{"build"}},
+		}},
+		"/w/green":  {RelativePath: "green", State: provider.CIStateSuccess},
+		"/w/broken": {RelativePath: "broken", State: provider.CIStateNone, Error: "no token"},
+	}
+
+	out := buildCIStatusOutput(result, reports)
+	if out.Summary.Failure != 1 || out.Summary.Success != 1 || out.Summary.None != 1 || out.Summary.Errors != 1 {
+		t.Fatalf("summary = %+v", out.Summary)
+	}
+
+	withColors(t, false)
+	var buf bytes.Buffer
+	renderCIStatus(&buf, out, true)
+	text := buf.String()
+	if strings.Contains(text, "green") {
+		t.Errorf("compact output should hide green repositories:\n%s", text)
+	}
+	if !strings.Contains(text, "default main: build") || !strings.Contains(text, "no token") {
+		t.Errorf("failing checks and errors must be named:\n%s", text)
+	}
+}
+
+func TestRenderInfoTable_CIColumnOnlyWhenRequested(t *testing.T) {
+	withColors(t, false)
+	repo := repository.RepositoryStatusResult{
+		Path: "/w/app", Branch: "main", Upstream: "origin/main", Status: "clean",
+		RemoteURL: "git@github.com:acme/app.git",
+	}
+
+	var without bytes.Buffer
+	renderInfoTable(&without, bulkResult(repo), map[string]infoEnrichment{}, false, false)
+	if strings.Contains(without.String(), " CI ") {
+		t.Errorf("CI column must not appear without --ci:\n%s", without.String())
+	}
+
+	report := ci.Report{State: provider.CIStateFailure, Refs: []ci.RefResult{
+		{Kind: ci.KindDefault, State: provider.CIStateFailure},
+	}}
+	var with bytes.Buffer
+	renderInfoTable(&with, bulkResult(repo), map[string]infoEnrichment{"/w/app": {CI: &report}}, false, false)
+	out := with.String()
+	if !strings.Contains(out, "CI") || !strings.Contains(out, "default ✗") {
+		t.Errorf("CI column should name the failing ref:\n%s", out)
+	}
+	if strings.Index(out, "CI") > strings.Index(out, "REMOTE ONLY") {
+		t.Errorf("REMOTE ONLY must stay the last column:\n%s", out)
+	}
+}
@@ -38,7 +38,10 @@ var configCmd = &cobra.Command{
   gz-git config profile use work
 
   # Show effective config
-  gz-git config show`),
+  gz-git config show
+
+  # Where a repository's sync strategy comes from
+  gz-git config explain ~/devbox/api sync.strategy`),
 	Example: ``,
 }
 
@@ -585,11 +588,15 @@ func printConfigValue(key, value, source string) {
 	fmt.Println()
 }
 
-// sanitizeToken removes sensitive parts of tokens for display.
+// sanitizeToken removes sensitive parts of tokens for display. A secret
+// reference such as ${keyring:gitlab} names no secret and is shown as is.
 func sanitizeToken(token string) string {
 	if token == "" {
 		return ""
 	}
+	if config.HasSecretRef(token) {
+		return token
+	}
 	if len(token) <= 8 {
 		return "***"
 	}
@@ -0,0 +1,299 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+)
+
+var (
+	configEditFile   string
+	configEditGlobal bool
+)
+
+const configEditTargetHelp = `
+Edits the .gz-git.yaml found from the current directory upward, or the file
+named by --file, the profile named by --profile, or the global config with
+--global. Paths are keys joined by dots, with [N] for list items, as
+'workspace validate' prints them; quote a key holding a dot:
+workspaces."api.v2".path.
+`
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <path>",
+	Short: "Print a value from a config file",
+	Long: cliutil.QuickStartHelp(`  # A setting of the nearest .gz-git.yaml
+  gz-git config get workspaces.devbox.sync.strategy
+
+  # A profile's base URL
+  gz-git config get baseURL --profile work`) + configEditTargetHelp + `
+Prints a value as written, before ${VAR} expansion; lists and mappings are
+printed as YAML. Fails when the path is not set.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <path> <value>",
+	Short: "Set a value in a config file, keeping its comments",
+	Long: cliutil.QuickStartHelp(`  # Change a workspace's sync strategy
+  gz-git config set workspaces.devbox.sync.strategy pull
+
+  # Lists and numbers are read as YAML
+  gz-git config set branch.defaultBranch '[main, develop]'
+  gz-git config set parallel 8
+
+  # Add a repository entry (an index one past the end appends)
+  gz-git config set 'repositories[3].url' https://github.com/org/web.git
+
+  # A profile's token, as a secret reference
+  gz-git config set token '${keyring:gitlab}' --profile work`) + configEditTargetHelp + `
+The value is read as YAML, so 8 is a number and '[a, b]' a list; quote it
+('"8"') to force a string. Missing mappings on the way are created, and the
+rest of the file, comments and key order included, is left as it was.
+
+The edited file is checked against the config schema before it is written:
+an unknown key or a value of the wrong type is an error and nothing changes.
+`,
+	Args: cobra.ExactArgs(2),
+	RunE: runConfigSet,
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <path>",
+	Short: "Remove a value from a config file, keeping its comments",
+	Long: cliutil.QuickStartHelp(`  # Drop a workspace's strategy override
+  gz-git config unset workspaces.devbox.sync.strategy
+
+  # Remove the second repository entry
+  gz-git config unset 'repositories[1]'`) + configEditTargetHelp + `
+Removing a path that is not set is not an error.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigUnset,
+}
+
+func init() {
+	for _, c := range []*cobra.Command{configGetCmd, configSetCmd, configUnsetCmd} {
+		configCmd.AddCommand(c)
+		c.Flags().StringVar(&configEditFile, "file", "", "config file to edit (default: nearest .gz-git.yaml)")
+		c.Flags().BoolVar(&configEditGlobal, "global", false, "edit the global config (~/.config/gz-git)")
+	}
+}
+
+// configEditTarget is the file a get, set or unset works on.
+type configEditTarget struct {
+	Path   string
+	Kind   config.SchemaKind
+	Exists bool
+}
+
+// resolveConfigEditTarget picks the file from the flags: --file, the global
+// --profile, or --global. With create, a missing project or global config is
+// a new file rather than an error; a profile has to exist, since 'config
+// profile create' sets it up.
+func resolveConfigEditTarget(create bool) (*configEditTarget, error) {
+	selected := 0
+	for _, set := range []bool{configEditFile != "", profileOverride != "", configEditGlobal} {
+		if set {
+			selected++
+		}
+	}
+	if selected > 1 {
+		return nil, fmt.Errorf("--file, --profile and --global are mutually exclusive")
+	}
+
+	target := &configEditTarget{Kind: config.SchemaKindConfig}
+	switch {
+	case configEditFile != "":
+		target.Path = configEditFile
+
+	case profileOverride != "":
+		paths, err := config.NewPaths()
+		if err != nil {
+			return nil, err
+		}
+		target.Kind = config.SchemaKindProfile
+		target.Path = paths.ProfilePath(profileOverride)
+		if target.Path == "" {
+			return nil, fmt.Errorf("profile %q not found: create it with 'gz-git config profile create %s'", profileOverride, profileOverride)
+		}
+
+	case configEditGlobal:
+		paths, err := config.NewPaths()
+		if err != nil {
+			return nil, err
+		}
+		target.Kind = config.SchemaKindGlobal
+		target.Path = paths.GlobalConfigFile
+		if target.Path == "" {
+			if !create {
+				return nil, fmt.Errorf("no global config in %s: create it with 'gz-git config init --global'", paths.ConfigDir)
+			}
+			if err := paths.EnsureDirectories(); err != nil {
+				return nil, err
+			}
+			target.Path = filepath.Join(paths.ConfigDir, config.GlobalConfigFileName+".yaml")
+		}
+
+	default:
+		cwd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current directory: %w", err)
+		}
+		target.Path, err = config.DetectConfigFile(cwd)
+		if err != nil {
+			if !create {
+				return nil, err
+			}
+			target.Path = filepath.Join(cwd, config.ProjectConfigFileName+".yaml")
+		}
+	}
+
+	if strings.EqualFold(filepath.Ext(target.Path), ".json") {
+		return nil, fmt.Errorf("%s: editing JSON config files is not supported", target.Path)
+	}
+	if _, err := os.Stat(target.Path); err == nil {
+		target.Exists = true
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	} else if !create {
+		return nil, fmt.Errorf("%s does not exist", target.Path)
+	}
+	return target, nil
+}
+
+// loadConfigEditor reads the target for editing; a missing file is empty.
+func loadConfigEditor(target *configEditTarget) ([]byte, *config.ConfigEditor, error) {
+	var data []byte
+	if target.Exists {
+		var err error
+		data, err = os.ReadFile(target.Path) // #nosec G304 -- path is a config file the user selected.
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", target.Path, err)
+		}
+	}
+	editor, err := config.NewConfigEditor(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", target.Path, err)
+	}
+	return data, editor, nil
+}
+
+// saveConfigEdit validates the edited file and writes it, keeping the mode
+// of an existing file. New files are private, as profiles are.
+func saveConfigEdit(target *configEditTarget, before []byte, editor *config.ConfigEditor) error {
+	after, err := editor.Bytes()
+	if err != nil {
+		return err
+	}
+	violations, err := config.ValidateConfigEdit(target.Kind, before, after)
+	if err != nil {
+		return fmt.Errorf("%s: edit makes the config invalid: %w", target.Path, err)
+	}
+	if len(violations) > 0 {
+		msgs := make([]string, 0, len(violations))
+		for _, v := range violations {
+			msgs = append(msgs, "  "+v.Error())
+		}
+		return fmt.Errorf("%s: edit makes the config invalid, nothing written:\n%s", target.Path, strings.Join(msgs, "\n"))
+	}
+
+	mode := os.FileMode(0o600)
+	if info, err := os.Stat(target.Path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := os.WriteFile(target.Path, after, mode); err != nil {
+		return fmt.Errorf("failed to write %s: %w", target.Path, err)
+	}
+	return nil
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	target, err := resolveConfigEditTarget(false)
+	if err != nil {
+		return err
+	}
+	_, editor, err := loadConfigEditor(target)
+	if err != nil {
+		return err
+	}
+	n, err := editor.Get(args[0])
+	if err != nil {
+		return err
+	}
+	if n == nil {
+		return fmt.Errorf("%s is not set in %s", args[0], target.Path)
+	}
+
+	out := cmd.OutOrStdout()
+	if n.Kind == yaml.ScalarNode {
+		fmt.Fprintln(out, n.Value)
+		return nil
+	}
+	enc := yaml.NewEncoder(out)
+	enc.SetIndent(2)
+	if err := enc.Encode(n); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", args[0], err)
+	}
+	return enc.Close()
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	target, err := resolveConfigEditTarget(true)
+	if err != nil {
+		return err
+	}
+	before, editor, err := loadConfigEditor(target)
+	if err != nil {
+		return err
+	}
+	if err := editor.Set(args[0], config.ParseConfigValue(args[1])); err != nil {
+		return fmt.Errorf("%s: %w", target.Path, err)
+	}
+	if err := saveConfigEdit(target, before, editor); err != nil {
+		return err
+	}
+	if !target.Exists {
+		fmt.Fprintf(cmd.OutOrStdout(), "Created %s\n", target.Path)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Set %s in %s\n", args[0], target.Path)
+	return nil
+}
+
+func runConfigUnset(cmd *cobra.Command, args []string) error {
+	target, err := resolveConfigEditTarget(false)
+	if err != nil {
+		return err
+	}
+	before, editor, err := loadConfigEditor(target)
+	if err != nil {
+		return err
+	}
+	removed, err := editor.Unset(args[0])
+	if err != nil {
+		return err
+	}
+	if !removed {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s is not set in %s\n", args[0], target.Path)
+		return nil
+	}
+	if err := saveConfigEdit(target, before, editor); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Unset %s in %s\n", args[0], target.Path)
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunConfigGetSetUnset(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	dir := filepath.Join(home, "work")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, dir, ".gz-git.yaml", "# devbox\nparallel: 4 # low on CI\nworkspaces:\n  api:\n    path: ./api\n")
+	file := filepath.Join(dir, ".gz-git.yaml")
+	t.Chdir(dir)
+
+	var out bytes.Buffer
+	for _, c := range []string{"get", "set", "unset"} {
+		cmd := findCommand(t, rootCmd, "config", c)
+		cmd.SetOut(&out)
+		t.Cleanup(func() { cmd.SetOut(nil) })
+	}
+	t.Cleanup(func() { configEditFile, configEditGlobal, profileOverride = "", false, "" })
+
+	if err := runConfigSet(configSetCmd, []string{"workspaces.api.sync.strategy", "pull"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := runConfigSet(configSetCmd, []string{"parallel", "8"}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# devbox\nparallel: 8 # low on CI\nworkspaces:\n  api:\n    path: ./api\n    sync:\n      strategy: pull\n"
+	if string(data) != want {
+		t.Errorf("file after set:\n%s", data)
+	}
+
+	// Invalid edits are rejected and leave the file alone.
+	for _, args := range [][]string{{"parallel", "many"}, {"workspaces.api.stratgy", "pull"}} {
+		if err := runConfigSet(configSetCmd, args); err == nil || !strings.Contains(err.Error(), "nothing written") {
+			t.Errorf("set %v: err = %v", args, err)
+		}
+	}
+	if after, _ := os.ReadFile(file); string(after) != want {
+		t.Errorf("a rejected edit changed the file:\n%s", after)
+	}
+
+	out.Reset()
+	if err := runConfigGet(configGetCmd, []string{"workspaces.api.sync.strategy"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := runConfigGet(configGetCmd, []string{"workspaces.api"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); got != "pull\npath: ./api\nsync:\n  strategy: pull\n" {
+		t.Errorf("get output = %q", got)
+	}
+	if err := runConfigGet(configGetCmd, []string{"workspaces.web"}); err == nil || !strings.Contains(err.Error(), "is not set") {
+		t.Errorf("get of a missing path: err = %v", err)
+	}
+
+	if err := runConfigUnset(configUnsetCmd, []string{"parallel"}); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(file); strings.Contains(string(data), "parallel") || !strings.HasPrefix(string(data), "# devbox\n") {
+		t.Errorf("file after unset:\n%s", data)
+	}
+
+	// --file starts a new file; --global and --file do not mix.
+	configEditFile = filepath.Join(dir, "new.yaml")
+	if err := runConfigSet(configSetCmd, []string{"kind", "repositories"}); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(configEditFile); string(data) != "kind: repositories\n" {
+		t.Errorf("new file = %q", data)
+	}
+	configEditGlobal = true
+	if err := runConfigGet(configGetCmd, []string{"kind"}); err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+)
+
+var configExplainCmd = &cobra.Command{
+	Use:   "explain <repo-path|workspace> [key]",
+	Short: "Explain where each effective setting of a repository comes from",
+	Long: cliutil.QuickStartHelp(`  # Every setting of a repository, and the layers that set it
+  gz-git config explain ~/devbox/api
+
+  # Why did this repository get its sync strategy?
+  gz-git config explain ~/devbox/api sync.strategy
+
+  # A workspace of the config in the current directory, by name
+  gz-git config explain devbox`) + `
+
+Resolves the settings of one repository or workspace directory the way
+'workspace sync' does, through every .gz-git.yaml that reaches it, and prints
+each layer that contributed, lowest precedence first:
+
+  built-in default      what sync uses when nothing is set
+  parent config         files named by parent:, farthest first
+  config defaults       the defaults: section, below the rest of the file
+  config                the file itself
+  profile <name>        the profile the config names
+  workspace <name>      the workspace entry holding the target, and its profile
+  repository <name>     the target's entry in a repositories list
+
+Explaining starts from the outermost config above the target whose workspaces
+reach it. A workspace of type config has its own .gz-git.yaml, which does not
+inherit from the config listing it: only the workspace entry applies on top.
+
+Values written as ${VAR} are shown expanded, with the reference they came
+from; tokens are masked. Command flags such as --strategy override all of
+this at run time.
+
+Keys: ` + strings.Join(config.ExplainKeys(), ", ") + `
+`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runConfigExplain,
+}
+
+func init() {
+	configCmd.AddCommand(configExplainCmd)
+}
+
+func runConfigExplain(cmd *cobra.Command, args []string) error {
+	key := ""
+	if len(args) == 2 {
+		key = args[1]
+		if !slices.Contains(config.ExplainKeys(), key) {
+			return fmt.Errorf("unknown key %q (want one of: %s)", key, strings.Join(config.ExplainKeys(), ", "))
+		}
+	}
+
+	paths, err := config.NewPaths()
+	if err != nil {
+		return err
+	}
+	explanation, err := explainTarget(args[0], paths)
+	if err != nil {
+		return err
+	}
+
+	printExplanation(cmd.OutOrStdout(), explanation, key)
+	return nil
+}
+
+// explainTarget explains a path, or a workspace of the config found from the
+// working directory when no such path exists.
+func explainTarget(target string, paths *config.Paths) (*config.Explanation, error) {
+	if _, err := os.Stat(target); err != nil {
+		if cwd, err := os.Getwd(); err == nil {
+			if dir, err := config.FindConfigRecursive(cwd, ".gz-git.yaml"); err == nil {
+				if explanation, err := config.ExplainWorkspace(dir, target, paths); err == nil {
+					return explanation, nil
+				}
+			}
+		}
+	}
+	return config.Explain(target, paths)
+}
+
+func printExplanation(out io.Writer, e *config.Explanation, key string) {
+	fmt.Fprintf(out, "Explaining %s\n", e.Target)
+	fmt.Fprintf(out, "  %s\n\n", strings.Join(e.Route, " → "))
+
+	fmt.Fprintln(out, "Layers (lowest precedence first):")
+	for i, layer := range e.Layers {
+		fmt.Fprintln(out, strings.TrimRight(fmt.Sprintf("  %d. %-22s %s", i+1, layer.Name, explainLayerFile(layer)), " "))
+	}
+	fmt.Fprintln(out)
+
+	settings := e.Settings()
+	if key != "" {
+		settings = slices.DeleteFunc(settings, func(s config.ExplainedSetting) bool { return s.Key != key })
+		if len(settings) == 0 {
+			fmt.Fprintf(out, "%s is not set by any layer\n", key)
+			return
+		}
+	}
+
+	fmt.Fprintln(out, "Effective settings:")
+	for _, s := range settings {
+		fmt.Fprintf(out, "  %-26s %s\n", s.Key, explainOrigin(s.Effective))
+		for _, o := range s.Overridden {
+			fmt.Fprintf(out, "    %-24s %s\n", "overrides", explainOrigin(o))
+		}
+	}
+}
+
+// explainOrigin formats a value with the layer and line it came from.
+func explainOrigin(o config.ExplainOrigin) string {
+	value := o.Value
+	switch {
+	case value == "":
+		value = `""`
+	case o.Secret:
+		value = sanitizeToken(value)
+	}
+	if o.Raw != "" {
+		value += " (from " + o.Raw + ")"
+	}
+	where := o.Layer.Name
+	if o.Layer.File != "" {
+		where += fmt.Sprintf(", %s:%d", o.Layer.File, o.Line)
+	}
+	return fmt.Sprintf("%s  [%s]", value, where)
+}
+
+func explainLayerFile(layer *config.ExplainLayer) string {
+	if layer.File == "" || layer.Path == "" {
+		return layer.File
+	}
+	return fmt.Sprintf("%s (%s)", layer.File, layer.Path)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunConfigExplain(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("EXPLAIN_CMD_TOKEN", "glpat-0123456789")
+	root := filepath.Join(home, "work")
+	if err := os.MkdirAll(filepath.Join(root, "devbox", "api"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, root, ".gz-git.yaml", `token: ${EXPLAIN_CMD_TOKEN}
+workspaces:
+  devbox:
+    type: config
+    sync:
+      strategy: reset
+`)
+	writeFile(t, filepath.Join(root, "devbox"), ".gz-git.yaml", `kind: repositories
+strategy: rebase
+repositories:
+  - url: https://github.com/org/api.git
+    strategy: pull
+`)
+	t.Chdir(root)
+
+	cmd := findCommand(t, rootCmd, "config", "explain")
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	t.Cleanup(func() { cmd.SetOut(nil) })
+
+	// A path: the repository entry wins over the workspace entry and the
+	// child config.
+	if err := runConfigExplain(cmd, []string{"devbox/api", "sync.strategy"}); err != nil {
+		t.Fatal(err)
+	}
+	child := filepath.Join(root, "devbox", ".gz-git.yaml")
+	for _, want := range []string{
+		"→ workspace devbox → " + child + " → repository api",
+		"sync.strategy              pull  [repository api, " + child + ":5]",
+		"overrides                reset  [workspace devbox, " + filepath.Join(root, ".gz-git.yaml") + ":6]",
+		"overrides                rebase  [config, " + child + ":2]",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	// The token is masked, and shown with its reference.
+	out.Reset()
+	if err := runConfigExplain(cmd, []string{".", "token"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "glpa...6789 (from ${EXPLAIN_CMD_TOKEN})"; !strings.Contains(out.String(), want) {
+		t.Errorf("output missing %q:\n%s", want, out.String())
+	}
+	if strings.Contains(out.String(), "glpat-0123456789") {
+		t.Error("token printed in full")
+	}
+
+	// A workspace name, when no such path exists.
+	out.Reset()
+	if err := runConfigExplain(cmd, []string{"devbox"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Explaining "+filepath.Join(root, "devbox")) {
+		t.Errorf("workspace name not resolved:\n%s", out.String())
+	}
+
+	if err := runConfigExplain(cmd, []string{".", "strategy"}); err == nil {
+		t.Error("unknown key should be rejected")
+	}
+}
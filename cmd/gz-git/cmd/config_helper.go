@@ -40,15 +40,7 @@ func LoadEffectiveConfig(cmd *cobra.Command, flags map[string]any) (*config.Effe
 	}
 
 	// Apply profile override if provided via --profile flag
-	if profileOverride != "" {
-		mgr, err := config.NewManager()
-		if err == nil && mgr.ProfileExists(profileOverride) {
-			overrideProfile, err := mgr.LoadProfile(profileOverride)
-			if err == nil {
-				loader.SetActiveProfileInternal(overrideProfile)
-			}
-		}
-	}
+	applyProfileOverride(loader)
 
 	// Resolve effective config with flags
 	effective, err := loader.ResolveConfig(flags)
@@ -59,6 +51,20 @@ func LoadEffectiveConfig(cmd *cobra.Command, flags map[string]any) (*config.Effe
 	return effective, nil
 }
 
+// applyProfileOverride makes the --profile flag's profile the active one.
+func applyProfileOverride(loader *config.ConfigLoader) {
+	if profileOverride == "" {
+		return
+	}
+	mgr, err := config.NewManager()
+	if err == nil && mgr.ProfileExists(profileOverride) {
+		overrideProfile, err := mgr.LoadProfile(profileOverride)
+		if err == nil {
+			loader.SetActiveProfileInternal(overrideProfile)
+		}
+	}
+}
+
 // ApplyConfigToFlags applies config values to command flags if not already set.
 // This is useful for commands that want to use config as defaults.
 //
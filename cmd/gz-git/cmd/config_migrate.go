@@ -0,0 +1,131 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+)
+
+var (
+	migrateCheck bool
+	migrateWrite bool
+)
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate [file...]",
+	Short: "Upgrade config files written for older gz-git releases",
+	Long: cliutil.QuickStartHelp(`  # What would change in the nearest .gz-git.yaml
+  gz-git config migrate
+
+  # Upgrade it in place
+  gz-git config migrate --write
+
+  # In CI: fail when any config is outdated
+  gz-git config migrate --check .gz-git.yaml teams/*.yaml`) + fmt.Sprintf(`
+
+Brings config files up to the current format (version %d): renames keys and
+values earlier releases used, then sets version:. Edits keep the file's
+comments and key order. Without a file, migrates the .gz-git.yaml found from
+the current directory upward.
+
+Without --write nothing is written; the changes are listed. --check lists
+them too and exits non-zero when a file needs migrating.
+`, config.CurrentConfigVersion),
+	Args: cobra.ArbitraryArgs,
+	RunE: runConfigMigrate,
+}
+
+func init() {
+	configCmd.AddCommand(configMigrateCmd)
+
+	configMigrateCmd.Flags().BoolVar(&migrateCheck, "check", false, "exit non-zero when a file needs migrating")
+	configMigrateCmd.Flags().BoolVar(&migrateWrite, "write", false, "upgrade the files in place")
+	configMigrateCmd.MarkFlagsMutuallyExclusive("check", "write")
+}
+
+func runConfigMigrate(cmd *cobra.Command, args []string) error {
+	files := args
+	if len(files) == 0 {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		path, err := config.DetectConfigFile(cwd)
+		if err != nil {
+			return err
+		}
+		files = []string{path}
+	}
+
+	out := cmd.OutOrStdout()
+	outdated := 0
+	for _, file := range files {
+		changed, err := migrateConfigFile(out, file, migrateWrite)
+		if err != nil {
+			return err
+		}
+		if changed {
+			outdated++
+		}
+	}
+
+	switch {
+	case outdated == 0 || migrateWrite:
+		return nil
+	case migrateCheck:
+		return fmt.Errorf("%d config file(s) need migrating: run 'gz-git config migrate --write'", outdated)
+	default:
+		fmt.Fprintln(out, "\nRun with --write to apply.")
+		return nil
+	}
+}
+
+// migrateConfigFile reports, and with write applies, the migration of one
+// file. It returns whether the file needed migrating.
+func migrateConfigFile(out io.Writer, path string, write bool) (bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- path is a config file the user named.
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	result, err := config.MigrateConfig(data)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if result.Kind == "" {
+		fmt.Fprintf(out, "%s: not a workspace or repositories config, left alone\n", path)
+		return false, nil
+	}
+	if !result.Changed() {
+		fmt.Fprintf(out, "%s: up to date (version %d)\n", path, result.ToVersion)
+		return false, nil
+	}
+
+	verb := "needs migrating"
+	if write {
+		if err := os.WriteFile(path, result.Output, info.Mode().Perm()); err != nil {
+			return true, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		verb = "migrated"
+	}
+	fmt.Fprintf(out, "%s: %s, version %d → %d\n", path, verb, result.FromVersion, result.ToVersion)
+	for _, change := range result.Changes {
+		fmt.Fprintf(out, "  - %s\n", change)
+	}
+	if result.FromVersion < result.ToVersion {
+		fmt.Fprintf(out, "  - version: %d\n", result.ToVersion)
+	}
+	return true, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunConfigMigrate(t *testing.T) {
+	dir := t.TempDir()
+	legacy := "# devbox\nkind: repository\nrepositories:\n  - url: https://github.com/org/api.git\n    targetPath: ./api\n"
+	writeFile(t, dir, "old.yaml", legacy)
+	writeFile(t, dir, "new.yaml", "version: 1\nkind: repositories\n")
+	profile := "name: work\nprovider: gitlab\n"
+	writeFile(t, dir, "work.yaml", profile)
+	oldFile, newFile, profileFile := filepath.Join(dir, "old.yaml"), filepath.Join(dir, "new.yaml"), filepath.Join(dir, "work.yaml")
+
+	cmd := findCommand(t, rootCmd, "config", "migrate")
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	t.Cleanup(func() {
+		migrateCheck, migrateWrite = false, false
+		cmd.SetOut(nil)
+	})
+
+	// Listing changes writes nothing.
+	if err := runConfigMigrate(cmd, []string{oldFile, newFile}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		oldFile + ": needs migrating, version 0 → 1",
+		"  - repository-target-path: repositories[0]: targetPath → path",
+		newFile + ": up to date (version 1)",
+		"Run with --write to apply.",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+	if data, _ := os.ReadFile(oldFile); string(data) != legacy {
+		t.Error("dry run wrote the file")
+	}
+
+	migrateCheck = true
+	if err := runConfigMigrate(cmd, []string{oldFile}); err == nil || !strings.Contains(err.Error(), "1 config file(s) need migrating") {
+		t.Errorf("--check err = %v", err)
+	}
+	if err := runConfigMigrate(cmd, []string{newFile}); err != nil {
+		t.Errorf("--check on a current file: %v", err)
+	}
+
+	migrateCheck, migrateWrite = false, true
+	out.Reset()
+	if err := runConfigMigrate(cmd, []string{oldFile, profileFile}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), profileFile+": not a workspace or repositories config, left alone") {
+		t.Errorf("output:\n%s", out.String())
+	}
+	if data, _ := os.ReadFile(profileFile); string(data) != profile {
+		t.Errorf("profile file was rewritten:\n%s", data)
+	}
+	data, err := os.ReadFile(oldFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# devbox\nversion: 1\nkind: repositories\nrepositories:\n  - url: https://github.com/org/api.git\n    path: ./api\n"; string(data) != want {
+		t.Errorf("migrated file:\n%s", data)
+	}
+}
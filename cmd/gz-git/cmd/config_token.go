@@ -16,7 +16,7 @@ import (
 
 var configTokenCmd = &cobra.Command{
 	Use:   "token",
-	Short: "Manage forge API tokens in the OS keychain",
+	Short: "Manage forge API tokens in the OS keychain or an encrypted file",
 	Long: cliutil.QuickStartHelp(`  # Store a GitHub token in the OS keychain
   gz-git config token set github ghp_...
 
@@ -26,32 +26,50 @@ var configTokenCmd = &cobra.Command{
   # Remove token
   gz-git config token delete github
 
+  # A GitHub Enterprise host, and a separate account for one org on github.com
+  gz-git config token set github ghp_... --host ghe.corp.example.com
+  gz-git config token set github ghp_... --host github.com --org acme
+
 Precedence for runtime token resolution:
-  flag > env > keychain > config profile > defaults
+  flag > env > token store (keychain or file) > config profile > defaults
+
+Per-repository operations (pr create, ci status) route by the remote's host
+first: a token stored for the host and org, then for the host, then the
+provider-wide sources above. List hosts under "credentials:" in the global
+config so doctor validates each of them.
 
 On headless Linux without Secret Service, set/get/delete warn and fall back
-gracefully so CI and doctor keep working.`),
+gracefully so CI and doctor keep working. To store tokens there anyway, use the
+encrypted file backend (tokens.enc in the config directory):
+
+  export GZ_GIT_TOKEN_PASSPHRASE=...             # or GZ_GIT_TOKEN_PASSPHRASE_COMMAND='pass show gz-git'
+  export GZ_GIT_TOKEN_STORE=file                 # or tokenStore: file in the global config
+  gz-git config token migrate --from keyring --to file`),
 }
 
-var tokenShowFull bool
+var (
+	tokenShowFull bool
+	tokenHost     string
+	tokenOrg      string
+)
 
 var configTokenSetCmd = &cobra.Command{
 	Use:   "set <provider> <token>",
-	Short: "Store a token in the OS keychain",
+	Short: "Store a token in the token store",
 	Args:  cobra.ExactArgs(2),
 	RunE:  runConfigTokenSet,
 }
 
 var configTokenGetCmd = &cobra.Command{
 	Use:   "get <provider>",
-	Short: "Read a token from the OS keychain",
+	Short: "Read a token from the token store",
 	Args:  cobra.ExactArgs(1),
 	RunE:  runConfigTokenGet,
 }
 
 var configTokenDeleteCmd = &cobra.Command{
 	Use:   "delete <provider>",
-	Short: "Delete a token from the OS keychain",
+	Short: "Delete a token from the token store",
 	Args:  cobra.ExactArgs(1),
 	RunE:  runConfigTokenDelete,
 }
@@ -62,29 +80,57 @@ func init() {
 	configTokenCmd.AddCommand(configTokenGetCmd)
 	configTokenCmd.AddCommand(configTokenDeleteCmd)
 	configTokenGetCmd.Flags().BoolVar(&tokenShowFull, "show", false, "print the full token (default: masked)")
+	configTokenCmd.PersistentFlags().StringVar(&tokenHost, "host", "", "scope the token to a forge host (e.g. ghe.corp.example.com)")
+	configTokenCmd.PersistentFlags().StringVar(&tokenOrg, "org", "", "further scope the token to one org/group on --host")
+}
+
+// tokenKey builds the token store key from the provider argument and the
+// --host/--org flags. Without --host it is the bare provider key gz-git has
+// always used.
+func tokenKey(provider string) (config.CredentialKey, error) {
+	key := config.CredentialKey{Provider: provider, Host: tokenHost, Org: tokenOrg}
+	if err := key.Validate(); err != nil {
+		return config.CredentialKey{}, err
+	}
+	return key, nil
+}
+
+// describeTokenKey names the key in user-facing messages.
+func describeTokenKey(key config.CredentialKey) string {
+	if key.Host == "" {
+		return fmt.Sprintf("provider %q", strings.ToLower(key.Provider))
+	}
+	return fmt.Sprintf("%q", key.String())
 }
 
 func runConfigTokenSet(cmd *cobra.Command, args []string) error {
 	provider, token := args[0], args[1]
-	if err := config.DefaultTokenStore.Set(provider, token); err != nil {
-		fmt.Fprintf(os.Stderr, "warning: keychain unavailable (%v); token not stored\n", err)
+	key, err := tokenKey(provider)
+	if err != nil {
+		return err
+	}
+	if err := config.DefaultTokenStore.Set(key.String(), token); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: token store unavailable (%v); token not stored\n", err)
 		fmt.Fprintf(os.Stderr, "hint: set %s or GZ_GIT_TOKEN in the environment for CI/headless use\n",
 			tokenEnvHint(provider))
 		return nil
 	}
-	fmt.Printf("Stored token for provider %q in OS keychain\n", strings.ToLower(provider))
+	fmt.Printf("Stored token for %s in %s\n", describeTokenKey(key), config.DescribeTokenStore(config.DefaultTokenStore))
 	return nil
 }
 
 func runConfigTokenGet(cmd *cobra.Command, args []string) error {
-	provider := args[0]
-	tok, err := config.DefaultTokenStore.Get(provider)
+	key, err := tokenKey(args[0])
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: keychain unavailable (%v)\n", err)
+		return err
+	}
+	tok, err := config.DefaultTokenStore.Get(key.String())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: token store unavailable (%v)\n", err)
 		return nil
 	}
 	if tok == "" {
-		fmt.Printf("No keychain token for provider %q\n", strings.ToLower(provider))
+		fmt.Printf("No stored token for %s\n", describeTokenKey(key))
 		return nil
 	}
 	if tokenShowFull {
@@ -96,12 +142,15 @@ func runConfigTokenGet(cmd *cobra.Command, args []string) error {
 }
 
 func runConfigTokenDelete(cmd *cobra.Command, args []string) error {
-	provider := args[0]
-	if err := config.DefaultTokenStore.Delete(provider); err != nil {
-		fmt.Fprintf(os.Stderr, "warning: keychain unavailable (%v)\n", err)
+	key, err := tokenKey(args[0])
+	if err != nil {
+		return err
+	}
+	if err := config.DefaultTokenStore.Delete(key.String()); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: token store unavailable (%v)\n", err)
 		return nil
 	}
-	fmt.Printf("Deleted keychain token for provider %q\n", strings.ToLower(provider))
+	fmt.Printf("Deleted stored token for %s\n", describeTokenKey(key))
 	return nil
 }
 
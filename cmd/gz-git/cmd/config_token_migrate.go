@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+)
+
+var (
+	tokenMigrateFrom         string
+	tokenMigrateTo           string
+	tokenMigrateDeleteSource bool
+	tokenMigrateOverwrite    bool
+	tokenMigrateDryRun       bool
+)
+
+var configTokenMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Copy or move tokens between the keychain and the encrypted file",
+	Long: cliutil.QuickStartHelp(`  # Preview moving every token out of the keychain into the encrypted file
+  gz-git config token migrate --from keyring --to file --dry-run
+
+  # Move them, removing the keychain copies once the file holds them
+  gz-git config token migrate --from keyring --to file --delete-source
+
+  # And back
+  gz-git config token migrate --from file --to keyring
+
+The OS keychain cannot be listed, so migrating out of it covers the keys
+gz-git knows about: github, gitlab, gitea, and every host under
+"credentials:" in the global config. The encrypted file is listed in full.
+Switch the active backend afterwards with GZ_GIT_TOKEN_STORE or tokenStore in
+the global config.`),
+	Args: cobra.NoArgs,
+	RunE: runConfigTokenMigrate,
+}
+
+func init() {
+	configTokenCmd.AddCommand(configTokenMigrateCmd)
+	configTokenMigrateCmd.Flags().StringVar(&tokenMigrateFrom, "from", "", "source backend: keyring or file (default: active backend)")
+	configTokenMigrateCmd.Flags().StringVar(&tokenMigrateTo, "to", "", "destination backend: keyring or file (required)")
+	configTokenMigrateCmd.Flags().BoolVar(&tokenMigrateDeleteSource, "delete-source", false, "remove each token from the source after copying")
+	configTokenMigrateCmd.Flags().BoolVar(&tokenMigrateOverwrite, "overwrite", false, "replace different tokens already in the destination")
+	configTokenMigrateCmd.Flags().BoolVar(&tokenMigrateDryRun, "dry-run", false, "report what would be migrated without writing")
+	_ = configTokenMigrateCmd.MarkFlagRequired("to")
+}
+
+// newMigrationStore returns DefaultTokenStore when it already is the named
+// backend, so tests and a configured file store share one instance.
+var newMigrationStore = func(backend string) (config.TokenStore, error) {
+	if config.TokenBackendName(config.DefaultTokenStore) == backend {
+		return config.DefaultTokenStore, nil
+	}
+	return config.NewTokenStore(backend)
+}
+
+func runConfigTokenMigrate(cmd *cobra.Command, _ []string) error {
+	fromName := tokenMigrateFrom
+	if fromName == "" {
+		fromName = config.TokenBackendName(config.DefaultTokenStore)
+	}
+	if fromName == tokenMigrateTo {
+		return fmt.Errorf("--from and --to are both %q", fromName)
+	}
+	from, err := newMigrationStore(fromName)
+	if err != nil {
+		return fmt.Errorf("source: %w", err)
+	}
+	to, err := newMigrationStore(tokenMigrateTo)
+	if err != nil {
+		return fmt.Errorf("destination: %w", err)
+	}
+
+	keys := config.KnownTokenKeys(config.LoadCredentials(), from)
+	results, err := config.MigrateTokens(from, to, keys, config.MigrationOptions{
+		DeleteSource: tokenMigrateDeleteSource,
+		Overwrite:    tokenMigrateOverwrite,
+		DryRun:       tokenMigrateDryRun,
+	})
+
+	out := cmd.OutOrStdout()
+	migrated := 0
+	for _, r := range results {
+		if r.Status == config.MigrateAbsent {
+			continue
+		}
+		if r.Status == config.MigrateCopied || r.Status == config.MigrateMoved {
+			migrated++
+		}
+		line := fmt.Sprintf("%-10s %s", r.Status, r.Key)
+		if r.Detail != "" {
+			line += "  (" + r.Detail + ")"
+		}
+		fmt.Fprintln(out, line)
+	}
+	if err != nil {
+		return err
+	}
+
+	verb := "Migrated"
+	if tokenMigrateDryRun {
+		verb = "Would migrate"
+	}
+	fmt.Fprintf(out, "%s %d token(s) from %s to %s\n", verb, migrated,
+		config.DescribeTokenStore(from), config.DescribeTokenStore(to))
+	return nil
+}
@@ -67,3 +67,88 @@ func TestConfigTokenSet_UnavailableFallback(t *testing.T) {
 		t.Fatalf("set unavailable: %v", err)
 	}
 }
+
+func TestConfigTokenHostScoped(t *testing.T) {
+	mem := config.NewMemoryTokenStore()
+	prev := config.DefaultTokenStore
+	config.SetTokenStore(mem)
+	t.Cleanup(func() {
+		config.SetTokenStore(prev)
+		tokenHost, tokenOrg = "", ""
+	})
+
+	tokenHost, tokenOrg = "ghe.corp.example.com", "platform"
+	out := captureStdout(t, func() {
+		if err := runConfigTokenSet(configTokenSetCmd, []string{"github", "ghe-token"}); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+	})
+	if !strings.Contains(out, "github@ghe.corp.example.com/platform") {
+		t.Fatalf("set out: %q", out)
+	}
+	if tok, _ := mem.Get("github@ghe.corp.example.com/platform"); tok != "ghe-token" {
+		t.Fatalf("stored under wrong key, got %q", tok)
+	}
+	if tok, _ := mem.Get("github"); tok != "" {
+		t.Fatalf("host-scoped set wrote the provider key: %q", tok)
+	}
+
+	tokenHost = ""
+	if err := runConfigTokenSet(configTokenSetCmd, []string{"github", "x"}); err == nil {
+		t.Fatal("--org without --host should be rejected")
+	}
+}
+
+func TestConfigTokenMigrate(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	keyring := config.NewMemoryTokenStore()
+	file := config.NewMemoryTokenStore()
+	prevNew := newMigrationStore
+	newMigrationStore = func(backend string) (config.TokenStore, error) {
+		if backend == config.TokenBackendFile {
+			return file, nil
+		}
+		return keyring, nil
+	}
+	t.Cleanup(func() {
+		newMigrationStore = prevNew
+		tokenMigrateFrom, tokenMigrateTo = "", ""
+		tokenMigrateDeleteSource, tokenMigrateDryRun = false, false
+		configTokenMigrateCmd.SetOut(nil)
+	})
+	_ = keyring.Set("github", "gh")
+
+	var out strings.Builder
+	configTokenMigrateCmd.SetOut(&out)
+	tokenMigrateFrom, tokenMigrateTo = "keyring", "file"
+	tokenMigrateDryRun = true
+	if err := runConfigTokenMigrate(configTokenMigrateCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if tok, _ := file.Get("github"); tok != "" {
+		t.Fatal("dry run wrote the destination")
+	}
+	if !strings.Contains(out.String(), "Would migrate 1 token") {
+		t.Fatalf("dry-run output: %q", out.String())
+	}
+
+	out.Reset()
+	tokenMigrateDryRun, tokenMigrateDeleteSource = false, true
+	if err := runConfigTokenMigrate(configTokenMigrateCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if tok, _ := file.Get("github"); tok != "gh" {
+		t.Fatalf("destination = %q", tok)
+	}
+	if tok, _ := keyring.Get("github"); tok != "" {
+		t.Fatal("--delete-source left the keychain copy")
+	}
+	if !strings.Contains(out.String(), "moved") {
+		t.Fatalf("output: %q", out.String())
+	}
+
+	tokenMigrateFrom, tokenMigrateTo = "file", "file"
+	if err := runConfigTokenMigrate(configTokenMigrateCmd, nil); err == nil {
+		t.Fatal("same source and destination accepted")
+	}
+}
@@ -6,12 +6,15 @@ import (
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
 )
 
-// conflictCmd represents the conflict detection command group.
+// conflictCmd represents the conflict detection and resolution command group.
 var conflictCmd = &cobra.Command{
 	Use:   "conflict",
-	Short: "Pre-merge conflict detection",
+	Short: "Detect conflicts before merging and resolve them after",
 	Long: cliutil.QuickStartHelp(`  # Detect conflicts before merging
-  gz-git conflict detect feature/new-feature main`),
+  gz-git conflict detect feature/new-feature main
+
+  # Resolve the conflicts a merge or rebase stopped on
+  gz-git conflict resolve`),
 	Example: ``,
 	Args:    cobra.NoArgs,
 }
@@ -2,40 +2,68 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/gizzahub/gzh-cli-gitforge/internal/gitcmd"
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/merge"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
 )
 
 var (
+	detectFlags         BulkCommandFlags
 	detectIncludeBinary bool
 	detectBaseCommit    string
 )
 
 // detectCmd represents the conflict detect command.
 var detectCmd = &cobra.Command{
-	Use:   "detect <source> <target>",
-	Short: "Detect potential merge conflicts",
-	Long: cliutil.QuickStartHelp(`  # Detect conflicts between branches
+	Use:   "detect <source> <target> [directory]",
+	Short: "Detect merge conflicts before merging",
+	Long: `Merge source into target in memory with git merge-tree and report the paths
+a real merge would leave conflicted: content, add/add, modify/delete,
+rename/delete, rename/rename and binary conflicts, with the hunks of each text
+conflict. Neither the working tree nor any ref is touched.
+
+With a directory, every repository under it is checked: repositories that
+have both branches are merged in memory, the others are skipped.
+
+Git older than 2.38 has no merge-tree --write-tree; detect then falls back to
+flagging the files both branches changed, and says so.
+` + cliutil.QuickStartHelp(`  # Detect conflicts between branches
   gz-git conflict detect feature/new-feature main
 
+  # Show each conflict's hunks (ours = target, theirs = source)
+  gz-git conflict detect feature/new-feature main -v
+
   # Include binary file conflicts
   gz-git conflict detect feature/new-feature main --include-binary
 
-  # Detect with specific base commit
-  gz-git conflict detect feature/new-feature main --base abc123`) + cliutil.ExitCodesConflictHelp(),
+  # Detect with specific base commit (git 2.40+)
+  gz-git conflict detect feature/new-feature main --base abc123
+
+  # Check one branch against main in every repository under ~/src
+  gz-git conflict detect feature/api-v2 main -d 2 ~/src`) + cliutil.ExitCodesConflictHelp(),
 	Example: ``,
-	Args:    cobra.ExactArgs(2),
+	Args:    cobra.RangeArgs(2, 3),
 	RunE:    runConflictDetect,
 }
 
 func init() {
 	conflictCmd.AddCommand(detectCmd)
 
+	addBulkFlagsWithOpts(detectCmd, &detectFlags, BulkFlagOptions{
+		SkipDryRun: true,
+		SkipFetch:  true,
+		SkipWatch:  true,
+	})
 	detectCmd.Flags().BoolVar(&detectIncludeBinary, "include-binary", false, "include binary file conflicts")
 	detectCmd.Flags().StringVar(&detectBaseCommit, "base", "", "base commit for three-way merge")
 }
@@ -44,9 +72,20 @@ func init() {
 // convention: 0 = no conflict (clean), 1 = conflict found, 2 = execution error.
 // This lets scripts branch on "is there a conflict?" via the exit code alone.
 func runConflictDetect(cmd *cobra.Command, args []string) error {
+	if err := validateBulkFormat(detectFlags.Format); err != nil {
+		return cliutil.NewExitError(2, err)
+	}
+	opts := merge.DetectOptions{
+		Source:        args[0],
+		Target:        args[1],
+		BaseCommit:    detectBaseCommit,
+		IncludeBinary: detectIncludeBinary,
+	}
+	if len(args) == 3 {
+		return runConflictDetectBulk(cmd, args[2], opts)
+	}
+
 	ctx := context.Background()
-	source := args[0]
-	target := args[1]
 
 	repo, err := openCurrentRepo(ctx)
 	if err != nil {
@@ -54,17 +93,10 @@ func runConflictDetect(cmd *cobra.Command, args []string) error {
 	}
 
 	detector := merge.NewConflictDetector(gitcmd.NewExecutor())
+	machine := cliutil.IsMachineFormat(detectFlags.Format)
 
-	// Build options
-	opts := merge.DetectOptions{
-		Source:        source,
-		Target:        target,
-		BaseCommit:    detectBaseCommit,
-		IncludeBinary: detectIncludeBinary,
-	}
-
-	if !quiet {
-		fmt.Printf("Analyzing conflicts: %s → %s\n", source, target)
+	if !quiet && !machine {
+		fmt.Printf("Analyzing conflicts: %s → %s\n", opts.Source, opts.Target)
 	}
 
 	// Detect conflicts
@@ -74,25 +106,28 @@ func runConflictDetect(cmd *cobra.Command, args []string) error {
 	}
 
 	// Display report
-	if !quiet {
+	switch {
+	case machine:
+		writeBulkOutput(detectFlags.Format, report)
+	case !quiet:
 		fmt.Println()
 		if report.TotalConflicts > 0 {
-			fmt.Printf("⚠ Found %d potential conflicts:\n\n", report.TotalConflicts)
+			fmt.Printf("⚠ Found %d conflicts:\n\n", report.TotalConflicts)
 			for _, conflict := range report.Conflicts {
-				fmt.Printf("  %s: %s\n", conflict.ConflictType, conflict.FilePath)
-				if verbose && conflict.Description != "" {
-					fmt.Printf("     Reason: %s\n", conflict.Description)
-				}
+				printConflict(cmd.OutOrStdout(), "  ", conflict)
 			}
 			fmt.Println()
 			fmt.Printf("Difficulty: %s\n", report.Difficulty)
-			fmt.Printf("Auto-resolvable: %d/%d\n", report.CanAutoResolve, report.TotalConflicts)
 		} else {
 			fmt.Println("✓ No conflicts detected - merge should be clean!")
 		}
+		if report.Heuristic {
+			fmt.Println()
+			fmt.Println("Note: git is older than 2.38, so these are files changed on both sides, not a real merge.")
+		}
 
 		// Check fast-forward
-		canFF, err := detector.CanFastForward(ctx, repo, source, target)
+		canFF, err := detector.CanFastForward(ctx, repo, opts.Source, opts.Target)
 		if err == nil && canFF {
 			fmt.Println()
 			fmt.Println("Tip: This merge can be fast-forwarded")
@@ -105,3 +140,227 @@ func runConflictDetect(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// printConflict writes one conflict line and, with --verbose, git's message
+// and the conflict's hunks.
+func printConflict(w io.Writer, indent string, c *merge.Conflict) {
+	line := fmt.Sprintf("%s%s: %s", indent, c.ConflictType, c.FilePath)
+	if c.OldPath != "" {
+		line += " (from " + c.OldPath + ")"
+	}
+	if n := len(c.Hunks); n > 0 {
+		line += fmt.Sprintf(" [%d %s]", n, pluralize(n, "hunk", "hunks"))
+	}
+	fmt.Fprintln(w, line)
+	if !verbose {
+		return
+	}
+	if c.Description != "" {
+		fmt.Fprintf(w, "%s   %s\n", indent, c.Description)
+	}
+	for i, h := range c.Hunks {
+		fmt.Fprintf(w, "%s   hunk %d:\n", indent, i+1)
+		writeHunkSide(w, indent, "ours", h.OursLabel, h.Ours)
+		if h.HasBase {
+			writeHunkSide(w, indent, "base", h.BaseLabel, h.Base)
+		}
+		writeHunkSide(w, indent, "theirs", h.TheirsLabel, h.Theirs)
+	}
+}
+
+func writeHunkSide(w io.Writer, indent, side, label string, lines []string) {
+	if label != "" {
+		side += " (" + label + ")"
+	}
+	fmt.Fprintf(w, "%s     %s:\n", indent, side)
+	for _, l := range lines {
+		fmt.Fprintf(w, "%s       │ %s\n", indent, l)
+	}
+}
+
+// ConflictDetectJSONOutput is the structured contract for bulk
+// `gz-git conflict detect`.
+type ConflictDetectJSONOutput struct {
+	Source       string                   `json:"source"`
+	Target       string                   `json:"target"`
+	TotalScanned int                      `json:"total_scanned"`
+	DurationMs   int64                    `json:"duration_ms"`
+	Summary      ConflictDetectSummary    `json:"summary"`
+	Repositories []ConflictDetectRepoJSON `json:"repositories"`
+}
+
+// ConflictDetectSummary counts repositories by outcome.
+type ConflictDetectSummary struct {
+	Clean      int `json:"clean"`
+	Conflicted int `json:"conflicted"`
+	Skipped    int `json:"skipped"`
+	Errors     int `json:"errors"`
+}
+
+// ConflictDetectRepoJSON is one repository's outcome. Status is clean,
+// conflict, skipped (a branch is missing) or error.
+type ConflictDetectRepoJSON struct {
+	Path         string                `json:"path"`
+	RelativePath string                `json:"relative_path"`
+	Status       string                `json:"status"`
+	Report       *merge.ConflictReport `json:"report,omitempty"`
+	Message      string                `json:"message,omitempty"`
+}
+
+func runConflictDetectBulk(cmd *cobra.Command, directory string, opts merge.DetectOptions) error {
+	ctx := cmdContext(cmd)
+
+	if _, err := validateBulkDirectory([]string{directory}); err != nil {
+		return cliutil.NewExitError(2, err)
+	}
+	if err := validateBulkDepth(cmd, detectFlags.Depth); err != nil {
+		return cliutil.NewExitError(2, err)
+	}
+
+	if shouldShowProgress(detectFlags.Format, quiet) {
+		printScanningMessage(directory, detectFlags.Depth, detectFlags.Parallel, false)
+	}
+
+	client := repository.NewClient()
+	result, err := client.BulkStatus(ctx, repository.BulkStatusOptions{
+		Directory:         directory,
+		Parallel:          detectFlags.Parallel,
+		MaxDepth:          detectFlags.Depth,
+		IncludeSubmodules: detectFlags.IncludeSubmodules,
+		IncludePattern:    detectFlags.Include,
+		ExcludePattern:    detectFlags.Exclude,
+		Verbose:           verbose,
+		Logger:            createBulkLogger(verbose),
+	})
+	if err != nil {
+		return cliutil.NewExitError(2, fmt.Errorf("scan failed: %w", err))
+	}
+
+	out := detectAcross(ctx, client, result, opts, detectFlags.Parallel)
+
+	switch detectFlags.Format {
+	case "json", "llm":
+		writeBulkOutput(detectFlags.Format, out)
+	default:
+		if !quiet {
+			renderConflictDetect(cmd.OutOrStdout(), out, detectFlags.Format == "compact")
+		}
+	}
+
+	switch {
+	case out.Summary.Conflicted > 0:
+		return cliutil.NewExitError(1, fmt.Errorf("%d of %d repositories would conflict", out.Summary.Conflicted, len(out.Repositories)))
+	case out.Summary.Errors > 0:
+		return cliutil.NewExitError(2, fmt.Errorf("%d of %d repositories could not be checked", out.Summary.Errors, len(out.Repositories)))
+	}
+	return nil
+}
+
+// detectAcross runs the in-memory merge in every scanned repository.
+func detectAcross(ctx context.Context, client repository.Client, result *repository.BulkStatusResult, opts merge.DetectOptions, parallel int) ConflictDetectJSONOutput {
+	detector := merge.NewConflictDetector(gitcmd.NewExecutor())
+	repos := make([]ConflictDetectRepoJSON, len(result.Repositories))
+
+	if parallel < 1 {
+		parallel = 1
+	}
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(parallel)
+	for i, status := range result.Repositories {
+		g.Go(func() error {
+			repos[i] = detectInRepo(gctx, client, detector, status, opts)
+			return nil // the error lives in the entry
+		})
+	}
+	_ = g.Wait()
+
+	out := ConflictDetectJSONOutput{
+		Source:       opts.Source,
+		Target:       opts.Target,
+		TotalScanned: result.TotalScanned,
+		DurationMs:   result.Duration.Milliseconds(),
+		Repositories: repos,
+	}
+	for _, r := range repos {
+		switch r.Status {
+		case "clean":
+			out.Summary.Clean++
+		case "conflict":
+			out.Summary.Conflicted++
+		case "skipped":
+			out.Summary.Skipped++
+		default:
+			out.Summary.Errors++
+		}
+	}
+	return out
+}
+
+func detectInRepo(ctx context.Context, client repository.Client, detector merge.ConflictDetector, status repository.RepositoryStatusResult, opts merge.DetectOptions) ConflictDetectRepoJSON {
+	entry := ConflictDetectRepoJSON{Path: status.Path, RelativePath: status.RelativePath}
+	if entry.RelativePath == "" {
+		entry.RelativePath = status.Path
+	}
+	if status.Error != nil {
+		entry.Status, entry.Message = "error", status.Error.Error()
+		return entry
+	}
+
+	repo, err := client.Open(ctx, status.Path)
+	if err != nil {
+		entry.Status, entry.Message = "error", err.Error()
+		return entry
+	}
+	report, err := detector.Detect(ctx, repo, opts)
+	switch {
+	case errors.Is(err, merge.ErrBranchNotFound):
+		// Most repositories in a workspace will not have a feature branch;
+		// that is not a failure.
+		entry.Status, entry.Message = "skipped", err.Error()
+	case err != nil:
+		entry.Status, entry.Message = "error", err.Error()
+	case report.TotalConflicts > 0:
+		entry.Status, entry.Report = "conflict", report
+	default:
+		entry.Status, entry.Report = "clean", report
+	}
+	return entry
+}
+
+func renderConflictDetect(w io.Writer, out ConflictDetectJSONOutput, compact bool) {
+	fmt.Fprintf(w, "\nMerging %s → %s in %d repositories\n\n", out.Source, out.Target, len(out.Repositories))
+	for _, r := range out.Repositories {
+		switch r.Status {
+		case "clean":
+			if !compact {
+				fmt.Fprintf(w, "✓ %s: clean\n", r.RelativePath)
+			}
+		case "conflict":
+			n := r.Report.TotalConflicts
+			fmt.Fprintf(w, "⚠ %s: %d %s\n", r.RelativePath, n, pluralize(n, "conflict", "conflicts"))
+			if !compact {
+				for _, c := range r.Report.Conflicts {
+					printConflict(w, "    ", c)
+				}
+			}
+		case "skipped":
+			if !compact && verbose {
+				fmt.Fprintf(w, "⊘ %s: skipped (%s)\n", r.RelativePath, r.Message)
+			}
+		default:
+			fmt.Fprintf(w, "✗ %s: %s\n", r.RelativePath, r.Message)
+		}
+	}
+
+	parts := []string{
+		fmt.Sprintf("%d clean", out.Summary.Clean),
+		fmt.Sprintf("%d conflicted", out.Summary.Conflicted),
+	}
+	if out.Summary.Skipped > 0 {
+		parts = append(parts, fmt.Sprintf("%d without both branches", out.Summary.Skipped))
+	}
+	if out.Summary.Errors > 0 {
+		parts = append(parts, fmt.Sprintf("%d errors", out.Summary.Errors))
+	}
+	fmt.Fprintf(w, "\nSummary: %s (%s)\n", strings.Join(parts, ", "), time.Duration(out.DurationMs)*time.Millisecond)
+}
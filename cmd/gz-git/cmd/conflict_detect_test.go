@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+)
+
+// TestConflictDetectBulk checks one branch against target across a
+// workspace: a repository that conflicts, one that merges cleanly, and one
+// without the branch at all.
+func TestConflictDetectBulk(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	parent := t.TempDir()
+	for name, conflicting := range map[string]bool{"conflicted": true, "clean": false} {
+		dir := newTwoBranchRepo(t, conflicting)
+		if err := os.Rename(dir, filepath.Join(parent, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	other := filepath.Join(parent, "other")
+	if err := os.Mkdir(other, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, other, "init", "--quiet")
+	runGit(t, other, "config", "user.email", "test@test.com")
+	runGit(t, other, "config", "user.name", "Test")
+	writeFile(t, other, "x.txt", "x\n")
+	runGit(t, other, "add", ".")
+	runGit(t, other, "commit", "--quiet", "-m", "x")
+
+	prevFlags, prevQuiet := detectFlags, quiet
+	t.Cleanup(func() { detectFlags, quiet = prevFlags, prevQuiet })
+	detectFlags = BulkCommandFlags{Depth: 1, Parallel: 2, Format: "json"}
+
+	var runErr error
+	stdout := captureStdout(t, func() {
+		runErr = runConflictDetect(detectCmd, []string{"source", "target", parent})
+	})
+	if got := cliutil.ExitCodeForError(runErr); got != 1 {
+		t.Errorf("exit code = %d, want 1 (a repository conflicts); err=%v", got, runErr)
+	}
+
+	var out ConflictDetectJSONOutput
+	if err := json.Unmarshal([]byte(stdout), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if out.Summary != (ConflictDetectSummary{Clean: 1, Conflicted: 1, Skipped: 1}) {
+		t.Errorf("summary = %+v", out.Summary)
+	}
+	for _, r := range out.Repositories {
+		if r.RelativePath != "conflicted" {
+			continue
+		}
+		if r.Status != "conflict" || r.Report == nil || len(r.Report.Conflicts) != 1 {
+			t.Fatalf("conflicted repo = %+v", r)
+		}
+		c := r.Report.Conflicts[0]
+		if c.FilePath != "base.txt" || len(c.Hunks) != 1 || c.Hunks[0].Theirs[0] != "source change" {
+			t.Errorf("conflict = %+v", c)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/merge"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/tui"
+)
+
+var (
+	resolveFlags    BulkCommandFlags
+	resolveList     bool
+	resolveTake     string
+	resolveContinue bool
+	resolveAbort    bool
+)
+
+// resolveCmd resolves conflicts a merge, rebase, cherry-pick or revert
+// stopped on, in one repository or every repository under a directory.
+var resolveCmd = &cobra.Command{
+	Use:   "resolve [directory]",
+	Short: "Resolve conflicts of stopped merges and rebases, hunk by hunk",
+	Long: `Find every repository under the directory stopped by conflicts - a merge,
+rebase, cherry-pick or revert, such as the rebases a bulk update leaves
+behind - and resolve them in a full-screen view. Each unmerged path is shown
+hunk by hunk with ours, the merge base and theirs; take ours, theirs, both, or
+edit the hunk in $EDITOR. A file is staged once every hunk is resolved, and the
+operation can then be continued or aborted from the same screen. A rebase
+that stops on the next commit shows its new conflicts straight away.
+
+During a rebase "ours" is the upstream being rebased onto and "theirs" is
+your commit being replayed.
+
+When rerere is enabled, resolutions are recorded as they are staged, and files
+rerere already resolved from a recorded resolution are offered as a whole:
+keep the working tree (w) to accept the replayed result.
+
+Keys:
+  ↑↓ Enter    choose a repository and file
+  o t b e     take ours, theirs, both (ours then theirs), or edit
+  u           undo the hunk's choice      n/p   next / previous hunk
+  s           save                        Esc   save and go back
+  c           continue the operation      A     abort it (asks first)
+
+--list, --take, --continue and --abort do the same without the screen.
+` + cliutil.QuickStartHelp(`  # Resolve the conflicts in the current repository
+  gz-git conflict resolve
+
+  # Every repository a bulk update left mid-rebase
+  gz-git conflict resolve ~/src
+
+  # List what is stuck, without opening the screen
+  gz-git conflict resolve --list ~/src
+
+  # Keep the upstream side everywhere and continue
+  gz-git conflict resolve --take ours --continue ~/src
+
+  # Give up on every stopped operation
+  gz-git conflict resolve --abort ~/src`),
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConflictResolve,
+}
+
+func init() {
+	conflictCmd.AddCommand(resolveCmd)
+
+	addBulkFlagsWithOpts(resolveCmd, &resolveFlags, BulkFlagOptions{
+		SkipDryRun: true,
+		SkipFetch:  true,
+		SkipFormat: true,
+		SkipWatch:  true,
+	})
+	resolveCmd.Flags().BoolVar(&resolveList, "list", false, "list stopped repositories and their unmerged paths, then exit")
+	resolveCmd.Flags().StringVar(&resolveTake, "take", "", "resolve every unmerged path with one side: ours or theirs")
+	resolveCmd.Flags().BoolVar(&resolveContinue, "continue", false, "continue each operation once nothing is unmerged")
+	resolveCmd.Flags().BoolVar(&resolveAbort, "abort", false, "abort each stopped operation")
+}
+
+func runConflictResolve(cmd *cobra.Command, args []string) error {
+	directory, err := validateBulkDirectory(args)
+	if err != nil {
+		return err
+	}
+	if err := validateBulkDepth(cmd, resolveFlags.Depth); err != nil {
+		return err
+	}
+
+	var take merge.Resolution
+	switch resolveTake {
+	case "":
+	case "ours":
+		take = merge.ResolveOurs
+	case "theirs":
+		take = merge.ResolveTheirs
+	default:
+		return fmt.Errorf("invalid --take %q: use ours or theirs", resolveTake)
+	}
+	if resolveAbort && (take != "" || resolveContinue) {
+		return fmt.Errorf("--abort cannot be combined with --take or --continue")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client := repository.NewClient()
+	resolver := merge.NewResolver(gitcmd.NewExecutor(), client)
+	repos, err := findStoppedRepos(ctx, client, resolver, directory)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if len(repos) == 0 {
+		if !quiet {
+			fmt.Fprintln(out, "✓ No repository is stopped by conflicts")
+		}
+		return nil
+	}
+
+	switch {
+	case resolveList:
+		printStoppedRepos(out, directory, repos)
+		return nil
+	case resolveAbort:
+		return applyToStopped(out, directory, repos, "aborted", func(repo *merge.RepoConflicts) error {
+			if repo.Operation == merge.OperationNone {
+				return fmt.Errorf("unmerged paths but no operation to abort")
+			}
+			return resolver.Abort(ctx, repo.Path)
+		})
+	case take != "" || resolveContinue:
+		done := "resolved"
+		if resolveContinue {
+			done = "continued"
+		}
+		return applyToStopped(out, directory, repos, done, func(repo *merge.RepoConflicts) error {
+			return takeAndContinue(ctx, resolver, repo, take, resolveContinue)
+		})
+	}
+
+	root, _ := filepath.Abs(directory)
+	model := tui.NewResolveModel(ctx, resolver, root, repos)
+	if _, err := tea.NewProgram(model, tea.WithAltScreen(), tea.WithContext(ctx)).Run(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("TUI error: %w", err)
+	}
+	return nil
+}
+
+// findStoppedRepos scans directory and keeps the repositories with an
+// operation in progress or unmerged paths.
+func findStoppedRepos(ctx context.Context, client repository.Client, resolver merge.Resolver, directory string) ([]*merge.RepoConflicts, error) {
+	status, err := client.BulkStatus(ctx, repository.BulkStatusOptions{
+		Directory:         directory,
+		Parallel:          resolveFlags.Parallel,
+		MaxDepth:          resolveFlags.Depth,
+		IncludeSubmodules: resolveFlags.IncludeSubmodules,
+		IncludePattern:    resolveFlags.Include,
+		ExcludePattern:    resolveFlags.Exclude,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan failed: %w", err)
+	}
+
+	var repos []*merge.RepoConflicts
+	for _, path := range repositoryPaths(status.Repositories) {
+		state, err := resolver.Inspect(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if state.Stuck() {
+			repos = append(repos, state)
+		}
+	}
+	return repos, nil
+}
+
+// takeAndContinue resolves every unmerged path of repo with take (when set)
+// and continues the operation (when cont is set). A rebase stopping on the
+// next commit is taken again, so --take ours --continue replays a whole
+// branch.
+func takeAndContinue(ctx context.Context, resolver merge.Resolver, repo *merge.RepoConflicts, take merge.Resolution, cont bool) error {
+	for {
+		if take != "" {
+			for _, path := range repo.Unmerged {
+				file, err := resolver.Load(ctx, repo.Path, path)
+				if err != nil {
+					return err
+				}
+				file.TakeAll(take)
+				if _, err := resolver.Save(ctx, repo.Path, file); err != nil {
+					return err
+				}
+			}
+		}
+		if !cont || repo.Operation == merge.OperationNone {
+			return nil
+		}
+
+		err := resolver.Continue(ctx, repo.Path)
+		if !errors.Is(err, merge.ErrStoppedAgain) || take == "" {
+			return err
+		}
+		next, err := resolver.Inspect(ctx, repo.Path)
+		if err != nil {
+			return err
+		}
+		repo = next
+	}
+}
+
+// applyToStopped runs fn on each repository and prints one line per
+// outcome. It fails when any repository failed.
+func applyToStopped(out io.Writer, directory string, repos []*merge.RepoConflicts, done string, fn func(*merge.RepoConflicts) error) error {
+	failed := 0
+	for _, repo := range repos {
+		name := displayRepoPath(directory, repo.Path)
+		if err := fn(repo); err != nil {
+			failed++
+			fmt.Fprintf(out, "✗ %s: %v\n", name, err)
+			continue
+		}
+		if !quiet {
+			fmt.Fprintf(out, "✓ %s: %s\n", name, done)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d repositories failed", failed, len(repos))
+	}
+	return nil
+}
+
+func printStoppedRepos(out io.Writer, directory string, repos []*merge.RepoConflicts) {
+	for _, repo := range repos {
+		op := string(repo.Operation)
+		if op == "" {
+			op = "unmerged paths"
+		}
+		if repo.Rerere {
+			op += ", rerere"
+		}
+		fmt.Fprintf(out, "%s (%s)\n", displayRepoPath(directory, repo.Path), op)
+		if len(repo.Unmerged) == 0 {
+			fmt.Fprintln(out, "  nothing unmerged; ready to continue")
+		}
+		for _, path := range repo.Unmerged {
+			fmt.Fprintf(out, "  %s\n", path)
+		}
+	}
+}
+
+// displayRepoPath shows path relative to the scanned directory.
+func displayRepoPath(directory, path string) string {
+	root, err := filepath.Abs(directory)
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
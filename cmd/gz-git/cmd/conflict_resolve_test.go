@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// stoppedRebase makes dir/name a repository whose feature branch is stopped
+// mid-rebase on a conflict in a.txt.
+func stoppedRebase(t *testing.T, dir, name string) string {
+	t.Helper()
+	repo := filepath.Join(dir, name)
+	if err := os.MkdirAll(repo, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "init", "-q", "-b", "main")
+	runGit(t, repo, "config", "user.name", "Test User")
+	runGit(t, repo, "config", "user.email", "test@example.com")
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, "a.txt"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("base\n")
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-q", "-m", "base")
+	runGit(t, repo, "checkout", "-q", "-b", "feature")
+	write("feature\n")
+	runGit(t, repo, "commit", "-q", "-am", "feature")
+	runGit(t, repo, "checkout", "-q", "main")
+	write("main\n")
+	runGit(t, repo, "commit", "-q", "-am", "main")
+	runGit(t, repo, "checkout", "-q", "feature")
+	runGitAllowFail(t, repo, "rebase", "main")
+	return repo
+}
+
+func setResolveTestGlobals(t *testing.T) {
+	t.Helper()
+	prevFlags, prevList, prevTake := resolveFlags, resolveList, resolveTake
+	prevContinue, prevAbort := resolveContinue, resolveAbort
+	t.Cleanup(func() {
+		resolveFlags, resolveList, resolveTake = prevFlags, prevList, prevTake
+		resolveContinue, resolveAbort = prevContinue, prevAbort
+	})
+	resolveFlags = BulkCommandFlags{Depth: 1, Parallel: 2}
+	resolveList, resolveTake, resolveContinue, resolveAbort = false, "", false, false
+}
+
+func TestConflictResolveBulk(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	parent := t.TempDir()
+	one := stoppedRebase(t, parent, "one")
+	two := stoppedRebase(t, parent, "two")
+
+	setResolveTestGlobals(t)
+	resolveList = true
+	var out bytes.Buffer
+	resolveCmd.SetOut(&out)
+	t.Cleanup(func() { resolveCmd.SetOut(nil) })
+
+	if err := runConflictResolve(resolveCmd, []string{parent}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"one (rebase)", "two (rebase)", "  a.txt"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("--list output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	// Keep the replayed commit's side and finish both rebases.
+	resolveList, resolveTake, resolveContinue = false, "theirs", true
+	out.Reset()
+	if err := runConflictResolve(resolveCmd, []string{parent}); err != nil {
+		t.Fatalf("--take theirs --continue: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "✓ one: continued") {
+		t.Errorf("output:\n%s", out.String())
+	}
+	for _, repo := range []string{one, two} {
+		data, _ := os.ReadFile(filepath.Join(repo, "a.txt"))
+		if string(data) != "feature\n" {
+			t.Errorf("%s: a.txt = %q", repo, data)
+		}
+		if _, err := os.Stat(filepath.Join(repo, ".git", "rebase-merge")); !os.IsNotExist(err) {
+			t.Errorf("%s: rebase still in progress", repo)
+		}
+	}
+
+	out.Reset()
+	resolveTake, resolveContinue = "", false
+	if err := runConflictResolve(resolveCmd, []string{parent}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "No repository is stopped") {
+		t.Errorf("output after continuing:\n%s", out.String())
+	}
+}
+
+func TestConflictResolveAbort(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	parent := t.TempDir()
+	repo := stoppedRebase(t, parent, "app")
+
+	setResolveTestGlobals(t)
+	resolveAbort, resolveContinue = true, true
+	if err := runConflictResolve(resolveCmd, []string{parent}); err == nil {
+		t.Error("--abort with --continue should be rejected")
+	}
+
+	resolveContinue = false
+	var out bytes.Buffer
+	resolveCmd.SetOut(&out)
+	t.Cleanup(func() { resolveCmd.SetOut(nil) })
+	if err := runConflictResolve(resolveCmd, []string{parent}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(filepath.Join(repo, "a.txt"))
+	if string(data) != "feature\n" {
+		t.Errorf("abort should restore the branch, a.txt = %q", data)
+	}
+}
+
+func TestConflictResolveRejectsBadTake(t *testing.T) {
+	setResolveTestGlobals(t)
+	resolveTake = "both"
+	if err := runConflictResolve(resolveCmd, []string{t.TempDir()}); err == nil || !strings.Contains(err.Error(), "ours or theirs") {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -24,8 +24,6 @@ func TestConfirmDestructiveAndInterrupt(t *testing.T) {
 		t.Error("expected canceled context")
 	}
 	_ = stdinIsInteractive()
-	playNotificationSound()
-	_ = playSystemSound()
 }
 
 func TestRunBulkWatch_OneShotCancel(t *testing.T) {
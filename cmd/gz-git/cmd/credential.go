@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/credential"
+)
+
+var credentialCmd = &cobra.Command{
+	Use:   "credential",
+	Short: "Git credential helper backed by gz-git token storage",
+	Long: `Serve git's HTTPS authentication from the tokens stored with
+'gz-git config token set', so one stored token covers both forge API calls and
+plain git fetch/push. Git runs these subcommands itself; they read a credential
+description on stdin and follow the git-credential(1) protocol.
+
+Tokens are routed by host, and by org when credential.useHttpPath is set:
+a token stored with --host/--org wins, then the provider-wide sources
+(GITHUB_TOKEN/GITLAB_TOKEN/GITEA_TOKEN/GZ_GIT_TOKEN, then the keychain).
+Provider-wide tokens go only to github.com, gitlab.com, gitea.com and
+codeberg.org; other hosts are answered only when declared under
+credentials: in the global config. Only https requests are answered.
+
+` + cliutil.QuickStartHelp(`  # Use gz-git for every HTTPS remote
+  git config --global credential.helper '!gz-git credential'
+
+  # ...or only for one host, keeping the system helper for the rest
+  git config --global credential.https://ghe.corp.example.com.helper '!gz-git credential'
+
+  # Scope tokens by org as well as host
+  git config --global credential.useHttpPath true`),
+}
+
+var credentialGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Print the credential for the host described on stdin",
+	Args:  cobra.NoArgs,
+	RunE:  runCredentialGet,
+}
+
+var credentialStoreCmd = &cobra.Command{
+	Use:   "store",
+	Short: "Remember a credential git reports as working",
+	Args:  cobra.NoArgs,
+	RunE:  runCredentialStore,
+}
+
+var credentialEraseCmd = &cobra.Command{
+	Use:   "erase",
+	Short: "Forget a credential git reports as rejected",
+	Args:  cobra.NoArgs,
+	RunE:  runCredentialErase,
+}
+
+func init() {
+	rootCmd.AddCommand(credentialCmd)
+	credentialCmd.AddCommand(credentialGetCmd)
+	credentialCmd.AddCommand(credentialStoreCmd)
+	credentialCmd.AddCommand(credentialEraseCmd)
+}
+
+func newCredentialHelper() *credential.Helper {
+	return &credential.Helper{Credentials: config.LoadCredentials()}
+}
+
+// runCredentialGet prints nothing when gz-git has no token for the host. Git
+// treats empty output as "no answer" and moves on to the next helper or the
+// prompt, which is the only correct way for a helper to decline. A token
+// reference that fails to resolve is an error, which git shows on stderr
+// before it moves on.
+func runCredentialGet(cmd *cobra.Command, _ []string) error {
+	req, err := credential.Parse(cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+	resp, ok, err := newCredentialHelper().Get(req)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	if err := resp.Write(cmd.OutOrStdout()); err != nil {
+		return fmt.Errorf("write credential: %w", err)
+	}
+	return nil
+}
+
+func runCredentialStore(cmd *cobra.Command, _ []string) error {
+	req, err := credential.Parse(cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+	return newCredentialHelper().Store(req)
+}
+
+func runCredentialErase(cmd *cobra.Command, _ []string) error {
+	req, err := credential.Parse(cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+	return newCredentialHelper().Erase(req)
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+)
+
+func TestCredentialGet_Protocol(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GZ_GIT_TOKEN", "")
+	mem := config.NewMemoryTokenStore()
+	prev := config.DefaultTokenStore
+	config.SetTokenStore(mem)
+	t.Cleanup(func() {
+		config.SetTokenStore(prev)
+		credentialGetCmd.SetIn(nil)
+		credentialGetCmd.SetOut(nil)
+	})
+	_ = mem.Set("github@github.com", "stored-token")
+
+	var out bytes.Buffer
+	credentialGetCmd.SetIn(strings.NewReader("protocol=https\nhost=github.com\n\n"))
+	credentialGetCmd.SetOut(&out)
+	if err := runCredentialGet(credentialGetCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "username=x-access-token\npassword=stored-token\n\n" {
+		t.Fatalf("get output = %q", out.String())
+	}
+
+	// No token for the host: say nothing so git falls through.
+	out.Reset()
+	credentialGetCmd.SetIn(strings.NewReader("protocol=https\nhost=gitlab.com\n\n"))
+	if err := runCredentialGet(credentialGetCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("declining get printed %q", out.String())
+	}
+}
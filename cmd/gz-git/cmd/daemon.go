@@ -0,0 +1,321 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/daemon"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/reposync"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/watch"
+)
+
+var (
+	daemonDepth    int
+	daemonParallel int
+	daemonFetch    time.Duration
+	daemonRescan   time.Duration
+	daemonSocket   string
+	daemonJSON     bool
+)
+
+// viaDaemonEnv turns --via-daemon on for every status and info run, so a
+// shell profile or an editor integration can opt in once.
+const viaDaemonEnv = "GZ_GIT_VIA_DAEMON"
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon [roots...]",
+	Short: "Serve repository status from an in-memory index",
+	Long: `Index every repository under the given roots (or daemon.roots in the
+global config) and keep the index current from file system events and
+background fetches. status and info with --via-daemon, or with
+GZ_GIT_VIA_DAEMON=1 in the environment, answer from the index instead of
+running git in every repository.
+
+The daemon runs in the foreground; use your service manager (systemd --user,
+launchd) to keep it running. It listens on a Unix socket only you can open.
+
+` + cliutil.QuickStartHelp(`  # Index ~/src two levels deep
+  gz-git daemon ~/src --scan-depth 2
+
+  # Then, from any shell
+  gz-git status --via-daemon ~/src/team
+  GZ_GIT_VIA_DAEMON=1 gz-git info ~/src
+
+  # Is it running, and what does it index?
+  gz-git daemon status
+
+  # Stop it
+  gz-git daemon stop`),
+	// Roots are positional; without this, cobra would read them as unknown
+	// subcommand names.
+	Args: cobra.ArbitraryArgs,
+	RunE: runDaemon,
+}
+
+var daemonStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether the daemon is running and what it indexes",
+	Args:  cobra.NoArgs,
+	RunE:  runDaemonStatus,
+}
+
+var daemonStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the running daemon",
+	Args:  cobra.NoArgs,
+	RunE:  runDaemonStop,
+}
+
+func init() {
+	rootCmd.AddCommand(daemonCmd)
+	daemonCmd.AddCommand(daemonStatusCmd)
+	daemonCmd.AddCommand(daemonStopCmd)
+
+	daemonCmd.PersistentFlags().StringVar(&daemonSocket, "socket", "", "Unix socket path (default: daemon.socket in config, else $XDG_RUNTIME_DIR/gz-git/daemon.sock)")
+	daemonCmd.Flags().IntVarP(&daemonDepth, "scan-depth", "d", repository.DefaultLocalScanDepth, "directory depth to index below each root")
+	daemonCmd.Flags().IntVarP(&daemonParallel, "parallel", "j", repository.DefaultLocalParallel, "repositories checked at once while scanning")
+	daemonCmd.Flags().DurationVar(&daemonFetch, "fetch-interval", 10*time.Minute, "fetch remotes in the background this often (0 = never)")
+	daemonCmd.Flags().DurationVar(&daemonRescan, "rescan-interval", 5*time.Minute, "look for added or removed repositories this often")
+	daemonStatusCmd.Flags().BoolVar(&daemonJSON, "json", false, "print the daemon's self-description as JSON")
+}
+
+func runDaemon(cmd *cobra.Command, args []string) error {
+	cfg := config.LoadDaemonConfig()
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("daemon config: %w", err)
+	}
+
+	roots, err := daemonRoots(cmd, args, cfg)
+	if err != nil {
+		return err
+	}
+	socket, err := resolveDaemonSocket(cfg)
+	if err != nil {
+		return err
+	}
+	fetch := daemonFetch
+	if !cmd.Flags().Changed("fetch-interval") && cfg.FetchInterval != "" {
+		// Validate already rejected a malformed value.
+		fetch, _ = time.ParseDuration(cfg.FetchInterval)
+	}
+	if fetch < 0 || (fetch > 0 && fetch < watch.MinFetchInterval) {
+		return fmt.Errorf("--fetch-interval must be 0 or at least %s", watch.MinFetchInterval)
+	}
+
+	server, err := daemon.NewServer(repository.NewClient(), daemon.Options{
+		Socket:         socket,
+		Roots:          roots,
+		Parallel:       daemonParallel,
+		FetchInterval:  fetch,
+		RescanInterval: daemonRescan,
+		Logger:         newWatchLogger(verbose),
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if !quiet {
+		fmt.Fprintf(cmd.ErrOrStderr(), "gz-git daemon listening on %s\n", socket)
+		for _, root := range roots {
+			fmt.Fprintf(cmd.ErrOrStderr(), "  - %s (depth %d)\n", root.Path, root.Depth)
+		}
+	}
+	return server.Run(ctx)
+}
+
+// daemonRoots resolves what to index: positional roots, else daemon.roots
+// from the global config, else the current directory.
+func daemonRoots(cmd *cobra.Command, args []string, cfg config.DaemonConfig) ([]daemon.Root, error) {
+	depth := daemonDepth
+	if !cmd.Flags().Changed("scan-depth") && cfg.Depth > 0 {
+		depth = cfg.Depth
+	}
+	if depth < 1 {
+		return nil, fmt.Errorf("scan-depth must be at least 1")
+	}
+
+	paths := args
+	if len(paths) == 0 {
+		var err error
+		if paths, err = cfg.RootPaths(); err != nil {
+			return nil, err
+		}
+	}
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	roots := make([]daemon.Root, 0, len(paths))
+	for _, p := range paths {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve path %s: %w", p, err)
+		}
+		if info, err := os.Stat(abs); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("directory does not exist: %s", p)
+		}
+		roots = append(roots, daemon.Root{Path: abs, Depth: depth})
+	}
+	return roots, nil
+}
+
+// resolveDaemonSocket picks the socket: --socket, then daemon.socket, then
+// the default location.
+func resolveDaemonSocket(cfg config.DaemonConfig) (string, error) {
+	if daemonSocket != "" {
+		return daemonSocket, nil
+	}
+	if cfg.Socket != "" {
+		return cfg.Socket, nil
+	}
+	return daemon.DefaultSocketPath()
+}
+
+func runDaemonStatus(cmd *cobra.Command, _ []string) error {
+	socket, err := resolveDaemonSocket(config.LoadDaemonConfig())
+	if err != nil {
+		return err
+	}
+	ping, err := daemon.NewClient(socket).Ping(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if daemonJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(ping)
+	}
+	out := cmd.OutOrStdout()
+	state := "ready"
+	if !ping.Ready {
+		state = "scanning"
+	}
+	fmt.Fprintf(out, "gz-git daemon (pid %d) on %s: %s\n", ping.PID, socket, state)
+	fmt.Fprintf(out, "  Up since:     %s\n", ping.StartedAt.Format(time.DateTime))
+	fmt.Fprintf(out, "  Repositories: %d\n", ping.Repositories)
+	for _, root := range ping.Roots {
+		fmt.Fprintf(out, "  Root:         %s (depth %d)\n", root.Path, root.Depth)
+	}
+	return nil
+}
+
+func runDaemonStop(cmd *cobra.Command, _ []string) error {
+	socket, err := resolveDaemonSocket(config.LoadDaemonConfig())
+	if err != nil {
+		return err
+	}
+	if err := daemon.NewClient(socket).Shutdown(cmd.Context()); err != nil {
+		return err
+	}
+	if !quiet {
+		fmt.Fprintln(cmd.OutOrStdout(), "gz-git daemon stopped")
+	}
+	return nil
+}
+
+// addViaDaemonFlag registers --via-daemon on a read-only bulk command.
+func addViaDaemonFlag(cmd *cobra.Command, target *bool) {
+	cmd.Flags().BoolVar(target, "via-daemon", false,
+		"answer from a running gz-git daemon when it covers the directory (also: "+viaDaemonEnv+"=1)")
+}
+
+// useDaemon reports whether a command should ask the daemon: --via-daemon
+// when given, otherwise GZ_GIT_VIA_DAEMON.
+func useDaemon(cmd *cobra.Command, flag bool) bool {
+	if cmd.Flags().Changed("via-daemon") {
+		return flag
+	}
+	on, _ := strconv.ParseBool(os.Getenv(viaDaemonEnv))
+	return on
+}
+
+// queryDaemon asks the daemon for the repositories a bulk scan of directory
+// with flags would find. ok is false when the daemon is not running or does
+// not cover the directory; the caller then scans as usual. Only an explicit
+// --via-daemon is worth a note, since the environment variable is meant to
+// be set once and forgotten.
+func queryDaemon(ctx context.Context, cmd *cobra.Command, directory string, flags BulkCommandFlags) (daemon.ReposResponse, bool) {
+	dir, err := filepath.Abs(directory)
+	if err != nil {
+		return daemon.ReposResponse{}, false
+	}
+	socket, err := resolveDaemonSocket(config.LoadDaemonConfig())
+	if err != nil {
+		return daemon.ReposResponse{}, false
+	}
+
+	resp, err := daemon.NewClient(socket).Repos(ctx, daemon.Query{
+		Dir:               dir,
+		Depth:             flags.Depth,
+		Include:           flags.Include,
+		Exclude:           flags.Exclude,
+		IncludeSubmodules: flags.IncludeSubmodules,
+	})
+	explicit := cmd.Flags().Changed("via-daemon")
+	switch {
+	case err != nil:
+		if explicit && !quiet {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Note: %v; scanning directly\n", err)
+		}
+		return daemon.ReposResponse{}, false
+	case !resp.Covered:
+		if explicit && !quiet {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Note: the daemon does not index %s at depth %d (or is still scanning); scanning directly\n", dir, flags.Depth)
+		}
+		return daemon.ReposResponse{}, false
+	}
+	return resp, true
+}
+
+// daemonBulkStatus assembles the BulkStatusResult info renders from the
+// daemon's records.
+func daemonBulkStatus(resp daemon.ReposResponse, started time.Time) *repository.BulkStatusResult {
+	results := make([]repository.RepositoryStatusResult, 0, len(resp.Repositories))
+	for _, r := range resp.Repositories {
+		status, _ := r.Results()
+		results = append(results, status)
+	}
+	return &repository.BulkStatusResult{
+		TotalScanned:   len(results),
+		TotalProcessed: len(results),
+		Repositories:   results,
+		Duration:       time.Since(started),
+		Summary:        repository.SummarizeStatus(results),
+	}
+}
+
+// daemonHealthReport assembles the HealthReport status renders from the
+// daemon's records. The daemon never fetches on demand; the upstream side is
+// as of its last background fetch.
+func daemonHealthReport(resp daemon.ReposResponse, started time.Time) *reposync.HealthReport {
+	results := make([]reposync.RepoHealth, 0, len(resp.Repositories))
+	for _, r := range resp.Repositories {
+		_, health := r.Results()
+		results = append(results, health)
+	}
+	return &reposync.HealthReport{
+		Results:       results,
+		Summary:       reposync.SummarizeHealth(results),
+		TotalDuration: time.Since(started),
+		CheckedAt:     time.Now(),
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/daemon"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+func newViaDaemonCmd(target *bool) *cobra.Command {
+	c := &cobra.Command{}
+	addViaDaemonFlag(c, target)
+	return c
+}
+
+func TestUseDaemon(t *testing.T) {
+	var flag bool
+
+	t.Setenv(viaDaemonEnv, "")
+	if useDaemon(newViaDaemonCmd(&flag), flag) {
+		t.Error("off by default")
+	}
+
+	t.Setenv(viaDaemonEnv, "1")
+	if !useDaemon(newViaDaemonCmd(&flag), flag) {
+		t.Errorf("%s=1 should turn it on", viaDaemonEnv)
+	}
+
+	c := newViaDaemonCmd(&flag)
+	_ = c.Flags().Set("via-daemon", "false")
+	if useDaemon(c, flag) {
+		t.Error("--via-daemon=false should win over the environment")
+	}
+}
+
+// TestQueryDaemonFallsBack checks that a missing daemon is not an error: the
+// command scans by itself.
+func TestQueryDaemonFallsBack(t *testing.T) {
+	saved := daemonSocket
+	t.Cleanup(func() { daemonSocket = saved })
+	daemonSocket = filepath.Join(t.TempDir(), "none.sock")
+
+	var flag bool
+	if _, ok := queryDaemon(context.Background(), newViaDaemonCmd(&flag), t.TempDir(), BulkCommandFlags{Depth: 1}); ok {
+		t.Error("queryDaemon() ok without a daemon")
+	}
+}
+
+func TestQueryDaemonAnswersInfoAndStatus(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	root := t.TempDir()
+	repo := filepath.Join(root, "svc")
+	for _, args := range [][]string{
+		{"init", "-q", repo},
+		{"-C", repo, "config", "user.name", "Test User"},
+		{"-C", repo, "config", "user.email", "test@example.com"},
+		{"-C", repo, "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil { //nolint:noctx // test setup
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	saved := daemonSocket
+	t.Cleanup(func() { daemonSocket = saved })
+	daemonSocket = filepath.Join(t.TempDir(), "d.sock")
+
+	server, err := daemon.NewServer(repository.NewClient(), daemon.Options{
+		Socket: daemonSocket,
+		Roots:  []daemon.Root{{Path: root, Depth: 2}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	var flag bool
+	var resp daemon.ReposResponse
+	deadline := time.Now().Add(10 * time.Second)
+	for ok := false; !ok; {
+		if time.Now().After(deadline) {
+			t.Fatal("daemon never covered the root")
+		}
+		time.Sleep(50 * time.Millisecond)
+		resp, ok = queryDaemon(context.Background(), newViaDaemonCmd(&flag), root, BulkCommandFlags{Depth: 1})
+	}
+
+	info := daemonBulkStatus(resp, time.Now())
+	if len(info.Repositories) != 1 || info.Repositories[0].RelativePath != "svc" {
+		t.Fatalf("info repositories = %+v", info.Repositories)
+	}
+	if info.Summary[info.Repositories[0].Status] != 1 {
+		t.Errorf("summary = %v", info.Summary)
+	}
+
+	health := daemonHealthReport(resp, time.Now())
+	if health.Summary.Total != 1 || health.Results[0].Repo.TargetPath != repo {
+		t.Errorf("health report = %+v", health)
+	}
+
+	// Deeper than the daemon indexes: the command must scan itself.
+	if _, ok := queryDaemon(context.Background(), newViaDaemonCmd(&flag), root, BulkCommandFlags{Depth: 3}); ok {
+		t.Error("queryDaemon() ok for a depth the daemon does not index")
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/provider"
+)
+
+// forgeRoute is where, and as whom, API calls for one repository go.
+type forgeRoute struct {
+	Provider string
+	BaseURL  string
+	Token    string
+	// TokenSource names the credential that supplied Token, so callers can
+	// share one client between repositories routed to the same credential.
+	TokenSource string
+	// TokenErr is set when the credentials entry or profile token is a
+	// secret reference that could not be resolved; Token is then empty.
+	TokenErr error
+}
+
+// forgeRouteOptions carries the command-level overrides that apply to every
+// repository in a bulk run.
+type forgeRouteOptions struct {
+	// Provider forces the forge software (--provider).
+	Provider string
+	// BaseURL is the profile's API base; it applies only to hosts that are not
+	// the public github.com / gitlab.com and have no credential entry.
+	BaseURL string
+	// Token is an explicit --token. It beats host routing.
+	Token string
+	// FallbackToken is the profile-resolved token, used only when no
+	// credential is routed to the repository's host. A secret reference in
+	// it is resolved only then.
+	FallbackToken string
+	Credentials   []config.Credential
+}
+
+// routeForgeRemote picks the provider, API base URL, and token for remote. A
+// credentials entry for the remote's host (or host and org) wins over
+// profile-wide settings because it is the more specific statement; an
+// explicit --provider or --token still wins over both.
+func routeForgeRemote(remote provider.ForgeRemote, opts forgeRouteOptions) forgeRoute {
+	route := forgeRoute{Provider: remote.Provider, BaseURL: remote.BaseURL}
+
+	entry, hasEntry := config.FindCredential(opts.Credentials, remote.Host, remote.Org())
+	if hasEntry {
+		if name := entry.ProviderName(); name != "" {
+			route.Provider = name
+		}
+	}
+	if opts.Provider != "" {
+		route.Provider = opts.Provider
+	}
+	switch {
+	case hasEntry && entry.BaseURL != "":
+		route.BaseURL = entry.BaseURL
+	case !hasEntry && opts.BaseURL != "" && remote.Host != "github.com" && remote.Host != "gitlab.com":
+		route.BaseURL = opts.BaseURL
+	}
+
+	if opts.Token != "" {
+		route.Token, route.TokenSource = opts.Token, "flag"
+		return route
+	}
+	host, org := remote.Host, remote.Org()
+	var err error
+	route.Token, route.TokenSource, err = config.ResolveHostToken(opts.Credentials, route.Provider, host, org)
+	if err != nil {
+		route.TokenErr = err
+		return route
+	}
+	if route.Token == "" && !hasEntry && opts.FallbackToken != "" {
+		token, err := config.ResolveSecret(opts.FallbackToken)
+		if err != nil {
+			route.TokenErr = fmt.Errorf("profile token: %w", err)
+			return route
+		}
+		route.Token, route.TokenSource = token, "profile"
+	}
+	return route
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/provider"
+)
+
+func TestRouteForgeRemote(t *testing.T) {
+	mem := config.NewMemoryTokenStore()
+	prev := config.DefaultTokenStore
+	config.SetTokenStore(mem)
+	t.Cleanup(func() { config.SetTokenStore(prev) })
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GITLAB_TOKEN", "")
+	t.Setenv("GZ_GIT_TOKEN", "")
+
+	creds := []config.Credential{
+		{Host: "git.internal.example", Provider: "gitlab", BaseURL: "https://git.internal.example/gl", Token: "internal"},
+	}
+	mustParse := func(raw string) provider.ForgeRemote {
+		t.Helper()
+		r, err := provider.ParseForgeRemote(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+
+	t.Run("credential entry supplies provider, base URL and token", func(t *testing.T) {
+		route := routeForgeRemote(mustParse("git@git.internal.example:team/app.git"), forgeRouteOptions{
+			BaseURL:       "https://profile.example",
+			FallbackToken: "profile-token",
+			Credentials:   creds,
+		})
+		if route.Provider != "gitlab" || route.BaseURL != "https://git.internal.example/gl" || route.Token != "internal" {
+			t.Fatalf("route = %+v", route)
+		}
+	})
+
+	t.Run("profile token is only a fallback", func(t *testing.T) {
+		route := routeForgeRemote(mustParse("https://github.com/acme/app.git"), forgeRouteOptions{
+			FallbackToken: "profile-token",
+			Credentials:   creds,
+		})
+		if route.Token != "profile-token" || route.TokenSource != "profile" {
+			t.Fatalf("route = %+v", route)
+		}
+		if err := mem.Set("github@github.com/acme", "acme-token"); err != nil {
+			t.Fatal(err)
+		}
+		route = routeForgeRemote(mustParse("https://github.com/acme/app.git"), forgeRouteOptions{
+			FallbackToken: "profile-token",
+		})
+		if route.Token != "acme-token" {
+			t.Fatalf("org keychain token not routed: %+v", route)
+		}
+	})
+
+	t.Run("declared host never gets the profile token", func(t *testing.T) {
+		route := routeForgeRemote(mustParse("git@git.internal.example:team/app.git"), forgeRouteOptions{
+			FallbackToken: "profile-token",
+			Credentials:   []config.Credential{{Host: "git.internal.example", Provider: "gitlab"}},
+		})
+		if route.Token != "" {
+			t.Fatalf("route = %+v", route)
+		}
+	})
+
+	t.Run("broken entry reference is an error", func(t *testing.T) {
+		route := routeForgeRemote(mustParse("git@git.internal.example:team/app.git"), forgeRouteOptions{
+			FallbackToken: "profile-token",
+			Credentials:   []config.Credential{{Host: "git.internal.example", Provider: "gitlab", Token: "${file:/nonexistent/token}"}},
+		})
+		if route.TokenErr == nil || route.Token != "" {
+			t.Fatalf("route = %+v", route)
+		}
+	})
+
+	t.Run("explicit token wins", func(t *testing.T) {
+		route := routeForgeRemote(mustParse("git@git.internal.example:team/app.git"), forgeRouteOptions{
+			Token:       "flag",
+			Credentials: creds,
+		})
+		if route.Token != "flag" {
+			t.Fatalf("route = %+v", route)
+		}
+	})
+}
@@ -16,8 +16,20 @@ var historyCmd = &cobra.Command{
   # List top contributors
   gz-git history contributors --top 10
 
+  # One report across every repository under ~/src
+  gz-git history stats ~/src
+  gz-git history contributors ~/src
+
+  # Which agents and devices made the commits
+  gz-git history agents ~/src
+
   # View file history
-  gz-git history file src/main.go`),
+  gz-git history file src/main.go
+
+  # Find hotspots, owners and files that change together
+  gz-git history hotspots --top 20
+  gz-git history ownership
+  gz-git history coupling`),
 	Example: ``,
 	Args:    cobra.NoArgs,
 }
@@ -0,0 +1,137 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/history"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+var (
+	agentsSince     string
+	agentsUntil     string
+	agentsBranch    string
+	agentsProtected []string
+	agentsFormat    string
+	agentsBulkFlags BulkCommandFlags
+)
+
+// agentsCmd represents the history agents command.
+var agentsCmd = &cobra.Command{
+	Use:   "agents [directory]",
+	Short: "Attribute commits to agents and devices",
+	Long: cliutil.QuickStartHelp(`  # Agents and devices in this repository
+  gz-git history agents
+
+  # Across every repository under ~/src, this quarter
+  gz-git history agents --since 2026-07-01 ~/src
+
+  # Treat only main and release branches as protected
+  gz-git history agents --protected main --protected 'release/*' ~/src`) + `
+
+Reads the Device and Agent trailers that handoff checkpoints and
+identity-aware commits carry, and reports:
+
+  - human and agent shares of the commits and changed lines
+  - commits, lines and repositories per agent, and the devices it ran on
+  - the same per device, with the agents that ran there
+  - agent commits made directly on a protected branch: on its first-parent
+    line, rather than merged into it
+
+A commit is an agent's when it has an Agent trailer. Commits of every local
+and remote-tracking branch are counted, once each, unless --branch names one;
+merge commits are left out. Protected branches default to the built-in list
+(main, master, develop, development, release/*, hotfix/*); remote-tracking
+branches match by their name on the remote.
+`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runHistoryAgents,
+}
+
+func init() {
+	historyCmd.AddCommand(agentsCmd)
+
+	agentsCmd.Flags().StringVar(&agentsSince, "since", "", "start date (e.g., '2024-01-01')")
+	agentsCmd.Flags().StringVar(&agentsUntil, "until", "", "end date (e.g., '2024-12-31')")
+	agentsCmd.Flags().StringVarP(&agentsBranch, "branch", "b", "", "specific branch (default: all local and remote-tracking)")
+	agentsCmd.Flags().StringSliceVar(&agentsProtected, "protected", nil, "protected branch names or trailing-* patterns (default: built-in list)")
+	agentsCmd.Flags().StringVar(&agentsFormat, "format", "table", "output format: table, json, csv, markdown, llm")
+	addBulkFlagsWithOpts(agentsCmd, &agentsBulkFlags, historyBulkFlagOptions)
+}
+
+func runHistoryAgents(cmd *cobra.Command, args []string) error {
+	if err := validateHistoryFormat(agentsFormat); err != nil {
+		return err
+	}
+	format, err := parseOutputFormat(agentsFormat)
+	if err != nil {
+		return err
+	}
+	since, err := parseDate(agentsSince)
+	if err != nil {
+		return fmt.Errorf("invalid --since date: %w", err)
+	}
+	until, err := parseDate(agentsUntil)
+	if err != nil {
+		return fmt.Errorf("invalid --until date: %w", err)
+	}
+	opts := history.AgentOptions{
+		Since:     since,
+		Until:     until,
+		Branch:    agentsBranch,
+		Protected: agentsProtected,
+	}
+
+	analyzer := history.NewAgentAnalyzer(gitcmd.NewExecutor())
+	progress := !quiet && format == history.FormatTable
+	var histories []*history.RepoAgentHistory
+
+	if len(args) == 1 {
+		client, result, err := scanHistoryWorkspace(cmd, args[0], agentsBulkFlags, progress)
+		if err != nil {
+			return err
+		}
+		histories = readAcross(cmdContext(cmd), client, result, agentsBulkFlags.Parallel,
+			func(ctx context.Context, repo *repository.Repository, path string) (*history.RepoAgentHistory, error) {
+				h, err := analyzer.Collect(ctx, repo, opts)
+				if err != nil {
+					return nil, err
+				}
+				h.Path = path
+				return h, nil
+			},
+			func(path string, err error) *history.RepoAgentHistory {
+				return &history.RepoAgentHistory{Path: path, Err: err}
+			})
+	} else {
+		ctx := cmdContext(cmd)
+		repo, err := openCurrentRepo(ctx)
+		if err != nil {
+			return err
+		}
+		if progress {
+			fmt.Println("Analyzing commit attribution...")
+		}
+		h, err := analyzer.Collect(ctx, repo, opts)
+		if err != nil {
+			return fmt.Errorf("failed to analyze agents: %w", err)
+		}
+		h.Path = "."
+		histories = []*history.RepoAgentHistory{h}
+	}
+
+	output, err := history.NewFormatter(format).FormatAgents(history.MergeAgents(histories))
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	fmt.Println(string(output))
+	return nil
+}
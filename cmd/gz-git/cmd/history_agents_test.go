@@ -0,0 +1,45 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestHistoryAgentsFlags(t *testing.T) {
+	cmd := findCommand(t, rootCmd, "history", "agents")
+	for _, name := range []string{"since", "until", "branch", "protected", "format", "scan-depth", "parallel", "include", "exclude"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("history agents missing --%s", name)
+		}
+	}
+	if err := cmd.Args(cmd, []string{"a", "b"}); err == nil {
+		t.Error("history agents should take at most one directory")
+	}
+}
+
+func TestRunHistoryAgents(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q", "-b", "main")
+	runGit(t, dir, "config", "user.name", "Test User")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "commit", "-q", "--allow-empty", "-m", "checkpoint\n\nDevice: laptop\nAgent: hermes")
+	t.Chdir(dir)
+
+	prevFormat := agentsFormat
+	t.Cleanup(func() { agentsFormat = prevFormat })
+	agentsFormat = "json"
+
+	cmd := findCommand(t, rootCmd, "history", "agents")
+	if err := runHistoryAgents(cmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	agentsFormat = "xml"
+	if err := runHistoryAgents(cmd, nil); err == nil {
+		t.Error("invalid format should be rejected")
+	}
+}
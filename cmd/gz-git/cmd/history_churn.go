@@ -0,0 +1,212 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/history"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+// churnFlags are the flags hotspots, ownership and coupling share.
+type churnFlags struct {
+	since      string
+	until      string
+	branch     string
+	path       string
+	maxCommits int
+	top        int
+	format     string
+}
+
+var (
+	hotspotsFlags  churnFlags
+	ownershipFlags churnFlags
+	couplingFlags  churnFlags
+
+	ownershipDepth    int
+	couplingMinShared int
+	couplingMaxFiles  int
+)
+
+// hotspotsCmd represents the history hotspots command.
+var hotspotsCmd = &cobra.Command{
+	Use:   "hotspots",
+	Short: "Rank files by change frequency × size",
+	Long: cliutil.QuickStartHelp(`  # The 20 files most worth a look
+  gz-git history hotspots --top 20
+
+  # Only this year's changes, under pkg/
+  gz-git history hotspots --since 2026-01-01 --path pkg/
+
+  # Export as CSV
+  gz-git history hotspots --format csv > hotspots.csv`) + `
+
+A hotspot is a file that is both changed often and large: the score is the
+number of commits that changed it times its size in lines (the complexity
+proxy), scaled so the top file scores 100. Renames are followed; deleted and
+binary files are left out.
+`,
+	Args: cobra.NoArgs,
+	RunE: runHistoryHotspots,
+}
+
+// ownershipCmd represents the history ownership command.
+var ownershipCmd = &cobra.Command{
+	Use:   "ownership",
+	Short: "Show primary authors and bus factor per directory",
+	Long: cliutil.QuickStartHelp(`  # Top-level directories, riskiest first
+  gz-git history ownership
+
+  # Two levels deep, last six months
+  gz-git history ownership --depth 2 --since 2026-04-01`) + `
+
+Each directory's changed lines (added + deleted) are attributed to their
+authors, by email. The bus factor is the fewest authors who together made
+half of them: 1 means one person wrote most of the directory. Directories
+are listed lowest bus factor first.
+`,
+	Args: cobra.NoArgs,
+	RunE: runHistoryOwnership,
+}
+
+// couplingCmd represents the history coupling command.
+var couplingCmd = &cobra.Command{
+	Use:   "coupling",
+	Short: "List files that change together",
+	Long: cliutil.QuickStartHelp(`  # Pairs changed together in at least 3 commits
+  gz-git history coupling
+
+  # Stronger evidence only
+  gz-git history coupling --min-shared 10 --top 20`) + `
+
+Two files are coupled when the same commits change both. The degree is the
+shared commits as a percentage of the pair's average number of changes.
+Commits touching more than --max-files files (mass renames, reformatting)
+are skipped.
+`,
+	Args: cobra.NoArgs,
+	RunE: runHistoryCoupling,
+}
+
+func init() {
+	historyCmd.AddCommand(hotspotsCmd, ownershipCmd, couplingCmd)
+
+	addChurnFlags(hotspotsCmd, &hotspotsFlags)
+	addChurnFlags(ownershipCmd, &ownershipFlags)
+	addChurnFlags(couplingCmd, &couplingFlags)
+
+	ownershipCmd.Flags().IntVar(&ownershipDepth, "depth", history.DefaultOwnershipDepth, "directory levels to group by")
+	couplingCmd.Flags().IntVar(&couplingMinShared, "min-shared", history.DefaultCouplingMinShared, "minimum commits a pair must share")
+	couplingCmd.Flags().IntVar(&couplingMaxFiles, "max-files", history.DefaultCouplingMaxFiles, "skip commits that change more files")
+}
+
+func addChurnFlags(cmd *cobra.Command, flags *churnFlags) {
+	cmd.Flags().StringVar(&flags.since, "since", "", "start date (e.g., '2024-01-01')")
+	cmd.Flags().StringVar(&flags.until, "until", "", "end date (e.g., '2024-12-31')")
+	cmd.Flags().StringVarP(&flags.branch, "branch", "b", "", "specific branch (default: current)")
+	cmd.Flags().StringVar(&flags.path, "path", "", "limit to a path")
+	cmd.Flags().IntVar(&flags.maxCommits, "max-commits", 0, "count only the newest N commits")
+	cmd.Flags().IntVar(&flags.top, "top", 0, "show only the top N results")
+	cmd.Flags().StringVar(&flags.format, "format", "table", "output format: table, json, csv, markdown, llm")
+}
+
+// churnOptions validates flags and builds the analyzer options.
+func (f churnFlags) churnOptions() (history.ChurnOptions, history.OutputFormat, error) {
+	if err := validateHistoryFormat(f.format); err != nil {
+		return history.ChurnOptions{}, "", err
+	}
+	format, err := parseOutputFormat(f.format)
+	if err != nil {
+		return history.ChurnOptions{}, "", err
+	}
+	since, err := parseDate(f.since)
+	if err != nil {
+		return history.ChurnOptions{}, "", fmt.Errorf("invalid --since date: %w", err)
+	}
+	until, err := parseDate(f.until)
+	if err != nil {
+		return history.ChurnOptions{}, "", fmt.Errorf("invalid --until date: %w", err)
+	}
+	return history.ChurnOptions{
+		Since:      since,
+		Until:      until,
+		Branch:     f.branch,
+		Path:       f.path,
+		MaxCommits: f.maxCommits,
+		Top:        f.top,
+	}, format, nil
+}
+
+// churnReport runs one analysis and formats it.
+type churnReport func(context.Context, history.ChurnAnalyzer, *repository.Repository, history.ChurnOptions, history.Formatter) ([]byte, error)
+
+// runChurn opens the current repository, runs one analysis and prints it.
+func runChurn(flags churnFlags, adjust func(*history.ChurnOptions), report churnReport) error {
+	ctx := context.Background()
+
+	opts, format, err := flags.churnOptions()
+	if err != nil {
+		return err
+	}
+	if adjust != nil {
+		adjust(&opts)
+	}
+
+	repo, err := openCurrentRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !quiet && format == history.FormatTable {
+		fmt.Println("Analyzing change history...")
+	}
+
+	output, err := report(ctx, history.NewChurnAnalyzer(gitcmd.NewExecutor()), repo, opts, history.NewFormatter(format))
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
+func runHistoryHotspots(cmd *cobra.Command, args []string) error {
+	return runChurn(hotspotsFlags, nil, func(ctx context.Context, a history.ChurnAnalyzer, repo *repository.Repository, opts history.ChurnOptions, f history.Formatter) ([]byte, error) {
+		hotspots, err := a.Hotspots(ctx, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze hotspots: %w", err)
+		}
+		return f.FormatHotspots(hotspots)
+	})
+}
+
+func runHistoryOwnership(cmd *cobra.Command, args []string) error {
+	adjust := func(opts *history.ChurnOptions) { opts.Depth = ownershipDepth }
+	return runChurn(ownershipFlags, adjust, func(ctx context.Context, a history.ChurnAnalyzer, repo *repository.Repository, opts history.ChurnOptions, f history.Formatter) ([]byte, error) {
+		owners, err := a.Ownership(ctx, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze ownership: %w", err)
+		}
+		return f.FormatOwnership(owners)
+	})
+}
+
+func runHistoryCoupling(cmd *cobra.Command, args []string) error {
+	adjust := func(opts *history.ChurnOptions) {
+		opts.MinShared, opts.MaxFiles = couplingMinShared, couplingMaxFiles
+	}
+	return runChurn(couplingFlags, adjust, func(ctx context.Context, a history.ChurnAnalyzer, repo *repository.Repository, opts history.ChurnOptions, f history.Formatter) ([]byte, error) {
+		couplings, err := a.Coupling(ctx, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze coupling: %w", err)
+		}
+		return f.FormatCoupling(couplings)
+	})
+}
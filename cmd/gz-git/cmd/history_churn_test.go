@@ -0,0 +1,43 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHistoryChurnFlags(t *testing.T) {
+	shared := []string{"since", "until", "branch", "path", "max-commits", "top", "format"}
+	for path, extra := range map[string][]string{
+		"hotspots":  nil,
+		"ownership": {"depth"},
+		"coupling":  {"min-shared", "max-files"},
+	} {
+		cmd := findCommand(t, rootCmd, "history", path)
+		for _, name := range append(shared, extra...) {
+			if cmd.Flags().Lookup(name) == nil {
+				t.Errorf("history %s missing --%s", path, name)
+			}
+		}
+	}
+}
+
+func TestChurnOptionsValidation(t *testing.T) {
+	if _, _, err := (churnFlags{format: "xml"}).churnOptions(); err == nil {
+		t.Error("invalid format should be rejected")
+	}
+	_, _, err := (churnFlags{format: "table", since: "yesterday-ish"}).churnOptions()
+	if err == nil || !strings.Contains(err.Error(), "--since") {
+		t.Errorf("invalid --since: %v", err)
+	}
+
+	opts, format, err := (churnFlags{format: "csv", since: "2026-01-01", path: "pkg", top: 5}).churnOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format != "csv" || opts.Path != "pkg" || opts.Top != 5 || opts.Since.Year() != 2026 {
+		t.Errorf("opts = %+v, format = %s", opts, format)
+	}
+}
@@ -19,11 +19,14 @@ var (
 	contribMinCommits int
 	contribSortBy     string
 	contribFormat     string
+
+	contribBulkFlags BulkCommandFlags
+	contribMailmap   string
 )
 
 // contributorsCmd represents the history contributors command.
 var contributorsCmd = &cobra.Command{
-	Use:   "contributors",
+	Use:   "contributors [directory]",
 	Short: "Analyze repository contributors",
 	Long: cliutil.QuickStartHelp(`  # List all contributors
   gz-git history contributors
@@ -38,8 +41,22 @@ var contributorsCmd = &cobra.Command{
   gz-git history contributors --since "2024-10-01"
 
   # Export as JSON
-  gz-git history contributors --format json > contributors.json`),
+  gz-git history contributors --format json > contributors.json
+
+  # Everyone who committed anywhere under ~/src this year
+  gz-git history contributors --since 2026-01-01 ~/src
+
+  # Merge aliases across repositories with a shared mailmap
+  gz-git history contributors --mailmap ~/team.mailmap ~/src`) + `
+
+With a directory, every repository under it is read in parallel and each
+person's commits are merged across repositories. Identities go through each
+repository's own .mailmap, then the --mailmap file (git's .mailmap format),
+and are then matched by email, ignoring case. --top, --min-commits and
+--sort apply to the merged list.
+`,
 	Example: ``,
+	Args:    cobra.MaximumNArgs(1),
 	RunE:    runHistoryContributors,
 }
 
@@ -52,6 +69,9 @@ func init() {
 	contributorsCmd.Flags().IntVar(&contribMinCommits, "min-commits", 0, "minimum commits threshold")
 	contributorsCmd.Flags().StringVar(&contribSortBy, "sort", "commits", "sort by: commits, additions, deletions, recent")
 	contributorsCmd.Flags().StringVar(&contribFormat, "format", "table", "output format: table, json, csv, markdown, llm")
+
+	addBulkFlagsWithOpts(contributorsCmd, &contribBulkFlags, historyBulkFlagOptions)
+	contributorsCmd.Flags().StringVar(&contribMailmap, "mailmap", "", "with a directory: mailmap file merging identities across repositories")
 }
 
 func runHistoryContributors(cmd *cobra.Command, args []string) error {
@@ -62,13 +82,6 @@ func runHistoryContributors(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	repo, err := openCurrentRepo(ctx)
-	if err != nil {
-		return err
-	}
-
-	analyzer := history.NewContributorAnalyzer(gitcmd.NewExecutor())
-
 	// Parse dates
 	sinceTime, err := parseDate(contribSince)
 	if err != nil {
@@ -86,6 +99,23 @@ func runHistoryContributors(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if len(args) == 1 {
+		return runHistoryContributorsWorkspace(cmd, args[0], history.WorkspaceOptions{
+			Since:      sinceTime,
+			Until:      untilTime,
+			MinCommits: contribMinCommits,
+			SortBy:     sortBy,
+			Top:        contribTop,
+		})
+	}
+
+	repo, err := openCurrentRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	analyzer := history.NewContributorAnalyzer(gitcmd.NewExecutor())
+
 	// Build options
 	opts := history.ContributorOptions{
 		Since:      sinceTime,
@@ -125,6 +155,31 @@ func runHistoryContributors(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runHistoryContributorsWorkspace merges the contributors of every
+// repository under directory.
+func runHistoryContributorsWorkspace(cmd *cobra.Command, directory string, opts history.WorkspaceOptions) error {
+	format, err := parseOutputFormat(contribFormat)
+	if err != nil {
+		return err
+	}
+	if opts.Mailmap, err = workspaceMailmap(contribMailmap); err != nil {
+		return err
+	}
+
+	progress := !quiet && format == history.FormatTable
+	report, err := collectWorkspaceHistory(cmd, directory, contribBulkFlags, opts, progress)
+	if err != nil {
+		return err
+	}
+
+	output, err := history.NewFormatter(format).FormatWorkspaceContributors(report.Contributors)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
 // parseContributorSortBy converts string to ContributorSortBy enum.
 func parseContributorSortBy(sort string) (history.ContributorSortBy, error) {
 	switch sort {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -18,11 +19,15 @@ var (
 	statsBranch string
 	statsAuthor string
 	statsFormat string
+
+	statsBulkFlags   BulkCommandFlags
+	statsMailmap     string
+	statsDormantDays int
 )
 
 // statsCmd represents the history stats command.
 var statsCmd = &cobra.Command{
-	Use:   "stats",
+	Use:   "stats [directory]",
 	Short: "Show commit statistics",
 	Long: cliutil.QuickStartHelp(`  # Show overall statistics
   gz-git history stats
@@ -34,8 +39,23 @@ var statsCmd = &cobra.Command{
   gz-git history stats --branch feature/new-feature
 
   # Export as JSON
-  gz-git history stats --format json > stats.json`),
+  gz-git history stats --format json > stats.json
+
+  # One report across every repository under ~/src
+  gz-git history stats --since 2026-01-01 ~/src
+
+  # Repositories without a commit in 90 days count as dormant
+  gz-git history stats --dormant-days 90 -d 2 ~/src`) + `
+
+With a directory, every repository under it is read in parallel and the
+results are merged: commit totals and the monthly trend across the
+workspace, unique authors after identity merging (see 'history
+contributors'), and each repository ranked by commits in the period. A
+repository whose newest commit is older than --dormant-days, or that has no
+commits, is marked dormant. CSV output lists the repository ranking.
+`,
 	Example: ``,
+	Args:    cobra.MaximumNArgs(1),
 	RunE:    runHistoryStats,
 }
 
@@ -47,6 +67,10 @@ func init() {
 	statsCmd.Flags().StringVarP(&statsBranch, "branch", "b", "", "specific branch (default: current)")
 	statsCmd.Flags().StringVar(&statsAuthor, "author", "", "filter by author")
 	statsCmd.Flags().StringVar(&statsFormat, "format", "table", "output format: table, json, csv, markdown, llm")
+
+	addBulkFlagsWithOpts(statsCmd, &statsBulkFlags, historyBulkFlagOptions)
+	statsCmd.Flags().StringVar(&statsMailmap, "mailmap", "", "with a directory: mailmap file merging identities across repositories")
+	statsCmd.Flags().IntVar(&statsDormantDays, "dormant-days", int(history.DefaultDormantAfter.Hours()/24), "with a directory: days without commits before a repository is dormant")
 }
 
 func runHistoryStats(cmd *cobra.Command, args []string) error {
@@ -57,13 +81,6 @@ func runHistoryStats(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	repo, err := openCurrentRepo(ctx)
-	if err != nil {
-		return err
-	}
-
-	analyzer := history.NewHistoryAnalyzer(gitcmd.NewExecutor())
-
 	// Parse dates
 	sinceTime, err := parseDate(statsSince)
 	if err != nil {
@@ -75,6 +92,17 @@ func runHistoryStats(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid --until date: %w", err)
 	}
 
+	if len(args) == 1 {
+		return runHistoryStatsWorkspace(cmd, args[0], sinceTime, untilTime)
+	}
+
+	repo, err := openCurrentRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	analyzer := history.NewHistoryAnalyzer(gitcmd.NewExecutor())
+
 	// Build options
 	opts := history.AnalyzeOptions{
 		Since:  sinceTime,
@@ -109,6 +137,43 @@ func runHistoryStats(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runHistoryStatsWorkspace merges the statistics of every repository under
+// directory.
+func runHistoryStatsWorkspace(cmd *cobra.Command, directory string, since, until time.Time) error {
+	format, err := parseOutputFormat(statsFormat)
+	if err != nil {
+		return err
+	}
+	if statsDormantDays < 1 {
+		return fmt.Errorf("--dormant-days must be at least 1")
+	}
+	mailmap, err := workspaceMailmap(statsMailmap)
+	if err != nil {
+		return err
+	}
+
+	opts := history.WorkspaceOptions{
+		Since:        since,
+		Until:        until,
+		Branch:       statsBranch,
+		Author:       statsAuthor,
+		Mailmap:      mailmap,
+		DormantAfter: time.Duration(statsDormantDays) * 24 * time.Hour,
+	}
+	progress := !quiet && format == history.FormatTable
+	report, err := collectWorkspaceHistory(cmd, directory, statsBulkFlags, opts, progress)
+	if err != nil {
+		return err
+	}
+
+	output, err := history.NewFormatter(format).FormatWorkspaceStats(report)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
 // parseOutputFormat converts string format to OutputFormat enum.
 // It must accept every format that validateHistoryFormat allows.
 func parseOutputFormat(format string) (history.OutputFormat, error) {
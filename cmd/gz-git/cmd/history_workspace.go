@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/gizzahub/gzh-cli-gitforge/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/history"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+// historyBulkFlagOptions keeps the scan flags of the bulk commands, and
+// leaves --format to the history formats.
+var historyBulkFlagOptions = BulkFlagOptions{
+	SkipDryRun: true,
+	SkipFetch:  true,
+	SkipFormat: true,
+	SkipWatch:  true,
+}
+
+// workspaceMailmap loads --mailmap, when given.
+func workspaceMailmap(path string) (*history.Mailmap, error) {
+	if path == "" {
+		return nil, nil
+	}
+	return history.LoadMailmap(path)
+}
+
+// scanHistoryWorkspace finds the repositories under directory.
+func scanHistoryWorkspace(cmd *cobra.Command, directory string, flags BulkCommandFlags, progress bool) (repository.Client, *repository.BulkStatusResult, error) {
+	if _, err := validateBulkDirectory([]string{directory}); err != nil {
+		return nil, nil, err
+	}
+	if err := validateBulkDepth(cmd, flags.Depth); err != nil {
+		return nil, nil, err
+	}
+
+	if progress {
+		printScanningMessage(directory, flags.Depth, flags.Parallel, false)
+	}
+
+	client := repository.NewClient()
+	result, err := client.BulkStatus(cmdContext(cmd), repository.BulkStatusOptions{
+		Directory:         directory,
+		Parallel:          flags.Parallel,
+		MaxDepth:          flags.Depth,
+		IncludeSubmodules: flags.IncludeSubmodules,
+		IncludePattern:    flags.Include,
+		ExcludePattern:    flags.Exclude,
+		Verbose:           verbose,
+		Logger:            createBulkLogger(verbose),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("scan failed: %w", err)
+	}
+	return client, result, nil
+}
+
+// readAcross runs read on every scanned repository, parallel at most at a
+// time, and returns the results in scan order. A repository that cannot be
+// opened or read is kept through failed, so reports still list it. path is
+// the repository's path relative to the scanned directory.
+func readAcross[T any](ctx context.Context, client repository.Client, result *repository.BulkStatusResult, parallel int,
+	read func(ctx context.Context, repo *repository.Repository, path string) (T, error),
+	failed func(path string, err error) T,
+) []T {
+	out := make([]T, len(result.Repositories))
+
+	if parallel < 1 {
+		parallel = 1
+	}
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(parallel)
+	for i, status := range result.Repositories {
+		g.Go(func() error {
+			path := status.RelativePath
+			if path == "" {
+				path = status.Path
+			}
+			err := status.Error
+			if err == nil {
+				var repo *repository.Repository
+				if repo, err = client.Open(gctx, status.Path); err == nil {
+					if out[i], err = read(gctx, repo, path); err == nil {
+						return nil
+					}
+				}
+			}
+			out[i] = failed(path, err)
+			return nil // the error lives in the entry
+		})
+	}
+	_ = g.Wait()
+	return out
+}
+
+// collectWorkspaceHistory scans directory for repositories, reads each one's
+// history in parallel and merges them into one report.
+func collectWorkspaceHistory(cmd *cobra.Command, directory string, flags BulkCommandFlags, opts history.WorkspaceOptions, progress bool) (*history.WorkspaceReport, error) {
+	client, result, err := scanHistoryWorkspace(cmd, directory, flags, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	analyzer := history.NewWorkspaceAnalyzer(gitcmd.NewExecutor())
+	histories := readAcross(cmdContext(cmd), client, result, flags.Parallel,
+		func(ctx context.Context, repo *repository.Repository, path string) (*history.RepoHistory, error) {
+			h, err := analyzer.Collect(ctx, repo, opts)
+			if err != nil {
+				return nil, err
+			}
+			h.Path = path
+			return h, nil
+		},
+		func(path string, err error) *history.RepoHistory {
+			return &history.RepoHistory{Path: path, Err: err}
+		})
+	return history.MergeWorkspace(histories, opts), nil
+}
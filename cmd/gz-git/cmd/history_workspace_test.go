@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/history"
+)
+
+func TestHistoryWorkspaceFlags(t *testing.T) {
+	for path, flags := range map[string][]string{
+		"stats":        {"scan-depth", "parallel", "include", "exclude", "mailmap", "dormant-days"},
+		"contributors": {"scan-depth", "parallel", "include", "exclude", "mailmap"},
+	} {
+		cmd := findCommand(t, rootCmd, "history", path)
+		for _, name := range flags {
+			if cmd.Flags().Lookup(name) == nil {
+				t.Errorf("history %s missing --%s", path, name)
+			}
+		}
+		if cmd.Flags().Lookup("format").DefValue != "table" {
+			t.Errorf("history %s --format should keep the history formats", path)
+		}
+	}
+}
+
+func TestCollectWorkspaceHistory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	root := t.TempDir()
+	for _, name := range []string{"api", "web", "empty"} {
+		dir := filepath.Join(root, name)
+		if err := os.Mkdir(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		runGit(t, dir, "init", "-q", "-b", "main")
+		runGit(t, dir, "config", "user.name", "Test User")
+		runGit(t, dir, "config", "user.email", "Test@Example.com")
+		if name == "empty" {
+			continue
+		}
+		writeFile(t, dir, "README.md", name+"\n")
+		runGit(t, dir, "add", ".")
+		runGit(t, dir, "commit", "-q", "-m", "init")
+	}
+	runGit(t, filepath.Join(root, "web"), "config", "user.email", "test@example.com")
+	writeFile(t, filepath.Join(root, "web"), "index.html", "<p>\n")
+	runGit(t, filepath.Join(root, "web"), "add", ".")
+	runGit(t, filepath.Join(root, "web"), "commit", "-q", "-m", "page")
+
+	cmd := findCommand(t, rootCmd, "history", "stats")
+	flags := BulkCommandFlags{Depth: 1, Parallel: 2}
+	report, err := collectWorkspaceHistory(cmd, root, flags, history.WorkspaceOptions{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Stats == nil || report.Stats.TotalCommits != 3 || report.Stats.UniqueAuthors != 1 {
+		t.Fatalf("stats = %+v", report.Stats)
+	}
+	if len(report.Repositories) != 3 || report.Repositories[0].Path != "web" || report.Repositories[0].Commits != 2 {
+		t.Errorf("ranking = %+v", report.Repositories)
+	}
+	if report.Dormant != 1 || report.Failed != 0 {
+		t.Errorf("only the empty repository is dormant: dormant %d, failed %d", report.Dormant, report.Failed)
+	}
+	if len(report.Contributors) != 1 || len(report.Contributors[0].Repositories) != 2 {
+		t.Errorf("contributors = %+v", report.Contributors)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/branch"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/ci"
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
 )
 
@@ -20,6 +21,7 @@ var (
 	infoFull    bool
 	infoAudit   bool
 	infoCompact bool
+	infoCI      bool
 )
 
 // infoCmd represents the info command.
@@ -48,6 +50,12 @@ By default:
   # Shorter lines: hide columns that have nothing to report anywhere
   gz-git info --compact
 
+  # Add a CI column: failing HEAD, upstream or default branch per repo
+  gz-git info --ci
+
+  # Answer from a running gz-git daemon's index
+  gz-git info --via-daemon ~/src
+
   # Machine-readable branch audit for an agent to act on
   gz-git info --audit
   gz-git info --audit | jq '.repositories[] | select(.audit_complete | not)'`,
@@ -60,6 +68,7 @@ func init() {
 	// Common bulk operation flags
 	// Add bulk flags
 	addBulkFlags(infoCmd, &infoFlags)
+	addViaDaemonFlag(infoCmd, &infoFlags.ViaDaemon)
 
 	// Add info-specific flags
 	infoCmd.Flags().IntVar(&itemLimit, "limit", 10, "max items to show in lists (branches, remotes)")
@@ -68,6 +77,8 @@ func init() {
 		"drop table columns that have nothing to report for any repository")
 	infoCmd.Flags().BoolVar(&infoAudit, "audit", false,
 		"emit a machine-readable branch audit (JSON) with typed findings and remediations")
+	infoCmd.Flags().BoolVar(&infoCI, "ci", false,
+		"query the forge for CI status of HEAD, upstream and default branch (network)")
 }
 
 func runInfo(cmd *cobra.Command, args []string) error {
@@ -78,8 +89,10 @@ func runInfo(cmd *cobra.Command, args []string) error {
 		baseCandidates   []string
 		autofixOverrides map[string]bool
 	)
+	ciOpts := ciQueryOptions{Kinds: ci.AllKinds}
 	effective, _ := LoadEffectiveConfig(cmd, nil)
 	if effective != nil {
+		ciOpts.applyEffective(effective)
 		autofixOverrides = effective.Audit.Autofix
 		if !cmd.Flags().Changed("parallel") && effective.Parallel > 0 {
 			infoFlags.Parallel = effective.Parallel
@@ -128,10 +141,21 @@ func runInfo(cmd *cobra.Command, args []string) error {
 		Logger:            logger,
 	}
 
-	// Execute scan
-	result, err := client.BulkStatus(ctx, bulkOpts)
-	if err != nil {
-		return fmt.Errorf("scan failed: %w", err)
+	// Execute scan, or read the daemon's index when it covers the directory.
+	// Base-branch, worktree and audit facts below are still gathered here:
+	// the daemon indexes what BulkStatus collects.
+	var result *repository.BulkStatusResult
+	if useDaemon(cmd, infoFlags.ViaDaemon) {
+		started := time.Now()
+		if resp, ok := queryDaemon(ctx, cmd, directory, infoFlags); ok {
+			result = daemonBulkStatus(resp, started)
+		}
+	}
+	if result == nil {
+		result, err = client.BulkStatus(ctx, bulkOpts)
+		if err != nil {
+			return fmt.Errorf("scan failed: %w", err)
+		}
 	}
 
 	// Base-branch and worktree facts are info-specific and cost extra git
@@ -142,6 +166,11 @@ func runInfo(cmd *cobra.Command, args []string) error {
 		result.Repositories, baseCandidates, infoFlags.Parallel, infoAudit,
 	)
 
+	if infoCI {
+		ciOpts.Parallel = infoFlags.Parallel
+		enrichInfoCI(ctx, client, result.Repositories, enrichment, ciOpts)
+	}
+
 	if infoAudit {
 		return runInfoAudit(cmd.OutOrStdout(), result, enrichment, directory, autofixOverrides, time.Now())
 	}
@@ -328,17 +357,18 @@ type InfoJSONOutput struct {
 }
 
 type InfoRepositoryJSONOutput struct {
-	Path               string   `json:"path"`
-	Branch             string   `json:"branch,omitempty"`
-	Status             string   `json:"status"`
-	UncommittedFiles   int      `json:"uncommitted_files,omitempty"`
-	UntrackedFiles     int      `json:"untracked_files,omitempty"`
-	CommitsAhead       int      `json:"commits_ahead,omitempty"`
-	CommitsBehind      int      `json:"commits_behind,omitempty"`
-	ConflictFiles      []string `json:"conflict_files,omitempty"`
-	RemoteOnlyBranches []string `json:"remote_only_branches"`
-	DurationMs         int64    `json:"duration_ms,omitempty"`
-	Error              string   `json:"error,omitempty"`
+	Path               string     `json:"path"`
+	Branch             string     `json:"branch,omitempty"`
+	Status             string     `json:"status"`
+	UncommittedFiles   int        `json:"uncommitted_files,omitempty"`
+	UntrackedFiles     int        `json:"untracked_files,omitempty"`
+	CommitsAhead       int        `json:"commits_ahead,omitempty"`
+	CommitsBehind      int        `json:"commits_behind,omitempty"`
+	ConflictFiles      []string   `json:"conflict_files,omitempty"`
+	RemoteOnlyBranches []string   `json:"remote_only_branches"`
+	CI                 *ci.Report `json:"ci,omitempty"`
+	DurationMs         int64      `json:"duration_ms,omitempty"`
+	Error              string     `json:"error,omitempty"`
 }
 
 func displayInfoResultsStructured(result *repository.BulkStatusResult, enrichment map[string]infoEnrichment, format string) {
@@ -361,6 +391,7 @@ func displayInfoResultsStructured(result *repository.BulkStatusResult, enrichmen
 			CommitsBehind:      repo.CommitsBehind,
 			ConflictFiles:      repo.ConflictFiles,
 			RemoteOnlyBranches: remoteOnlyTrackingBranches(repo, enrichment[repo.Path]),
+			CI:                 enrichment[repo.Path].CI,
 			DurationMs:         repo.Duration.Milliseconds(),
 		}
 		if repo.Error != nil {
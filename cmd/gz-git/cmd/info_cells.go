@@ -10,7 +10,9 @@ import (
 	"strings"
 	"unicode/utf8"
 
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/ci"
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/provider"
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
 )
 
@@ -46,11 +48,16 @@ func buildInfoRow(
 	cells[colOther] = otherBranchesCell(repo, enr)
 	cells[colRemoteOnly] = remoteOnlyBranchesCell(repo, enr)
 
-	return infoRow{
+	row := infoRow{
 		marker:   infoMarker(repo, enr),
 		cells:    cells,
 		baseName: enr.Base.Name,
 	}
+	if enr.CI != nil {
+		cell := ciCell(*enr.CI)
+		row.ci = &cell
+	}
+	return row
 }
 
 // infoMarker classifies the row. Blocked outranks attention: a repository in
@@ -406,6 +413,29 @@ func remoteCell(repo *repository.RepositoryStatusResult) infoCell {
 	return infoCell{}
 }
 
+// ciCell reports the worst CI state across the tracked refs, naming which ref
+// is red so "default ✗" and "head ✗" — a broken base versus a broken push —
+// read differently. Green and "no CI" print nothing, by the same rule as every
+// other column.
+func ciCell(report ci.Report) infoCell {
+	switch {
+	case report.State == provider.CIStateFailure:
+		var kinds []string
+		for _, ref := range report.Refs {
+			if ref.State == provider.CIStateFailure {
+				kinds = append(kinds, ref.Kind)
+			}
+		}
+		return infoCell{text: strings.Join(kinds, ",") + " ✗", color: cliutil.ColorRed}
+	case report.State == provider.CIStatePending:
+		return infoCell{text: "pending", color: cliutil.ColorYellow}
+	case report.Failed():
+		return infoCell{text: "error", color: cliutil.ColorGray}
+	default:
+		return infoCell{}
+	}
+}
+
 // elideMiddle shortens s to max runes, keeping both ends. Branch names carry
 // their meaning at both ends ("feat/" prefix, ticket suffix), so a trailing
 // truncation would drop exactly the part that distinguishes siblings.
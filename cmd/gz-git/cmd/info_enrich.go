@@ -10,6 +10,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/branch"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/ci"
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
 )
 
@@ -61,6 +62,10 @@ type infoEnrichment struct {
 	RemoteBotSuperseded []string
 	RemoteBotPending    []string
 
+	// CI is the forge CI verdict for the tracked refs, nil unless --ci was
+	// given. It is the only enrichment that leaves the machine.
+	CI *ci.Report
+
 	// Err records why enrichment was incomplete, or nil. It is reported in the
 	// detail view rather than aborting the scan: failing to resolve a base
 	// branch must not hide the repository's status.
@@ -195,6 +200,27 @@ func enrichOne(
 	return out
 }
 
+// enrichInfoCI attaches a CI verdict to every entry, reusing the base branch
+// the enrichment already resolved so the column and BASE agree on which branch
+// is the default.
+func enrichInfoCI(
+	ctx context.Context,
+	client repository.Client,
+	repos []repository.RepositoryStatusResult,
+	enrichment map[string]infoEnrichment,
+	opts ciQueryOptions,
+) {
+	bases := make(map[string]string, len(enrichment))
+	for path, enr := range enrichment {
+		bases[path] = enr.Base.Name
+	}
+	for path, report := range collectCIReports(ctx, client, repos, bases, opts) {
+		enr := enrichment[path]
+		enr.CI = &report
+		enrichment[path] = enr
+	}
+}
+
 // resolvePath canonicalizes a path for identity comparison. Symlinks are
 // resolved because the scanner and git can name the same directory differently
 // (/tmp vs /private/tmp on macOS being the common case); when resolution fails
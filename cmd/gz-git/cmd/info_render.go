@@ -76,6 +76,10 @@ type infoRow struct {
 	marker   string
 	cells    []infoCell
 	baseName string // for deciding whether to hoist the base name into the header
+
+	// ci is the CI cell, nil unless --ci asked the forges. It is kept out of
+	// cells because the column exists only on request; see insertCIColumn.
+	ci *infoCell
 }
 
 // infoColumns are the fixed-position columns, in print order. "REMOTE ONLY"
@@ -120,6 +124,7 @@ func renderInfoTable(
 	}
 
 	headers := hoistUniformBase(infoColumns, rows)
+	headers, rows = insertCIColumn(headers, rows)
 	if compact {
 		headers, rows = dropEmptyColumns(headers, rows)
 	} else {
@@ -143,6 +148,35 @@ func renderInfoTable(
 	fmt.Fprintln(w)
 }
 
+// insertCIColumn adds the CI column just before REMOTE ONLY when --ci was
+// given. The column costs a forge round trip per branch, so it is opt-in — and
+// unlike the columns that are always checked, a CI column that was never asked
+// for must not print as "-", which would read as "checked, nothing failing".
+func insertCIColumn(headers []string, rows []infoRow) ([]string, []infoRow) {
+	checked := false
+	for _, row := range rows {
+		if row.ci != nil {
+			checked = true
+			break
+		}
+	}
+	if !checked {
+		return headers, rows
+	}
+
+	at := len(headers) - 1
+	outHeaders := append(append(append([]string(nil), headers[:at]...), "CI"), headers[at:]...)
+	for i := range rows {
+		cell := infoCell{}
+		if rows[i].ci != nil {
+			cell = *rows[i].ci
+		}
+		cells := append(append(append([]infoCell(nil), rows[i].cells[:at]...), cell), rows[i].cells[at:]...)
+		rows[i].cells = cells
+	}
+	return outHeaders, rows
+}
+
 // fillNormalCells replaces every empty cell with cellNormal so the reader can
 // tell "checked, nothing to report" from "this column is not here".
 //
@@ -79,17 +79,21 @@ func runPRCreate(cmd *cobra.Command, args []string) error {
 		return cliutil.NewExitError(cliutil.ExitToolError, err)
 	}
 
+	routeOpts := forgeRouteOptions{
+		Provider:    prCreateProvider,
+		Token:       prCreateToken,
+		Credentials: config.LoadCredentials(),
+	}
 	effective, _ := LoadEffectiveConfig(cmd, map[string]any{
 		"provider": prCreateProvider,
 		"token":    prCreateToken,
 	})
 	if effective != nil {
-		if prCreateProvider == "" {
-			prCreateProvider = effective.Provider
-		}
-		if prCreateToken == "" {
-			prCreateToken = effective.Token
+		if routeOpts.Provider == "" {
+			routeOpts.Provider = effective.Provider
 		}
+		routeOpts.BaseURL = effective.BaseURL
+		routeOpts.FallbackToken = effective.Token
 	}
 
 	client := repository.NewClient()
@@ -117,7 +121,7 @@ func runPRCreate(cmd *cobra.Command, args []string) error {
 			defer wg.Done()
 			sem <- struct{}{}
 			defer func() { <-sem }()
-			outcomes[i] = createPRForRepo(ctx, client, path, effective)
+			outcomes[i] = createPRForRepo(ctx, client, path, routeOpts)
 		}(i, path)
 	}
 	wg.Wait()
@@ -138,7 +142,7 @@ func runPRCreate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func createPRForRepo(ctx context.Context, client repository.Client, path string, effective *config.EffectiveConfig) prCreateOutcome {
+func createPRForRepo(ctx context.Context, client repository.Client, path string, routeOpts forgeRouteOptions) prCreateOutcome {
 	repo, err := client.Open(ctx, path)
 	if err != nil {
 		return prCreateOutcome{Path: path, Status: "failed", Message: err.Error(), Err: err}
@@ -157,18 +161,14 @@ func createPRForRepo(ctx context.Context, client repository.Client, path string,
 	if err != nil {
 		return prCreateOutcome{Path: path, Status: "failed", Message: err.Error(), Err: err}
 	}
-	provName := remote.Provider
-	if prCreateProvider != "" {
-		provName = prCreateProvider
-	}
+	route := routeForgeRemote(remote, routeOpts)
+	provName, baseURL, token := route.Provider, route.BaseURL, route.Token
 	if provName == "" {
 		return prCreateOutcome{Path: path, Status: "failed", Message: "unknown forge host; pass --provider"}
 	}
-	baseURL := remote.BaseURL
-	if effective != nil && effective.BaseURL != "" && remote.Host != "github.com" && remote.Host != "gitlab.com" {
-		baseURL = effective.BaseURL
+	if route.TokenErr != nil && !prCreateFlags.DryRun {
+		return prCreateOutcome{Path: path, Status: "failed", Message: route.TokenErr.Error()}
 	}
-	token := resolveForgeToken(provName, prCreateToken)
 	if token == "" && !prCreateFlags.DryRun {
 		return prCreateOutcome{Path: path, Status: "failed", Message: "missing " + provName + " token"}
 	}
@@ -234,19 +234,6 @@ func newPullRequester(name, token, baseURL string) (provider.PullRequester, erro
 	return requester, nil
 }
 
-func resolveForgeToken(providerName, flagToken string) string {
-	if flagToken != "" {
-		return flagToken
-	}
-	if tok, _ := config.ResolveTokenFromEnv(providerName); tok != "" {
-		return tok
-	}
-	if tok, err := config.DefaultTokenStore.Get(providerName); err == nil && tok != "" {
-		return tok
-	}
-	return ""
-}
-
 func defaultPRTitle(branch string) string {
 	parts := strings.Split(strings.Trim(branch, "/"), "/")
 	slug := parts[len(parts)-1]
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
 )
 
 var (
@@ -154,6 +155,10 @@ func Execute(version string) {
 	setCommandGroups(rootCmd)
 	applyUsageTemplateRecursive(rootCmd, buildUsageTemplate())
 
+	if err := config.ConfigureTokenStore(); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v; using the OS keychain\n", err)
+	}
+
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(cliutil.ExitCodeForError(err))
@@ -177,7 +182,7 @@ func setCommandGroups(cmd *cobra.Command) {
 		case "clone", "status", "fetch", "pull", "push", "switch", "commit", "update", "diff", "sync", "clean",
 			"branch", "stash", "tag", "worktree":
 			c.GroupID = coreGroup.ID
-		case "workspace", "config", "forge", "schema", "cleanup", "doctor":
+		case "workspace", "config", "credential", "forge", "schema", "cleanup", "doctor":
 			c.GroupID = mgmtGroup.ID
 		default:
 			c.GroupID = toolGroup.ID
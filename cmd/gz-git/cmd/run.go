@@ -0,0 +1,367 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+)
+
+// recipeStackEnv lists the recipes running in parent processes, so that a
+// recipe that runs itself, directly or through another, is caught.
+const recipeStackEnv = "GZ_GIT_RECIPE_STACK"
+
+var (
+	runVars   []string
+	runDryRun bool
+	runYes    bool
+)
+
+// recipeStepRunner runs one step as a gz-git process and returns its exit
+// code. Tests replace it.
+var recipeStepRunner = runGzGitStep
+
+var runCmd = &cobra.Command{
+	Use:   "run [recipe]",
+	Short: "Run a saved sequence of gz-git commands",
+	Long: cliutil.QuickStartHelp(`  # List the recipes of the project config and the active profile
+  gz-git run
+
+  # Run one
+  gz-git run morning
+
+  # Fill in its variables
+  gz-git run morning --var include='api|web' --var depth=2
+
+  # Show the commands it would run, without running them
+  gz-git run morning --dry-run`) + `
+Recipes are defined under recipes: in .gz-git.yaml or in a profile; a
+project recipe replaces a profile recipe of the same name.
+
+  recipes:
+    morning:
+      description: Catch up with every repository
+      vars:
+        depth: "3"
+        include: ""              # required: --var include=...
+      steps:
+        - name: fetch
+          run: fetch -d {{ .depth }} --include {{ .include }} --format compact
+        - run: pull -d {{ .depth }} --include {{ .include }}
+        - run: status -d {{ .depth }}
+          if: always
+        - run: push -d {{ .depth }}
+          if: fetch.success
+          confirm: Push local commits?
+
+Each step is a gz-git command line. Its words are split as a shell would and
+each is a Go template over the vars. A step runs when its if: holds:
+success (the default: no step has failed so far), failure, always, or
+<step>.success / <step>.failure for an earlier named step. A step with
+continueOnError: true cannot fail the recipe. confirm: asks before the step
+runs, and answering no stops the recipe; --yes answers yes.
+
+After the last step a summary lists every step with its outcome. The exit
+code is 2 when a step failed.
+` + cliutil.ExitCodesBulkHelp(),
+	Args: cobra.MaximumNArgs(1),
+	RunE: runRun,
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+
+	runCmd.Flags().StringArrayVar(&runVars, "var", nil, "set a recipe variable (name=value, can be repeated)")
+	runCmd.Flags().BoolVarP(&runDryRun, "dry-run", "n", false, "show the commands without running them")
+	runCmd.Flags().BoolVarP(&runYes, "yes", "y", false, "answer yes to every confirm: question")
+}
+
+func runRun(cmd *cobra.Command, args []string) error {
+	loader, err := config.NewLoader()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if err := loader.Load(); err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	applyProfileOverride(loader)
+	recipes := loader.Recipes()
+
+	if len(args) == 0 {
+		printRecipeList(cmd.OutOrStdout(), recipes)
+		return nil
+	}
+
+	name := args[0]
+	i := slices.IndexFunc(recipes, func(r config.NamedRecipe) bool { return r.Name == name })
+	if i < 0 {
+		names := make([]string, len(recipes))
+		for j, r := range recipes {
+			names[j] = r.Name
+		}
+		if len(names) == 0 {
+			return fmt.Errorf("unknown recipe %q: no recipes are defined (add them under recipes: in .gz-git.yaml or a profile)", name)
+		}
+		return fmt.Errorf("unknown recipe %q (defined: %s)", name, strings.Join(names, ", "))
+	}
+	recipe := recipes[i]
+
+	vars := make(map[string]string, len(runVars))
+	for _, kv := range runVars {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" {
+			return fmt.Errorf("invalid --var %q: want name=value", kv)
+		}
+		vars[k] = v
+	}
+	steps, err := recipe.Plan(vars)
+	if err != nil {
+		return fmt.Errorf("recipe %s: %w", name, err)
+	}
+
+	if runDryRun {
+		printRecipePlan(cmd.OutOrStdout(), name, steps)
+		return nil
+	}
+
+	var stack []string
+	if s := os.Getenv(recipeStackEnv); s != "" {
+		stack = strings.Split(s, ",")
+	}
+	if slices.Contains(stack, name) {
+		return fmt.Errorf("recipe %s runs itself: %s", name, strings.Join(append(stack, name), " → "))
+	}
+	env := append(os.Environ(), recipeStackEnv+"="+strings.Join(append(stack, name), ","))
+
+	results, err := executeRecipe(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), steps, env)
+	printRecipeSummary(cmd.OutOrStdout(), name, results)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.Status == recipeStepFailed && !r.ContinueOnError {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return cliutil.NewExitError(cliutil.ExitPartialFailed,
+			fmt.Errorf("recipe %s: %d of %d steps failed", name, failed, len(results)))
+	}
+	return nil
+}
+
+// Recipe step outcomes.
+const (
+	recipeStepOK       = "ok"
+	recipeStepFailed   = "failed"
+	recipeStepSkipped  = "skipped"
+	recipeStepDeclined = "declined"
+)
+
+type recipeStepResult struct {
+	config.PlannedStep
+	Status   string
+	ExitCode int
+	Duration time.Duration
+	Reason   string
+}
+
+// executeRecipe runs the steps in order. It stops early, with an error,
+// only when a confirmation cannot be asked or is declined; step failures
+// are recorded in the results.
+func executeRecipe(ctx context.Context, stdout, stderr io.Writer, steps []config.PlannedStep, env []string) ([]recipeStepResult, error) {
+	results := make([]recipeStepResult, len(steps))
+	for i := range steps {
+		results[i] = recipeStepResult{PlannedStep: steps[i], Status: recipeStepSkipped}
+	}
+
+	failed := false
+	outcome := map[string]string{}
+	for i := range results {
+		r := &results[i]
+		if run, reason := recipeConditionHolds(r.If, failed, outcome); !run {
+			r.Reason = reason
+			continue
+		}
+
+		if r.Confirm != "" && !runYes {
+			if !stdinIsInteractive() {
+				r.Status, r.Reason = recipeStepDeclined, "no terminal to confirm"
+				return results, fmt.Errorf("step %s asks %q: re-run with --yes to run it in a non-interactive environment", r.Name, r.Confirm)
+			}
+			fmt.Fprintf(stderr, "%s [y/N]: ", r.Confirm)
+			ok, err := readYesNo(os.Stdin)
+			if err != nil {
+				return results, err
+			}
+			if !ok {
+				r.Status, r.Reason = recipeStepDeclined, "declined"
+				for j := i + 1; j < len(results); j++ {
+					results[j].Reason = "recipe stopped"
+				}
+				return results, fmt.Errorf("step %s declined; recipe stopped", r.Name)
+			}
+		}
+
+		args := r.Args
+		if profileOverride != "" {
+			args = append([]string{"--profile", profileOverride}, args...)
+		}
+		fmt.Fprintf(stdout, "▶ [%d/%d] %s: gz-git %s\n", i+1, len(results), r.Name, shellJoin(r.Args))
+		start := time.Now()
+		code, err := recipeStepRunner(ctx, args, env, stdout, stderr)
+		r.Duration = time.Since(start)
+		r.ExitCode = code
+		if err != nil {
+			return results, fmt.Errorf("step %s: %w", r.Name, err)
+		}
+
+		if code == 0 {
+			r.Status = recipeStepOK
+			outcome[r.Name] = config.RecipeIfSuccess
+		} else {
+			r.Status = recipeStepFailed
+			outcome[r.Name] = config.RecipeIfFailure
+			if !r.ContinueOnError {
+				failed = true
+			}
+		}
+		fmt.Fprintln(stdout)
+	}
+	return results, nil
+}
+
+// recipeConditionHolds evaluates a step's if:, and otherwise says why the
+// step is skipped.
+func recipeConditionHolds(cond string, failed bool, outcome map[string]string) (bool, string) {
+	switch cond {
+	case "", config.RecipeIfSuccess:
+		return !failed, "an earlier step failed"
+	case config.RecipeIfFailure:
+		return failed, "no step failed"
+	case config.RecipeIfAlways:
+		return true, ""
+	}
+	step, want, _ := strings.Cut(cond, ".")
+	got, ran := outcome[step]
+	if !ran {
+		return false, "if: " + cond + " (" + step + " did not run)"
+	}
+	return got == want, "if: " + cond
+}
+
+func runGzGitStep(ctx context.Context, args, env []string, stdout, stderr io.Writer) (int, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return -1, fmt.Errorf("find gz-git executable: %w", err)
+	}
+	c := exec.CommandContext(ctx, exe, args...) // #nosec G204 -- re-runs this gz-git binary with recipe arguments from the user's config.
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, stdout, stderr
+	c.Env = env
+	err = c.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return -1, err
+	}
+	return 0, nil
+}
+
+func printRecipeList(out io.Writer, recipes []config.NamedRecipe) {
+	if len(recipes) == 0 {
+		fmt.Fprintln(out, "No recipes defined. Add them under recipes: in .gz-git.yaml or a profile (see 'gz-git run --help').")
+		return
+	}
+	width := 0
+	for _, r := range recipes {
+		width = max(width, len(r.Name))
+	}
+	for _, r := range recipes {
+		desc := r.Description
+		if desc == "" {
+			desc = fmt.Sprintf("%d steps", len(r.Steps))
+		}
+		fmt.Fprintf(out, "%-*s  %s  (%s)\n", width, r.Name, desc, r.Source)
+	}
+}
+
+func printRecipePlan(out io.Writer, name string, steps []config.PlannedStep) {
+	fmt.Fprintf(out, "Recipe %s (%d steps, dry run):\n", name, len(steps))
+	for i, s := range steps {
+		line := fmt.Sprintf("  %d. %s: gz-git %s", i+1, s.Name, shellJoin(s.Args))
+		var notes []string
+		if s.If != "" {
+			notes = append(notes, "if: "+s.If)
+		}
+		if s.Confirm != "" {
+			notes = append(notes, fmt.Sprintf("confirm: %q", s.Confirm))
+		}
+		if s.ContinueOnError {
+			notes = append(notes, "continueOnError")
+		}
+		if len(notes) > 0 {
+			line += "  [" + strings.Join(notes, ", ") + "]"
+		}
+		fmt.Fprintln(out, line)
+	}
+}
+
+func printRecipeSummary(out io.Writer, name string, results []recipeStepResult) {
+	width := 0
+	for _, r := range results {
+		width = max(width, len(r.Name))
+	}
+
+	counts := map[string]int{}
+	fmt.Fprintf(out, "=== Recipe %s ===\n", name)
+	for _, r := range results {
+		counts[r.Status]++
+		switch r.Status {
+		case recipeStepOK:
+			fmt.Fprintf(out, "  ✓ %-*s  %s\n", width, r.Name, r.Duration.Round(100*time.Millisecond))
+		case recipeStepFailed:
+			note := ""
+			if r.ContinueOnError {
+				note = ", continued"
+			}
+			fmt.Fprintf(out, "  ✗ %-*s  exit %d, %s%s\n", width, r.Name, r.ExitCode, r.Duration.Round(100*time.Millisecond), note)
+		case recipeStepDeclined:
+			fmt.Fprintf(out, "  ⊘ %-*s  %s\n", width, r.Name, r.Reason)
+		default:
+			fmt.Fprintf(out, "  ⊘ %-*s  skipped (%s)\n", width, r.Name, r.Reason)
+		}
+	}
+	fmt.Fprintf(out, "%d steps: %d succeeded, %d failed, %d skipped\n",
+		len(results), counts[recipeStepOK], counts[recipeStepFailed], counts[recipeStepSkipped]+counts[recipeStepDeclined])
+}
+
+// shellJoin renders arguments as a command line a shell would split back
+// into the same words.
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		if a != "" && !strings.ContainsAny(a, " \t\n'\"\\$`|&;<>()*?[]{}!#~") {
+			quoted[i] = a
+			continue
+		}
+		quoted[i] = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+)
+
+// fakeRecipeSteps replaces the step runner with one that records the
+// commands and fails those whose first argument is in fail.
+func fakeRecipeSteps(t *testing.T, fail ...string) *[]string {
+	t.Helper()
+	var ran []string
+	orig := recipeStepRunner
+	recipeStepRunner = func(_ context.Context, args, _ []string, _, _ io.Writer) (int, error) {
+		ran = append(ran, strings.Join(args, " "))
+		for _, f := range fail {
+			if args[0] == f {
+				return 1, nil
+			}
+		}
+		return 0, nil
+	}
+	t.Cleanup(func() { recipeStepRunner = orig })
+	return &ran
+}
+
+func planTestRecipe(t *testing.T, steps ...config.RecipeStep) []config.PlannedStep {
+	t.Helper()
+	planned, err := (&config.Recipe{Steps: steps}).Plan(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return planned
+}
+
+func TestExecuteRecipeConditions(t *testing.T) {
+	steps := planTestRecipe(t,
+		config.RecipeStep{Name: "fetch", Run: "fetch -d 3"},
+		config.RecipeStep{Run: "lint", ContinueOnError: true},
+		config.RecipeStep{Run: "pull"},
+		config.RecipeStep{Run: "push", If: "fetch.success"},
+		config.RecipeStep{Run: "diff", If: config.RecipeIfFailure},
+		config.RecipeStep{Run: "status", If: config.RecipeIfAlways},
+	)
+	ran := fakeRecipeSteps(t, "lint", "pull")
+
+	var out bytes.Buffer
+	results, err := executeRecipe(t.Context(), &out, io.Discard, steps, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// lint fails but continues; pull fails, so push (fetch.success) still
+	// runs, diff (failure) runs and status always does.
+	if got := strings.Join(*ran, ", "); got != "fetch -d 3, lint, pull, push, diff, status" {
+		t.Errorf("ran %q", got)
+	}
+	var statuses []string
+	for _, r := range results {
+		statuses = append(statuses, r.Status)
+	}
+	if got := strings.Join(statuses, " "); got != "ok failed failed ok ok ok" {
+		t.Errorf("statuses = %q", got)
+	}
+	if !strings.Contains(out.String(), "▶ [1/6] fetch: gz-git fetch -d 3\n") {
+		t.Errorf("output lacks the step header:\n%s", out.String())
+	}
+
+	ran = fakeRecipeSteps(t)
+	if results, err = executeRecipe(t.Context(), io.Discard, io.Discard, planTestRecipe(t,
+		config.RecipeStep{Run: "fetch"},
+		config.RecipeStep{Run: "pull"},
+		config.RecipeStep{Run: "diff", If: config.RecipeIfFailure},
+	), nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(*ran, ", "); got != "fetch, pull" {
+		t.Errorf("ran %q", got)
+	}
+	if results[2].Status != recipeStepSkipped || results[2].Reason != "no step failed" {
+		t.Errorf("diff = %+v", results[2])
+	}
+}
+
+func TestExecuteRecipeConfirm(t *testing.T) {
+	steps := planTestRecipe(t,
+		config.RecipeStep{Run: "fetch"},
+		config.RecipeStep{Run: "push", Confirm: "Push?"},
+	)
+	ran := fakeRecipeSteps(t)
+
+	// Tests have no terminal, so the question cannot be asked.
+	results, err := executeRecipe(t.Context(), io.Discard, io.Discard, steps, nil)
+	if err == nil || !strings.Contains(err.Error(), "re-run with --yes") {
+		t.Errorf("err = %v", err)
+	}
+	if results[1].Status != recipeStepDeclined || strings.Join(*ran, ", ") != "fetch" {
+		t.Errorf("results = %+v, ran %v", results, *ran)
+	}
+
+	runYes = true
+	t.Cleanup(func() { runYes = false })
+	*ran = nil
+	if _, err := executeRecipe(t.Context(), io.Discard, io.Discard, steps, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(*ran, ", "); got != "fetch, push" {
+		t.Errorf("with --yes ran %q", got)
+	}
+}
+
+func TestPrintRecipeSummaryAndPlan(t *testing.T) {
+	steps := planTestRecipe(t,
+		config.RecipeStep{Name: "fetch", Run: "fetch --include 'api|web'"},
+		config.RecipeStep{Run: "push", If: "fetch.success", Confirm: "Push?"},
+	)
+
+	var plan bytes.Buffer
+	printRecipePlan(&plan, "morning", steps)
+	want := "Recipe morning (2 steps, dry run):\n" +
+		"  1. fetch: gz-git fetch --include 'api|web'\n" +
+		"  2. push: gz-git push  [if: fetch.success, confirm: \"Push?\"]\n"
+	if plan.String() != want {
+		t.Errorf("plan =\n%s\nwant\n%s", plan.String(), want)
+	}
+
+	var summary bytes.Buffer
+	printRecipeSummary(&summary, "morning", []recipeStepResult{
+		{PlannedStep: steps[0], Status: recipeStepFailed, ExitCode: 2},
+		{PlannedStep: steps[1], Status: recipeStepSkipped, Reason: "if: fetch.success"},
+	})
+	for _, line := range []string{
+		"=== Recipe morning ===",
+		"  ✗ fetch  exit 2, 0s",
+		"  ⊘ push   skipped (if: fetch.success)",
+		"2 steps: 0 succeeded, 1 failed, 1 skipped",
+	} {
+		if !strings.Contains(summary.String(), line+"\n") {
+			t.Errorf("summary lacks %q:\n%s", line, summary.String())
+		}
+	}
+}
+
+func TestRunRecipeCommand(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv(recipeStackEnv, "")
+	t.Chdir(dir)
+	cfg := `recipes:
+  morning:
+    description: Catch up
+    vars:
+      include: ""
+    steps:
+      - run: fetch --include {{ .include }}
+      - run: push
+`
+	if err := os.WriteFile(filepath.Join(dir, ".gz-git.yaml"), []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ran := fakeRecipeSteps(t, "push")
+	var out bytes.Buffer
+	runCmd.SetOut(&out)
+	runCmd.SetContext(t.Context())
+	t.Cleanup(func() {
+		runVars, runDryRun = nil, false
+		runCmd.SetOut(nil)
+	})
+
+	if err := runRun(runCmd, nil); err != nil || !strings.Contains(out.String(), "morning  Catch up  (project)") {
+		t.Errorf("list: out = %q, err = %v", out.String(), err)
+	}
+
+	if err := runRun(runCmd, []string{"evening"}); err == nil || !strings.Contains(err.Error(), `unknown recipe "evening" (defined: morning)`) {
+		t.Errorf("unknown recipe: err = %v", err)
+	}
+	if err := runRun(runCmd, []string{"morning"}); err == nil || !strings.Contains(err.Error(), "missing required variables: include") {
+		t.Errorf("missing var: err = %v", err)
+	}
+
+	runVars = []string{"include=api"}
+	runDryRun = true
+	if err := runRun(runCmd, []string{"morning"}); err != nil || len(*ran) != 0 {
+		t.Errorf("dry run: ran %v, err = %v", *ran, err)
+	}
+
+	runDryRun = false
+	err := runRun(runCmd, []string{"morning"})
+	if err == nil || !strings.Contains(err.Error(), "recipe morning: 1 of 2 steps failed") {
+		t.Errorf("failing step: err = %v", err)
+	}
+	if got := strings.Join(*ran, ", "); got != "fetch --include api, push" {
+		t.Errorf("ran %q", got)
+	}
+
+	t.Setenv(recipeStackEnv, "evening,morning")
+	if err := runRun(runCmd, []string{"morning"}); err == nil || !strings.Contains(err.Error(), "runs itself: evening → morning → morning") {
+		t.Errorf("recursion: err = %v", err)
+	}
+}
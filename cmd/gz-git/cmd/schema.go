@@ -4,7 +4,9 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -12,6 +14,11 @@ import (
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
 )
 
+var (
+	schemaJSON bool
+	schemaKind string
+)
+
 // schemaCmd represents the schema command.
 var schemaCmd = &cobra.Command{
 	Use:   "schema",
@@ -20,14 +27,63 @@ var schemaCmd = &cobra.Command{
   gz-git schema
 
   # Save default config template
-  gz-git schema > .gz-git.yaml`),
+  gz-git schema > .gz-git.yaml
+
+  # Save the JSON Schema of .gz-git.yaml for your editor
+  gz-git schema --json > gz-git.schema.json
+
+  # JSON Schema of the global config (~/.config/gz-git/config.yaml)
+  gz-git schema --json --kind global`) + `
+
+With --json, prints a JSON Schema (draft 2020-12) generated from the types
+gz-git decodes its config files into: every key, the values it takes, and
+its description. Editors that use yaml-language-server (VS Code's YAML
+extension, Neovim, Helix) then complete and check .gz-git.yaml. Point them
+at the schema with a modeline at the top of the file:
+
+  # yaml-language-server: $schema=./gz-git.schema.json
+
+or for every .gz-git.yaml, in VS Code's settings.json:
+
+  "yaml.schemas": { "./gz-git.schema.json": [".gz-git.yaml"] }
+
+'gz-git workspace validate' checks a file against the same schema and
+reports each problem at its line and column.
+`,
 	Example: ``,
 	Args:    cobra.NoArgs,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Print(config.ExampleConfig)
-	},
+	RunE:    runSchema,
 }
 
 func init() {
 	rootCmd.AddCommand(schemaCmd)
+
+	kinds := make([]string, len(config.SchemaKinds))
+	for i, k := range config.SchemaKinds {
+		kinds[i] = string(k)
+	}
+	schemaCmd.Flags().BoolVar(&schemaJSON, "json", false, "print the JSON Schema instead of the example config")
+	schemaCmd.Flags().StringVar(&schemaKind, "kind", string(config.SchemaKindConfig),
+		"config file the JSON Schema describes: "+strings.Join(kinds, ", "))
+}
+
+func runSchema(cmd *cobra.Command, args []string) error {
+	if !schemaJSON {
+		if cmd.Flags().Changed("kind") {
+			return fmt.Errorf("--kind requires --json")
+		}
+		fmt.Fprint(cmd.OutOrStdout(), config.ExampleConfig)
+		return nil
+	}
+
+	schema, err := config.GenerateSchema(config.SchemaKind(schemaKind))
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode schema: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	return nil
 }
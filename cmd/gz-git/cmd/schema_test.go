@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+)
+
+func TestRunSchema(t *testing.T) {
+	cmd := findCommand(t, rootCmd, "schema")
+	for _, name := range []string{"json", "kind"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Fatalf("schema missing --%s", name)
+		}
+	}
+
+	kindFlag := cmd.Flags().Lookup("kind")
+	t.Cleanup(func() {
+		schemaJSON = false
+		schemaKind = string(config.SchemaKindConfig)
+		kindFlag.Changed = false
+		cmd.SetOut(nil)
+	})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	// Without --json: the example config.
+	if err := runSchema(cmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != config.ExampleConfig {
+		t.Error("schema should print the example config")
+	}
+
+	// With --json: a schema per kind.
+	schemaJSON = true
+	for _, kind := range config.SchemaKinds {
+		out.Reset()
+		if err := cmd.Flags().Set("kind", string(kind)); err != nil {
+			t.Fatal(err)
+		}
+		if err := runSchema(cmd, nil); err != nil {
+			t.Fatalf("--kind %s: %v", kind, err)
+		}
+		var schema config.Schema
+		if err := json.Unmarshal(out.Bytes(), &schema); err != nil {
+			t.Fatalf("--kind %s: output is not JSON: %v", kind, err)
+		}
+		if schema.Schema != config.SchemaDraft {
+			t.Errorf("--kind %s: $schema = %q", kind, schema.Schema)
+		}
+	}
+
+	schemaKind = "nope"
+	if err := runSchema(cmd, nil); err == nil {
+		t.Error("unknown kind should be rejected")
+	}
+
+	schemaJSON = false
+	if err := runSchema(cmd, nil); err == nil {
+		t.Error("--kind without --json should be rejected")
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+)
+
+// stackCmd groups the stacked-branch commands.
+var stackCmd = &cobra.Command{
+	Use:   "stack",
+	Short: "Work in stacks of dependent branches",
+	Long: cliutil.QuickStartHelp(`  # Cut a branch from the current one and remember it is stacked on it
+  gz-git stack create feat/api
+  gz-git stack create feat/ui
+
+  # See the stack, and which branches are behind their parent
+  gz-git stack list
+
+  # Replay every branch onto its parent after one of them changed
+  gz-git stack restack
+
+  # Open a PR per branch, each against its parent
+  gz-git stack submit`) + `
+
+A stack is a chain (or tree) of branches, each cut from and reviewed against
+the one below it. The parent of each branch is recorded in git config as
+branch.<name>.gz-stack-parent, so renaming or deleting the branch with git
+carries the record along.
+
+After a parent lands, run restack --fetch: its children move onto the
+parent's own base, and submit then retargets their pull requests.
+`,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(stackCmd)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/stack"
+)
+
+var (
+	stackCreateParent     string
+	stackCreateNoCheckout bool
+	stackCreateAdopt      bool
+)
+
+var stackCreateCmd = &cobra.Command{
+	Use:   "create <branch>",
+	Short: "Create a branch stacked on the current branch",
+	Long: cliutil.QuickStartHelp(`  # Stack a new branch on the current one and switch to it
+  gz-git stack create feat/api
+
+  # Stack on another branch, without switching
+  gz-git stack create feat/ui --parent feat/api --no-checkout
+
+  # Record the parent of a branch that already exists
+  gz-git stack create feat/old --parent main --adopt`),
+	Args: cobra.ExactArgs(1),
+	RunE: runStackCreate,
+}
+
+func init() {
+	stackCmd.AddCommand(stackCreateCmd)
+	stackCreateCmd.Flags().StringVar(&stackCreateParent, "parent", "", "branch to stack on (default: current branch)")
+	stackCreateCmd.Flags().BoolVar(&stackCreateNoCheckout, "no-checkout", false, "do not switch to the branch")
+	stackCreateCmd.Flags().BoolVar(&stackCreateAdopt, "adopt", false, "record the parent of an existing branch instead of creating it")
+}
+
+func runStackCreate(cmd *cobra.Command, args []string) error {
+	ctx := cmdContext(cmd)
+	repo, err := openCurrentRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	created, err := stack.NewManager().Create(ctx, repo, stack.CreateOptions{
+		Name:     args[0],
+		Parent:   stackCreateParent,
+		Checkout: !stackCreateNoCheckout,
+		Adopt:    stackCreateAdopt,
+	})
+	if err != nil {
+		return err
+	}
+	if !quiet {
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ %s stacked on %s\n", created.Name, created.Parent)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/stack"
+)
+
+var stackListFormat string
+
+var stackListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show the stacks in the current repository",
+	Long: cliutil.QuickStartHelp(`  # Tree of stacked branches; * marks the current branch
+  gz-git stack list
+
+  # Machine-readable
+  gz-git stack list --format json`) + `
+
+A branch is marked "needs restack" when its parent has moved on since it was
+stacked, or has landed, and "landed" when it has been merged into its parent.
+`,
+	Args: cobra.NoArgs,
+	RunE: runStackList,
+}
+
+func init() {
+	stackCmd.AddCommand(stackListCmd)
+	stackListCmd.Flags().StringVar(&stackListFormat, "format", "default", "output format: default, json, llm")
+}
+
+func runStackList(cmd *cobra.Command, _ []string) error {
+	if err := validateBulkFormat(stackListFormat); err != nil {
+		return err
+	}
+	ctx := cmdContext(cmd)
+	repo, err := openCurrentRepo(ctx)
+	if err != nil {
+		return err
+	}
+	trees, err := stack.NewManager().List(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	switch stackListFormat {
+	case "json":
+		return cliutil.WriteJSON(out, trees, true)
+	case "llm":
+		return cliutil.WriteLLM(out, trees)
+	}
+	if len(trees) == 0 {
+		fmt.Fprintln(out, "No stacked branches. Start one with: gz-git stack create <branch>")
+		return nil
+	}
+	for _, trunk := range trees {
+		fmt.Fprintln(out, trunk.Name)
+		printStackChildren(out, trunk.Children, "")
+	}
+	return nil
+}
+
+func printStackChildren(out io.Writer, branches []*stack.Branch, indent string) {
+	for i, b := range branches {
+		connector, next := "├── ", "│   "
+		if i == len(branches)-1 {
+			connector, next = "└── ", "    "
+		}
+		var notes []string
+		if b.Landed {
+			notes = append(notes, "landed")
+		}
+		if b.NeedsRestack {
+			notes = append(notes, "needs restack")
+		}
+		line := indent + connector + b.Name
+		if b.Current {
+			line += " *"
+		}
+		if len(notes) > 0 {
+			line += " (" + strings.Join(notes, ", ") + ")"
+		}
+		fmt.Fprintln(out, line)
+		printStackChildren(out, b.Children, indent+next)
+	}
+}
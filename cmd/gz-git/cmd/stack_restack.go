@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/stack"
+)
+
+var (
+	stackRestackAll    bool
+	stackRestackFetch  bool
+	stackRestackRemote string
+)
+
+var stackRestackCmd = &cobra.Command{
+	Use:   "restack [branch]",
+	Short: "Rebase each branch of a stack onto its parent",
+	Long: cliutil.QuickStartHelp(`  # Restack the stack the current branch is in
+  gz-git stack restack
+
+  # After a parent was merged and its remote branch deleted
+  gz-git stack restack --fetch
+
+  # Every stack in the repository
+  gz-git stack restack --all`) + `
+
+Branches are replayed with git rebase --update-refs --onto <parent>, starting
+from the commit each was last stacked on, so an amended or squash-merged
+parent's old commits are dropped rather than replayed. Branches already on
+top of their parent are left alone.
+
+A branch has landed when its remote branch is gone or all its commits are in
+its parent. Its children are moved onto its parent, and it is no longer part
+of the stack.
+
+A conflict stops the restack with the rebase in progress: resolve it (gz-git
+conflict resolve), run git rebase --continue, and restack again.
+`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStackRestack,
+}
+
+func init() {
+	stackCmd.AddCommand(stackRestackCmd)
+	stackRestackCmd.Flags().BoolVar(&stackRestackAll, "all", false, "restack every stack in the repository")
+	stackRestackCmd.Flags().BoolVar(&stackRestackFetch, "fetch", false, "fetch --prune first, to notice parents that landed")
+	stackRestackCmd.Flags().StringVar(&stackRestackRemote, "remote", "origin", "remote to fetch from")
+}
+
+func runStackRestack(cmd *cobra.Command, args []string) error {
+	ctx := cmdContext(cmd)
+	repo, err := openCurrentRepo(ctx)
+	if err != nil {
+		return err
+	}
+	opts := stack.RestackOptions{All: stackRestackAll, Fetch: stackRestackFetch, Remote: stackRestackRemote}
+	if len(args) == 1 {
+		opts.Branch = args[0]
+	}
+
+	result, err := stack.NewManager().Restack(ctx, repo, opts)
+	out := cmd.OutOrStdout()
+	if result != nil && !quiet {
+		for _, name := range result.Landed {
+			fmt.Fprintf(out, "✓ %s landed\n", name)
+		}
+		for _, r := range result.Reparented {
+			fmt.Fprintf(out, "✓ %s moved from %s onto %s\n", r.Branch, r.From, r.To)
+		}
+		for _, name := range result.Rebased {
+			fmt.Fprintf(out, "✓ %s restacked\n", name)
+		}
+		if err == nil && len(result.Landed)+len(result.Reparented)+len(result.Rebased) == 0 {
+			fmt.Fprintln(out, "✓ Stack is up to date")
+		}
+	}
+	if errors.Is(err, stack.ErrRestackStopped) {
+		return cliutil.NewExitError(cliutil.ExitPartialFailed, err)
+	}
+	return err
+}
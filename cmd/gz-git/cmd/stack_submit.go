@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/provider"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/stack"
+)
+
+var (
+	stackSubmitAll      bool
+	stackSubmitDraft    bool
+	stackSubmitDryRun   bool
+	stackSubmitNoPush   bool
+	stackSubmitRemote   string
+	stackSubmitProvider string
+	stackSubmitToken    string
+)
+
+var stackSubmitCmd = &cobra.Command{
+	Use:   "submit [branch]",
+	Short: "Push a stack and open a pull request per branch against its parent",
+	Long: cliutil.QuickStartHelp(`  # Push the current stack and open or update its PRs
+  gz-git stack submit
+
+  # Open them as drafts
+  gz-git stack submit --draft
+
+  # See what would be created or retargeted
+  gz-git stack submit --dry-run`) + `
+
+Each branch is pushed with --force-with-lease, since restacking rewrites it,
+and gets a pull request (merge request on GitLab) whose base is its parent;
+the lowest branch targets the branch the stack is rooted on. A branch whose
+pull request targets another base - because its parent landed and it was
+restacked - has it retargeted. An existing pull request is otherwise left
+as it is.
+
+The stack must be restacked first; submit refuses a branch that is not on top
+of its parent. --dry-run pushes nothing and changes nothing on the forge, but
+still reads the forge to tell what would happen.
+`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStackSubmit,
+}
+
+func init() {
+	stackCmd.AddCommand(stackSubmitCmd)
+	stackSubmitCmd.Flags().BoolVar(&stackSubmitAll, "all", false, "submit every stack in the repository")
+	stackSubmitCmd.Flags().BoolVar(&stackSubmitDraft, "draft", false, "open new pull requests as drafts")
+	stackSubmitCmd.Flags().BoolVar(&stackSubmitDryRun, "dry-run", false, "show what would be pushed, created and retargeted")
+	stackSubmitCmd.Flags().BoolVar(&stackSubmitNoPush, "no-push", false, "do not push the branches first")
+	stackSubmitCmd.Flags().StringVar(&stackSubmitRemote, "remote", "origin", "remote to push to and read the forge from")
+	stackSubmitCmd.Flags().StringVar(&stackSubmitProvider, "provider", "", "force provider: github, gitlab, or gitea")
+	stackSubmitCmd.Flags().StringVar(&stackSubmitToken, "token", "", "forge API token")
+}
+
+func runStackSubmit(cmd *cobra.Command, args []string) error {
+	ctx := cmdContext(cmd)
+	repo, err := openCurrentRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	remoteURL, err := gitcmd.NewExecutor().RunOutput(ctx, repo.Path, "remote", "get-url", stackSubmitRemote)
+	if err != nil {
+		return fmt.Errorf("remote %s: %w", stackSubmitRemote, err)
+	}
+	remote, err := provider.ParseForgeRemote(remoteURL)
+	if err != nil {
+		return err
+	}
+
+	routeOpts := forgeRouteOptions{
+		Provider:    stackSubmitProvider,
+		Token:       stackSubmitToken,
+		Credentials: config.LoadCredentials(),
+	}
+	effective, _ := LoadEffectiveConfig(cmd, map[string]any{
+		"provider": stackSubmitProvider,
+		"token":    stackSubmitToken,
+	})
+	if effective != nil {
+		if routeOpts.Provider == "" {
+			routeOpts.Provider = effective.Provider
+		}
+		routeOpts.BaseURL = effective.BaseURL
+		routeOpts.FallbackToken = effective.Token
+	}
+	route := routeForgeRemote(remote, routeOpts)
+	if route.Provider == "" {
+		return fmt.Errorf("unknown forge host %s; pass --provider", remote.Host)
+	}
+	if route.TokenErr != nil {
+		return route.TokenErr
+	}
+	if route.Token == "" {
+		return fmt.Errorf("missing %s token", route.Provider)
+	}
+	requester, err := newPullRequester(route.Provider, route.Token, route.BaseURL)
+	if err != nil {
+		return err
+	}
+
+	opts := stack.SubmitOptions{
+		Owner:  remote.Owner,
+		Repo:   remote.Repo,
+		All:    stackSubmitAll,
+		Remote: stackSubmitRemote,
+		NoPush: stackSubmitNoPush,
+		Draft:  stackSubmitDraft,
+		DryRun: stackSubmitDryRun,
+	}
+	if len(args) == 1 {
+		opts.Branch = args[0]
+	}
+	outcomes, err := stack.NewManager().Submit(ctx, repo, requester, opts)
+	printStackSubmit(cmd, outcomes)
+	return err
+}
+
+func printStackSubmit(cmd *cobra.Command, outcomes []stack.SubmitOutcome) {
+	if quiet {
+		return
+	}
+	out := cmd.OutOrStdout()
+	for _, o := range outcomes {
+		line := fmt.Sprintf("%s\t%s → %s", o.Action, o.Branch, o.Base)
+		if o.URL != "" {
+			line += "\t" + o.URL
+		}
+		fmt.Fprintln(out, strings.TrimSpace(line))
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestStackSubcommandFlags(t *testing.T) {
+	for path, flags := range map[string][]string{
+		"create":  {"parent", "no-checkout", "adopt"},
+		"list":    {"format"},
+		"restack": {"all", "fetch", "remote"},
+		"submit":  {"all", "draft", "dry-run", "no-push", "remote", "provider", "token"},
+	} {
+		cmd := findCommand(t, rootCmd, "stack", path)
+		for _, name := range flags {
+			if cmd.Flags().Lookup(name) == nil {
+				t.Errorf("stack %s missing --%s", path, name)
+			}
+		}
+	}
+}
+
+func TestStackCreateListRestack(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q", "-b", "main")
+	runGit(t, dir, "config", "user.name", "Test User")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	writeFile(t, dir, "base.txt", "base\n")
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-q", "-m", "base")
+	t.Chdir(dir)
+
+	prevParent, prevNoCheckout, prevAdopt := stackCreateParent, stackCreateNoCheckout, stackCreateAdopt
+	prevFormat := stackListFormat
+	t.Cleanup(func() {
+		stackCreateParent, stackCreateNoCheckout, stackCreateAdopt = prevParent, prevNoCheckout, prevAdopt
+		stackListFormat = prevFormat
+	})
+	stackCreateParent, stackCreateNoCheckout, stackCreateAdopt = "", false, false
+	stackListFormat = "default"
+
+	var out bytes.Buffer
+	for _, name := range []string{"api", "ui"} {
+		stackCreateCmd.SetOut(&out)
+		if err := runStackCreate(stackCreateCmd, []string{name}); err != nil {
+			t.Fatal(err)
+		}
+		writeFile(t, dir, name+".txt", name+"\n")
+		runGit(t, dir, "add", ".")
+		runGit(t, dir, "commit", "-q", "-m", name)
+	}
+	t.Cleanup(func() { stackCreateCmd.SetOut(nil) })
+	if !strings.Contains(out.String(), "✓ ui stacked on api") {
+		t.Errorf("create output:\n%s", out.String())
+	}
+
+	runGit(t, dir, "checkout", "-q", "main")
+	writeFile(t, dir, "main.txt", "main\n")
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-q", "-m", "main moves")
+	runGit(t, dir, "checkout", "-q", "ui")
+
+	out.Reset()
+	stackListCmd.SetOut(&out)
+	t.Cleanup(func() { stackListCmd.SetOut(nil) })
+	if err := runStackList(stackListCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := "main\n└── api (needs restack)\n    └── ui *\n"
+	if out.String() != want {
+		t.Errorf("list output:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	stackRestackCmd.SetOut(&out)
+	t.Cleanup(func() { stackRestackCmd.SetOut(nil) })
+	if err := runStackRestack(stackRestackCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "✓ api restacked") || !strings.Contains(out.String(), "✓ ui restacked") {
+		t.Errorf("restack output:\n%s", out.String())
+	}
+}
@@ -35,6 +35,9 @@ var statusCmd = &cobra.Command{
   # Quick check (skip network fetch)
   gz-git status --skip-fetch
 
+  # Answer from a running gz-git daemon's index
+  gz-git status --via-daemon ~/projects
+
   # Continuously check at intervals (watch mode)
   gz-git status --scan-depth 2 --watch --interval 30s ~/projects`) + cliutil.ExitCodesBulkHelp(),
 	Args: cobra.MaximumNArgs(1),
@@ -46,6 +49,7 @@ func init() {
 
 	// Common bulk operation flags
 	addBulkFlags(statusCmd, &statusFlags)
+	addViaDaemonFlag(statusCmd, &statusFlags.ViaDaemon)
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
@@ -87,7 +91,7 @@ func runStatus(cmd *cobra.Command, args []string) error {
 
 	// Watch mode: continuously check at intervals
 	if statusFlags.Watch {
-		return runStatusWatch(ctx, client, directory, logger)
+		return runStatusWatch(ctx, cmd, client, directory, logger)
 	}
 
 	// One-time status check with diagnostic
@@ -95,7 +99,7 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		printScanningMessage(directory, statusFlags.Depth, statusFlags.Parallel, false)
 	}
 
-	result, err := runDiagnosticStatus(ctx, client, directory, logger)
+	result, err := loadStatusReport(ctx, cmd, client, directory, logger)
 	if err != nil {
 		return fmt.Errorf("status check failed: %w", err)
 	}
@@ -120,6 +124,18 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	return errPartialFailure(result.Summary.Error, result.Summary.Total)
 }
 
+// loadStatusReport answers from the daemon when asked to and it covers
+// directory, and runs the diagnostic itself otherwise.
+func loadStatusReport(ctx context.Context, cmd *cobra.Command, client repository.Client, directory string, logger repository.Logger) (*reposync.HealthReport, error) {
+	if useDaemon(cmd, statusFlags.ViaDaemon) {
+		started := time.Now()
+		if resp, ok := queryDaemon(ctx, cmd, directory, statusFlags); ok {
+			return daemonHealthReport(resp, started), nil
+		}
+	}
+	return runDiagnosticStatus(ctx, client, directory, logger)
+}
+
 func runDiagnosticStatus(ctx context.Context, client repository.Client, directory string, logger repository.Logger) (*reposync.HealthReport, error) {
 	// Scan for repositories (lightweight, no GetInfo/GetStatus)
 	scanResult, err := client.ScanRepositories(ctx, repository.ScanOptions{
@@ -164,7 +180,7 @@ func runDiagnosticStatus(ctx context.Context, client repository.Client, director
 	return executor.CheckHealth(ctx, repoSpecs, opts)
 }
 
-func runStatusWatch(ctx context.Context, client repository.Client, directory string, logger repository.Logger) error {
+func runStatusWatch(ctx context.Context, cmd *cobra.Command, client repository.Client, directory string, logger repository.Logger) error {
 	cfg := WatchConfig{
 		Interval:      statusFlags.Interval,
 		Format:        statusFlags.Format,
@@ -176,12 +192,12 @@ func runStatusWatch(ctx context.Context, client repository.Client, directory str
 	}
 
 	return RunBulkWatch(cfg, func() error {
-		return executeStatusDiagnostic(ctx, client, directory, logger)
+		return executeStatusDiagnostic(ctx, cmd, client, directory, logger)
 	})
 }
 
-func executeStatusDiagnostic(ctx context.Context, client repository.Client, directory string, logger repository.Logger) error {
-	result, err := runDiagnosticStatus(ctx, client, directory, logger)
+func executeStatusDiagnostic(ctx context.Context, cmd *cobra.Command, client repository.Client, directory string, logger repository.Logger) error {
+	result, err := loadStatusReport(ctx, cmd, client, directory, logger)
 	if err != nil {
 		return fmt.Errorf("diagnostic status failed: %w", err)
 	}
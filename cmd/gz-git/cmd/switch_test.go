@@ -25,11 +25,19 @@ func captureStdout(t *testing.T, fn func()) string {
 	os.Stdout = w
 	defer func() { os.Stdout = orig }()
 
+	// Drain the pipe while fn runs: output larger than the pipe buffer would
+	// otherwise block fn forever.
+	var buf bytes.Buffer
+	copied := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(&buf, r)
+		copied <- err
+	}()
+
 	fn()
 
 	_ = w.Close()
-	var buf bytes.Buffer
-	if _, err := io.Copy(&buf, r); err != nil {
+	if err := <-copied; err != nil {
 		t.Fatalf("copy stdout: %v", err)
 	}
 	return buf.String()
@@ -0,0 +1,169 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/tui"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/watch"
+)
+
+var (
+	tuiFlags      BulkCommandFlags
+	tuiFetch      time.Duration
+	tuiStaleStash time.Duration
+)
+
+// tuiCmd is the interactive multi-repository dashboard.
+var tuiCmd = &cobra.Command{
+	Use:   "tui [directory]",
+	Short: "Interactive dashboard for every repository under a directory",
+	Long: `Open a full-screen dashboard listing every repository under the directory
+with its branch, ahead/behind counts and working tree state. Rows update live
+as files change, and the selected repositories can be fetched, pulled,
+pushed, committed, stashed, switched or cleaned up without leaving it. Each
+action is one bulk operation, the same one the matching command runs, and its
+outcome is written to the log pane.
+
+Keys:
+  ↑↓ j/k      move              Space   select / unselect
+  a / n       select all / none Enter   changed files, then a file's diff
+  f p P       fetch, pull (fast-forward only), push
+  c           commit (prompts for a message)
+  s           stash local changes
+  b           switch branch (prompts for the name)
+  x           delete merged and gone branches (asks first)
+  r           rescan the directory
+  1-5         only dirty / ahead / behind / conflicted / stale stash
+  0           show all          q       quit
+
+With nothing selected, an action applies to the repository under the cursor.
+Pushes obey the same push policy and foreign-work check as gz-git push.
+
+` + cliutil.QuickStartHelp(`  # Dashboard for the current directory
+  gz-git tui
+
+  # Two levels deep, fetching in the background so behind counts stay current
+  gz-git tui -d 2 --fetch-interval 5m ~/src
+
+  # Treat stashes older than three days as stale
+  gz-git tui --stale-stash 72h`),
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTUI,
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+
+	addBulkFlagsWithOpts(tuiCmd, &tuiFlags, BulkFlagOptions{
+		SkipDryRun: true,
+		SkipFetch:  true,
+		SkipFormat: true,
+		SkipWatch:  true,
+	})
+	tuiCmd.Flags().DurationVar(&tuiFetch, "fetch-interval", 0, "fetch remotes in the background this often (0 = never)")
+	tuiCmd.Flags().DurationVar(&tuiStaleStash, "stale-stash", tui.DefaultStaleStashAfter, "stash age the stale-stash filter (5) reports")
+}
+
+func runTUI(cmd *cobra.Command, args []string) error {
+	effective, _ := LoadEffectiveConfig(cmd, nil)
+	if effective != nil && !cmd.Flags().Changed("parallel") && effective.Parallel > 0 {
+		tuiFlags.Parallel = effective.Parallel
+	}
+
+	directory, err := validateBulkDirectory(args)
+	if err != nil {
+		return err
+	}
+	if err := validateBulkDepth(cmd, tuiFlags.Depth); err != nil {
+		return err
+	}
+	if tuiFetch < 0 {
+		return fmt.Errorf("fetch-interval must not be negative")
+	}
+	if tuiFetch > 0 && tuiFetch < watch.MinFetchInterval {
+		return fmt.Errorf("fetch-interval must be 0 or at least %s", watch.MinFetchInterval)
+	}
+	guards, err := resolvePushGuards(effective, pushOverrides{})
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client := repository.NewClient()
+	if !quiet {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Scanning %s...\n", directory)
+	}
+	status, err := client.BulkStatus(ctx, repository.BulkStatusOptions{
+		Directory:         directory,
+		Parallel:          tuiFlags.Parallel,
+		MaxDepth:          tuiFlags.Depth,
+		IncludeSubmodules: tuiFlags.IncludeSubmodules,
+		IncludePattern:    tuiFlags.Include,
+		ExcludePattern:    tuiFlags.Exclude,
+	})
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+
+	opts := tui.DashboardOptions{
+		Directory:         directory,
+		MaxDepth:          tuiFlags.Depth,
+		Parallel:          tuiFlags.Parallel,
+		IncludeSubmodules: tuiFlags.IncludeSubmodules,
+		IncludePattern:    tuiFlags.Include,
+		ExcludePattern:    tuiFlags.Exclude,
+		StaleStashAfter:   tuiStaleStash,
+		PushPolicy:        guards.policy,
+		Identity:          guards.identity,
+	}
+
+	// The watcher is what makes the list live. Without one (no repositories,
+	// or no file system notifications) the dashboard still works; r rescans.
+	if paths := repositoryPaths(status.Repositories); len(paths) > 0 {
+		watcher, err := watch.NewWatcher(client, watch.WatchOptions{
+			IncludeClean:  true,
+			FetchInterval: tuiFetch,
+			Workers:       tuiFlags.Parallel,
+			Identity:      guards.identity,
+		})
+		if err == nil {
+			err = watcher.Start(ctx, paths)
+		}
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: live updates disabled: %v\n", err)
+		} else {
+			defer watcher.Stop() //nolint:errcheck // best effort on exit
+			opts.Events = watcher.Events()
+		}
+	}
+
+	model := tui.NewDashboardModel(ctx, client, status.Repositories, opts)
+	if _, err := tea.NewProgram(model, tea.WithAltScreen(), tea.WithContext(ctx)).Run(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("TUI error: %w", err)
+	}
+	return nil
+}
+
+// repositoryPaths lists the paths of results.
+func repositoryPaths(results []repository.RepositoryStatusResult) []string {
+	paths := make([]string, len(results))
+	for i, r := range results {
+		paths[i] = r.Path
+	}
+	return paths
+}
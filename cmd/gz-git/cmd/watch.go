@@ -14,6 +14,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/watch"
 )
@@ -23,7 +24,9 @@ var (
 	watchInterval     time.Duration
 	watchIncludeClean bool
 	watchOutputFormat string
-	watchNotifySound  bool
+	watchNotify       bool
+	watchFetch        time.Duration
+	watchAutoFF       bool
 )
 
 // watchCmd represents the watch command.
@@ -36,14 +39,40 @@ var watchCmd = &cobra.Command{
   # Watch multiple repositories
   gz-git watch /path/to/repo1 /path/to/repo2
 
-  # Watch with custom interval
+  # Poll interval for repositories over the file watch limit
   gz-git watch --interval 5s
 
   # Notify when repository becomes clean
   gz-git watch --include-clean
 
   # Compact output format
-  gz-git watch --format compact`),
+  gz-git watch --format compact
+
+  # Desktop notification per event (notify-send)
+  gz-git watch --notify
+
+  # Fetch every 5 minutes and report incoming, diverged, and gone upstreams
+  gz-git watch --fetch-interval 5m
+
+  # ...and fast-forward clean branches that only have incoming commits
+  gz-git watch --fetch-interval 5m --auto-ff
+
+Hooks, NDJSON logs, webhooks, and fetching are configured per project in
+.gz-git.yaml:
+
+  watch:
+    fetchInterval: 5m
+    autoFastForward: true
+    sinks:
+      - type: exec
+        command: [make, lint]     # event JSON on stdin
+        events: [modified, staged]
+        limit: 1
+        per: 30s
+      - type: file
+        path: .git/watch.ndjson
+      - type: webhook
+        url: https://hooks.example.com/gz-git`),
 	Example: ``,
 	RunE:    runWatch,
 }
@@ -52,13 +81,12 @@ func init() {
 	rootCmd.AddCommand(watchCmd)
 
 	// Flags
-	watchCmd.Flags().DurationVar(&watchInterval, "interval", 2*time.Second, "polling interval for checking changes")
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 2*time.Second, "polling interval for repositories too large for file notifications")
 	watchCmd.Flags().BoolVar(&watchIncludeClean, "include-clean", false, "notify when repository becomes clean")
 	watchCmd.Flags().StringVar(&watchOutputFormat, "format", "default", "output format: default, compact, json, llm")
-	watchCmd.Flags().BoolVar(&watchNotifySound, "notify", false, "play sound on changes (not yet implemented; flag reserved)")
-	// Hidden until the sound backend is implemented; passing it emits a
-	// "not implemented" warning at startup instead of failing.
-	_ = watchCmd.Flags().MarkHidden("notify")
+	watchCmd.Flags().BoolVar(&watchNotify, "notify", false, "show a desktop notification for each event (notify-send)")
+	watchCmd.Flags().DurationVar(&watchFetch, "fetch-interval", 0, "fetch remotes in the background this often to report upstream changes (0 = off)")
+	watchCmd.Flags().BoolVar(&watchAutoFF, "auto-ff", false, "fast-forward clean branches when their upstream gains commits (needs --fetch-interval)")
 }
 
 func runWatch(cmd *cobra.Command, args []string) error {
@@ -69,11 +97,21 @@ func runWatch(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Warn once if --notify was requested: the feature is not implemented yet
-	// (real sound support is tracked separately). Goes to stderr so it never
-	// pollutes a --format json/llm stdout stream.
-	if watchNotifySound {
-		fmt.Fprintln(os.Stderr, "warning: --notify is not yet implemented; no sound will play")
+	logger := newWatchLogger(verbose)
+	effective, err := LoadEffectiveConfig(cmd, nil)
+	if err != nil {
+		// Terminal output does not depend on the config; sinks and fetching
+		// fall back to the flags.
+		logger.Warn("watch settings from .gz-git.yaml not loaded: %v", err)
+		effective = nil
+	}
+	sinks, err := buildWatchSinks(effective)
+	if err != nil {
+		return err
+	}
+	fetchInterval, autoFF, err := watchFetchSettings(cmd, effective)
+	if err != nil {
+		return err
 	}
 
 	// Determine paths to watch
@@ -110,7 +148,10 @@ func runWatch(cmd *cobra.Command, args []string) error {
 		Interval:         watchInterval,
 		IncludeClean:     watchIncludeClean,
 		DebounceDuration: 500 * time.Millisecond,
-		Logger:           newWatchLogger(verbose),
+		FetchInterval:    fetchInterval,
+		AutoFastForward:  autoFF,
+		Identity:         pushIdentity(effective),
+		Logger:           logger,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create watcher: %w", err)
@@ -124,10 +165,9 @@ func runWatch(cmd *cobra.Command, args []string) error {
 
 	// Print header (suppressed for machine formats so stdout stays parseable)
 	if shouldShowProgress(watchOutputFormat, quiet) {
-		fmt.Printf("Watching %d repositor%s for changes (interval: %s)\n",
+		fmt.Printf("Watching %d repositor%s for changes\n",
 			len(absPaths),
-			pluralize(len(absPaths), "y", "ies"),
-			watchInterval)
+			pluralize(len(absPaths), "y", "ies"))
 		for _, path := range absPaths {
 			fmt.Printf("  - %s\n", path)
 		}
@@ -142,6 +182,11 @@ func runWatch(cmd *cobra.Command, args []string) error {
 	// Create event formatter
 	formatter := newEventFormatter(watchOutputFormat)
 
+	// Sinks run beside the terminal output; stopping gives queued deliveries
+	// a moment to finish so the last change still reaches its hook.
+	dispatcher := watch.NewDispatcher(ctx, sinks, logger)
+	defer dispatcher.Close(5 * time.Second)
+
 	// Event loop
 	for {
 		select {
@@ -160,10 +205,7 @@ func runWatch(cmd *cobra.Command, args []string) error {
 			output := formatter.Format(event)
 			fmt.Print(output)
 
-			// Play notification sound if requested
-			if watchNotifySound {
-				playNotificationSound()
-			}
+			dispatcher.Dispatch(event)
 
 		case err, ok := <-watcher.Errors():
 			if !ok {
@@ -176,6 +218,57 @@ func runWatch(cmd *cobra.Command, args []string) error {
 	}
 }
 
+// buildWatchSinks returns the sinks from the project's watch config, plus a
+// desktop notification sink for --notify. effective may be nil when no
+// config could be loaded.
+func buildWatchSinks(effective *config.EffectiveConfig) ([]watch.Sink, error) {
+	var configs []watch.SinkConfig
+	if effective != nil {
+		configs = append(configs, effective.Watch.Sinks...)
+	}
+	if watchNotify {
+		configs = append(configs, watch.SinkConfig{Type: watch.SinkNotify})
+	}
+
+	sinks := make([]watch.Sink, 0, len(configs))
+	for i, c := range configs {
+		sink, err := watch.NewSink(c)
+		if err != nil {
+			return nil, fmt.Errorf("watch.sinks[%d]: %w", i, err)
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
+
+// watchFetchSettings resolves background fetching: a flag given on the
+// command line wins over watch.fetchInterval and watch.autoFastForward.
+func watchFetchSettings(cmd *cobra.Command, effective *config.EffectiveConfig) (time.Duration, bool, error) {
+	interval, autoFF := watchFetch, watchAutoFF
+	if effective != nil {
+		if !cmd.Flags().Changed("fetch-interval") && effective.Watch.FetchInterval != "" {
+			d, err := time.ParseDuration(effective.Watch.FetchInterval)
+			if err != nil {
+				return 0, false, fmt.Errorf("watch.fetchInterval: %w", err)
+			}
+			interval = d
+		}
+		if !cmd.Flags().Changed("auto-ff") {
+			autoFF = autoFF || effective.Watch.AutoFastForward
+		}
+	}
+	if interval < 0 {
+		return 0, false, fmt.Errorf("--fetch-interval must not be negative")
+	}
+	if interval > 0 && interval < watch.MinFetchInterval {
+		return 0, false, fmt.Errorf("--fetch-interval must be 0 or at least %s", watch.MinFetchInterval)
+	}
+	if autoFF && interval == 0 {
+		return 0, false, fmt.Errorf("--auto-ff needs --fetch-interval: nothing brings in upstream commits otherwise")
+	}
+	return interval, autoFF, nil
+}
+
 // eventFormatter formats watch events for display.
 type eventFormatter interface {
 	Format(event watch.Event) string
@@ -220,6 +313,16 @@ func (f *defaultFormatter) Format(event watch.Event) string {
 		sb.WriteString(cliutil.ColorCyan + "● Branch Changed" + cliutil.ColorReset)
 	case watch.EventTypeClean:
 		sb.WriteString(cliutil.ColorGreen + "✓ Clean" + cliutil.ColorReset)
+	case watch.EventTypeIncoming:
+		sb.WriteString(cliutil.ColorCyan + "↓ Incoming" + cliutil.ColorReset)
+	case watch.EventTypeDiverged:
+		sb.WriteString(cliutil.ColorRed + "⇅ Diverged" + cliutil.ColorReset)
+	case watch.EventTypeUpstreamGone:
+		sb.WriteString(cliutil.ColorRed + "✗ Upstream Gone" + cliutil.ColorReset)
+	case watch.EventTypeForeignWork:
+		sb.WriteString(cliutil.ColorMagenta + "● Foreign Work" + cliutil.ColorReset)
+	case watch.EventTypeFastForward:
+		sb.WriteString(cliutil.ColorGreen + "↓ Fast-forwarded" + cliutil.ColorReset)
 	default:
 		fmt.Fprintf(&sb, "● %s", event.Type)
 	}
@@ -231,6 +334,13 @@ func (f *defaultFormatter) Format(event watch.Event) string {
 
 	sb.WriteString("\n")
 
+	if up := event.Upstream; up != nil {
+		fmt.Fprintf(&sb, "    %s\n", up.Describe())
+		for _, c := range up.Foreign {
+			fmt.Fprintf(&sb, "    %s\n", c)
+		}
+	}
+
 	// Show files (limit to first 5)
 	if len(event.Files) > 0 {
 		maxFiles := 5
@@ -259,32 +369,25 @@ func (f *compactFormatter) Format(event watch.Event) string {
 		fileInfo = fmt.Sprintf(" [%d]", len(event.Files))
 	}
 
+	if up := event.Upstream; up != nil {
+		fileInfo = fmt.Sprintf(" (↓%d ↑%d)", up.Behind, up.Ahead)
+		if up.Gone {
+			fileInfo = " (gone)"
+		}
+	}
+
 	return fmt.Sprintf("[%s] %s: %s%s\n", timestamp, repoName, event.Type, fileInfo)
 }
 
 // jsonFormatter provides JSON output for machine parsing.
 type jsonFormatter struct{}
 
-// watchEventJSON is the wire format for a watch event.
-type watchEventJSON struct {
-	Timestamp string   `json:"timestamp"`
-	Path      string   `json:"path"`
-	Type      string   `json:"type"`
-	Files     []string `json:"files"`
-}
+// watchEventJSON is the wire format for a watch event, shared with the
+// sinks so hooks and --format json consumers parse the same thing.
+type watchEventJSON = watch.EventRecord
 
 func (f *jsonFormatter) Format(event watch.Event) string {
-	files := event.Files
-	if files == nil {
-		files = []string{}
-	}
-
-	data, err := json.Marshal(watchEventJSON{
-		Timestamp: event.Timestamp.Format(time.RFC3339),
-		Path:      event.Path,
-		Type:      string(event.Type),
-		Files:     files,
-	})
+	data, err := json.Marshal(watch.NewEventRecord(event))
 	if err != nil {
 		return fmt.Sprintf(`{"error":%q}`+"\n", err.Error())
 	}
@@ -314,6 +417,23 @@ func (f *llmFormatter) Format(event watch.Event) string {
 		}
 	}
 
+	if up := event.Upstream; up != nil {
+		fmt.Fprintf(&sb, "- Branch: %s\n", up.Branch)
+		fmt.Fprintf(&sb, "- Upstream: %s\n", up.Ref)
+		if up.Gone {
+			sb.WriteString("- Upstream Gone: true\n")
+		} else {
+			fmt.Fprintf(&sb, "- Behind: %d\n", up.Behind)
+			fmt.Fprintf(&sb, "- Ahead: %d\n", up.Ahead)
+		}
+		if len(up.Foreign) > 0 {
+			sb.WriteString("- Foreign Commits:\n")
+			for _, c := range up.Foreign {
+				fmt.Fprintf(&sb, "  - %s\n", c)
+			}
+		}
+	}
+
 	sb.WriteString("\n")
 	return sb.String()
 }
@@ -354,29 +474,3 @@ func pluralize(count int, singular, plural string) string {
 	}
 	return plural
 }
-
-// playNotificationSound plays a system notification sound.
-// TODO(feature): Implement platform-specific sound notifications
-//
-// Planned implementation:
-// - macOS: Use 'afplay /System/Library/Sounds/Ping.aiff'
-// - Linux: Use 'paplay' or 'aplay' with system sounds
-// - Windows: Use Windows Beep API via syscall
-//
-// Example:
-//
-//	macOS: exec.Command("afplay", "/System/Library/Sounds/Ping.aiff").Run()
-//	Linux: exec.Command("paplay", "/usr/share/sounds/freedesktop/stereo/message.oga").Run()
-//
-// Reference: docs/design/WATCH_OUTPUT_IMPROVEMENTS.md (Phase 1)
-// Priority: Medium (P2) - Planned for v0.3.0.
-func playNotificationSound() {
-	// Placeholder - feature not yet implemented
-	_ = playSystemSound()
-}
-
-func playSystemSound() error {
-	// Platform-specific implementation pending
-	// See TODO above for planned approach
-	return nil
-}
@@ -5,9 +5,13 @@ package cmd
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/watch"
 )
 
@@ -87,3 +91,66 @@ func TestWatchProgressSuppressedForMachineFormats(t *testing.T) {
 		}
 	}
 }
+
+// TestJSONFormatter_Upstream checks that remote events carry the upstream
+// position, and that local events leave it out rather than send a null.
+func TestJSONFormatter_Upstream(t *testing.T) {
+	f := &jsonFormatter{}
+	when := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+	out := f.Format(watch.Event{
+		Path:      "/repo",
+		Type:      watch.EventTypeIncoming,
+		Timestamp: when,
+		Upstream:  &watch.UpstreamStatus{Branch: "main", Ref: "origin/main", Behind: 2},
+	})
+	var decoded watchEventJSON
+	if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if decoded.Upstream == nil || decoded.Upstream.Behind != 2 || decoded.Upstream.Ref != "origin/main" {
+		t.Errorf("upstream not preserved: %+v", decoded.Upstream)
+	}
+
+	local := f.Format(watch.Event{Path: "/repo", Type: watch.EventTypeModified, Timestamp: when})
+	if strings.Contains(local, "upstream") {
+		t.Errorf("local event should omit upstream: %s", local)
+	}
+}
+
+func TestWatchFetchSettings(t *testing.T) {
+	t.Cleanup(func() { watchFetch, watchAutoFF = 0, false })
+
+	newCmd := func() *cobra.Command {
+		c := &cobra.Command{}
+		c.Flags().DurationVar(&watchFetch, "fetch-interval", 0, "")
+		c.Flags().BoolVar(&watchAutoFF, "auto-ff", false, "")
+		return c
+	}
+	effective := &config.EffectiveConfig{Watch: config.WatchConfig{FetchInterval: "5m", AutoFastForward: true}}
+
+	interval, autoFF, err := watchFetchSettings(newCmd(), effective)
+	if err != nil || interval != 5*time.Minute || !autoFF {
+		t.Errorf("from config = %s, %v, %v; want 5m, true, nil", interval, autoFF, err)
+	}
+
+	c := newCmd()
+	_ = c.Flags().Set("fetch-interval", "30s")
+	_ = c.Flags().Set("auto-ff", "false")
+	interval, autoFF, err = watchFetchSettings(c, effective)
+	if err != nil || interval != 30*time.Second || autoFF {
+		t.Errorf("flags over config = %s, %v, %v; want 30s, false, nil", interval, autoFF, err)
+	}
+
+	c = newCmd()
+	_ = c.Flags().Set("auto-ff", "true")
+	if _, _, err := watchFetchSettings(c, nil); err == nil {
+		t.Error("--auto-ff without --fetch-interval should be rejected")
+	}
+
+	c = newCmd()
+	_ = c.Flags().Set("fetch-interval", "3ns")
+	if _, _, err := watchFetchSettings(c, nil); err == nil || !strings.Contains(err.Error(), "at least 1s") {
+		t.Errorf("--fetch-interval 3ns: err = %v, want the minimum enforced", err)
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package ci
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/provider"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+// Target kinds, in report order.
+const (
+	KindHead     = "head"
+	KindUpstream = "upstream"
+	KindDefault  = "default"
+)
+
+// AllKinds lists every tracked ref kind in report order.
+var AllKinds = []string{KindHead, KindUpstream, KindDefault}
+
+// Target is one ref to ask a forge about.
+type Target struct {
+	Kind string
+	// Ref is a full commit SHA for KindHead and a branch name on the forge
+	// for the other kinds: a branch is judged by its current tip on the
+	// forge, not by whatever this clone last fetched.
+	Ref string
+	// RemoteURL is the remote the ref lives on, which is not always origin:
+	// an upstream can track a fork.
+	RemoteURL string
+}
+
+// RefResult is the CI verdict for one Target.
+type RefResult struct {
+	Kind         string           `json:"kind"`
+	Ref          string           `json:"ref"`
+	SHA          string           `json:"sha,omitempty"`
+	State        provider.CIState `json:"state"`
+	URL          string           `json:"url,omitempty"`
+	FailedChecks []string         `json:"failed_checks,omitempty"`
+	Error        string           `json:"error,omitempty"`
+}
+
+// Report is the CI verdict for one repository.
+type Report struct {
+	Path         string           `json:"-"`
+	RelativePath string           `json:"path"`
+	Provider     string           `json:"provider,omitempty"`
+	Repository   string           `json:"repository,omitempty"`
+	State        provider.CIState `json:"state"`
+	Refs         []RefResult      `json:"refs"`
+	Error        string           `json:"error,omitempty"`
+}
+
+// Red reports whether any tracked ref is failing.
+func (r Report) Red() bool {
+	return r.State == provider.CIStateFailure
+}
+
+// Failed reports whether the report is incomplete because a query failed.
+func (r Report) Failed() bool {
+	if r.Error != "" {
+		return true
+	}
+	for _, ref := range r.Refs {
+		if ref.Error != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Ref returns the result for kind, or nil when that kind was not checked.
+func (r Report) Ref(kind string) *RefResult {
+	for i := range r.Refs {
+		if r.Refs[i].Kind == kind {
+			return &r.Refs[i]
+		}
+	}
+	return nil
+}
+
+// Targets derives the refs to check for one scanned repository. headSHA must
+// be the full object name — forges that filter by SHA do not resolve
+// abbreviations — and base is the resolved default branch, or "" when none.
+//
+// A kind that does not apply is left out rather than reported as "none": a
+// detached HEAD has no upstream, and a branch that is the default branch is
+// only asked about once.
+func Targets(st repository.RepositoryStatusResult, headSHA, base string, kinds []string) []Target {
+	want := make(map[string]bool, len(kinds))
+	for _, k := range kinds {
+		want[k] = true
+	}
+	origin := st.RemoteURL
+
+	var out []Target
+	if want[KindHead] && headSHA != "" && origin != "" {
+		out = append(out, Target{Kind: KindHead, Ref: headSHA, RemoteURL: origin})
+	}
+	if want[KindUpstream] {
+		if remote, branch, ok := splitUpstream(st.Upstream, st.Remotes); ok {
+			url := st.Remotes[remote]
+			if !(want[KindDefault] && branch == base && url == origin) {
+				out = append(out, Target{Kind: KindUpstream, Ref: branch, RemoteURL: url})
+			}
+		}
+	}
+	if want[KindDefault] && base != "" && origin != "" {
+		out = append(out, Target{Kind: KindDefault, Ref: base, RemoteURL: origin})
+	}
+	return out
+}
+
+// splitUpstream resolves "<remote>/<branch>" against the configured remotes,
+// taking the longest matching remote name because git allows "/" in both.
+func splitUpstream(upstream string, remotes map[string]string) (remote, branch string, ok bool) {
+	for name := range remotes {
+		prefix := name + "/"
+		if strings.HasPrefix(upstream, prefix) && len(name) > len(remote) {
+			remote = name
+			branch = strings.TrimPrefix(upstream, prefix)
+		}
+	}
+	if remote == "" || branch == "" || remotes[remote] == "" {
+		return "", "", false
+	}
+	return remote, branch, true
+}
+
+// ReaderFunc returns the CI reader for a parsed forge remote. Check calls it
+// once per distinct remote so callers can cache clients and route tokens.
+type ReaderFunc func(remote provider.ForgeRemote) (provider.CIStatusReader, error)
+
+// Check asks the forges about every target and rolls the answers up. Errors
+// are recorded on the affected ref rather than returned: one unreachable
+// forge should blank one cell, not the whole report.
+func Check(ctx context.Context, readerFor ReaderFunc, targets []Target) Report {
+	report := Report{State: provider.CIStateNone, Refs: make([]RefResult, 0, len(targets))}
+
+	type key struct{ remote, ref string }
+	cache := make(map[key]RefResult)
+	states := make([]provider.CIState, 0, len(targets))
+
+	for _, t := range targets {
+		result := RefResult{Kind: t.Kind, Ref: t.Ref, State: provider.CIStateNone}
+		if cached, ok := cache[key{t.RemoteURL, t.Ref}]; ok {
+			cached.Kind = t.Kind
+			report.Refs = append(report.Refs, cached)
+			states = append(states, cached.State)
+			continue
+		}
+
+		remote, err := provider.ParseForgeRemote(t.RemoteURL)
+		if err == nil && report.Repository == "" {
+			report.Provider = remote.Provider
+			report.Repository = remote.Owner + "/" + remote.Repo
+		}
+		var reader provider.CIStatusReader
+		if err == nil {
+			reader, err = readerFor(remote)
+		}
+		if err == nil {
+			var status *provider.CIStatus
+			status, err = reader.CommitCIStatus(ctx, remote.Owner, remote.Repo, t.Ref)
+			if err == nil {
+				result.SHA = status.SHA
+				result.State = status.State
+				result.URL = status.URL
+				result.FailedChecks = status.Failed()
+			}
+		}
+		if err != nil {
+			result.Error = err.Error()
+		}
+
+		cache[key{t.RemoteURL, t.Ref}] = result
+		report.Refs = append(report.Refs, result)
+		states = append(states, result.State)
+	}
+
+	report.State = provider.WorstCIState(states...)
+	return report
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package ci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/provider"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+type fakeReader struct {
+	states map[string]provider.CIState
+	calls  int
+}
+
+func (f *fakeReader) CommitCIStatus(_ context.Context, _, _, ref string) (*provider.CIStatus, error) {
+	f.calls++
+	state, ok := f.states[ref]
+	if !ok {
+		return nil, errors.New("boom")
+	}
+	status := &provider.CIStatus{Ref: ref, State: state}
+	if state == provider.CIStateFailure {
+		status.Checks = []provider.CICheck{{Name: "build", State: state}}
+	}
+	return status, nil
+}
+
+const origin = "git@github.com:acme/app.git"
+
+func TestTargets(t *testing.T) {
+	st := repository.RepositoryStatusResult{
+		RemoteURL: origin,
+		Upstream:  "origin/feat/x",
+		Remotes:   map[string]string{"origin": origin},
+	}
+
+	got := Targets(st, "abc123", "main", AllKinds)
+	if len(got) != 3 {
+		t.Fatalf("targets = %+v", got)
+	}
+	if got[0].Kind != KindHead || got[0].Ref != "abc123" {
+		t.Errorf("head = %+v", got[0])
+	}
+	if got[1].Kind != KindUpstream || got[1].Ref != "feat/x" {
+		t.Errorf("upstream = %+v", got[1])
+	}
+	if got[2].Kind != KindDefault || got[2].Ref != "main" {
+		t.Errorf("default = %+v", got[2])
+	}
+
+	st.Upstream = "origin/main"
+	if got := Targets(st, "abc123", "main", AllKinds); len(got) != 2 {
+		t.Errorf("upstream on the default branch should be asked once, got %+v", got)
+	}
+
+	st.Upstream = ""
+	if got := Targets(st, "abc123", "main", []string{KindUpstream}); len(got) != 0 {
+		t.Errorf("no upstream should yield no target, got %+v", got)
+	}
+}
+
+func TestCheckRollsUpAndRecordsErrors(t *testing.T) {
+	reader := &fakeReader{states: map[string]provider.CIState{
+		"abc123": provider.CIStateSuccess,
+		"main":   provider.CIStateFailure,
+	}}
+	readerFor := func(provider.ForgeRemote) (provider.CIStatusReader, error) { return reader, nil }
+
+	report := Check(context.Background(), readerFor, []Target{
+		{Kind: KindHead, Ref: "abc123", RemoteURL: origin},
+		{Kind: KindUpstream, Ref: "feat/x", RemoteURL: origin},
+		{Kind: KindDefault, Ref: "main", RemoteURL: origin},
+	})
+
+	if !report.Red() || report.Repository != "acme/app" || report.Provider != "github" {
+		t.Fatalf("report = %+v", report)
+	}
+	if !report.Failed() {
+		t.Error("a failed query should mark the report incomplete")
+	}
+	if ref := report.Ref(KindDefault); ref == nil || len(ref.FailedChecks) != 1 {
+		t.Errorf("default = %+v", ref)
+	}
+	if ref := report.Ref(KindUpstream); ref == nil || ref.Error == "" || ref.State != provider.CIStateNone {
+		t.Errorf("upstream = %+v", ref)
+	}
+}
+
+func TestCheckAsksEachRefOnce(t *testing.T) {
+	reader := &fakeReader{states: map[string]provider.CIState{"main": provider.CIStatePending}}
+	readerFor := func(provider.ForgeRemote) (provider.CIStatusReader, error) { return reader, nil }
+
+	report := Check(context.Background(), readerFor, []Target{
+		{Kind: KindUpstream, Ref: "main", RemoteURL: origin},
+		{Kind: KindDefault, Ref: "main", RemoteURL: origin},
+	})
+	if reader.calls != 1 {
+		t.Errorf("calls = %d, want 1", reader.calls)
+	}
+	if report.State != provider.CIStatePending || report.Refs[1].Kind != KindDefault {
+		t.Errorf("report = %+v", report)
+	}
+}
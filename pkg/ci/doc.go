@@ -0,0 +1,16 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package ci reports forge CI state for the branches a working tree cares
+// about, without opening each forge's web UI.
+//
+// Three refs are tracked per repository: HEAD (the exact commit checked out
+// here), its upstream (the branch tip this one pushes to), and the default
+// branch (what everyone else builds on). They answer different questions —
+// "did my last push pass", "is my branch green", "is the base broken" — so
+// each is reported separately and rolled up into a single worst state.
+//
+// Targets derives what to ask from a bulk status scan, and Check asks the
+// forges through provider.CIStatusReader, so the forge-specific vocabulary
+// never leaks past the provider packages.
+package ci
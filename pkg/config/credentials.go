@@ -0,0 +1,252 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/provider"
+)
+
+// Credential routes one forge host, optionally narrowed to a single
+// organization on that host, to a token. A machine that talks to github.com,
+// two GitHub Enterprise servers, and a self-hosted GitLab lists one entry per
+// host; a user with a personal and a work account on the same host adds an
+// org-scoped entry for the work organization.
+//
+//	credentials:
+//	  - host: ghe.corp.example.com
+//	    provider: github
+//	    token: ${CORP_GHE_TOKEN}
+//	  - host: github.com
+//	    org: acme
+//	    token: ${ACME_GITHUB_TOKEN}
+//	  - host: git.internal.example
+//	    provider: gitlab
+//
+// An entry without a token is still useful: it declares the host so doctor
+// validates it, and the token is read from the keychain under the entry's key.
+type Credential struct {
+	// Host is the forge hostname as it appears in remote URLs.
+	Host string `yaml:"host"`
+	// Provider is github, gitlab, or gitea. When empty it is inferred from
+	// the hostname by the same rules remote parsing uses.
+	Provider string `yaml:"provider,omitempty"`
+	// Org narrows the entry to repositories under one top-level namespace.
+	Org string `yaml:"org,omitempty"`
+	// BaseURL overrides the API base for hosts that do not serve it at
+	// https://<host>.
+	BaseURL string `yaml:"baseURL,omitempty"`
+	// Token may use ${VAR} expansion or a secret reference such as
+	// ${keyring:...} or ${cmd:...}. Empty means "look in the keychain".
+	Token string `yaml:"token,omitempty"`
+}
+
+// ProviderName returns Provider, or the provider inferred from Host.
+func (c Credential) ProviderName() string {
+	if c.Provider != "" {
+		return normalizeProvider(c.Provider)
+	}
+	return provider.ClassifyForgeHost(strings.ToLower(c.Host))
+}
+
+// APIBaseURL returns BaseURL, or the base URL remote parsing would derive
+// for Host: empty for github.com and gitlab.com, https://<host> otherwise.
+func (c Credential) APIBaseURL() string {
+	if c.BaseURL != "" {
+		return c.BaseURL
+	}
+	host := strings.ToLower(c.Host)
+	if host == "github.com" || host == "gitlab.com" {
+		return ""
+	}
+	return "https://" + host
+}
+
+// Key returns the keychain key the entry's token is stored under.
+func (c Credential) Key() CredentialKey {
+	return CredentialKey{Provider: c.ProviderName(), Host: c.Host, Org: c.Org}
+}
+
+// CredentialKey names a token in the keychain. The bare provider form
+// ("github") is the key gz-git has always used and remains the catch-all; a
+// host ("github@ghe.corp.example.com") or host and org
+// ("github@github.com/acme") narrows it.
+type CredentialKey struct {
+	Provider string
+	Host     string
+	Org      string
+}
+
+// String renders the key as the keychain account name.
+func (k CredentialKey) String() string {
+	s := normalizeProvider(k.Provider)
+	host := normalizeProvider(k.Host)
+	if host == "" {
+		return s
+	}
+	s += "@" + host
+	if org := normalizeProvider(k.Org); org != "" {
+		s += "/" + org
+	}
+	return s
+}
+
+// Validate rejects keys that cannot be stored unambiguously.
+func (k CredentialKey) Validate() error {
+	if normalizeProvider(k.Provider) == "" {
+		return fmt.Errorf("provider is required")
+	}
+	if strings.ContainsAny(k.Provider, "@/") || strings.ContainsAny(k.Host, "@/") {
+		return fmt.Errorf("provider and host must not contain '@' or '/'")
+	}
+	if normalizeProvider(k.Org) != "" && normalizeProvider(k.Host) == "" {
+		return fmt.Errorf("an org-scoped credential needs a host")
+	}
+	return nil
+}
+
+// Candidates lists the keys that may hold a token for k, most specific first:
+// host and org, then host, then the bare provider.
+func (k CredentialKey) Candidates() []CredentialKey {
+	out := make([]CredentialKey, 0, 3)
+	if normalizeProvider(k.Host) != "" {
+		if normalizeProvider(k.Org) != "" {
+			out = append(out, k)
+		}
+		out = append(out, CredentialKey{Provider: k.Provider, Host: k.Host})
+	}
+	return append(out, CredentialKey{Provider: k.Provider})
+}
+
+// ParseCredentialKey is the inverse of CredentialKey.String.
+func ParseCredentialKey(s string) (CredentialKey, error) {
+	name, rest, hasHost := strings.Cut(strings.TrimSpace(s), "@")
+	k := CredentialKey{Provider: name}
+	if hasHost {
+		k.Host, k.Org, _ = strings.Cut(rest, "/")
+		if k.Host == "" {
+			return CredentialKey{}, fmt.Errorf("credential key %q has an empty host", s)
+		}
+	}
+	if err := k.Validate(); err != nil {
+		return CredentialKey{}, fmt.Errorf("credential key %q: %w", s, err)
+	}
+	return k, nil
+}
+
+// ResolveHostToken finds the token for a repository on host under org.
+//
+// Host-scoped sources are consulted before provider-wide ones, so a GITHUB_TOKEN
+// exported for github.com is never sent to an enterprise server that has its
+// own entry. Within one scope the usual precedence holds (env > keychain >
+// config). The order is:
+//
+//  1. keychain, then configured credentials, for host and org
+//  2. keychain, then configured credentials, for host
+//  3. provider environment variables (GITHUB_TOKEN, …, GZ_GIT_TOKEN)
+//  4. keychain for the bare provider
+//
+// Steps 3 and 4 apply only to the public forge of providerName (github.com,
+// gitlab.com, gitea.com, codeberg.org). A host declared under credentials:
+// is governed by its entries alone, and any other host, however much its
+// name looks like a forge, gets nothing.
+//
+// source describes where the token came from ("keychain:github@host",
+// "config:host/org", "env:GITHUB_TOKEN"); both are empty when nothing matched.
+// A configured token reference that fails to resolve is an error, not a
+// miss: falling through would quietly send a different credential.
+func ResolveHostToken(creds []Credential, providerName, host, org string) (token, source string, err error) {
+	want := CredentialKey{Provider: providerName, Host: host, Org: org}
+	for _, key := range want.Candidates() {
+		if key.Host == "" {
+			break
+		}
+		if tok, err := DefaultTokenStore.Get(key.String()); err == nil && tok != "" {
+			return tok, "keychain:" + key.String(), nil
+		}
+		if c, ok := matchCredential(creds, key); ok && c.Token != "" {
+			tok, err := ResolveSecret(c.Token)
+			if err != nil {
+				return "", "", fmt.Errorf("credentials entry %s: token: %w", credentialLabel(c), err)
+			}
+			if tok != "" {
+				return tok, "config:" + credentialLabel(c), nil
+			}
+		}
+	}
+	if _, declared := FindCredential(creds, host, org); declared {
+		return "", "", nil
+	}
+	if provider.PublicForgeProvider(host) != normalizeProvider(providerName) {
+		return "", "", nil
+	}
+	if tok, src := ResolveTokenFromEnv(providerName); tok != "" {
+		return tok, src, nil
+	}
+	if tok, err := DefaultTokenStore.Get(providerName); err == nil && tok != "" {
+		return tok, "keychain:" + normalizeProvider(providerName), nil
+	}
+	return "", "", nil
+}
+
+// matchCredential finds the configured entry for exactly key's scope. An
+// entry whose provider cannot be inferred matches any provider on its host.
+func matchCredential(creds []Credential, key CredentialKey) (Credential, bool) {
+	for _, c := range creds {
+		if !strings.EqualFold(c.Host, key.Host) || !strings.EqualFold(c.Org, key.Org) {
+			continue
+		}
+		if name := c.ProviderName(); name != "" && !strings.EqualFold(name, key.Provider) {
+			continue
+		}
+		return c, true
+	}
+	return Credential{}, false
+}
+
+// FindCredential returns the configured entry that governs a repository on
+// host under org, preferring an org-scoped entry. Callers use it for the
+// entry's BaseURL and Provider overrides; tokens go through ResolveHostToken.
+func FindCredential(creds []Credential, host, org string) (Credential, bool) {
+	var hostOnly *Credential
+	for i, c := range creds {
+		if !strings.EqualFold(c.Host, host) {
+			continue
+		}
+		if c.Org != "" && strings.EqualFold(c.Org, org) {
+			return c, true
+		}
+		if c.Org == "" && hostOnly == nil {
+			hostOnly = &creds[i]
+		}
+	}
+	if hostOnly != nil {
+		return *hostOnly, true
+	}
+	return Credential{}, false
+}
+
+func credentialLabel(c Credential) string {
+	if c.Org == "" {
+		return strings.ToLower(c.Host)
+	}
+	return strings.ToLower(c.Host) + "/" + strings.ToLower(c.Org)
+}
+
+// LoadCredentials returns the credentials declared in the global config. A
+// missing or unreadable global config yields none: routing then falls back to
+// the provider-wide sources, which is what gz-git did before hosts existed.
+func LoadCredentials() []Credential {
+	manager, err := NewManager()
+	if err != nil {
+		return nil
+	}
+	global, err := manager.LoadGlobalConfig()
+	if err != nil || global == nil {
+		return nil
+	}
+	return global.Credentials
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCredentialKey_StringAndParse(t *testing.T) {
+	tests := []struct {
+		key  CredentialKey
+		want string
+	}{
+		{CredentialKey{Provider: "GitHub"}, "github"},
+		{CredentialKey{Provider: "github", Host: "GHE.corp.example.com"}, "github@ghe.corp.example.com"},
+		{CredentialKey{Provider: "github", Host: "github.com", Org: "Acme"}, "github@github.com/acme"},
+	}
+	for _, tt := range tests {
+		if got := tt.key.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+		parsed, err := ParseCredentialKey(tt.want)
+		if err != nil {
+			t.Fatalf("ParseCredentialKey(%q): %v", tt.want, err)
+		}
+		if parsed.String() != tt.want {
+			t.Errorf("round trip %q -> %q", tt.want, parsed.String())
+		}
+	}
+
+	for _, bad := range []string{"", "github@", "@host"} {
+		if _, err := ParseCredentialKey(bad); err == nil {
+			t.Errorf("ParseCredentialKey(%q) = nil, want error", bad)
+		}
+	}
+	if err := (CredentialKey{Provider: "github", Org: "acme"}).Validate(); err == nil {
+		t.Error("org without host should not validate")
+	}
+}
+
+func TestResolveHostToken_MostSpecificWins(t *testing.T) {
+	mem := NewMemoryTokenStore()
+	prev := DefaultTokenStore
+	SetTokenStore(mem)
+	t.Cleanup(func() { SetTokenStore(prev) })
+	t.Setenv("GITHUB_TOKEN", "from-env")
+	t.Setenv("GZ_GIT_TOKEN", "")
+
+	creds := []Credential{
+		{Host: "ghe.corp.example.com", Token: "ghe-config"},
+		{Host: "github.com", Org: "acme", Token: "acme-config"},
+	}
+	if err := mem.Set("github@ghe.corp.example.com", "ghe-keychain"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name, host, org  string
+		wantTok, wantSrc string
+	}{
+		{"keychain host beats config host", "ghe.corp.example.com", "team", "ghe-keychain", "keychain:github@ghe.corp.example.com"},
+		{"org entry beats env", "github.com", "acme", "acme-config", "config:github.com/acme"},
+		{"other org falls back to env", "github.com", "someone", "from-env", "env:GITHUB_TOKEN"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tok, src, _ := ResolveHostToken(creds, "github", tt.host, tt.org)
+			if tok != tt.wantTok || src != tt.wantSrc {
+				t.Fatalf("got %q (%s), want %q (%s)", tok, src, tt.wantTok, tt.wantSrc)
+			}
+		})
+	}
+
+	t.Setenv("GITHUB_TOKEN", "")
+	if err := mem.Set("github", "provider-wide"); err != nil {
+		t.Fatal(err)
+	}
+	if tok, src, _ := ResolveHostToken(nil, "github", "github.com", "someone"); tok != "provider-wide" || src != "keychain:github" {
+		t.Fatalf("provider fallback = %q (%s)", tok, src)
+	}
+}
+
+func TestResolveHostToken_LookAlikeHostGetsNothing(t *testing.T) {
+	mem := NewMemoryTokenStore()
+	prev := DefaultTokenStore
+	SetTokenStore(mem)
+	t.Cleanup(func() { SetTokenStore(prev) })
+	t.Setenv("GITHUB_TOKEN", "github-env")
+	t.Setenv("GITLAB_TOKEN", "gitlab-env")
+	t.Setenv("GZ_GIT_TOKEN", "catch-all-env")
+	_ = mem.Set("github", "github-keychain")
+
+	for _, tt := range []struct{ provider, host string }{
+		{"github", "github.attacker.io"},
+		{"gitlab", "evil-gitlab.net"},
+		{"gitea", "gitea.example.org"},
+		{"gitlab", "github.com"},
+	} {
+		if tok, src, _ := ResolveHostToken(nil, tt.provider, tt.host, "acme"); tok != "" {
+			t.Errorf("%s on %s got %q (%s)", tt.provider, tt.host, tok, src)
+		}
+	}
+	if tok, _, _ := ResolveHostToken(nil, "github", "GitHub.com", "acme"); tok != "github-env" {
+		t.Errorf("github.com = %q, want the provider-wide token", tok)
+	}
+}
+
+func TestResolveHostToken_DeclaredHostStopsAtEntry(t *testing.T) {
+	mem := NewMemoryTokenStore()
+	prev := DefaultTokenStore
+	SetTokenStore(mem)
+	t.Cleanup(func() { SetTokenStore(prev) })
+	t.Setenv("GITLAB_TOKEN", "public-env")
+	t.Setenv("GZ_GIT_TOKEN", "catch-all-env")
+	if err := mem.Set("gitlab", "public-keychain"); err != nil {
+		t.Fatal(err)
+	}
+
+	creds := []Credential{
+		{Host: "git.internal.example", Provider: "gitlab"},
+		{Host: "git.internal.example", Provider: "gitlab", Org: "platform", Token: "${EMPTY_INTERNAL_TOKEN}"},
+	}
+	t.Setenv("EMPTY_INTERNAL_TOKEN", "")
+	for _, org := range []string{"", "team", "platform"} {
+		if tok, src, _ := ResolveHostToken(creds, "gitlab", "git.internal.example", org); tok != "" {
+			t.Errorf("org %q: declared host got %q (%s)", org, tok, src)
+		}
+	}
+
+	if err := mem.Set("gitlab@git.internal.example", "internal"); err != nil {
+		t.Fatal(err)
+	}
+	if tok, src, _ := ResolveHostToken(creds, "gitlab", "git.internal.example", "team"); tok != "internal" || src != "keychain:gitlab@git.internal.example" {
+		t.Errorf("host keychain = %q (%s)", tok, src)
+	}
+}
+
+func TestResolveHostToken_UnresolvedReference(t *testing.T) {
+	mem := NewMemoryTokenStore()
+	prev := DefaultTokenStore
+	SetTokenStore(mem)
+	t.Cleanup(func() { SetTokenStore(prev) })
+	t.Setenv("GITHUB_TOKEN", "from-env")
+	_ = mem.Set("github", "catch-all")
+
+	creds := []Credential{{Host: "github.com", Org: "acme", Token: "${file:/nonexistent/acme-token}"}}
+	tok, src, err := ResolveHostToken(creds, "github", "github.com", "acme")
+	if err == nil || !strings.Contains(err.Error(), "credentials entry github.com/acme") {
+		t.Fatalf("err = %v, want the entry's resolution error", err)
+	}
+	if tok != "" || src != "" {
+		t.Fatalf("got %q (%s) in place of the broken reference", tok, src)
+	}
+
+	// Other orgs on the host are not governed by the broken entry.
+	if tok, _, err := ResolveHostToken(creds, "github", "github.com", "someone"); err != nil || tok != "from-env" {
+		t.Fatalf("other org = %q, %v", tok, err)
+	}
+}
+
+func TestFindCredential_PrefersOrgEntry(t *testing.T) {
+	creds := []Credential{
+		{Host: "git.internal.example", Provider: "gitlab", BaseURL: "https://git.internal.example/api"},
+		{Host: "git.internal.example", Provider: "gitlab", Org: "platform", Token: "x"},
+	}
+	c, ok := FindCredential(creds, "git.internal.example", "platform")
+	if !ok || c.Org != "platform" {
+		t.Fatalf("org lookup = %+v %v", c, ok)
+	}
+	c, ok = FindCredential(creds, "git.internal.example", "other")
+	if !ok || c.Org != "" || c.APIBaseURL() != "https://git.internal.example/api" {
+		t.Fatalf("host lookup = %+v %v", c, ok)
+	}
+	if _, ok := FindCredential(creds, "github.com", ""); ok {
+		t.Fatal("unrelated host matched")
+	}
+	if got := (Credential{Host: "git.example"}).ProviderName(); got != "" {
+		t.Fatalf("ProviderName for unclassifiable host = %q", got)
+	}
+}
+
+func TestValidateGlobalConfig_Credentials(t *testing.T) {
+	v := NewValidator()
+	if err := v.ValidateGlobalConfig(&GlobalConfig{Credentials: []Credential{{Host: "git.example"}}}); err == nil {
+		t.Fatal("expected error for host with no inferable provider")
+	}
+	if err := v.ValidateGlobalConfig(&GlobalConfig{Credentials: []Credential{{Host: "git.example", Provider: "gitea"}}}); err != nil {
+		t.Fatalf("valid entry rejected: %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/watch"
+)
+
+// DaemonConfig configures `gz-git daemon`. It lives in the global config
+// because the daemon is one per user, not one per project.
+//
+//	daemon:
+//	  roots: [~/src, ~/work]
+//	  depth: 2
+//	  fetchInterval: 10m
+//	  socket: /run/user/1000/gz-git/daemon.sock
+type DaemonConfig struct {
+	// Roots are the directories whose repositories are indexed. A leading
+	// ~/ is the home directory.
+	Roots []string `yaml:"roots,omitempty"`
+	// Depth is how far below each root to look for repositories, as
+	// --scan-depth. Zero means the daemon command's default.
+	Depth int `yaml:"depth,omitempty"`
+	// FetchInterval is how often the daemon fetches every remote in the
+	// background, e.g. "10m". "0" turns fetching off.
+	FetchInterval string `yaml:"fetchInterval,omitempty"`
+	// Socket overrides the socket path for the daemon and for --via-daemon.
+	Socket string `yaml:"socket,omitempty"`
+}
+
+// Validate checks the fields that can be checked without touching the disk.
+func (d *DaemonConfig) Validate() error {
+	if d.Depth < 0 {
+		return fmt.Errorf("depth must not be negative")
+	}
+	if d.FetchInterval != "" {
+		if iv, err := time.ParseDuration(d.FetchInterval); err != nil || iv < 0 || (iv > 0 && iv < watch.MinFetchInterval) {
+			return fmt.Errorf("invalid fetchInterval %q: want 0 or at least %s", d.FetchInterval, watch.MinFetchInterval)
+		}
+	}
+	for i, root := range d.Roots {
+		if strings.TrimSpace(root) == "" {
+			return fmt.Errorf("roots[%d] is empty", i)
+		}
+	}
+	return nil
+}
+
+// RootPaths returns Roots with ~/ expanded and made absolute.
+func (d *DaemonConfig) RootPaths() ([]string, error) {
+	paths := make([]string, 0, len(d.Roots))
+	for _, root := range d.Roots {
+		if rest, ok := strings.CutPrefix(root, "~/"); ok {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, fmt.Errorf("expand %s: %w", root, err)
+			}
+			root = filepath.Join(home, rest)
+		}
+		abs, err := filepath.Abs(root)
+		if err != nil {
+			return nil, fmt.Errorf("resolve %s: %w", root, err)
+		}
+		paths = append(paths, abs)
+	}
+	return paths, nil
+}
+
+// LoadDaemonConfig returns the daemon section of the global config. A missing
+// or unreadable global config yields the zero value, and the daemon command
+// falls back to its flags.
+func LoadDaemonConfig() DaemonConfig {
+	manager, err := NewManager()
+	if err != nil {
+		return DaemonConfig{}
+	}
+	global, err := manager.LoadGlobalConfig()
+	if err != nil || global == nil || global.Daemon == nil {
+		return DaemonConfig{}
+	}
+	return *global.Daemon
+}
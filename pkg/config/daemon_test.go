@@ -0,0 +1,40 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDaemonConfigValidate(t *testing.T) {
+	valid := DaemonConfig{Roots: []string{"~/src"}, Depth: 2, FetchInterval: "10m"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+	for _, bad := range []DaemonConfig{
+		{Depth: -1},
+		{FetchInterval: "often"},
+		{FetchInterval: "3ns"},
+		{Roots: []string{" "}},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want an error", bad)
+		}
+	}
+}
+
+func TestDaemonConfigRootPaths(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cfg := DaemonConfig{Roots: []string{"~/src", "/opt/work"}}
+	paths, err := cfg.RootPaths()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if paths[0] != filepath.Join(home, "src") || paths[1] != "/opt/work" {
+		t.Errorf("RootPaths() = %v", paths)
+	}
+}
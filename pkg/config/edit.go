@@ -0,0 +1,395 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigEditor edits a config file as a YAML node tree rather than as
+// decoded values, so comments, key order and quoting survive everywhere the
+// edit does not touch. Paths are keys joined by dots, with [N] for list
+// items, as schema errors print them: workspaces.devbox.sync.strategy,
+// repositories[2].url. A key holding a dot is quoted:
+// workspaces."api.v2".path.
+type ConfigEditor struct {
+	doc yaml.Node
+}
+
+// NewConfigEditor parses a config file for editing. Empty data starts an
+// empty file.
+func NewConfigEditor(data []byte) (*ConfigEditor, error) {
+	e := &ConfigEditor{}
+	if err := yaml.Unmarshal(data, &e.doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if len(e.doc.Content) == 0 {
+		e.doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if root := e.doc.Content[0]; root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config is not a YAML mapping")
+	}
+	return e, nil
+}
+
+// Get returns the node at path, nil when it is not set.
+func (e *ConfigEditor) Get(path string) (*yaml.Node, error) {
+	segs, err := parseConfigPath(path)
+	if err != nil {
+		return nil, err
+	}
+	n := e.doc.Content[0]
+	for _, seg := range segs {
+		n = lookupNode(n)
+		switch {
+		case n == nil:
+			return nil, nil
+		case seg.isIndex() && n.Kind == yaml.SequenceNode && seg.index < len(n.Content):
+			n = n.Content[seg.index]
+		case !seg.isIndex() && n.Kind == yaml.MappingNode && mappingIndex(n, seg.key) >= 0:
+			n = n.Content[mappingIndex(n, seg.key)+1]
+		default:
+			return nil, nil
+		}
+	}
+	return lookupNode(n), nil
+}
+
+// Value returns the scalar at path. ok is false when path is not set, is
+// null, or holds a list or mapping.
+func (e *ConfigEditor) Value(path string) (value string, ok bool) {
+	n, err := e.Get(path)
+	if err != nil || n == nil || n.Kind != yaml.ScalarNode || n.Tag == "!!null" {
+		return "", false
+	}
+	return n.Value, true
+}
+
+// Set puts value at path, creating the mappings and lists on the way. An
+// index one past the end of a list appends to it. A replaced value keeps
+// the comments of the one it replaces.
+func (e *ConfigEditor) Set(path string, value *yaml.Node) error {
+	segs, err := parseConfigPath(path)
+	if err != nil {
+		return err
+	}
+	n := e.doc.Content[0]
+	for i, seg := range segs {
+		last := i == len(segs)-1
+		at := configPathString(segs[:i])
+		if n.Kind == yaml.AliasNode {
+			return fmt.Errorf("%s: cannot edit through a YAML alias", at)
+		}
+		var next *yaml.Node
+		if !last {
+			next = newContainer(segs[i+1])
+		}
+
+		if !seg.isIndex() {
+			if err := asContainer(n, yaml.MappingNode, at); err != nil {
+				return err
+			}
+			idx := mappingIndex(n, seg.key)
+			switch {
+			case idx < 0 && last:
+				n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: seg.key}, value)
+				return nil
+			case idx < 0:
+				n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: seg.key}, next)
+				n = next
+			case last:
+				n.Content[idx+1] = keepComments(n.Content[idx+1], value)
+				return nil
+			default:
+				n = n.Content[idx+1]
+			}
+			continue
+		}
+
+		if err := asContainer(n, yaml.SequenceNode, at); err != nil {
+			return err
+		}
+		switch {
+		case seg.index > len(n.Content):
+			return fmt.Errorf("%s: index %d out of range (the list has %d items)", configPathString(segs[:i+1]), seg.index, len(n.Content))
+		case seg.index == len(n.Content) && last:
+			n.Content = append(n.Content, value)
+			return nil
+		case seg.index == len(n.Content):
+			n.Content = append(n.Content, next)
+			n = next
+		case last:
+			n.Content[seg.index] = keepComments(n.Content[seg.index], value)
+			return nil
+		default:
+			n = n.Content[seg.index]
+		}
+	}
+	return nil
+}
+
+// Unset removes path, and reports whether there was anything to remove.
+func (e *ConfigEditor) Unset(path string) (bool, error) {
+	segs, err := parseConfigPath(path)
+	if err != nil {
+		return false, err
+	}
+	parent := e.doc.Content[0]
+	if len(segs) > 1 {
+		parentPath := configPathString(segs[:len(segs)-1])
+		if parent, err = e.Get(parentPath); err != nil || parent == nil {
+			return false, err
+		}
+	}
+	last := segs[len(segs)-1]
+	switch {
+	case !last.isIndex() && parent.Kind == yaml.MappingNode:
+		idx := mappingIndex(parent, last.key)
+		if idx < 0 {
+			return false, nil
+		}
+		// A comment heading the file stays at the top, on the key that
+		// becomes the first.
+		if parent == e.doc.Content[0] && idx == 0 && len(parent.Content) > 2 && parent.Content[2].HeadComment == "" {
+			parent.Content[2].HeadComment = parent.Content[0].HeadComment
+		}
+		parent.Content = append(parent.Content[:idx], parent.Content[idx+2:]...)
+		return true, nil
+	case last.isIndex() && parent.Kind == yaml.SequenceNode:
+		if last.index >= len(parent.Content) {
+			return false, nil
+		}
+		parent.Content = append(parent.Content[:last.index], parent.Content[last.index+1:]...)
+		return true, nil
+	}
+	return false, nil
+}
+
+// Bytes encodes the edited file, indented as gz-git writes config files.
+func (e *ConfigEditor) Bytes() ([]byte, error) {
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&e.doc); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// ParseConfigValue reads a value given on the command line as YAML, so
+// `4` is a number, `true` a bool and `[main, develop]` a list. Text that is
+// not YAML is taken as a string.
+func ParseConfigValue(s string) *yaml.Node {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(s), &doc); err == nil && len(doc.Content) > 0 {
+		return doc.Content[0]
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+}
+
+// ValidateConfigEdit checks an edited file against the schema of its kind
+// and decodes it into the config types, returning the schema violations the
+// edit introduced. Ones the file already had are left to `workspace
+// validate`, so an edit is not blocked by an unrelated problem.
+func ValidateConfigEdit(kind SchemaKind, before, after []byte) ([]SchemaError, error) {
+	var target any
+	switch kind {
+	case SchemaKindGlobal:
+		target = &GlobalConfig{}
+	case SchemaKindProfile:
+		target = &Profile{}
+	default:
+		target = &Config{}
+	}
+	if err := yaml.Unmarshal(after, target); err != nil {
+		return nil, err
+	}
+
+	schema, err := GenerateSchema(kind)
+	if err != nil {
+		return nil, err
+	}
+	known := map[string]bool{}
+	if len(before) > 0 {
+		old, err := schema.ValidateYAML(before)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range old {
+			known[v.Path+"\x00"+v.Message] = true
+		}
+	}
+	violations, err := schema.ValidateYAML(after)
+	if err != nil {
+		return nil, err
+	}
+	var introduced []SchemaError
+	for _, v := range violations {
+		if !known[v.Path+"\x00"+v.Message] {
+			introduced = append(introduced, v)
+		}
+	}
+	return introduced, nil
+}
+
+// configPathSegment is a mapping key, or a list index when index >= 0.
+type configPathSegment struct {
+	key   string
+	index int
+}
+
+func (s configPathSegment) isIndex() bool { return s.index >= 0 }
+
+// parseConfigPath splits a path into keys and indexes.
+func parseConfigPath(path string) ([]configPathSegment, error) {
+	var segs []configPathSegment
+	rest := path
+	for rest != "" {
+		var key string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid config path %q: unterminated quote", path)
+			}
+			key, rest = rest[1:end+1], rest[end+2:]
+		} else {
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			key, rest = rest[:end], rest[end:]
+		}
+		if key == "" {
+			return nil, fmt.Errorf("invalid config path %q: empty key", path)
+		}
+		segs = append(segs, configPathSegment{key: key, index: -1})
+
+		for strings.HasPrefix(rest, "[") {
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid config path %q: unterminated [", path)
+			}
+			idx, err := strconv.Atoi(rest[1:end])
+			if err != nil || idx < 0 {
+				return nil, fmt.Errorf("invalid config path %q: index %q is not a non-negative integer", path, rest[1:end])
+			}
+			segs = append(segs, configPathSegment{index: idx})
+			rest = rest[end+1:]
+		}
+
+		switch {
+		case rest == "":
+		case strings.HasPrefix(rest, ".") && len(rest) > 1:
+			rest = rest[1:]
+		default:
+			return nil, fmt.Errorf("invalid config path %q: unexpected %q", path, rest)
+		}
+	}
+	if len(segs) == 0 {
+		return nil, fmt.Errorf("config path is empty")
+	}
+	return segs, nil
+}
+
+// configPathString renders segments back into a path, for messages.
+func configPathString(segs []configPathSegment) string {
+	var b strings.Builder
+	for _, s := range segs {
+		switch {
+		case s.isIndex():
+			fmt.Fprintf(&b, "[%d]", s.index)
+		default:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			if strings.ContainsAny(s.key, ".[") {
+				b.WriteString(`"` + s.key + `"`)
+			} else {
+				b.WriteString(s.key)
+			}
+		}
+	}
+	if b.Len() == 0 {
+		return "the config"
+	}
+	return b.String()
+}
+
+func mappingIndex(n *yaml.Node, key string) int {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// newContainer is the node a missing path segment is created as: a list
+// when the next segment indexes into it, a mapping otherwise.
+func newContainer(next configPathSegment) *yaml.Node {
+	if next.isIndex() {
+		return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	}
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+// asContainer checks that n is a mapping or list of the wanted kind. A null
+// (`sync:` with nothing under it) becomes an empty one; an empty flow
+// collection (`repositories: []`) switches to block style so items added to
+// it are written one per line.
+func asContainer(n *yaml.Node, kind yaml.Kind, at string) error {
+	if n.Kind == yaml.ScalarNode && (n.Tag == "!!null" || (n.Tag == "" && n.Value == "")) {
+		n.Kind, n.Value, n.Style = kind, "", 0
+		n.Tag = "!!map"
+		if kind == yaml.SequenceNode {
+			n.Tag = "!!seq"
+		}
+	}
+	if n.Kind != kind {
+		want := "a mapping"
+		if kind == yaml.SequenceNode {
+			want = "a list"
+		}
+		return fmt.Errorf("%s is %s, not %s", at, nodeKindName(n), want)
+	}
+	if len(n.Content) == 0 {
+		n.Style &^= yaml.FlowStyle
+	}
+	return nil
+}
+
+func nodeKindName(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	case yaml.AliasNode:
+		return "an alias"
+	}
+	return "a value"
+}
+
+// keepComments carries the comments of a replaced node over to its
+// replacement, unless the replacement brings its own.
+func keepComments(old, replacement *yaml.Node) *yaml.Node {
+	if replacement.HeadComment == "" {
+		replacement.HeadComment = old.HeadComment
+	}
+	if replacement.LineComment == "" {
+		replacement.LineComment = old.LineComment
+	}
+	if replacement.FootComment == "" {
+		replacement.FootComment = old.FootComment
+	}
+	return replacement
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+const editFixture = `# Team devbox
+parallel: 4 # keep it low on CI
+workspaces:
+  # the API services
+  devbox:
+    path: ./devbox
+    sync:
+      strategy: reset
+  "api.v2":
+    path: ./api
+repositories: []
+`
+
+func TestConfigEditor_Get(t *testing.T) {
+	e, err := NewConfigEditor([]byte(editFixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]string{
+		"parallel":                        "4",
+		"workspaces.devbox.sync.strategy": "reset",
+		`workspaces."api.v2".path`:        "./api",
+	} {
+		if got, ok := e.Value(path); !ok || got != want {
+			t.Errorf("Value(%q) = %q, %v; want %q", path, got, ok, want)
+		}
+	}
+	for _, path := range []string{"missing", "workspaces.devbox.url", "parallel.x", "workspaces[0]", "repositories[0]"} {
+		if n, err := e.Get(path); err != nil || n != nil {
+			t.Errorf("Get(%q) = %v, %v; want unset", path, n, err)
+		}
+	}
+	if _, ok := e.Value("workspaces.devbox"); ok {
+		t.Error("Value of a mapping should not be ok")
+	}
+}
+
+func TestConfigEditor_SetKeepsComments(t *testing.T) {
+	e, err := NewConfigEditor([]byte(editFixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, set := range [][2]string{
+		{"parallel", "8"},
+		{"workspaces.devbox.sync.strategy", "pull"},
+		{"workspaces.devbox.branch.defaultBranch", "[main, develop]"},
+		{"repositories[0].url", "https://github.com/org/api.git"},
+		{"repositories[0].path", "./api"},
+	} {
+		if err := e.Set(set[0], ParseConfigValue(set[1])); err != nil {
+			t.Fatalf("Set(%q): %v", set[0], err)
+		}
+	}
+	out, err := e.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# Team devbox
+parallel: 8 # keep it low on CI
+workspaces:
+  # the API services
+  devbox:
+    path: ./devbox
+    sync:
+      strategy: pull
+    branch:
+      defaultBranch: [main, develop]
+  "api.v2":
+    path: ./api
+repositories:
+  - url: https://github.com/org/api.git
+    path: ./api
+`
+	if string(out) != want {
+		t.Errorf("output:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestConfigEditor_SetErrors(t *testing.T) {
+	e, err := NewConfigEditor([]byte(editFixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]string{
+		"parallel.x":       "parallel is a value, not a mapping",
+		"workspaces[0]":    "workspaces is a mapping, not a list",
+		"repositories[2]":  "index 2 out of range (the list has 0 items)",
+		"":                 "config path is empty",
+		"a..b":             "empty key",
+		"a[x]":             "not a non-negative integer",
+		`workspaces."open`: "unterminated quote",
+	} {
+		if err := e.Set(path, ParseConfigValue("v")); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Set(%q) err = %v, want %q", path, err, want)
+		}
+	}
+	if _, err := NewConfigEditor([]byte("- a\n")); err == nil {
+		t.Error("a list document should be rejected")
+	}
+}
+
+func TestConfigEditor_Unset(t *testing.T) {
+	e, err := NewConfigEditor([]byte(editFixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]bool{
+		`workspaces."api.v2"`:               true,
+		"workspaces.devbox.sync.strategy":   true,
+		"workspaces.devbox.sync.strategy.x": false,
+		"missing.key":                       false,
+		"repositories[0]":                   false,
+	} {
+		if got, err := e.Unset(path); err != nil || got != want {
+			t.Errorf("Unset(%q) = %v, %v; want %v", path, got, err, want)
+		}
+	}
+	out, err := e.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(out), "api.v2") || !strings.Contains(string(out), "# the API services") {
+		t.Errorf("output:\n%s", out)
+	}
+}
+
+func TestConfigEditor_EmptyFile(t *testing.T) {
+	e, err := NewConfigEditor(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Set("sync.strategy", ParseConfigValue("pull")); err != nil {
+		t.Fatal(err)
+	}
+	out, err := e.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "sync:\n  strategy: pull\n" {
+		t.Errorf("output = %q", out)
+	}
+}
+
+func TestParseConfigValue(t *testing.T) {
+	for in, tag := range map[string]string{
+		"4":             "!!int",
+		"true":          "!!bool",
+		"pull":          "!!str",
+		"":              "!!str",
+		"[a, b]":        "!!seq",
+		"{a: 1}":        "!!map",
+		"'8'":           "!!str",
+		"[unterminated": "!!str",
+	} {
+		if got := ParseConfigValue(in); got.Tag != tag {
+			t.Errorf("ParseConfigValue(%q).Tag = %s, want %s", in, got.Tag, tag)
+		}
+	}
+}
+
+func TestValidateConfigEdit(t *testing.T) {
+	before := []byte("parallel: 4\nbogus: 1\n")
+
+	// The pre-existing unknown key does not block an unrelated edit.
+	violations, err := ValidateConfigEdit(SchemaKindConfig, before, []byte("parallel: 8\nbogus: 1\n"))
+	if err != nil || len(violations) != 0 {
+		t.Errorf("violations = %v, err = %v", violations, err)
+	}
+
+	violations, err = ValidateConfigEdit(SchemaKindConfig, before, []byte("parallel: 4\nbogus: 1\nstrategy: sideways\n"))
+	if err != nil || len(violations) != 1 || violations[0].Path != "strategy" {
+		t.Errorf("violations = %v, err = %v", violations, err)
+	}
+
+	if _, err := ValidateConfigEdit(SchemaKindProfile, nil, []byte("sshPort: many\n")); err == nil {
+		t.Error("expected a decode error for a non-integer sshPort")
+	}
+}
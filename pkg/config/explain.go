@@ -0,0 +1,555 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+// explainConfigFile is the config file name LoadConfigRecursive follows.
+const explainConfigFile = ".gz-git.yaml"
+
+// ExplainLayer is one place settings come from: the built-in defaults, a config
+// file or a section of one, or a profile.
+type ExplainLayer struct {
+	// Name says what the layer is: built-in default, parent config, config
+	// defaults, config, profile <name>, workspace <name> or repository <name>.
+	Name string
+
+	// File is the file the layer is read from; empty for built-in defaults.
+	File string
+
+	// Path is the key path of the layer in File, e.g. workspaces.devbox;
+	// empty when the layer is the whole file.
+	Path string
+
+	// Values are the settings the layer sets.
+	Values []ExplainValue
+}
+
+// ExplainValue is one setting as a layer sets it.
+type ExplainValue struct {
+	Key   string // setting, e.g. sync.strategy
+	Value string // after ${VAR} expansion
+	Raw   string // as written, when expansion changed it
+	Line  int    // line in the layer's file; 0 for built-in defaults
+
+	// Secret marks a value, such as a token, that should not be printed.
+	Secret bool
+}
+
+// ExplainOrigin is a value and the layer it came from.
+type ExplainOrigin struct {
+	Layer *ExplainLayer
+	ExplainValue
+}
+
+// ExplainedSetting is the effective value of one setting and the values of
+// lower layers it overrode, highest precedence first.
+type ExplainedSetting struct {
+	Key        string
+	Effective  ExplainOrigin
+	Overridden []ExplainOrigin
+}
+
+// Explanation is how the settings of one repository or workspace directory
+// were resolved.
+type Explanation struct {
+	// Target is the absolute path explained.
+	Target string
+
+	// Route is how the target was reached from the outermost config: config
+	// files, then the workspace and repository entries that hold it.
+	Route []string
+
+	// Layers are the layers that apply to the target, lowest precedence
+	// first.
+	Layers []*ExplainLayer
+}
+
+// explainSetting is a setting Explain traces, and the keys that set it. A
+// layer sets it with the first key it has: a workspace entry, a defaults
+// section and a repositories file spell the same setting differently.
+type explainSetting struct {
+	key    string
+	paths  []string
+	expand bool // ${VAR} references are expanded, as the loader does
+	secret bool
+}
+
+var explainSettings = []explainSetting{
+	{key: "profile", paths: []string{"profile"}},
+	{key: "provider", paths: []string{"provider", "source.provider"}},
+	{key: "baseURL", paths: []string{"baseURL", "source.baseURL"}, expand: true},
+	{key: "token", paths: []string{"token", "source.token"}, expand: true, secret: true},
+	{key: "includeSubgroups", paths: []string{"includeSubgroups", "source.includeSubgroups"}},
+	{key: "subgroupMode", paths: []string{"subgroupMode", "source.subgroupMode"}},
+	{key: "cloneProto", paths: []string{"cloneProto", "clone.proto"}},
+	{key: "sshPort", paths: []string{"sshPort", "clone.sshPort"}},
+	{key: "parallel", paths: []string{"parallel", "sync.parallel"}},
+	{key: "sync.strategy", paths: []string{"strategy", "sync.strategy"}},
+	{key: "sync.maxRetries", paths: []string{"maxRetries", "sync.maxRetries"}},
+	{key: "sync.timeout", paths: []string{"sync.timeout"}},
+	{key: "branch.defaultBranch", paths: []string{"branch", "branch.defaultBranch"}},
+	{key: "branch.protectedBranches", paths: []string{"branch.protectedBranches"}},
+	{key: "fetch.allRemotes", paths: []string{"fetch.allRemotes"}},
+	{key: "fetch.prune", paths: []string{"fetch.prune"}},
+	{key: "pull.rebase", paths: []string{"pull.rebase"}},
+	{key: "pull.ffOnly", paths: []string{"pull.ffOnly"}},
+	{key: "push.setUpstream", paths: []string{"push.setUpstream"}},
+}
+
+// ExplainKeys lists the settings Explain traces.
+func ExplainKeys() []string {
+	keys := make([]string, len(explainSettings))
+	for i, s := range explainSettings {
+		keys[i] = s.key
+	}
+	return keys
+}
+
+// Explain resolves the settings of the repository or workspace directory at
+// target the way workspace sync does, and records every layer on the way.
+//
+// It starts from the outermost .gz-git.yaml above target whose workspaces or
+// repositories reach it, falling back to the nearest one. Within a config, the
+// layers are, lowest precedence first:
+//
+//  1. built-in defaults
+//  2. the parent chain (parent:), farthest first, each defaults then body
+//  3. the config's defaults section, then its body
+//  4. the profile the config names
+//  5. for each workspace entry holding target: the profile it names, then
+//     the entry
+//  6. the repositories entry for target
+//
+// A workspace of type config has its own .gz-git.yaml. That file does not
+// inherit from the config listing it; LoadConfigRecursive only applies the
+// workspace entry on top of it. So the explanation restarts from the child
+// config, with the entries that led to it in place of step 5.
+//
+// paths locates external profiles; with nil, only inline profiles are found.
+func Explain(target string, paths *Paths) (*Explanation, error) {
+	abs, err := filepath.Abs(expandHome(target))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", target, err)
+	}
+
+	dirs, err := configDirsAbove(abs)
+	if err != nil {
+		return nil, err
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no %s in %s or any parent directory", explainConfigFile, target)
+	}
+
+	for i := len(dirs) - 1; i >= 0; i-- {
+		e := &explainer{paths: paths, docs: map[string]*yaml.Node{}, result: &Explanation{Target: abs}}
+		reached, err := e.level(dirs[i], abs, nil)
+		if err != nil {
+			return nil, err
+		}
+		if reached || i == 0 {
+			return e.result, nil
+		}
+	}
+	return nil, nil // unreachable: the nearest config is always accepted
+}
+
+// ExplainWorkspace explains the workspace called name in the .gz-git.yaml of
+// configDir. A nested workspace is named by its path of names, e.g.
+// devbox/api.
+func ExplainWorkspace(configDir, name string, paths *Paths) (*Explanation, error) {
+	configDir, err := filepath.Abs(configDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", configDir, err)
+	}
+	cfg, err := LoadConfigRecursive(configDir, explainConfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces, base := cfg.Workspaces, configDir
+	var wsPath string
+	for _, part := range strings.Split(name, "/") {
+		ws := workspaces[part]
+		if ws == nil {
+			return nil, fmt.Errorf("workspace %q not found in %s", name, cfg.ConfigPath)
+		}
+		if wsPath, err = workspaceDir(base, part, ws); err != nil {
+			return nil, err
+		}
+		workspaces, base = ws.Workspaces, wsPath
+	}
+
+	e := &explainer{paths: paths, docs: map[string]*yaml.Node{}, result: &Explanation{Target: wsPath}}
+	if _, err := e.level(configDir, wsPath, nil); err != nil {
+		return nil, err
+	}
+	return e.result, nil
+}
+
+// Settings returns every setting some layer sets, with its effective value
+// and the values it overrode, in a fixed order.
+func (e *Explanation) Settings() []ExplainedSetting {
+	var settings []ExplainedSetting
+	for _, s := range explainSettings {
+		var origins []ExplainOrigin
+		for i := len(e.Layers) - 1; i >= 0; i-- {
+			for _, v := range e.Layers[i].Values {
+				if v.Key == s.key {
+					origins = append(origins, ExplainOrigin{Layer: e.Layers[i], ExplainValue: v})
+				}
+			}
+		}
+		if len(origins) > 0 {
+			settings = append(settings, ExplainedSetting{Key: s.key, Effective: origins[0], Overridden: origins[1:]})
+		}
+	}
+	return settings
+}
+
+type explainer struct {
+	paths  *Paths
+	docs   map[string]*yaml.Node // parsed files, by path
+	result *Explanation
+}
+
+// level sets the layers of the config in dir, with overrides on top of its
+// own, and descends into the workspace and repository entries holding
+// target. It reports whether target was reached: it is dir or inside one of
+// its entries.
+func (e *explainer) level(dir, target string, overrides []*ExplainLayer) (bool, error) {
+	cfg, err := LoadConfigRecursive(dir, explainConfigFile)
+	if err != nil {
+		return false, err
+	}
+
+	layers := []*ExplainLayer{builtinLayer()}
+	chain := GetParentChain(cfg)
+	for i := len(chain) - 1; i >= 0; i-- {
+		name := "config"
+		if i > 0 {
+			name = "parent config"
+		}
+		defaults, err := e.fileLayer(name+" defaults", chain[i].ConfigPath, "defaults")
+		if err != nil {
+			return false, err
+		}
+		body, err := e.fileLayer(name, chain[i].ConfigPath)
+		if err != nil {
+			return false, err
+		}
+		layers = append(layers, defaults, body)
+	}
+	if cfg.Profile != "" {
+		profile, err := e.profileLayer(cfg, cfg.Profile)
+		if err != nil {
+			return false, err
+		}
+		layers = append(layers, profile)
+	}
+	e.result.Route = append(e.result.Route, cfg.ConfigPath)
+
+	reached := target == dir
+	workspaces, base := cfg.Workspaces, dir
+	keyPath := []string{}
+	entries := append([]*ExplainLayer(nil), overrides...)
+	for {
+		name, ws, wsPath, err := coveringWorkspace(workspaces, base, target)
+		if err != nil {
+			return false, err
+		}
+		if ws == nil {
+			break
+		}
+		reached = true
+		keyPath = append(keyPath, "workspaces", name)
+		e.result.Route = append(e.result.Route, "workspace "+name)
+
+		if ws.Profile != "" {
+			profile, err := e.profileLayer(cfg, ws.Profile)
+			if err != nil {
+				return false, err
+			}
+			entries = append(entries, profile)
+		}
+		entry, err := e.fileLayer("workspace "+name, cfg.ConfigPath, keyPath...)
+		if err != nil {
+			return false, err
+		}
+		entries = append(entries, entry)
+
+		if ws.Type.Resolve(ws.Source != nil) == WorkspaceTypeConfig && hasFile(wsPath, explainConfigFile) {
+			if _, err := e.level(wsPath, target, entries); err != nil {
+				return false, err
+			}
+			return true, nil
+		}
+		workspaces, base = ws.Workspaces, wsPath
+	}
+	e.result.Layers = append(layers, entries...)
+
+	repo, err := e.repositoryLayer(cfg.ConfigPath, dir, target)
+	if err != nil {
+		return false, err
+	}
+	if repo != nil {
+		reached = true
+		e.result.Layers = append(e.result.Layers, repo)
+		e.result.Route = append(e.result.Route, repo.Name)
+	}
+	return reached, nil
+}
+
+// coveringWorkspace returns the workspace whose directory is target or holds
+// it, the deepest when several do. A workspace at base itself is skipped, as
+// sync skips it.
+func coveringWorkspace(workspaces map[string]*Workspace, base, target string) (string, *Workspace, string, error) {
+	names := make([]string, 0, len(workspaces))
+	for name := range workspaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var bestName, bestPath string
+	var best *Workspace
+	for _, name := range names {
+		ws := workspaces[name]
+		if ws == nil {
+			continue
+		}
+		dir, err := workspaceDir(base, name, ws)
+		if err != nil {
+			return "", nil, "", err
+		}
+		if dir == base || !withinDir(dir, target) {
+			continue
+		}
+		if len(dir) > len(bestPath) {
+			bestName, best, bestPath = name, ws, dir
+		}
+	}
+	return bestName, best, bestPath, nil
+}
+
+// workspaceDir is the directory of a workspace; its path defaults to its
+// name, as LoadConfigRecursive does.
+func workspaceDir(base, name string, ws *Workspace) (string, error) {
+	path := ws.Path
+	if path == "" {
+		path = name
+	}
+	dir, err := resolvePath(base, path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve workspace '%s' path '%s': %w", name, path, err)
+	}
+	return filepath.Clean(dir), nil
+}
+
+// repositoryLayer is the repositories entry of file whose directory is
+// target or holds it, or nil.
+func (e *explainer) repositoryLayer(file, dir, target string) (*ExplainLayer, error) {
+	data, err := os.ReadFile(file) // #nosec G304 -- file is a config LoadConfigRecursive already read.
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", file, err)
+	}
+	var raw RepositoriesConfig
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", file, err)
+	}
+
+	for i, r := range raw.Repositories {
+		name := r.Name
+		if name == "" {
+			if name, err = repository.ExtractRepoNameFromURL(r.URL); err != nil {
+				continue
+			}
+		}
+		path := r.Path
+		if path == "" {
+			path = name
+		}
+		repoDir, err := resolvePath(dir, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve repository '%s' path '%s': %w", name, path, err)
+		}
+		if withinDir(filepath.Clean(repoDir), target) {
+			return e.fileLayer("repository "+name, file, "repositories", strconv.Itoa(i))
+		}
+	}
+	return nil, nil
+}
+
+// profileLayer is the profile called name: inline in cfg or its parent
+// chain, as GetProfileFromChain finds it, or else the external profile file.
+// A profile found nowhere is a layer with no values.
+func (e *explainer) profileLayer(cfg *Config, name string) (*ExplainLayer, error) {
+	layerName := "profile " + name
+	for c := cfg; c != nil; c = c.ParentConfig {
+		if _, ok := c.Profiles[name]; ok {
+			return e.fileLayer(layerName, c.ConfigPath, "profiles", name)
+		}
+	}
+	if e.paths != nil {
+		if path := e.paths.ProfilePath(name); path != "" {
+			return e.fileLayer(layerName, path)
+		}
+	}
+	return &ExplainLayer{Name: layerName + " (not found)"}, nil
+}
+
+// fileLayer reads the settings under keyPath in file.
+func (e *explainer) fileLayer(name, file string, keyPath ...string) (*ExplainLayer, error) {
+	doc, ok := e.docs[file]
+	if !ok {
+		data, err := os.ReadFile(file) // #nosec G304 -- file is a config or profile the loader resolved.
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		doc = &yaml.Node{}
+		if err := yaml.Unmarshal(data, doc); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		e.docs[file] = doc
+	}
+
+	layer := &ExplainLayer{Name: name, File: file, Path: strings.Join(keyPath, ".")}
+	node := lookupNode(doc, keyPath...)
+	if node == nil {
+		return layer, nil
+	}
+	validator := NewValidator()
+	for _, s := range explainSettings {
+		for _, path := range s.paths {
+			n := lookupNode(node, strings.Split(path, ".")...)
+			value, ok := leafValue(n)
+			if !ok {
+				continue
+			}
+			v := ExplainValue{Key: s.key, Value: value, Line: n.Line, Secret: s.secret}
+			if s.expand {
+				if expanded, err := validator.expandString(value); err == nil && expanded != value {
+					v.Raw, v.Value = value, expanded
+				}
+			}
+			layer.Values = append(layer.Values, v)
+			break
+		}
+	}
+	return layer, nil
+}
+
+func builtinLayer() *ExplainLayer {
+	return &ExplainLayer{
+		Name: "built-in default",
+		Values: []ExplainValue{
+			{Key: "parallel", Value: strconv.Itoa(repository.DefaultLocalParallel)},
+			{Key: "sync.strategy", Value: "reset"},
+			{Key: "sync.maxRetries", Value: "3"},
+		},
+	}
+}
+
+// lookupNode follows keys through mappings, and indexes through sequences.
+func lookupNode(n *yaml.Node, keys ...string) *yaml.Node {
+	for n != nil && (n.Kind == yaml.DocumentNode || n.Kind == yaml.AliasNode) {
+		if n.Kind == yaml.DocumentNode {
+			if len(n.Content) == 0 {
+				return nil
+			}
+			n = n.Content[0]
+		} else {
+			n = n.Alias
+		}
+	}
+	if n == nil || len(keys) == 0 {
+		return n
+	}
+
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if n.Content[i].Value == keys[0] {
+				return lookupNode(n.Content[i+1], keys[1:]...)
+			}
+		}
+	case yaml.SequenceNode:
+		if i, err := strconv.Atoi(keys[0]); err == nil && i >= 0 && i < len(n.Content) {
+			return lookupNode(n.Content[i], keys[1:]...)
+		}
+	}
+	return nil
+}
+
+// leafValue is a scalar, or a list of scalars joined by commas as branch
+// lists are written. Maps and nulls are not values.
+func leafValue(n *yaml.Node) (string, bool) {
+	n = lookupNode(n)
+	if n == nil {
+		return "", false
+	}
+	switch n.Kind {
+	case yaml.ScalarNode:
+		return n.Value, n.Tag != "!!null"
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(n.Content))
+		for _, item := range n.Content {
+			item = lookupNode(item)
+			if item.Kind != yaml.ScalarNode {
+				return "", false
+			}
+			items = append(items, item.Value)
+		}
+		return strings.Join(items, ","), true
+	}
+	return "", false
+}
+
+// configDirsAbove lists the directories from dir upward that hold a
+// .gz-git.yaml, nearest first, stopping at $HOME as config discovery does.
+func configDirsAbove(dir string) ([]string, error) {
+	home, _ := os.UserHomeDir()
+	var dirs []string
+	for {
+		found, _, err := findConfigUpward(dir, []string{explainConfigFile})
+		if err != nil {
+			return nil, err
+		}
+		if found == "" {
+			return dirs, nil
+		}
+		dirs = append(dirs, found)
+		parent := filepath.Dir(found)
+		if found == home || parent == found {
+			return dirs, nil
+		}
+		dir = parent
+	}
+}
+
+// withinDir reports whether path is dir or inside it.
+func withinDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// expandHome expands a leading ~/ to the home directory.
+func expandHome(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[2:])
+		}
+	}
+	return path
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeExplainFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// explainTree is a workstation config with a parent, an inline profile, a git
+// workspace, and a config workspace whose child config lists repositories.
+func explainTree(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	root := filepath.Join(home, "work")
+
+	writeExplainFile(t, filepath.Join(root, "base.yaml"), `defaults:
+  sync:
+    strategy: pull
+token: ${EXPLAIN_TOKEN}
+`)
+	writeExplainFile(t, filepath.Join(root, ".gz-git.yaml"), `parent: ./base.yaml
+profile: work
+profiles:
+  work:
+    cloneProto: https
+defaults:
+  sync:
+    parallel: 8
+workspaces:
+  tools:
+    type: git
+    url: https://github.com/org/tools.git
+    sync:
+      strategy: fetch
+  devbox:
+    type: config
+    sync:
+      strategy: reset
+`)
+	writeExplainFile(t, filepath.Join(root, "devbox", ".gz-git.yaml"), `kind: repositories
+strategy: rebase
+repositories:
+  - url: https://github.com/org/api.git
+    strategy: pull
+  - url: https://github.com/org/web.git
+`)
+	return root
+}
+
+func layerNames(e *Explanation) []string {
+	var names []string
+	for _, l := range e.Layers {
+		names = append(names, l.Name)
+	}
+	return names
+}
+
+func setting(t *testing.T, e *Explanation, key string) ExplainedSetting {
+	t.Helper()
+	for _, s := range e.Settings() {
+		if s.Key == key {
+			return s
+		}
+	}
+	t.Fatalf("no %s in the explanation", key)
+	return ExplainedSetting{}
+}
+
+func TestExplain_Workspace(t *testing.T) {
+	root := explainTree(t)
+	t.Setenv("EXPLAIN_TOKEN", "glpat-secret")
+
+	e, err := Explain(filepath.Join(root, "tools"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"built-in default",
+		"parent config defaults", "parent config",
+		"config defaults", "config",
+		"profile work",
+		"workspace tools",
+	}
+	if got := layerNames(e); !slices.Equal(got, want) {
+		t.Errorf("layers = %v, want %v", got, want)
+	}
+	if want := []string{filepath.Join(root, ".gz-git.yaml"), "workspace tools"}; !slices.Equal(e.Route, want) {
+		t.Errorf("route = %v, want %v", e.Route, want)
+	}
+
+	strategy := setting(t, e, "sync.strategy")
+	if strategy.Effective.Value != "fetch" || strategy.Effective.Layer.Name != "workspace tools" || strategy.Effective.Line != 14 {
+		t.Errorf("sync.strategy = %+v", strategy.Effective)
+	}
+	var overridden []string
+	for _, o := range strategy.Overridden {
+		overridden = append(overridden, o.Value+" from "+o.Layer.Name)
+	}
+	if want := []string{"pull from parent config defaults", "reset from built-in default"}; !slices.Equal(overridden, want) {
+		t.Errorf("overridden = %v, want %v", overridden, want)
+	}
+
+	token := setting(t, e, "token").Effective
+	if token.Value != "glpat-secret" || token.Raw != "${EXPLAIN_TOKEN}" || !token.Secret {
+		t.Errorf("token = %+v", token)
+	}
+	if token.Layer.File != filepath.Join(root, "base.yaml") {
+		t.Errorf("token file = %s", token.Layer.File)
+	}
+
+	proto := setting(t, e, "cloneProto").Effective
+	if proto.Value != "https" || proto.Layer.Path != "profiles.work" || proto.Line != 5 {
+		t.Errorf("cloneProto = %+v", proto)
+	}
+	if got := setting(t, e, "parallel").Effective; got.Value != "8" || got.Layer.Name != "config defaults" {
+		t.Errorf("parallel = %+v", got)
+	}
+}
+
+func TestExplain_ChildConfig(t *testing.T) {
+	root := explainTree(t)
+
+	// The child config does not inherit from the workstation config; only the
+	// devbox entry applies on top of it.
+	e, err := Explain(filepath.Join(root, "devbox", "api"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"built-in default", "config defaults", "config", "workspace devbox", "repository api"}
+	if got := layerNames(e); !slices.Equal(got, want) {
+		t.Errorf("layers = %v, want %v", got, want)
+	}
+	wantRoute := []string{
+		filepath.Join(root, ".gz-git.yaml"), "workspace devbox",
+		filepath.Join(root, "devbox", ".gz-git.yaml"), "repository api",
+	}
+	if !slices.Equal(e.Route, wantRoute) {
+		t.Errorf("route = %v, want %v", e.Route, wantRoute)
+	}
+	strategy := setting(t, e, "sync.strategy")
+	if strategy.Effective.Value != "pull" || len(strategy.Overridden) != 3 {
+		t.Errorf("sync.strategy = %+v, overridden %d", strategy.Effective, len(strategy.Overridden))
+	}
+
+	e, err = Explain(filepath.Join(root, "devbox", "web"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := setting(t, e, "sync.strategy").Effective; got.Value != "reset" || got.Layer.Name != "workspace devbox" {
+		t.Errorf("web sync.strategy = %+v", got)
+	}
+}
+
+func TestExplainWorkspace(t *testing.T) {
+	root := explainTree(t)
+
+	e, err := ExplainWorkspace(root, "devbox", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Target != filepath.Join(root, "devbox") {
+		t.Errorf("target = %s", e.Target)
+	}
+	if got := setting(t, e, "sync.strategy").Effective; got.Value != "reset" {
+		t.Errorf("sync.strategy = %+v", got)
+	}
+
+	if _, err := ExplainWorkspace(root, "nope", nil); err == nil {
+		t.Error("unknown workspace should be an error")
+	}
+}
+
+func TestExplain_UnlinkedConfig(t *testing.T) {
+	root := explainTree(t)
+	other := filepath.Join(root, "scratch")
+	writeExplainFile(t, filepath.Join(other, ".gz-git.yaml"), "strategy: skip\n")
+
+	// No workspace of the outer config holds scratch, so its own config
+	// governs it.
+	e, err := Explain(filepath.Join(other, "repo"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(e.Route, []string{filepath.Join(other, ".gz-git.yaml")}) {
+		t.Errorf("route = %v", e.Route)
+	}
+	if got := setting(t, e, "sync.strategy").Effective; got.Value != "skip" {
+		t.Errorf("sync.strategy = %+v", got)
+	}
+
+	if _, err := Explain(t.TempDir(), nil); err == nil {
+		t.Error("a directory with no config above it should be an error")
+	}
+}
@@ -0,0 +1,390 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// TokenFileName is the encrypted token file under the config directory.
+	TokenFileName = "tokens.enc"
+
+	// PassphraseEnv holds the passphrase for the encrypted token file.
+	PassphraseEnv = "GZ_GIT_TOKEN_PASSPHRASE"
+
+	// PassphraseCommandEnv names a command that prints the passphrase, so it
+	// can come from an agent (pass, op, secret-tool, a TPM helper) instead of
+	// sitting in the environment of every process.
+	PassphraseCommandEnv = "GZ_GIT_TOKEN_PASSPHRASE_COMMAND"
+
+	tokenFileVersion = 1
+)
+
+// scrypt parameters for new files. N=2^15 costs ~50ms and 32 MiB, which is
+// noticeable once per command and prohibitive per guess. They are recorded in
+// the file, so raising them later does not strand existing files.
+const (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// ErrTokenStoreLocked is returned when the encrypted token file cannot be
+// opened because no passphrase is configured.
+var ErrTokenStoreLocked = fmt.Errorf("token store locked: set %s or %s", PassphraseEnv, PassphraseCommandEnv)
+
+// ErrTokenStoreDecrypt is returned when the passphrase does not open the file.
+var ErrTokenStoreDecrypt = errors.New("cannot decrypt token file: wrong passphrase or corrupted file")
+
+// FileTokenStore keeps tokens in a single file encrypted with NaCl secretbox
+// (XSalsa20-Poly1305) under a key derived from a passphrase with scrypt. It is
+// the store for machines with no OS keychain: headless Linux without Secret
+// Service, containers, CI runners that persist a home directory.
+//
+// The whole map is re-encrypted with a fresh nonce on every write; there are
+// a handful of tokens, and a file that is either the old or the new version
+// (written to a temp file and renamed) is worth more than partial updates.
+type FileTokenStore struct {
+	path       string
+	passphrase func() (string, error)
+
+	mu          sync.Mutex
+	unavailable bool
+	// key caches the derived key for salt, so a command that reads several
+	// tokens pays for scrypt once.
+	key  *[32]byte
+	salt []byte
+}
+
+// tokenFile is the on-disk envelope. Everything needed to decrypt, except the
+// passphrase, is in it.
+type tokenFile struct {
+	Version int          `json:"version"`
+	KDF     tokenFileKDF `json:"kdf"`
+	Nonce   string       `json:"nonce"`
+	Box     string       `json:"box"`
+}
+
+type tokenFileKDF struct {
+	Name string `json:"name"`
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+	Salt string `json:"salt"`
+}
+
+// NewFileTokenStore creates a store backed by path. passphrase is called
+// lazily, on the first operation that needs it; nil means PassphraseFromEnv.
+func NewFileTokenStore(path string, passphrase func() (string, error)) *FileTokenStore {
+	if passphrase == nil {
+		passphrase = PassphraseFromEnv
+	}
+	return &FileTokenStore{path: path, passphrase: passphrase}
+}
+
+// DefaultTokenFilePath returns the encrypted token file under the config dir.
+func DefaultTokenFilePath() (string, error) {
+	paths, err := NewPaths()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(paths.ConfigDir, TokenFileName), nil
+}
+
+// PassphraseFromEnv returns GZ_GIT_TOKEN_PASSPHRASE, or the first line printed
+// by GZ_GIT_TOKEN_PASSPHRASE_COMMAND, or ErrTokenStoreLocked. The command is
+// run the way a ${cmd:...} reference is: split into arguments, without a
+// shell.
+func PassphraseFromEnv() (string, error) {
+	if v := os.Getenv(PassphraseEnv); v != "" {
+		return v, nil
+	}
+	command := strings.TrimSpace(os.Getenv(PassphraseCommandEnv))
+	if command == "" {
+		return "", ErrTokenStoreLocked
+	}
+	out, err := resolveCommandSecret(context.Background(), command)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", PassphraseCommandEnv, err)
+	}
+	line, _, _ := strings.Cut(out, "\n")
+	line = strings.TrimRight(line, "\r")
+	if line == "" {
+		return "", fmt.Errorf("%s printed an empty passphrase", PassphraseCommandEnv)
+	}
+	return line, nil
+}
+
+// Path returns the file the store reads and writes.
+func (s *FileTokenStore) Path() string { return s.path }
+
+// Set stores a token, creating the file on first use.
+func (s *FileTokenStore) Set(key, token string) error {
+	key = normalizeProvider(key)
+	if key == "" {
+		return fmt.Errorf("provider is required")
+	}
+	if token == "" {
+		return fmt.Errorf("token is required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	unlock, err := lockTokenFile(s.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	tokens, err := s.load()
+	if err != nil {
+		return err
+	}
+	tokens[key] = token
+	return s.save(tokens)
+}
+
+// Get returns the token for key, or "" when it is not stored or the file
+// does not exist yet.
+func (s *FileTokenStore) Get(key string) (string, error) {
+	key = normalizeProvider(key)
+	if key == "" {
+		return "", fmt.Errorf("provider is required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tokens, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	return tokens[key], nil
+}
+
+// Delete removes the token for key, succeeding when there was none.
+func (s *FileTokenStore) Delete(key string) error {
+	key = normalizeProvider(key)
+	if key == "" {
+		return fmt.Errorf("provider is required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	unlock, err := lockTokenFile(s.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	tokens, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := tokens[key]; !ok {
+		return nil
+	}
+	delete(tokens, key)
+	return s.save(tokens)
+}
+
+// Available reports whether the last operation could open the file.
+func (s *FileTokenStore) Available() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.unavailable
+}
+
+// Keys lists the stored keys in sorted order. Unlike the OS keychain, the file
+// can be enumerated, which is what makes migrating out of it complete.
+func (s *FileTokenStore) Keys() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tokens, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(tokens))
+	for k := range tokens {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// tokenLockTimeout bounds how long a write waits for another process to
+// finish its own.
+const tokenLockTimeout = 30 * time.Second
+
+// lockTokenFile takes path+".lock" the way git takes its lock files: created
+// exclusively, and removed by the returned unlock. Set and Delete hold it
+// around their read-modify-write, so two processes writing at once (two
+// 'config token set' runs) serialize instead of one losing its token. Reads
+// need no lock: the file is replaced by rename, never rewritten in place.
+func lockTokenFile(path string) (unlock func(), err error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("create config directory: %w", err)
+	}
+	lock := path + ".lock"
+	deadline := time.Now().Add(tokenLockTimeout)
+	for {
+		f, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600) // #nosec G304 -- the store's own lock file under the config dir.
+		if err == nil {
+			_ = f.Close()
+			return func() { _ = os.Remove(lock) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("lock token file: %w", err)
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("token file is locked by another gz-git process; remove %s if none is running", lock)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// load decrypts the file. A missing file is an empty store.
+func (s *FileTokenStore) load() (map[string]string, error) {
+	data, err := os.ReadFile(s.path) // #nosec G304 -- the store's own file under the config dir.
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		s.unavailable = true
+		return nil, fmt.Errorf("read token file: %w", err)
+	}
+
+	var env tokenFile
+	if err := json.Unmarshal(data, &env); err != nil {
+		s.unavailable = true
+		return nil, fmt.Errorf("parse token file %s: %w", s.path, err)
+	}
+	if env.Version != tokenFileVersion || env.KDF.Name != "scrypt" {
+		s.unavailable = true
+		return nil, fmt.Errorf("token file %s: unsupported version %d / kdf %q", s.path, env.Version, env.KDF.Name)
+	}
+	salt, err1 := base64.StdEncoding.DecodeString(env.KDF.Salt)
+	nonceBytes, err2 := base64.StdEncoding.DecodeString(env.Nonce)
+	box, err3 := base64.StdEncoding.DecodeString(env.Box)
+	if err := errors.Join(err1, err2, err3); err != nil || len(nonceBytes) != 24 {
+		s.unavailable = true
+		return nil, fmt.Errorf("token file %s is corrupted", s.path)
+	}
+
+	key, err := s.deriveKey(salt, env.KDF.N, env.KDF.R, env.KDF.P)
+	if err != nil {
+		return nil, err
+	}
+	var nonce [24]byte
+	copy(nonce[:], nonceBytes)
+	plain, ok := secretbox.Open(nil, box, &nonce, key)
+	if !ok {
+		s.unavailable = true
+		s.key, s.salt = nil, nil
+		return nil, ErrTokenStoreDecrypt
+	}
+
+	tokens := map[string]string{}
+	if err := json.Unmarshal(plain, &tokens); err != nil {
+		s.unavailable = true
+		return nil, fmt.Errorf("token file %s: decrypted payload is not valid: %w", s.path, err)
+	}
+	s.unavailable = false
+	return tokens, nil
+}
+
+// save encrypts tokens under the cached salt (or a new one) and a fresh nonce
+// and replaces the file atomically.
+func (s *FileTokenStore) save(tokens map[string]string) error {
+	salt := s.salt
+	if salt == nil {
+		salt = make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return fmt.Errorf("generate salt: %w", err)
+		}
+	}
+	key, err := s.deriveKey(salt, scryptN, scryptR, scryptP)
+	if err != nil {
+		return err
+	}
+	var nonce [24]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return fmt.Errorf("generate nonce: %w", err)
+	}
+	plain, err := json.Marshal(tokens)
+	if err != nil {
+		return fmt.Errorf("encode tokens: %w", err)
+	}
+	env := tokenFile{
+		Version: tokenFileVersion,
+		KDF: tokenFileKDF{
+			Name: "scrypt", N: scryptN, R: scryptR, P: scryptP,
+			Salt: base64.StdEncoding.EncodeToString(salt),
+		},
+		Nonce: base64.StdEncoding.EncodeToString(nonce[:]),
+		Box:   base64.StdEncoding.EncodeToString(secretbox.Seal(nil, plain, &nonce, key)),
+	}
+	data, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode token file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".tokens-*.tmp")
+	if err != nil {
+		return fmt.Errorf("write token file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // best-effort cleanup; gone after a successful rename
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write token file: %w", err)
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write token file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write token file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("write token file: %w", err)
+	}
+	return nil
+}
+
+// deriveKey runs scrypt for salt, reusing the cached key when the salt and
+// parameters match the file's. Callers hold s.mu.
+func (s *FileTokenStore) deriveKey(salt []byte, n, r, p int) (*[32]byte, error) {
+	if s.key != nil && bytes.Equal(s.salt, salt) && n == scryptN && r == scryptR && p == scryptP {
+		return s.key, nil
+	}
+	passphrase, err := s.passphrase()
+	if err != nil {
+		s.unavailable = true
+		return nil, err
+	}
+	raw, err := scrypt.Key([]byte(passphrase), salt, n, r, p, 32)
+	if err != nil {
+		s.unavailable = true
+		return nil, fmt.Errorf("derive key: %w", err)
+	}
+	var key [32]byte
+	copy(key[:], raw)
+	if n == scryptN && r == scryptR && p == scryptP {
+		s.key, s.salt = &key, append([]byte(nil), salt...)
+	}
+	return &key, nil
+}
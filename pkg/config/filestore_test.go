@@ -0,0 +1,152 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func fixedPassphrase(p string) func() (string, error) {
+	return func() (string, error) { return p, nil }
+}
+
+func TestFileTokenStore_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gz-git", TokenFileName)
+	s := NewFileTokenStore(path, fixedPassphrase("correct horse"))
+
+	if got, err := s.Get("github"); err != nil || got != "" {
+		t.Fatalf("empty store get=%q err=%v", got, err)
+	}
+	if err := s.Set("GitHub", "tok-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("github@ghe.example.com", "tok-2"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "tok-1") {
+		t.Fatal("token written in the clear")
+	}
+	if runtime.GOOS != "windows" {
+		info, _ := os.Stat(path)
+		if info.Mode().Perm() != 0o600 {
+			t.Fatalf("mode = %04o, want 0600", info.Mode().Perm())
+		}
+	}
+
+	// A fresh store derives the key from the file's salt.
+	reopened := NewFileTokenStore(path, fixedPassphrase("correct horse"))
+	if got, _ := reopened.Get("github"); got != "tok-1" {
+		t.Fatalf("reopened get = %q", got)
+	}
+	keys, err := reopened.Keys()
+	if err != nil || strings.Join(keys, ",") != "github,github@ghe.example.com" {
+		t.Fatalf("keys = %v err=%v", keys, err)
+	}
+	if err := reopened.Delete("github"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.Get("github"); got != "" {
+		t.Fatalf("after delete get = %q", got)
+	}
+}
+
+func TestFileTokenStore_WrongPassphraseAndLocked(t *testing.T) {
+	path := filepath.Join(t.TempDir(), TokenFileName)
+	if err := NewFileTokenStore(path, fixedPassphrase("right")).Set("github", "x"); err != nil {
+		t.Fatal(err)
+	}
+
+	wrong := NewFileTokenStore(path, fixedPassphrase("wrong"))
+	if _, err := wrong.Get("github"); !errors.Is(err, ErrTokenStoreDecrypt) {
+		t.Fatalf("err = %v, want ErrTokenStoreDecrypt", err)
+	}
+	if wrong.Available() {
+		t.Fatal("store that cannot decrypt reports available")
+	}
+	// A failed write must not replace the file with one under the wrong key.
+	if err := wrong.Set("gitlab", "y"); err == nil {
+		t.Fatal("set with wrong passphrase succeeded")
+	}
+
+	t.Setenv(PassphraseEnv, "")
+	t.Setenv(PassphraseCommandEnv, "")
+	locked := NewFileTokenStore(path, nil)
+	if _, err := locked.Get("github"); !errors.Is(err, ErrTokenStoreLocked) {
+		t.Fatalf("err = %v, want ErrTokenStoreLocked", err)
+	}
+}
+
+func TestPassphraseFromEnv(t *testing.T) {
+	t.Setenv(PassphraseEnv, "from-env")
+	if p, err := PassphraseFromEnv(); err != nil || p != "from-env" {
+		t.Fatalf("p=%q err=%v", p, err)
+	}
+	if runtime.GOOS == "windows" {
+		t.Skip("test commands are POSIX utilities")
+	}
+	t.Setenv(PassphraseEnv, "")
+	t.Setenv(PassphraseCommandEnv, "printf 'from-agent\\nignored\\n'")
+	if p, err := PassphraseFromEnv(); err != nil || p != "from-agent" {
+		t.Fatalf("p=%q err=%v", p, err)
+	}
+	t.Setenv(PassphraseCommandEnv, "exit 3")
+	if _, err := PassphraseFromEnv(); err == nil {
+		t.Fatal("failing command should be an error")
+	}
+	// No shell runs the command: the pipe is an argument to echo.
+	t.Setenv(PassphraseCommandEnv, "echo secret | tr a-z A-Z")
+	if p, err := PassphraseFromEnv(); err != nil || p != "secret | tr a-z A-Z" {
+		t.Fatalf("p=%q err=%v", p, err)
+	}
+}
+
+func TestFileTokenStore_ConcurrentWritersKeepEveryToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), TokenFileName)
+	// Separate stores stand in for separate processes: they share nothing
+	// but the file.
+	stores := []*FileTokenStore{
+		NewFileTokenStore(path, fixedPassphrase("pw")),
+		NewFileTokenStore(path, fixedPassphrase("pw")),
+		NewFileTokenStore(path, fixedPassphrase("pw")),
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, 12)
+	for i := range 12 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- stores[i%len(stores)].Set(fmt.Sprintf("github@host%d.example", i), "tok")
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	keys, err := NewFileTokenStore(path, fixedPassphrase("pw")).Keys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 12 {
+		t.Fatalf("kept %d of 12 tokens: %v", len(keys), keys)
+	}
+	if _, err := os.Stat(path + ".lock"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("lock file left behind: %v", err)
+	}
+}
@@ -0,0 +1,323 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gizzahub/gzh-cli-gitforge/internal/gitcmd"
+)
+
+// includeCacheDirName is the directory under the user cache directory
+// (~/.cache on Linux) holding checkouts of git includes.
+const includeCacheDirName = "gz-git/includes"
+
+// includeRef is a parsed git include: "<url>@<ref>[//<file>]".
+type includeRef struct {
+	URL  string
+	Ref  string
+	File string
+}
+
+// isGitInclude reports whether an include names a git repository rather than
+// a local file: a URL with a scheme, or scp-like "user@host:path".
+func isGitInclude(include string) bool {
+	if i := strings.Index(include, "://"); i > 0 {
+		return true
+	}
+	colon := strings.IndexByte(include, ':')
+	if colon <= 0 || strings.ContainsAny(include[:colon], `/\`) {
+		return false
+	}
+	return strings.Contains(include[:colon], "@")
+}
+
+// parseGitInclude splits a git include into its URL, ref and file. The ref is
+// required so a config does not silently follow whatever a branch points at
+// today; the file defaults to .gz-git.yaml at the repository root.
+func parseGitInclude(include string) (includeRef, error) {
+	// Where the host part ends: an "@" or "//" before it belongs to the URL.
+	hostEnd := strings.IndexByte(include, ':')
+	if i := strings.Index(include, "://"); i > 0 {
+		hostEnd = i + 3
+		if slash := strings.IndexByte(include[hostEnd:], '/'); slash >= 0 {
+			hostEnd += slash
+		} else {
+			hostEnd = len(include)
+		}
+	}
+
+	ref := includeRef{File: ".gz-git.yaml"}
+	rest := include
+	if i := strings.Index(rest[hostEnd:], "//"); i >= 0 {
+		ref.File = strings.Trim(rest[hostEnd+i+2:], "/")
+		rest = rest[:hostEnd+i]
+	}
+	at := strings.LastIndexByte(rest, '@')
+	if at <= hostEnd {
+		return includeRef{}, fmt.Errorf("git include %q has no @ref (write <url>@<tag|branch|commit>)", include)
+	}
+	ref.URL, ref.Ref = rest[:at], rest[at+1:]
+
+	if ref.Ref == "" || strings.HasPrefix(ref.Ref, "-") {
+		return includeRef{}, fmt.Errorf("git include %q has an invalid ref", include)
+	}
+	if ref.File == "" || filepath.IsAbs(ref.File) || strings.HasPrefix(filepath.Clean(ref.File), "..") {
+		return includeRef{}, fmt.Errorf("git include %q: file must be a path inside the repository", include)
+	}
+	if err := gitcmd.SanitizeURL(ref.URL); err != nil {
+		return includeRef{}, fmt.Errorf("git include %q: %w", include, err)
+	}
+	if u, err := url.Parse(ref.URL); err == nil && u.User != nil {
+		if _, hasPassword := u.User.Password(); hasPassword {
+			return includeRef{}, fmt.Errorf("git include %q: credentials do not belong in the URL", include)
+		}
+	}
+	return ref, nil
+}
+
+// fetchGitInclude returns the path of the included file in a local checkout
+// of the include's repository at its ref. Each url@ref is fetched once into
+// the user cache directory and reused from there afterwards, so pin tags or
+// commits; remove the cache directory to pick up a moved branch.
+func fetchGitInclude(ctx context.Context, ref includeRef) (string, error) {
+	cacheRoot, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate cache directory: %w", err)
+	}
+	sum := sha256.Sum256([]byte(ref.URL + "@" + ref.Ref))
+	checkout := filepath.Join(cacheRoot, includeCacheDirName, hex.EncodeToString(sum[:8]))
+
+	if _, err := os.Stat(filepath.Join(checkout, ".git")); err != nil {
+		if err := cloneIncludeRef(ctx, ref, checkout); err != nil {
+			return "", err
+		}
+	}
+
+	file := filepath.Join(checkout, filepath.FromSlash(ref.File))
+	if _, err := os.Stat(file); err != nil {
+		return "", fmt.Errorf("%s@%s has no %s: %w", ref.URL, ref.Ref, ref.File, err)
+	}
+	return file, nil
+}
+
+// cloneIncludeRef fetches one ref, shallowly, into a temporary directory
+// next to checkout and renames it into place, so an interrupted fetch never
+// leaves a half-populated checkout behind.
+func cloneIncludeRef(ctx context.Context, ref includeRef, checkout string) error {
+	if err := os.MkdirAll(filepath.Dir(checkout), 0o755); err != nil {
+		return fmt.Errorf("failed to create include cache: %w", err)
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(checkout), "fetch-")
+	if err != nil {
+		return fmt.Errorf("failed to create include cache: %w", err)
+	}
+	defer os.RemoveAll(tmp) //nolint:errcheck // best-effort cleanup; tmp is gone after a successful rename
+
+	git := gitcmd.NewExecutor()
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"remote", "add", "origin", ref.URL},
+		{"fetch", "--quiet", "--depth", "1", "origin", ref.Ref},
+		{"checkout", "--quiet", "--detach", "FETCH_HEAD"},
+	} {
+		if _, err := git.RunOutput(ctx, tmp, args...); err != nil {
+			return fmt.Errorf("failed to fetch %s@%s: %w", ref.URL, ref.Ref, err)
+		}
+	}
+
+	if err := os.Rename(tmp, checkout); err != nil {
+		// Another process may have fetched the same ref meanwhile.
+		if _, statErr := os.Stat(filepath.Join(checkout, ".git")); statErr == nil {
+			return nil
+		}
+		return fmt.Errorf("failed to store %s@%s: %w", ref.URL, ref.Ref, err)
+	}
+	return nil
+}
+
+// resolveInclude returns the absolute path of the file an include names.
+// Local paths are relative to the directory of the including file.
+func resolveInclude(include, fromDir string) (string, error) {
+	if isGitInclude(include) {
+		ref, err := parseGitInclude(include)
+		if err != nil {
+			return "", err
+		}
+		return fetchGitInclude(context.Background(), ref)
+	}
+	path, err := resolvePath(fromDir, include)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(path)
+}
+
+// applyIncludes merges the files config.Include names into config. The
+// including file wins over what it includes, and a later include wins over an
+// earlier one. Included files may include further files; stack holds the
+// files being loaded so a cycle is reported instead of followed, while the
+// same file included twice along different paths is fine. remote is set
+// when config itself came from a git include, so everything it includes
+// does too.
+func applyIncludes(config *Config, stack []string, depth int, remote bool) error {
+	from := filepath.Dir(config.ConfigPath)
+	for i := len(config.Include) - 1; i >= 0; i-- {
+		include := config.Include[i]
+		included, err := loadInclude(include, from, stack, depth+1, remote || isGitInclude(include))
+		if err != nil {
+			return fmt.Errorf("include %q in %s: %w", include, config.ConfigPath, err)
+		}
+		mergeIncludedConfig(config, included)
+	}
+	return nil
+}
+
+// loadInclude loads one included file, with its own includes applied. A
+// remote one, fetched from git, is someone else's file: it may not use
+// secret references other than ${VAR} and ${env:VAR}, and its hooks and
+// recipes are dropped (see dropIncludedCommands).
+func loadInclude(include, fromDir string, stack []string, depth int, remote bool) (*Config, error) {
+	if depth > MaxConfigDepth {
+		return nil, fmt.Errorf("config include depth exceeded (max %d): possible circular or excessively nested includes", MaxConfigDepth)
+	}
+
+	path, err := resolveInclude(include, fromDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, loading := range stack {
+		if loading == path {
+			return nil, fmt.Errorf("circular config include detected: %s", strings.Join(append(stack, path), " → "))
+		}
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 -- path is an include named by the user's own config.
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("included file %s does not exist", path)
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if remote {
+		if err := checkRemoteSecretRefs(data); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	var included Config
+	if err := yaml.Unmarshal(data, &included); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if included.Parent != "" {
+		return nil, fmt.Errorf("%s sets parent:, which included files cannot; include the parent instead", path)
+	}
+	included.ConfigPath = path
+
+	if err := applyIncludes(&included, append(stack, path), depth, remote); err != nil {
+		return nil, err
+	}
+	if remote {
+		dropIncludedCommands(&included)
+	}
+	return &included, nil
+}
+
+// checkRemoteSecretRefs rejects a remote include that uses a secret
+// reference resolved on use. ${cmd:...} would run a command of the
+// include's choosing and ${file:...} or ${keyring:...} would hand a local
+// secret to whatever forge the include names; those belong in the user's
+// own files.
+func checkRemoteSecretRefs(data []byte) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse: %w", err)
+	}
+	var walk func(n *yaml.Node) error
+	walk = func(n *yaml.Node) error {
+		if n.Kind == yaml.ScalarNode && HasSecretRef(n.Value) {
+			return fmt.Errorf("line %d: %q is a secret reference, which git includes may not use (only ${VAR} and ${env:VAR})", n.Line, n.Value)
+		}
+		for _, c := range n.Content {
+			if err := walk(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(&doc)
+}
+
+// dropIncludedCommands removes what would run commands from a config
+// fetched from git: hooks, at the top and in every workspace and template,
+// and profile recipes. A shared repository supplies settings, profiles and
+// workspaces; the commands gz-git runs come from the user's own files.
+func dropIncludedCommands(config *Config) {
+	config.Hooks = nil
+	for _, p := range config.Profiles {
+		if p != nil {
+			p.Recipes = nil
+		}
+	}
+	dropWorkspaceHooks(config.Workspaces)
+	dropWorkspaceHooks(config.WorkspaceTemplates)
+}
+
+func dropWorkspaceHooks(workspaces map[string]*Workspace) {
+	for _, ws := range workspaces {
+		if ws != nil {
+			ws.Hooks = nil
+			dropWorkspaceHooks(ws.Workspaces)
+		}
+	}
+}
+
+// mergeIncludedConfig fills config from an included file: settings the way a
+// parent config provides them, plus the included profiles, workspaces and
+// workspace templates under names config does not define itself.
+func mergeIncludedConfig(config, included *Config) {
+	mergeParentConfig(config, included)
+
+	if config.Profile == "" {
+		config.Profile = included.Profile
+	}
+	if config.DefaultWorkspaceType == "" {
+		config.DefaultWorkspaceType = included.DefaultWorkspaceType
+	}
+	if config.ChildConfigMode == "" {
+		config.ChildConfigMode = included.ChildConfigMode
+	}
+	if config.Hooks == nil {
+		config.Hooks = included.Hooks
+	}
+
+	config.Profiles = mergeNamed(config.Profiles, included.Profiles)
+	config.Workspaces = mergeNamed(config.Workspaces, included.Workspaces)
+	config.WorkspaceTemplates = mergeNamed(config.WorkspaceTemplates, included.WorkspaceTemplates)
+}
+
+// mergeNamed adds the entries of from that into does not already have.
+func mergeNamed[T any](into, from map[string]T) map[string]T {
+	if len(from) == 0 {
+		return into
+	}
+	if into == nil {
+		into = make(map[string]T, len(from))
+	}
+	for name, v := range from {
+		if _, ok := into[name]; !ok {
+			into[name] = v
+		}
+	}
+	return into
+}
@@ -0,0 +1,329 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/watch"
+)
+
+//go:generate go run schema_docs_gen.go
+
+// SchemaDraft is the JSON Schema dialect the generated schemas declare.
+const SchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// SchemaKind names a config file layout that has a JSON Schema.
+type SchemaKind string
+
+const (
+	// SchemaKindConfig is .gz-git.yaml in any of its layouts: a workspace
+	// tree, a repositories list, or project settings.
+	SchemaKindConfig SchemaKind = "config"
+
+	// SchemaKindGlobal is ~/.config/gz-git/config.yaml.
+	SchemaKindGlobal SchemaKind = "global"
+
+	// SchemaKindProfile is a profile file, ~/.config/gz-git/profiles/*.yaml.
+	SchemaKindProfile SchemaKind = "profile"
+
+	// SchemaKindProject is the project settings of .gz-git.yaml alone.
+	SchemaKindProject SchemaKind = "project"
+
+	// SchemaKindWorkspace is one entry of a workspaces map.
+	SchemaKindWorkspace SchemaKind = "workspace"
+)
+
+// SchemaKinds lists every kind GenerateSchema accepts.
+var SchemaKinds = []SchemaKind{
+	SchemaKindConfig,
+	SchemaKindGlobal,
+	SchemaKindProfile,
+	SchemaKindProject,
+	SchemaKindWorkspace,
+}
+
+// Schema is a JSON Schema (draft 2020-12) node: the subset the generated
+// schemas use, which is also what ValidateYAML checks.
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	Type    string   `json:"type,omitempty"`
+	Enum    []string `json:"enum,omitempty"`
+	Minimum *int     `json:"minimum,omitempty"`
+	Maximum *int     `json:"maximum,omitempty"`
+
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	// AdditionalProperties is false for a closed object, or the *Schema of
+	// a map's values.
+	AdditionalProperties any       `json:"additionalProperties,omitempty"`
+	Items                *Schema   `json:"items,omitempty"`
+	OneOf                []*Schema `json:"oneOf,omitempty"`
+
+	Defs map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// GenerateSchema builds the JSON Schema of one config file kind from the Go
+// types the file is decoded into. Descriptions come from the types' doc
+// comments, by way of schema_docs.go.
+func GenerateSchema(kind SchemaKind) (*Schema, error) {
+	b := &schemaBuilder{defs: map[string]*Schema{}}
+
+	var root *Schema
+	switch kind {
+	case SchemaKindConfig:
+		// .gz-git.yaml is read by several loaders, each taking the keys it
+		// knows, so its schema is their union. Where two declare the same
+		// key, the first type listed wins; its schema accepts the others'.
+		root = b.object(
+			reflect.TypeOf(ConfigMeta{}),
+			reflect.TypeOf(Config{}),
+			reflect.TypeOf(ProjectConfig{}),
+			reflect.TypeOf(RepositoriesConfig{}),
+		)
+		root.Title = "gz-git .gz-git.yaml"
+		root.Description = "Workspace, repositories or project config, found by walking up from the working directory."
+	case SchemaKindGlobal:
+		root = b.rootOf(reflect.TypeOf(GlobalConfig{}), "gz-git global config (~/.config/gz-git/config.yaml)")
+	case SchemaKindProfile:
+		root = b.rootOf(reflect.TypeOf(Profile{}), "gz-git profile (~/.config/gz-git/profiles/*.yaml)")
+	case SchemaKindProject:
+		root = b.rootOf(reflect.TypeOf(ProjectConfig{}), "gz-git project config (.gz-git.yaml)")
+	case SchemaKindWorkspace:
+		root = b.rootOf(reflect.TypeOf(Workspace{}), "gz-git workspace entry")
+	default:
+		return nil, fmt.Errorf("unknown schema kind %q (want one of: %s)", kind, joinKinds(SchemaKinds))
+	}
+
+	root.Schema = SchemaDraft
+	root.Defs = b.defs
+	return root, nil
+}
+
+func joinKinds(kinds []SchemaKind) string {
+	names := make([]string, len(kinds))
+	for i, k := range kinds {
+		names[i] = string(k)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Types whose YAML form is not their Go shape: each has an UnmarshalYAML
+// that also takes a plain string.
+var (
+	branchConfigType = reflect.TypeOf(BranchConfig{})
+	branchListType   = reflect.TypeOf(BranchList{})
+	flexBranchType   = reflect.TypeOf(FlexBranch(""))
+)
+
+// schemaTypeEnums are the values of the string types that have a fixed set.
+var schemaTypeEnums = map[reflect.Type][]string{
+	// "workspaces" and "repository" are deprecated spellings that
+	// `workspace validate` still accepts with a warning.
+	reflect.TypeOf(ConfigKind("")):                 {"workspace", "repositories", "workspaces", "repository"},
+	reflect.TypeOf(WorkspaceType("")):              {"forge", "git", "config"},
+	reflect.TypeOf(ChildConfigMode("")):            {"repositories", "workspaces", "none"},
+	reflect.TypeOf(DiscoveryMode("")):              {"hybrid", "explicit", "auto"},
+	reflect.TypeOf(repository.ForceMode("")):       {"lease-only", "allow", "deny"},
+	reflect.TypeOf(repository.ForeignWorkMode("")): {"block", "allow"},
+}
+
+// schemaFieldEnums are the values of plain string fields that have a fixed
+// set, keyed like schemaFieldDocs.
+var schemaFieldEnums = func() map[string][]string {
+	providers := sortedKeys(validProviders)
+	protos := sortedKeys(validCloneProtos)
+	subgroups := sortedKeys(validSubgroupModes)
+	strategies := sortedKeys(validSyncStrategies)
+	return map[string][]string{
+		"config.Profile.Provider":              providers,
+		"config.Config.Provider":               providers,
+		"config.ForgeSource.Provider":          providers,
+		"config.Credential.Provider":           providers,
+		"config.Profile.CloneProto":            protos,
+		"config.Workspace.CloneProto":          protos,
+		"config.CloneDefaults.Proto":           protos,
+		"config.RepositoriesConfig.CloneProto": protos,
+		"config.RepositoryEntry.CloneProto":    protos,
+		"config.Profile.SubgroupMode":          subgroups,
+		"config.Config.SubgroupMode":           subgroups,
+		"config.ForgeSource.SubgroupMode":      subgroups,
+		"config.SyncConfig.Strategy":           strategies,
+		"config.SyncDefaults.Strategy":         strategies,
+		"config.RepositoriesConfig.Strategy":   strategies,
+		"config.RepositoryEntry.Strategy":      strategies,
+		"config.SelfSyncConfig.Strategy":       {"fetch", "pull", "skip"},
+		"config.GlobalConfig.TokenStore":       {TokenBackendKeyring, TokenBackendFile},
+		"watch.SinkConfig.Type":                watch.SinkTypes,
+	}
+}()
+
+// schemaRequired are the keys a type cannot do without.
+var schemaRequired = map[string][]string{
+	"config.Credential":      {"host"},
+	"config.ForgeSource":     {"provider", "org"},
+	"config.Recipe":          {"steps"},
+	"config.RecipeStep":      {"run"},
+	"config.RepositoryEntry": {"url"},
+	"watch.SinkConfig":       {"type"},
+}
+
+// schemaOpen are the types that take keys beyond their fields. Metadata is
+// descriptive, and generated configs record more of it (syncedAt,
+// description) than the type names.
+var schemaOpen = map[string]bool{
+	"config.Metadata": true,
+}
+
+// schemaRanges bound integer fields, by YAML key: minimum and maximum, where
+// a maximum of -1 means none.
+var schemaRanges = map[string][2]int{
+	"sshPort":    {0, 65535},
+	"parallel":   {0, -1},
+	"maxRetries": {0, -1},
+	"depth":      {0, -1},
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// schemaBuilder turns Go types into schemas, putting each struct in $defs
+// once so recursive types (a workspace's workspaces) terminate.
+type schemaBuilder struct {
+	defs map[string]*Schema
+}
+
+// rootOf is a document whose top level is t.
+func (b *schemaBuilder) rootOf(t reflect.Type, title string) *Schema {
+	ref := b.schemaFor(t)
+	return &Schema{Ref: ref.Ref, Title: title, Description: schemaTypeDocs[typeKey(t)]}
+}
+
+// typeKey names a type the way schema_docs.go does: package.Type.
+func typeKey(t reflect.Type) string {
+	pkg := t.PkgPath()
+	return pkg[strings.LastIndex(pkg, "/")+1:] + "." + t.Name()
+}
+
+// object is a closed object with the fields of every struct in types.
+func (b *schemaBuilder) object(types ...reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}, AdditionalProperties: false}
+	for _, t := range types {
+		b.addFields(s, t)
+		s.Required = append(s.Required, schemaRequired[typeKey(t)]...)
+		if schemaOpen[typeKey(t)] {
+			s.AdditionalProperties = nil
+		}
+	}
+	return s
+}
+
+func (b *schemaBuilder) addFields(s *Schema, t reflect.Type) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if strings.Contains(opts, "inline") {
+			b.addFields(s, field.Type)
+			continue
+		}
+		if name == "" {
+			continue
+		}
+		if _, taken := s.Properties[name]; taken {
+			continue
+		}
+
+		key := typeKey(t) + "." + field.Name
+		prop := b.schemaFor(field.Type)
+		if enum, ok := schemaFieldEnums[key]; ok {
+			prop.Enum = enum
+		}
+		if r, ok := schemaRanges[name]; ok && prop.Type == "integer" {
+			prop.Minimum = &r[0]
+			if r[1] >= 0 {
+				prop.Maximum = &r[1]
+			}
+		}
+		prop.Description = schemaFieldDocs[key]
+		s.Properties[name] = prop
+	}
+}
+
+// schemaFor returns a fresh schema for t, which the caller may annotate.
+func (b *schemaBuilder) schemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case branchConfigType:
+		// branch: develop is short for branch: {defaultBranch: develop}.
+		return &Schema{OneOf: []*Schema{{Type: "string"}, b.ref(t)}}
+	case branchListType:
+		return &Schema{OneOf: []*Schema{{Type: "string"}, {Type: "array", Items: &Schema{Type: "string"}}}}
+	case flexBranchType:
+		return &Schema{OneOf: []*Schema{
+			{Type: "string"},
+			{
+				Type:                 "object",
+				Properties:           map[string]*Schema{"defaultBranch": b.schemaFor(branchListType)},
+				AdditionalProperties: false,
+			},
+		}}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string", Enum: schemaTypeEnums[t]}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: b.schemaFor(t.Elem())}
+	case reflect.Map:
+		s := &Schema{Type: "object"}
+		if t.Elem().Kind() != reflect.Interface {
+			s.AdditionalProperties = b.schemaFor(t.Elem())
+		}
+		return s
+	case reflect.Struct:
+		return b.ref(t)
+	default:
+		// any: whatever the file holds
+		return &Schema{}
+	}
+}
+
+// ref defines t in $defs, if it is not already, and refers to it.
+func (b *schemaBuilder) ref(t reflect.Type) *Schema {
+	name := t.Name()
+	if _, ok := b.defs[name]; !ok {
+		b.defs[name] = nil // placeholder: t may refer to itself
+		def := b.object(t)
+		def.Description = schemaTypeDocs[typeKey(t)]
+		b.defs[name] = def
+	}
+	return &Schema{Ref: "#/$defs/" + name}
+}
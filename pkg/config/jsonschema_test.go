@@ -0,0 +1,219 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGenerateSchema(t *testing.T) {
+	for _, kind := range SchemaKinds {
+		t.Run(string(kind), func(t *testing.T) {
+			schema, err := GenerateSchema(kind)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if schema.Schema != SchemaDraft {
+				t.Errorf("$schema = %q", schema.Schema)
+			}
+			data, err := json.Marshal(schema)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Every $ref must land in $defs.
+			for _, ref := range strings.Split(string(data), `"$ref":"#/$defs/`)[1:] {
+				name, _, _ := strings.Cut(ref, `"`)
+				if schema.Defs[name] == nil {
+					t.Errorf("dangling $ref to %s", name)
+				}
+			}
+		})
+	}
+
+	if _, err := GenerateSchema("nope"); err == nil {
+		t.Error("unknown kind should be rejected")
+	}
+}
+
+func TestGenerateSchema_Config(t *testing.T) {
+	schema, err := GenerateSchema(SchemaKindConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The union of the layouts: meta, workspace tree, project, repositories.
+	for _, key := range []string{"version", "kind", "workspaces", "profiles", "watch", "audit", "repositories", "strategy"} {
+		if schema.Properties[key] == nil {
+			t.Errorf("missing top-level key %q", key)
+		}
+	}
+
+	ws := schema.Defs["Workspace"]
+	if ws == nil {
+		t.Fatal("Workspace not in $defs")
+	}
+	if got := ws.Properties["workspaces"].AdditionalProperties.(*Schema).Ref; got != "#/$defs/Workspace" {
+		t.Errorf("nested workspaces ref = %q", got)
+	}
+	if got := ws.Properties["type"].Enum; !slices.Equal(got, []string{"forge", "git", "config"}) {
+		t.Errorf("workspace type enum = %v", got)
+	}
+	if ws.Properties["path"].Description == "" {
+		t.Error("workspace path has no description")
+	}
+	if got := schema.Defs["ForgeSource"].Required; !slices.Equal(got, []string{"provider", "org"}) {
+		t.Errorf("ForgeSource required = %v", got)
+	}
+	if got := schema.Defs["PushPolicy"].Properties["forceMode"].Enum; !slices.Contains(got, "lease-only") {
+		t.Errorf("forceMode enum = %v", got)
+	}
+	if len(schema.Properties["branch"].OneOf) != 2 {
+		t.Error("branch should take a string or a map")
+	}
+}
+
+func TestSchemaValidateYAML(t *testing.T) {
+	schema, err := GenerateSchema(SchemaKindConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		doc  string
+		want []string // Error() of each violation, in order
+	}{
+		{
+			name: "valid workspace tree",
+			doc: `version: 1
+kind: workspace
+branch: develop,main
+workspaces:
+  devbox:
+    path: ~/devbox
+    source:
+      provider: gitlab
+      org: devbox
+    sync:
+      strategy: pull
+    workspaces:
+      nested:
+        path: nested
+        type: git
+`,
+		},
+		{
+			name: "valid repositories list",
+			doc: `kind: repositories
+strategy: reset
+parallel: 4
+repositories:
+  - url: https://github.com/org/api.git
+    branch:
+      defaultBranch: [develop, main]
+`,
+		},
+		{
+			name: "wrong values with positions",
+			doc: `kind: workspace
+workspaces:
+  devbox:
+    path: ~/devbox
+    type: svn
+    sshPort: 70000
+    sync:
+      strategy: yolo
+`,
+			want: []string{
+				`5:11: workspaces.devbox.type: "svn" is not one of: forge, git, config`,
+				`6:14: workspaces.devbox.sshPort: 70000 is above the maximum of 65535`,
+				`8:17: workspaces.devbox.sync.strategy: "yolo" is not one of: clone, fetch, pull, rebase, reset, skip`,
+			},
+		},
+		{
+			name: "wrong shapes",
+			doc: `branch: 3
+repositories:
+  - url: https://github.com/org/api.git
+    enabled: maybe
+  - path: web
+workspaces: []
+`,
+			want: []string{
+				`1:9: branch: expected a string or a map, got an integer`,
+				`4:14: repositories[0].enabled: expected a boolean, got a string`,
+				`5:5: repositories[1]: missing required key "url"`,
+				`6:13: workspaces: expected a map, got a list`,
+			},
+		},
+		{
+			name: "unknown keys",
+			doc: `kind: workspace
+stratgy: pull
+push:
+  policy:
+    protect: [main]
+`,
+			want: []string{
+				`2:1: stratgy: unknown key`,
+				`5:5: push.policy.protect: unknown key`,
+			},
+		},
+		{
+			name: "null sections",
+			doc:  "sync:\nbranch:\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs, err := schema.ValidateYAML([]byte(tt.doc))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, e := range errs {
+				got = append(got, e.Error())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got:\n  %s\nwant:\n  %s", strings.Join(got, "\n  "), strings.Join(tt.want, "\n  "))
+			}
+		})
+	}
+
+	if _, err := schema.ValidateYAML([]byte("a: [b")); err == nil {
+		t.Error("invalid YAML should be an error")
+	}
+}
+
+// TestSchemaValidateYAML_Examples keeps the shipped examples in step with the
+// schema: an example that fails here would show errors in an editor.
+func TestSchemaValidateYAML_Examples(t *testing.T) {
+	schema, err := GenerateSchema(SchemaKindConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := filepath.Glob("../../examples/*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		errs, err := schema.ValidateYAML(data)
+		if err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		for _, e := range errs {
+			t.Errorf("%s:%s", filepath.Base(file), e)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaError is one place a YAML document breaks its schema.
+type SchemaError struct {
+	Path    string // key path, e.g. workspaces.devbox.sync.strategy or repositories[2].url
+	Line    int
+	Column  int
+	Message string
+
+	// Unknown marks a key the schema does not declare. gz-git ignores such
+	// keys, so it is more likely a typo than a broken file.
+	Unknown bool
+}
+
+// Error formats the error with its position, as line:column.
+func (e SchemaError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("%d:%d: %s: %s", e.Line, e.Column, e.Path, e.Message)
+}
+
+// ValidateYAML checks a YAML document against the schema and returns every
+// violation, in document order. It returns an error only when the document
+// is not YAML at all.
+//
+// A null value passes any schema, as it does when the document is decoded:
+// `sync:` with nothing under it is the same as leaving it out.
+func (s *Schema) ValidateYAML(data []byte) ([]SchemaError, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	v := &schemaValidator{root: s}
+	v.check(s, doc.Content[0], "")
+	return v.errs, nil
+}
+
+type schemaValidator struct {
+	root *Schema
+	errs []SchemaError
+}
+
+func (v *schemaValidator) fail(n *yaml.Node, path, format string, args ...any) {
+	v.errs = append(v.errs, SchemaError{Path: path, Line: n.Line, Column: n.Column, Message: fmt.Sprintf(format, args...)})
+}
+
+// resolve follows a $ref into the root's $defs.
+func (v *schemaValidator) resolve(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		s = v.root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+	}
+	return s
+}
+
+func (v *schemaValidator) check(s *Schema, n *yaml.Node, path string) {
+	s = v.resolve(s)
+	if s == nil {
+		return
+	}
+	for n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+		return
+	}
+
+	if len(s.OneOf) > 0 {
+		v.checkOneOf(s, n, path)
+		return
+	}
+	if s.Type != "" && !matchesType(s.Type, n) {
+		v.fail(n, path, "expected %s, got %s", typeName(s.Type), nodeName(n))
+		return
+	}
+
+	switch n.Kind {
+	case yaml.ScalarNode:
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, n.Value) {
+			v.fail(n, path, "%q is not one of: %s", n.Value, strings.Join(s.Enum, ", "))
+		}
+		if s.Type == "integer" {
+			i, err := strconv.Atoi(n.Value)
+			if err != nil {
+				break
+			}
+			if s.Minimum != nil && i < *s.Minimum {
+				v.fail(n, path, "%d is below the minimum of %d", i, *s.Minimum)
+			}
+			if s.Maximum != nil && i > *s.Maximum {
+				v.fail(n, path, "%d is above the maximum of %d", i, *s.Maximum)
+			}
+		}
+	case yaml.SequenceNode:
+		if s.Items != nil {
+			for i, item := range n.Content {
+				v.check(s.Items, item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	case yaml.MappingNode:
+		v.checkMapping(s, n, path)
+	}
+}
+
+func (v *schemaValidator) checkMapping(s *Schema, n *yaml.Node, path string) {
+	seen := map[string]bool{}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if key.Value == "<<" {
+			continue // a merge key: the merged map is checked where it is defined
+		}
+		seen[key.Value] = true
+		keyPath := joinPath(path, key.Value)
+
+		if prop, ok := s.Properties[key.Value]; ok {
+			v.check(prop, value, keyPath)
+			continue
+		}
+		switch extra := s.AdditionalProperties.(type) {
+		case *Schema:
+			v.check(extra, value, keyPath)
+		case bool:
+			if !extra {
+				v.errs = append(v.errs, SchemaError{
+					Path:    keyPath,
+					Line:    key.Line,
+					Column:  key.Column,
+					Message: "unknown key",
+					Unknown: true,
+				})
+			}
+		}
+	}
+	for _, key := range s.Required {
+		if !seen[key] {
+			v.fail(n, path, "missing required key %q", key)
+		}
+	}
+}
+
+// checkOneOf passes n if any alternative does. Otherwise it reports the
+// errors of the one alternative of n's shape, since that is the one the
+// author meant; with none or several, it reports the shapes it expected.
+func (v *schemaValidator) checkOneOf(s *Schema, n *yaml.Node, path string) {
+	var shaped []*Schema
+	for _, alt := range s.OneOf {
+		sub := &schemaValidator{root: v.root}
+		sub.check(alt, n, path)
+		if len(sub.errs) == 0 {
+			return
+		}
+		if r := v.resolve(alt); r != nil && r.Type != "" && matchesType(r.Type, n) {
+			shaped = append(shaped, alt)
+		}
+	}
+	if len(shaped) == 1 {
+		v.check(shaped[0], n, path)
+		return
+	}
+	var names []string
+	for _, alt := range s.OneOf {
+		if r := v.resolve(alt); r != nil {
+			names = append(names, typeName(r.Type))
+		}
+	}
+	v.fail(n, path, "expected %s, got %s", strings.Join(names, " or "), nodeName(n))
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func matchesType(typ string, n *yaml.Node) bool {
+	switch typ {
+	case "object":
+		return n.Kind == yaml.MappingNode
+	case "array":
+		return n.Kind == yaml.SequenceNode
+	case "string":
+		return n.Kind == yaml.ScalarNode && n.Tag == "!!str"
+	case "integer":
+		return n.Kind == yaml.ScalarNode && n.Tag == "!!int"
+	case "number":
+		return n.Kind == yaml.ScalarNode && (n.Tag == "!!int" || n.Tag == "!!float")
+	case "boolean":
+		return n.Kind == yaml.ScalarNode && n.Tag == "!!bool"
+	}
+	return true
+}
+
+// typeName is how messages name a schema type.
+func typeName(typ string) string {
+	switch typ {
+	case "object":
+		return "a map"
+	case "array":
+		return "a list"
+	case "integer":
+		return "an integer"
+	case "":
+		return "a value"
+	}
+	return "a " + typ
+}
+
+// nodeName is how messages name what the document holds.
+func nodeName(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "a map"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+	switch n.Tag {
+	case "!!int":
+		return "an integer"
+	case "!!float":
+		return "a number"
+	case "!!bool":
+		return "a boolean"
+	}
+	return "a string"
+}
@@ -263,6 +263,17 @@ func (l *ConfigLoader) applyProjectConfig(cfg *EffectiveConfig) {
 	if proj.Audit != nil {
 		l.applyAuditConfig(&cfg.Audit, proj.Audit)
 	}
+	if proj.Watch != nil {
+		// Sinks are a project's own hooks; there is no lower layer to merge
+		// them with.
+		cfg.Watch.Sinks = proj.Watch.Sinks
+		if proj.Watch.FetchInterval != "" {
+			cfg.Watch.FetchInterval = proj.Watch.FetchInterval
+		}
+		if proj.Watch.AutoFastForward {
+			cfg.Watch.AutoFastForward = true
+		}
+	}
 }
 
 // applyKeychainToken loads a forge token from the OS keychain when available.
@@ -0,0 +1,245 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentConfigVersion is the version: of the config file format this build
+// reads and writes. Files without a version are version 0.
+const CurrentConfigVersion = 1
+
+// ConfigMigration upgrades a config file from one version of the format to the
+// next. Migrations edit the parsed YAML node tree in place, so comments,
+// key order and the rest of the file survive the upgrade.
+type ConfigMigration struct {
+	// From is the version this migration upgrades; it produces From+1.
+	From int
+
+	// Name identifies the migration in reports.
+	Name string
+
+	// Kinds limits the migration to files of these kinds (kind: groups and
+	// kind: flat are the clone config kinds); nil applies it to every file.
+	Kinds []ConfigKind
+
+	// Apply edits the mapping at the root of the file and describes each
+	// change it made, one entry per change.
+	Apply func(root *yaml.Node) []string
+}
+
+// migrations are applied in order. Each From is below CurrentConfigVersion,
+// and a version's migrations run before any of the next version's.
+var migrations = []ConfigMigration{
+	{
+		From:  0,
+		Name:  "kind-aliases",
+		Apply: migrateKindAliases,
+	},
+	{
+		From:  0,
+		Name:  "repository-target-path",
+		Kinds: []ConfigKind{KindRepositories},
+		Apply: migrateTargetPath,
+	},
+}
+
+// ConfigMigrations returns the registered migrations, in the order they apply.
+func ConfigMigrations() []ConfigMigration {
+	return slices.Clone(migrations)
+}
+
+// ConfigMigrationResult is the outcome of migrating one file.
+type ConfigMigrationResult struct {
+	// Kind is empty for a file that is not a workspace or repositories
+	// config, such as a profile or the global config. Those have no
+	// version: and are left alone.
+	Kind        ConfigKind
+	FromVersion int
+	ToVersion   int
+
+	// Changes lists what the migrations changed, prefixed by their names.
+	// Setting version: is not listed.
+	Changes []string
+
+	// Output is the upgraded file; the input, unchanged, when there is
+	// nothing to migrate.
+	Output []byte
+}
+
+// Changed reports whether migrating changed the file.
+func (r *ConfigMigrationResult) Changed() bool {
+	return len(r.Changes) > 0 || r.FromVersion < r.ToVersion
+}
+
+// MigrateConfig upgrades the content of a config file to
+// CurrentConfigVersion. Files from a newer gz-git are an error rather than
+// being downgraded.
+func MigrateConfig(data []byte) (*ConfigMigrationResult, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	root := lookupNode(&doc)
+	if root == nil || root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config is not a YAML mapping")
+	}
+
+	version, err := configVersion(root)
+	if err != nil {
+		return nil, err
+	}
+	if version > CurrentConfigVersion {
+		return nil, fmt.Errorf("config version %d is newer than this gz-git supports (%d): upgrade gz-git", version, CurrentConfigVersion)
+	}
+
+	if !versionedConfig(root) {
+		return &ConfigMigrationResult{FromVersion: version, ToVersion: version, Output: data}, nil
+	}
+
+	result := &ConfigMigrationResult{Kind: migrationKind(root), FromVersion: version, ToVersion: CurrentConfigVersion, Output: data}
+	for _, m := range migrations {
+		if m.From < version {
+			continue
+		}
+		// Each migration sees the kind as the ones before it left it.
+		result.Kind = migrationKind(root)
+		if m.Kinds != nil && !slices.Contains(m.Kinds, result.Kind) {
+			continue
+		}
+		for _, change := range m.Apply(root) {
+			result.Changes = append(result.Changes, m.Name+": "+change)
+		}
+	}
+	if version < CurrentConfigVersion {
+		setVersion(root, CurrentConfigVersion)
+	}
+
+	if !result.Changed() {
+		return result, nil
+	}
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	result.Output = out.Bytes()
+	return result, nil
+}
+
+// configVersion is the file's version:, 0 when it has none.
+func configVersion(root *yaml.Node) (int, error) {
+	n := lookupNode(root, "version")
+	if n == nil {
+		return 0, nil
+	}
+	v, err := strconv.Atoi(n.Value)
+	if err != nil || n.Kind != yaml.ScalarNode || v < 0 {
+		return 0, fmt.Errorf("line %d: version must be a non-negative integer, got %q", n.Line, n.Value)
+	}
+	return v, nil
+}
+
+// setVersion sets version:, adding it as the first key when missing so it
+// heads the file as the templates write it.
+func setVersion(root *yaml.Node, version int) {
+	value := strconv.Itoa(version)
+	if n := lookupNode(root, "version"); n != nil {
+		n.Value, n.Tag, n.Style = value, "!!int", 0
+		return
+	}
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "version"}
+	val := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: value}
+	// A comment heading the file stays at the top, above the new key.
+	if len(root.Content) > 0 && root.Content[0].HeadComment != "" {
+		key.HeadComment, root.Content[0].HeadComment = root.Content[0].HeadComment, ""
+	}
+	root.Content = append([]*yaml.Node{key, val}, root.Content...)
+}
+
+// versionedConfig reports whether root is a file that carries version:: one
+// that declares its kind or version, or has the repositories, workspaces or
+// profiles key the loaders tell those kinds by. Profile files and the global
+// config have none of them.
+func versionedConfig(root *yaml.Node) bool {
+	for _, key := range []string{"version", "kind", "repositories", "workspaces", "profiles"} {
+		if lookupNode(root, key) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// migrationKind is the kind a file declares or, without kind:, the one its
+// keys imply, as the loaders infer it.
+func migrationKind(root *yaml.Node) ConfigKind {
+	if kind, ok := leafValue(lookupNode(root, "kind")); ok && kind != "" {
+		return ConfigKind(kind)
+	}
+	if lookupNode(root, "workspaces") != nil || lookupNode(root, "profiles") != nil {
+		return KindWorkspace
+	}
+	return KindRepositories
+}
+
+// kindAliases are the kind: spellings earlier releases accepted.
+var kindAliases = map[string]string{
+	"workspaces": string(KindWorkspace),
+	"repository": string(KindRepositories),
+}
+
+func migrateKindAliases(root *yaml.Node) []string {
+	n := lookupNode(root, "kind")
+	if n == nil || n.Kind != yaml.ScalarNode {
+		return nil
+	}
+	replacement, ok := kindAliases[n.Value]
+	if !ok {
+		return nil
+	}
+	change := fmt.Sprintf("kind: %s → %s", n.Value, replacement)
+	n.Value = replacement
+	return []string{change}
+}
+
+// migrateTargetPath renames the targetPath: of repository entries, which the
+// loader no longer reads, to path:. An entry that has both keeps path:.
+func migrateTargetPath(root *yaml.Node) []string {
+	repos := lookupNode(root, "repositories")
+	if repos == nil || repos.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var changes []string
+	for i, entry := range repos.Content {
+		entry = lookupNode(entry)
+		if entry.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 0; j+1 < len(entry.Content); j += 2 {
+			key := entry.Content[j]
+			if key.Value != "targetPath" {
+				continue
+			}
+			if lookupNode(entry, "path") != nil {
+				entry.Content = slices.Delete(entry.Content, j, j+2)
+				changes = append(changes, fmt.Sprintf("repositories[%d]: dropped targetPath, path is set", i))
+			} else {
+				key.Value = "path"
+				changes = append(changes, fmt.Sprintf("repositories[%d]: targetPath → path", i))
+			}
+			break
+		}
+	}
+	return changes
+}
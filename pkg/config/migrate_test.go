@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfigMigrations_Ordered(t *testing.T) {
+	last := 0
+	for _, m := range ConfigMigrations() {
+		if m.From < last || m.From >= CurrentConfigVersion {
+			t.Errorf("migration %s: from %d out of order or past version %d", m.Name, m.From, CurrentConfigVersion)
+		}
+		last = m.From
+		if m.Name == "" || m.Apply == nil {
+			t.Errorf("migration %+v is incomplete", m)
+		}
+	}
+}
+
+func TestMigrateConfig_Repositories(t *testing.T) {
+	in := `# Team devbox
+kind: repository
+strategy: pull # keep local work
+repositories:
+  # the API
+  - url: https://github.com/org/api.git
+    targetPath: ./services/api
+  - url: https://github.com/org/web.git
+    path: ./web
+    targetPath: ./old-web
+`
+	res, err := MigrateConfig([]byte(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.FromVersion != 0 || res.ToVersion != CurrentConfigVersion || res.Kind != KindRepositories {
+		t.Errorf("result = %+v", res)
+	}
+	want := []string{
+		"kind-aliases: kind: repository → repositories",
+		"repository-target-path: repositories[0]: targetPath → path",
+		"repository-target-path: repositories[1]: dropped targetPath, path is set",
+	}
+	if strings.Join(res.Changes, "\n") != strings.Join(want, "\n") {
+		t.Errorf("changes = %q", res.Changes)
+	}
+
+	out := string(res.Output)
+	wantOut := `# Team devbox
+version: 1
+kind: repositories
+strategy: pull # keep local work
+repositories:
+  # the API
+  - url: https://github.com/org/api.git
+    path: ./services/api
+  - url: https://github.com/org/web.git
+    path: ./web
+`
+	if out != wantOut {
+		t.Errorf("output:\n%s\nwant:\n%s", out, wantOut)
+	}
+
+	// A migrated file has nothing left to migrate.
+	again, err := MigrateConfig(res.Output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Changed() || string(again.Output) != out {
+		t.Errorf("second migration changed %q", again.Changes)
+	}
+}
+
+func TestMigrateConfig_Kinds(t *testing.T) {
+	// targetPath only means something in a repositories file.
+	res, err := MigrateConfig([]byte("kind: workspaces\nworkspaces:\n  a:\n    path: ./a\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Kind != KindWorkspace || len(res.Changes) != 1 {
+		t.Errorf("result = %+v", res)
+	}
+
+	// Without a version, only version: is added.
+	res, err = MigrateConfig([]byte("kind: workspace\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Changed() || len(res.Changes) != 0 || string(res.Output) != "version: 1\nkind: workspace\n" {
+		t.Errorf("result = %+v, output %q", res, res.Output)
+	}
+
+	res, err = MigrateConfig([]byte("version: 1\nkind: groups\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Changed() {
+		t.Errorf("a current file should not be migrated: %q", res.Changes)
+	}
+
+	// kind: group was never a spelling gz-git accepted; it is not rewritten.
+	res, err = MigrateConfig([]byte("version: 1\nkind: group\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Changed() {
+		t.Errorf("kind: group should be left alone: %q", res.Changes)
+	}
+}
+
+func TestMigrateConfig_LeavesUnversionedFilesAlone(t *testing.T) {
+	for name, in := range map[string]string{
+		"profile": "name: work\nprovider: gitlab\nbaseURL: https://gitlab.example.com\ntoken: ${GITLAB_TOKEN}\n",
+		"global":  "activeProfile: work\ntokenStore: file\ncredentials:\n  - host: github.com\n",
+	} {
+		res, err := MigrateConfig([]byte(in))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if res.Kind != "" || res.Changed() || string(res.Output) != in {
+			t.Errorf("%s: result = %+v, output %q; want the file left alone", name, res, res.Output)
+		}
+	}
+}
+
+func TestMigrateConfig_Errors(t *testing.T) {
+	for in, want := range map[string]string{
+		"version: 2\n":      "newer than this gz-git supports",
+		"version: one\n":    "non-negative integer",
+		"- a\n- b\n":        "not a YAML mapping",
+		"kind: [repository": "failed to parse",
+	} {
+		if _, err := MigrateConfig([]byte(in)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: err = %v, want %q", in, err, want)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+)
+
+// Recipe is a named sequence of gz-git commands, run by 'gz-git run'.
+//
+// Example (.gz-git.yaml or a profile):
+//
+//	recipes:
+//	  morning:
+//	    description: Catch up with every repository
+//	    vars:
+//	      depth: "3"
+//	      include: ""            # required: --var include=...
+//	    steps:
+//	      - name: fetch
+//	        run: fetch -d {{ .depth }} --include {{ .include }} --format compact
+//	      - run: pull -d {{ .depth }} --include {{ .include }}
+//	      - run: status -d {{ .depth }}
+//	        if: always
+//	      - run: push -d {{ .depth }}
+//	        if: fetch.success
+//	        confirm: Push local commits?
+type Recipe struct {
+	// Description is shown by 'gz-git run' without arguments.
+	Description string `yaml:"description,omitempty"`
+
+	// Vars declares the variables steps can use as {{ .name }}, with their
+	// defaults; an empty default makes one required.
+	Vars map[string]string `yaml:"vars,omitempty"`
+
+	// Steps run in order.
+	Steps []RecipeStep `yaml:"steps"`
+}
+
+// RecipeStep is one gz-git command of a recipe.
+type RecipeStep struct {
+	// Name identifies the step in the summary and in if: conditions;
+	// defaults to the command (the first word of run).
+	Name string `yaml:"name,omitempty"`
+
+	// Run is the gz-git command line, without "gz-git", e.g.
+	// "fetch -d 3 --format compact". Words are split as a shell would
+	// (quotes group them) and each word is a Go template over the vars.
+	Run string `yaml:"run"`
+
+	// If decides whether the step runs: success (default: no step has
+	// failed so far), failure (one has), always, or <step>.success and
+	// <step>.failure for an earlier named step.
+	If string `yaml:"if,omitempty"`
+
+	// Confirm is a question asked before the step runs; answering no stops
+	// the recipe.
+	Confirm string `yaml:"confirm,omitempty"`
+
+	// ContinueOnError keeps a failure of this step from failing the recipe
+	// and from skipping the steps after it.
+	ContinueOnError bool `yaml:"continueOnError,omitempty"`
+}
+
+// Recipe step conditions.
+const (
+	RecipeIfSuccess = "success"
+	RecipeIfFailure = "failure"
+	RecipeIfAlways  = "always"
+)
+
+// NamedRecipe is a recipe with its name and where it is defined.
+type NamedRecipe struct {
+	Name   string
+	Source ConfigSource
+	*Recipe
+}
+
+// PlannedStep is a recipe step with its variables filled in.
+type PlannedStep struct {
+	RecipeStep
+	// Args are the gz-git arguments.
+	Args []string
+}
+
+// Validate checks a recipe's steps and conditions.
+func (r *Recipe) Validate() error {
+	if r == nil || len(r.Steps) == 0 {
+		return fmt.Errorf("no steps")
+	}
+	names := map[string]bool{}
+	for i, s := range r.Steps {
+		label := fmt.Sprintf("steps[%d]", i)
+		if s.Name != "" {
+			label = s.Name
+		}
+		if strings.TrimSpace(s.Run) == "" {
+			return fmt.Errorf("%s: run is empty", label)
+		}
+		if _, err := splitRecipeLine(s.Run); err != nil {
+			return fmt.Errorf("%s: %w", label, err)
+		}
+		if err := validateRecipeIf(s.If, names); err != nil {
+			return fmt.Errorf("%s: %w", label, err)
+		}
+		if s.Name != "" {
+			if names[s.Name] {
+				return fmt.Errorf("step name %q is used twice", s.Name)
+			}
+			names[s.Name] = true
+		}
+	}
+	return nil
+}
+
+func validateRecipeIf(cond string, earlier map[string]bool) error {
+	switch cond {
+	case "", RecipeIfSuccess, RecipeIfFailure, RecipeIfAlways:
+		return nil
+	}
+	step, outcome, ok := strings.Cut(cond, ".")
+	if !ok || (outcome != RecipeIfSuccess && outcome != RecipeIfFailure) {
+		return fmt.Errorf("invalid if %q (want success, failure, always, <step>.success or <step>.failure)", cond)
+	}
+	if !earlier[step] {
+		return fmt.Errorf("if %q: no earlier step is named %q", cond, step)
+	}
+	return nil
+}
+
+// Plan fills the variables into every step. vars override the recipe's
+// defaults; each must be declared, and every required one given.
+func (r *Recipe) Plan(vars map[string]string) ([]PlannedStep, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	data := maps.Clone(r.Vars)
+	if data == nil {
+		data = map[string]string{}
+	}
+	for k, v := range vars {
+		if _, ok := r.Vars[k]; !ok {
+			return nil, fmt.Errorf("unknown variable %q (declared: %s)", k, declaredVars(r.Vars))
+		}
+		data[k] = v
+	}
+	var missing []string
+	for k, v := range data {
+		if v == "" {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("missing required variables: %s (set them with --var name=value)", strings.Join(missing, ", "))
+	}
+
+	steps := make([]PlannedStep, len(r.Steps))
+	for i, s := range r.Steps {
+		words, _ := splitRecipeLine(s.Run)
+		if len(words) > 0 && words[0] == "gz-git" {
+			words = words[1:]
+		}
+		args := make([]string, 0, len(words))
+		for _, w := range words {
+			arg, err := evalTemplate(fmt.Sprintf("steps[%d].run", i), w, data)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+		}
+		if len(args) == 0 {
+			return nil, fmt.Errorf("steps[%d]: run has no command", i)
+		}
+		steps[i] = PlannedStep{RecipeStep: s, Args: args}
+		if steps[i].Name == "" {
+			steps[i].Name = args[0]
+		}
+	}
+	return steps, nil
+}
+
+func declaredVars(vars map[string]string) string {
+	if len(vars) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(vars))
+	for k := range vars {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// splitRecipeLine splits a command line into words as a POSIX shell would,
+// without expansions: single and double quotes group words, a backslash
+// escapes the next character outside single quotes. A {{ ... }} template
+// action is kept whole, spaces and all.
+func splitRecipeLine(line string) ([]string, error) {
+	var (
+		words   []string
+		cur     strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case escaped:
+			cur.WriteRune(c)
+			escaped = false
+		case c == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote == 0 && c == '{' && i+1 < len(runes) && runes[i+1] == '{':
+			end := strings.Index(string(runes[i:]), "}}")
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed {{ in %q", line)
+			}
+			action := string(runes[i:])[:end+2]
+			cur.WriteString(action)
+			i += len([]rune(action)) - 1
+			inWord = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(c)
+			}
+		case c == '\'' || c == '"':
+			quote, inWord = c, true
+		case c == ' ' || c == '\t' || c == '\n':
+			if inWord {
+				words = append(words, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		default:
+			cur.WriteRune(c)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unclosed %c quote in %q", quote, line)
+	}
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash in %q", line)
+	}
+	if inWord {
+		words = append(words, cur.String())
+	}
+	return words, nil
+}
+
+// Recipes returns the recipes of the active profile and the project config,
+// sorted by name. A project recipe replaces a profile recipe of the same
+// name.
+func (l *ConfigLoader) Recipes() []NamedRecipe {
+	byName := map[string]NamedRecipe{}
+	if l.activeProfile != nil {
+		for name, r := range l.activeProfile.Recipes {
+			byName[name] = NamedRecipe{Name: name, Source: SourceProfile, Recipe: r}
+		}
+	}
+	if l.projectConfig != nil {
+		for name, r := range l.projectConfig.Recipes {
+			byName[name] = NamedRecipe{Name: name, Source: SourceProject, Recipe: r}
+		}
+	}
+
+	recipes := make([]NamedRecipe, 0, len(byName))
+	for _, r := range byName {
+		if r.Recipe != nil {
+			recipes = append(recipes, r)
+		}
+	}
+	sort.Slice(recipes, func(i, j int) bool { return recipes[i].Name < recipes[j].Name })
+	return recipes
+}
+
+func validateRecipes(recipes map[string]*Recipe) error {
+	names := make([]string, 0, len(recipes))
+	for name := range recipes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !validProfileName.MatchString(name) {
+			return fmt.Errorf("invalid recipe name '%s': must contain only alphanumeric, dash, or underscore", name)
+		}
+		if err := recipes[name].Validate(); err != nil {
+			return fmt.Errorf("recipe %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitRecipeLine(t *testing.T) {
+	for line, want := range map[string]string{
+		`fetch -d 3 --format compact`:                  "fetch|-d|3|--format|compact",
+		`  exec 'git log -1'   --include "a b" `:       "exec|git log -1|--include|a b",
+		`commit -m it\'s`:                              "commit|-m|it's",
+		`fetch --include {{ .include | printf "%s" }}`: `fetch|--include|{{ .include | printf "%s" }}`,
+		`status ""`: "status|",
+	} {
+		words, err := splitRecipeLine(line)
+		if err != nil {
+			t.Errorf("splitRecipeLine(%q): %v", line, err)
+			continue
+		}
+		if got := strings.Join(words, "|"); got != want {
+			t.Errorf("splitRecipeLine(%q) = %q, want %q", line, got, want)
+		}
+	}
+	for line, want := range map[string]string{
+		`exec 'git log`:      "unclosed ' quote",
+		`fetch \`:            "trailing backslash",
+		`fetch -d {{ .depth`: "unclosed {{",
+	} {
+		if _, err := splitRecipeLine(line); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("splitRecipeLine(%q) err = %v, want %q", line, err, want)
+		}
+	}
+}
+
+func TestRecipeValidate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		recipe *Recipe
+		want   string
+	}{
+		"no steps":   {&Recipe{}, "no steps"},
+		"empty run":  {&Recipe{Steps: []RecipeStep{{Name: "x"}}}, "x: run is empty"},
+		"bad if":     {&Recipe{Steps: []RecipeStep{{Run: "fetch", If: "sometimes"}}}, `invalid if "sometimes"`},
+		"later step": {&Recipe{Steps: []RecipeStep{{Run: "fetch", If: "pull.success"}, {Name: "pull", Run: "pull"}}}, `no earlier step is named "pull"`},
+		"duplicate":  {&Recipe{Steps: []RecipeStep{{Name: "a", Run: "fetch"}, {Name: "a", Run: "pull"}}}, `step name "a" is used twice`},
+	} {
+		if err := tc.recipe.Validate(); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want %q", name, err, tc.want)
+		}
+	}
+
+	ok := &Recipe{Steps: []RecipeStep{
+		{Name: "fetch", Run: "fetch"},
+		{Run: "push", If: "fetch.failure"},
+		{Run: "status", If: RecipeIfAlways},
+	}}
+	if err := ok.Validate(); err != nil {
+		t.Errorf("valid recipe: %v", err)
+	}
+}
+
+func TestRecipePlan(t *testing.T) {
+	r := &Recipe{
+		Vars: map[string]string{"depth": "3", "include": ""},
+		Steps: []RecipeStep{
+			{Name: "catch-up", Run: "gz-git fetch -d {{ .depth }} --include '{{ .include }}' --format compact"},
+			{Run: "status -d {{ .depth }}", If: RecipeIfAlways},
+		},
+	}
+
+	steps, err := r.Plan(map[string]string{"include": "api|web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(steps[0].Args, " "); got != "fetch -d 3 --include api|web --format compact" {
+		t.Errorf("step 1 args = %q", got)
+	}
+	if steps[0].Name != "catch-up" || steps[1].Name != "status" || steps[1].If != RecipeIfAlways {
+		t.Errorf("steps = %+v", steps)
+	}
+
+	if steps, err := r.Plan(map[string]string{"include": "api", "depth": "1"}); err != nil || steps[1].Args[2] != "1" {
+		t.Errorf("override: steps = %+v, err = %v", steps, err)
+	}
+	if _, err := r.Plan(nil); err == nil || !strings.Contains(err.Error(), "missing required variables: include") {
+		t.Errorf("missing var: err = %v", err)
+	}
+	if _, err := r.Plan(map[string]string{"include": "x", "dpeth": "1"}); err == nil || !strings.Contains(err.Error(), `unknown variable "dpeth" (declared: depth, include)`) {
+		t.Errorf("unknown var: err = %v", err)
+	}
+	bad := &Recipe{Steps: []RecipeStep{{Run: "fetch -d {{ .depth }}"}}}
+	if _, err := bad.Plan(nil); err == nil {
+		t.Error("expected an undeclared template variable to be rejected")
+	}
+}
+
+func TestLoaderRecipes(t *testing.T) {
+	shared := &Recipe{Steps: []RecipeStep{{Run: "fetch"}}}
+	l := &ConfigLoader{
+		activeProfile: &Profile{Recipes: map[string]*Recipe{
+			"morning": shared,
+			"release": {Steps: []RecipeStep{{Run: "push"}}},
+		}},
+		projectConfig: &ProjectConfig{Recipes: map[string]*Recipe{
+			"morning": {Description: "project", Steps: []RecipeStep{{Run: "pull"}}},
+		}},
+	}
+
+	recipes := l.Recipes()
+	if len(recipes) != 2 {
+		t.Fatalf("recipes = %+v", recipes)
+	}
+	if recipes[0].Name != "morning" || recipes[0].Source != SourceProject || recipes[0].Description != "project" {
+		t.Errorf("morning = %+v", recipes[0])
+	}
+	if recipes[1].Name != "release" || recipes[1].Source != SourceProfile {
+		t.Errorf("release = %+v", recipes[1])
+	}
+}
+
+func TestValidateRecipes(t *testing.T) {
+	if err := validateRecipes(map[string]*Recipe{"bad name": {Steps: []RecipeStep{{Run: "fetch"}}}}); err == nil || !strings.Contains(err.Error(), "invalid recipe name") {
+		t.Errorf("bad name: err = %v", err)
+	}
+	if err := validateRecipes(map[string]*Recipe{"empty": {}}); err == nil || !strings.Contains(err.Error(), "recipe empty: no steps") {
+		t.Errorf("empty recipe: err = %v", err)
+	}
+}
@@ -77,6 +77,15 @@ func loadConfigRecursiveWithVisited(path, configFile string, visited map[string]
 	// Store the config path for relative path resolution
 	config.ConfigPath = absConfigPath
 
+	// Merge included files, then build workspaces from their templates and
+	// evaluate templated paths and URLs
+	if err := applyIncludes(&config, []string{absConfigPath}, depth, false); err != nil {
+		return nil, err
+	}
+	if err := applyWorkspaceTemplates(&config); err != nil {
+		return nil, fmt.Errorf("%s: %w", absConfigPath, err)
+	}
+
 	// Expand environment variables
 	validator := NewValidator()
 	if err := validator.ExpandEnvVarsInConfig(&config); err != nil {
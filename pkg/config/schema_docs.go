@@ -0,0 +1,217 @@
+// Code generated by schema_docs_gen.go; DO NOT EDIT.
+
+package config
+
+// schemaTypeDocs describes each config type, keyed by package.Type.
+var schemaTypeDocs = map[string]string{
+	"branch.Naming":             "Naming holds one branch-name template per kind. An empty template falls back to the default for that kind, so a config may override one and leave the rest.",
+	"config.AuditConfig":        "AuditConfig holds `info --audit` defaults.",
+	"config.BranchConfig":       "BranchConfig holds branch command defaults. Supports both string shorthand and struct format in YAML.",
+	"config.CloneDefaults":      "CloneDefaults holds clone-related default settings.",
+	"config.Config":             "Config represents a hierarchical configuration that can be nested recursively. This is the unified config type used at ALL levels: workstation, workspace, project, submodule, etc.",
+	"config.ConfigMeta":         "ConfigMeta holds common metadata for all config file types. This should be at the top of every config file.",
+	"config.Credential":         "Credential routes one forge host, optionally narrowed to a single organization on that host, to a token. A machine that talks to github.com, two GitHub Enterprise servers, and a self-hosted GitLab lists one entry per host; a user with a personal and a work account on the same host adds an org-scoped entry for the work organization.",
+	"config.DaemonConfig":       "DaemonConfig configures `gz-git daemon`. It lives in the global config because the daemon is one per user, not one per project.",
+	"config.DefaultsConfig":     "DefaultsConfig groups all default settings for clarity. These settings apply globally unless overridden at workspace level.",
+	"config.DiscoveryConfig":    "DiscoveryConfig controls how children are discovered.",
+	"config.Environment":        "Environment represents a named set of API tokens.",
+	"config.FetchConfig":        "FetchConfig holds fetch command defaults.",
+	"config.FilterDefaults":     "FilterDefaults holds filter pattern settings.",
+	"config.ForgeSource":        "ForgeSource defines a forge (GitLab/GitHub/Gitea) to sync repositories from.",
+	"config.GlobalConfig":       "GlobalConfig represents ~/.config/gz-git/config.yaml",
+	"config.Hooks":              "Hooks represents before/after hook commands for sync operations. Hooks are executed without shell interpretation for security (no pipes, redirects, etc.).",
+	"config.Metadata":           "Metadata holds optional information about a config level.",
+	"config.OutputDefaults":     "OutputDefaults holds output-related default settings.",
+	"config.Profile":            "Profile represents a named configuration profile. A profile contains default values for command flags, eliminating the need to repeatedly specify the same options.",
+	"config.ProjectConfig":      "ProjectConfig represents .gz-git.yaml in a project directory. This file is auto-detected by walking up the directory tree.",
+	"config.ProjectMetadata":    "ProjectMetadata holds optional project information.",
+	"config.PullConfig":         "PullConfig holds pull command defaults.",
+	"config.PushConfig":         "PushConfig holds push command defaults.",
+	"config.Recipe":             "Recipe is a named sequence of gz-git commands, run by 'gz-git run'.",
+	"config.RecipeStep":         "RecipeStep is one gz-git command of a recipe.",
+	"config.RepositoriesConfig": "RepositoriesConfig is the flat `kind: repositories` layout that `workspace sync` reads: one list of repositories and the sync settings that apply to all of them.",
+	"config.RepositoryEntry":    "RepositoryEntry is one repository of a RepositoriesConfig.",
+	"config.ScanDefaults":       "ScanDefaults holds scan-related default settings.",
+	"config.SelfSyncConfig":     "SelfSyncConfig controls sync behavior for the config directory itself. This allows the devbox/orchestrator directory to be synced along with workspaces.",
+	"config.SyncConfig":         "SyncConfig holds sync command defaults.",
+	"config.SyncDefaults":       "SyncDefaults holds sync-related default settings.",
+	"config.WatchConfig":        "WatchConfig holds `watch` settings for a project.",
+	"config.Workspace":          "Workspace represents a named workspace in the hierarchy. Each workspace can sync from a forge source or manage existing git repos.",
+	"identity.Identity":         "Identity is who made a commit, beyond the git author.",
+	"repository.PushPolicy":     "PushPolicy restricts which branches a push may write and how.",
+	"watch.SinkConfig":          "SinkConfig declares one sink in the watch section of .gz-git.yaml.",
+}
+
+// schemaFieldDocs describes each config field, keyed by package.Type.Field.
+var schemaFieldDocs = map[string]string{
+	"config.AuditConfig.Autofix":               "Autofix overrides repository.DefaultAutofixPolicy per finding code. Keys are finding codes (BRANCH_BEHIND_BASE, …); a code that is absent keeps its built-in default, so a project states only where it disagrees.",
+	"config.BranchConfig.DefaultBranch":        "main, develop, master (string or list)",
+	"config.BranchConfig.IntegrationBranch":    "IntegrationBranch is the ordered list of integration-branch names. Consumers must read it from the repo-root file (LoadRepoRootTaskPattern), not from the 5-layer merger.",
+	"config.BranchConfig.Naming":               "Naming templates the branch names that `gz-git branch name` builds, so a task branch is spelled the same way on every machine and by every agent.",
+	"config.BranchConfig.ProtectedBranches":    "Branches to protect",
+	"config.BranchConfig.TaskPattern":          "TaskPattern is the reclaim allow-list (first-* namespace prefix). Load it only via LoadRepoRootTaskPattern — never findConfigUpward.",
+	"config.CloneDefaults.Proto":               "ssh, https",
+	"config.CloneDefaults.SSHKeyContent":       "SSH private key content (use ${ENV_VAR} or ${file:...})",
+	"config.CloneDefaults.SSHKeyPath":          "SSH private key file path",
+	"config.CloneDefaults.SSHPort":             "Custom SSH port",
+	"config.Config.BaseURL":                    "API endpoint",
+	"config.Config.ChildConfigMode":            "ChildConfigMode sets the default child config mode for all workspaces. Values: \"repositories\" (default), \"workspaces\", \"none\"",
+	"config.Config.DefaultWorkspaceType":       "forge/git/config",
+	"config.Config.Defaults":                   "Defaults groups all default settings",
+	"config.Config.Discovery":                  "Discovery controls how workspaces are discovered",
+	"config.Config.Groups":                     "Groups names sets of repositories for --group. Each member is a selector such as name=api or label=backend,!archived; a repository matching any member is in the group.",
+	"config.Config.Hooks":                      "Hooks defines global before/after commands for all workspace syncs",
+	"config.Config.Include":                    "Include lists config files merged into this one, for settings, profiles, workspaces and workspace templates shared between configs. Each entry is a local path (relative to this file) or a git repository pinned to a ref: \"<url>@<ref>\" for its .gz-git.yaml, \"<url>@<ref>//<file>\" for another file. This file overrides what it includes; later includes override earlier ones. A git include brings no hooks or recipes, and may not use secret references other than ${VAR} and ${env:VAR}.",
+	"config.Config.IncludeSubgroups":           "GitLab subgroups",
+	"config.Config.Metadata":                   "Metadata is optional information about this level",
+	"config.Config.Parent":                     "Parent specifies an explicit path to a parent config file. When set, the parent config is loaded and merged (child overrides parent). Supports: absolute paths, home-relative (~), relative paths.",
+	"config.Config.Profile":                    "Profile specifies which profile to use at this level",
+	"config.Config.Profiles":                   "Profiles defines named profiles inline (no external file needed)",
+	"config.Config.Provider":                   "github, gitlab, gitea",
+	"config.Config.SelfSync":                   "Self-sync configuration (sync config directory itself)",
+	"config.Config.SubgroupMode":               "flat, nested",
+	"config.Config.Sync":                       "Command-specific overrides",
+	"config.Config.Token":                      "API token (use ${ENV_VAR} or ${keyring:...})",
+	"config.Config.WorkspaceTemplates":         "WorkspaceTemplates defines reusable workspace entries. A workspace names one with template: and fills in its params; the template's params map declares them, with their defaults (an empty default makes one required).",
+	"config.Config.Workspaces":                 "Workspaces is a map of named workspace configurations",
+	"config.ConfigMeta.Kind":                   "Kind specifies the config type: \"repositories\" or \"workspace\". If omitted, inferred from content.",
+	"config.ConfigMeta.Metadata":               "Metadata holds optional descriptive information",
+	"config.ConfigMeta.Version":                "Version is the schema version (currently 1)",
+	"config.Credential.BaseURL":                "BaseURL overrides the API base for hosts that do not serve it at https://<host>.",
+	"config.Credential.Host":                   "Host is the forge hostname as it appears in remote URLs.",
+	"config.Credential.Org":                    "Org narrows the entry to repositories under one top-level namespace.",
+	"config.Credential.Provider":               "Provider is github, gitlab, or gitea. When empty it is inferred from the hostname by the same rules remote parsing uses.",
+	"config.Credential.Token":                  "Token may use ${VAR} expansion or a secret reference such as ${keyring:...} or ${cmd:...}. Empty means \"look in the keychain\".",
+	"config.DaemonConfig.Depth":                "Depth is how far below each root to look for repositories, as --scan-depth. Zero means the daemon command's default.",
+	"config.DaemonConfig.FetchInterval":        "FetchInterval is how often the daemon fetches every remote in the background, e.g. \"10m\". \"0\" turns fetching off.",
+	"config.DaemonConfig.Roots":                "Roots are the directories whose repositories are indexed. A leading ~/ is the home directory.",
+	"config.DaemonConfig.Socket":               "Socket overrides the socket path for the daemon and for --via-daemon.",
+	"config.DefaultsConfig.Clone":              "Clone settings",
+	"config.DefaultsConfig.Filter":             "Filter settings",
+	"config.DefaultsConfig.Output":             "Output settings",
+	"config.DefaultsConfig.Scan":               "Scan settings",
+	"config.DefaultsConfig.Sync":               "Sync settings",
+	"config.DiscoveryConfig.Mode":              "Mode controls the discovery behavior. Values: \"explicit\" (use children only), \"auto\" (scan directories).",
+	"config.FetchConfig.AllRemotes":            "Fetch all remotes",
+	"config.FetchConfig.Prune":                 "Prune deleted branches",
+	"config.FilterDefaults.Exclude":            "Exclude repos matching these patterns",
+	"config.FilterDefaults.Include":            "Include repos matching these patterns",
+	"config.ForgeSource.BaseURL":               "BaseURL is the API endpoint (optional, uses default for provider)",
+	"config.ForgeSource.IncludeSubgroups":      "IncludeSubgroups includes subgroups (GitLab only)",
+	"config.ForgeSource.Org":                   "Org is the organization/group to sync from",
+	"config.ForgeSource.Provider":              "Provider is the forge type: gitlab, github, gitea",
+	"config.ForgeSource.SubgroupMode":          "SubgroupMode controls directory structure: \"flat\" or \"nested\"",
+	"config.ForgeSource.Token":                 "Token overrides the profile token (use ${ENV_VAR} or a secret reference such as ${keyring:...} for security)",
+	"config.GlobalConfig.ActiveProfile":        "ActiveProfile is the default profile to use",
+	"config.GlobalConfig.Credentials":          "Credentials route forge hosts (and optionally orgs on them) to tokens, so one machine can talk to several hosts of the same provider. An. Environment holds one token per provider and cannot express that.",
+	"config.GlobalConfig.Daemon":               "Daemon configures `gz-git daemon`: which roots it indexes and where its socket is.",
+	"config.GlobalConfig.Defaults":             "Defaults apply to all profiles unless overridden",
+	"config.GlobalConfig.Environments":         "Environments define named token sets",
+	"config.GlobalConfig.Identity":             "Identity names this machine and, if one is driving, the agent on it. It lives here rather than in a project's .gz-git.yaml because that file is committed, and a shared device name names nothing.",
+	"config.GlobalConfig.TokenStore":           "TokenStore selects where tokens set with `config token set` live: \"keyring\" (the OS keychain, default) or \"file\" (an encrypted file under the config directory, for machines without a keychain). The. GZ_GIT_TOKEN_STORE environment variable overrides it.",
+	"config.Hooks.After":                       "Commands to run after sync operation",
+	"config.Hooks.Before":                      "Commands to run before sync operation",
+	"config.Metadata.Name":                     "workstation, mydevbox, project-name",
+	"config.Metadata.Owner":                    "archmagece, team-name",
+	"config.Metadata.Repository":               "https://...",
+	"config.Metadata.Team":                     "backend, frontend",
+	"config.Metadata.Type":                     "development, production, personal",
+	"config.OutputDefaults.Compact":            "Omit redundant fields in generated configs",
+	"config.OutputDefaults.Format":             "Output format (default, compact, json, llm)",
+	"config.Profile.BaseURL":                   "API endpoint",
+	"config.Profile.CloneProto":                "ssh, https",
+	"config.Profile.Identity":                  "Identity names the machine and agent recorded on automated commits.",
+	"config.Profile.IncludeSubgroups":          "GitLab subgroups",
+	"config.Profile.Name":                      "Name is the profile identifier (e.g., \"work\", \"personal\")",
+	"config.Profile.Parallel":                  "Parallel job count",
+	"config.Profile.Provider":                  "github, gitlab, gitea",
+	"config.Profile.Recipes":                   "Recipes are named command sequences for 'gz-git run'.",
+	"config.Profile.SSHKeyContent":             "SSH private key content (use ${ENV_VAR} or ${file:...})",
+	"config.Profile.SSHKeyPath":                "SSH private key file path (priority)",
+	"config.Profile.SSHPort":                   "Custom SSH port",
+	"config.Profile.SubgroupMode":              "flat, nested",
+	"config.Profile.Sync":                      "Command-specific overrides",
+	"config.Profile.Token":                     "API token (use ${ENV_VAR} or ${keyring:...})",
+	"config.ProjectConfig.Metadata":            "Metadata is optional project information",
+	"config.ProjectConfig.Profile":             "Profile specifies which profile to use for this project",
+	"config.ProjectConfig.Recipes":             "Recipes are named command sequences for 'gz-git run'; they replace profile recipes of the same name.",
+	"config.ProjectConfig.Sync":                "Command-specific overrides",
+	"config.PullConfig.FFOnly":                 "Fast-forward only",
+	"config.PullConfig.Rebase":                 "Use rebase instead of merge",
+	"config.PushConfig.Policy":                 "Policy restricts which branches push may write and how. Unset means no branch is protected and only the built-in lease-only force rule applies.",
+	"config.PushConfig.SetUpstream":            "Auto set upstream",
+	"config.Recipe.Description":                "Description is shown by 'gz-git run' without arguments.",
+	"config.Recipe.Steps":                      "Steps run in order.",
+	"config.Recipe.Vars":                       "Vars declares the variables steps can use as {{ .name }}, with their defaults; an empty default makes one required.",
+	"config.RecipeStep.Confirm":                "Confirm is a question asked before the step runs; answering no stops the recipe.",
+	"config.RecipeStep.ContinueOnError":        "ContinueOnError keeps a failure of this step from failing the recipe and from skipping the steps after it.",
+	"config.RecipeStep.If":                     "If decides whether the step runs: success (default: no step has failed so far), failure (one has), always, or <step>.success and <step>.failure for an earlier named step.",
+	"config.RecipeStep.Name":                   "Name identifies the step in the summary and in if: conditions; defaults to the command (the first word of run).",
+	"config.RecipeStep.Run":                    "Run is the gz-git command line, without \"gz-git\", e.g. \"fetch -d 3 --format compact\". Words are split as a shell would (quotes group them) and each word is a Go template over the vars.",
+	"config.RepositoriesConfig.BasePath":       "BasePath records the directory `workspace init` scanned. Repository paths are resolved against the config file's directory regardless.",
+	"config.RepositoriesConfig.Branch":         "default branch for every repository",
+	"config.RepositoriesConfig.CleanupOrphans": "Delete local repos not in the list",
+	"config.RepositoriesConfig.CloneProto":     "ssh, https",
+	"config.RepositoriesConfig.MaxRetries":     "Retry count",
+	"config.RepositoriesConfig.Parallel":       "Parallel workers",
+	"config.RepositoriesConfig.Roots":          "directories orphans are looked for in",
+	"config.RepositoriesConfig.SSHPort":        "Custom SSH port",
+	"config.RepositoriesConfig.Strategy":       "default sync strategy (default: reset)",
+	"config.RepositoryEntry.AdditionalRemotes": "extra git remotes (name: url)",
+	"config.RepositoryEntry.AssumePresent":     "skip the clone check",
+	"config.RepositoryEntry.Branch":            "overrides the top-level branch",
+	"config.RepositoryEntry.CloneProto":        "ssh, https",
+	"config.RepositoryEntry.Description":       "human-readable description",
+	"config.RepositoryEntry.Enabled":           "false excludes it from sync (default: true)",
+	"config.RepositoryEntry.Labels":            "labels for --select",
+	"config.RepositoryEntry.Name":              "defaults to the name in the URL",
+	"config.RepositoryEntry.Path":              "defaults to the name",
+	"config.RepositoryEntry.Strategy":          "overrides the top-level strategy",
+	"config.RepositoryEntry.URL":               "clone URL (required)",
+	"config.ScanDefaults.Depth":                "Default scan depth for bulk operations",
+	"config.SelfSyncConfig.Enabled":            "Enabled controls whether the config directory itself should be synced. Default: false (config directory is not synced)",
+	"config.SelfSyncConfig.Strategy":           "Strategy specifies how to sync the config directory. Values: \"fetch\" (default, safe), \"pull\" (with dirty check), \"skip\". Note: \"reset\" is not allowed for self-sync to prevent data loss.",
+	"config.SyncConfig.CleanupOrphans":         "Delete local repos not in forge",
+	"config.SyncConfig.MaxRetries":             "Retry count",
+	"config.SyncConfig.Recursive":              "Auto-sync child workspace repos",
+	"config.SyncConfig.Strategy":               "pull, reset, rebase, skip, clone",
+	"config.SyncConfig.Timeout":                "Operation timeout",
+	"config.SyncDefaults.MaxRetries":           "Retry count",
+	"config.SyncDefaults.Parallel":             "Parallel workers",
+	"config.SyncDefaults.Strategy":             "reset, pull, rebase, fetch, skip, clone",
+	"config.SyncDefaults.Timeout":              "Operation timeout",
+	"config.WatchConfig.AutoFastForward":       "AutoFastForward fast-forwards a clean branch when its upstream gains commits and it has none of its own. --auto-ff overrides it.",
+	"config.WatchConfig.FetchInterval":         "FetchInterval turns on background fetching (e.g. \"5m\"), so watch can report incoming, diverged, upstream-gone, and foreign-work events. Empty leaves it off; --fetch-interval overrides it.",
+	"config.WatchConfig.Sinks":                 "Sinks receive every event watch prints, in addition to the terminal. See watch.SinkConfig for the fields of each entry.",
+	"config.Workspace.AdditionalRemotes":       "AdditionalRemotes defines extra git remotes to configure after clone. Map of remote name to URL (e.g., {\"upstream\": \"https://github.com/original/repo.git\"})",
+	"config.Workspace.ChildConfigMode":         "ChildConfigMode controls how child config files are generated during sync. Values: \"repositories\" (default), \"workspaces\", \"none\"",
+	"config.Workspace.ConfigLink":              "ConfigLink specifies a config file to symlink into the workspace as .gz-git.yaml. Supports: absolute paths, home-relative (~/), relative (./), relative to parent config dir. The symlink is created at {workspace.Path}/.gz-git.yaml → {configLink}",
+	"config.Workspace.ExcludePatterns":         "Exclude repos matching these patterns",
+	"config.Workspace.Hooks":                   "Hooks defines before/after commands for this workspace sync. Before hooks run before clone/update, After hooks run after successful sync",
+	"config.Workspace.IncludePatterns":         "Include repos matching these patterns",
+	"config.Workspace.Labels":                  "Labels tag the workspace and every repository in it for --select.",
+	"config.Workspace.Params":                  "Params are the values path, url, configLink, additionalRemotes and source.org/baseURL can use as Go templates ({{ .org }}), next to {{ .name }}, the workspace name. In a template they declare its params.",
+	"config.Workspace.Path":                    "Path is the target directory for this workspace. Supports: absolute (/foo/bar), relative (./foo), home-relative (~/foo)",
+	"config.Workspace.Profile":                 "Profile overrides the parent profile for this workspace",
+	"config.Workspace.SSHKeyContent":           "SSH private key content",
+	"config.Workspace.SSHKeyPath":              "SSH private key file path",
+	"config.Workspace.Source":                  "Source defines the forge to sync from",
+	"config.Workspace.Template":                "Template names an entry of workspaceTemplates this workspace is built from; the workspace's own keys override the template's.",
+	"config.Workspace.Type":                    "Type specifies what kind of workspace this is. Values: \"forge\" (sync from forge), \"git\" (single repo), \"config\" (has nested config). Default: \"forge\" if Source is set, \"git\" otherwise",
+	"config.Workspace.URL":                     "URL is the git clone URL (required for type=git sync). Supports: HTTPS, SSH, git:// protocols",
+	"config.Workspace.Workspaces":              "Workspaces allows nested workspace definitions",
+	"identity.Identity.Agent":                  "Agent names the automation acting on this machine. Empty means a person is driving, so there is nothing to record.",
+	"identity.Identity.Device":                 "Device names the machine. Defaults to the hostname.",
+	"repository.PushPolicy.ForceMode":          "ForceMode decides which force pushes are allowed to every other branch.",
+	"repository.PushPolicy.ForeignWork":        "ForeignWork decides what happens to a force push that would discard commits signed by another machine or agent. Unset means block.",
+	"repository.PushPolicy.Protected":          "Protected lists branch names and trailing-* patterns that may not be pushed to at all, matching the pattern syntax used for deletion.",
+	"watch.SinkConfig.Command":                 "Command is the argv an exec sink runs for each event. It is not passed through a shell; the event JSON arrives on stdin, and the event type and repository path in GZ_GIT_WATCH_EVENT and GZ_GIT_WATCH_PATH.",
+	"watch.SinkConfig.Events":                  "Events restricts the sink to these event types. Empty means all.",
+	"watch.SinkConfig.Headers":                 "Headers are added to every webhook request.",
+	"watch.SinkConfig.Limit":                   "Limit and Per rate-limit the sink to Limit deliveries in any Per window (for example limit: 1, per: 30s). Events over the limit are dropped, not queued: a hook that rebuilds the project needs the latest change, not a backlog of every one. Zero Limit means unlimited.",
+	"watch.SinkConfig.Name":                    "Name labels the sink in warnings; it defaults to the type and target.",
+	"watch.SinkConfig.Path":                    "Path is the file a file sink appends NDJSON to. A relative path is relative to the directory `watch` was started in.",
+	"watch.SinkConfig.Timeout":                 "Timeout bounds one delivery (default 10s). Ignored by file sinks.",
+	"watch.SinkConfig.Type":                    "Type is exec, file, webhook, or notify.",
+	"watch.SinkConfig.URL":                     "URL is the endpoint a webhook sink POSTs the event JSON to.",
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+//go:build ignore
+
+// schema_docs_gen.go writes schema_docs.go: the doc comments of the types
+// config files are decoded into, which become the descriptions of the
+// generated JSON Schema. Run it through go generate after changing a
+// documented config field:
+//
+//	go generate ./pkg/config
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// sources are the package directories, relative to pkg/config, whose types
+// appear in config files.
+var sources = []string{".", "../branch", "../identity", "../repository", "../watch"}
+
+func main() {
+	types := map[string]string{}
+	fields := map[string]string{}
+	for _, dir := range sources {
+		if err := collect(dir, types, fields); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by schema_docs_gen.go; DO NOT EDIT.\n\n")
+	b.WriteString("package config\n\n")
+	b.WriteString("// schemaTypeDocs describes each config type, keyed by package.Type.\n")
+	writeMap(&b, "schemaTypeDocs", types)
+	b.WriteString("\n// schemaFieldDocs describes each config field, keyed by package.Type.Field.\n")
+	writeMap(&b, "schemaFieldDocs", fields)
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile("schema_docs.go", src, 0o644); err != nil { //nolint:gosec // generated source is world-readable
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// collect reads the structs of one package that have yaml-tagged fields.
+func collect(dir string, types, fields map[string]string) error {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", dir, err)
+	}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+					st, ok := ts.Type.(*ast.StructType)
+					if !ok || !ts.Name.IsExported() {
+						continue
+					}
+					typeKey := pkg.Name + "." + ts.Name.Name
+					tagged := false
+					for _, field := range st.Fields.List {
+						if !hasYAMLTag(field) {
+							continue
+						}
+						tagged = true
+						// A trailing comment is specific to the field; a comment
+						// above it may head a group of fields.
+						doc := summary(field.Comment)
+						if doc == "" {
+							doc = summary(field.Doc)
+						}
+						if doc == "" {
+							continue
+						}
+						for _, name := range field.Names {
+							fields[typeKey+"."+name.Name] = doc
+						}
+					}
+					if !tagged {
+						continue
+					}
+					doc := ts.Doc
+					if doc == nil {
+						doc = gen.Doc
+					}
+					if s := summary(doc); s != "" {
+						types[typeKey] = s
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func hasYAMLTag(field *ast.Field) bool {
+	if field.Tag == nil || len(field.Names) == 0 {
+		return false
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return false
+	}
+	name, _, _ := strings.Cut(reflect.StructTag(tag).Get("yaml"), ",")
+	return name != "" && name != "-"
+}
+
+// summary is the first paragraph of a comment, on one line. It stops at an
+// indented block (an example or a list), so the colon or comma that led into
+// the block becomes a full stop. Lines are sentences often enough without a
+// full stop that one is added before a line starting with a capital.
+func summary(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, line := range strings.Split(group.Text(), "\n") {
+		if strings.TrimSpace(line) == "" || line[0] == ' ' || line[0] == '\t' {
+			break
+		}
+		line = strings.TrimSpace(line)
+		if b.Len() > 0 {
+			if prev := b.String(); unicode.IsUpper(rune(line[0])) && !strings.ContainsAny(prev[len(prev)-1:], ".:;,!?") {
+				b.WriteByte('.')
+			}
+			b.WriteByte(' ')
+		}
+		b.WriteString(line)
+	}
+	s := b.String()
+	if strings.HasSuffix(s, ":") || strings.HasSuffix(s, ",") {
+		s = s[:len(s)-1] + "."
+	}
+	return s
+}
+
+func writeMap(b *bytes.Buffer, name string, m map[string]string) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fmt.Fprintf(b, "var %s = map[string]string{\n", name)
+	for _, k := range keys {
+		fmt.Fprintf(b, "\t%q: %q,\n", k, m[k])
+	}
+	b.WriteString("}\n")
+}
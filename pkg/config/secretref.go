@@ -0,0 +1,298 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Secret references let a config name where a token or key lives instead of
+// holding it: ${env:VAR}, ${file:~/.secrets/gitlab}, ${keyring:gitlab} (a key
+// of gz-git's token store) or ${cmd:op read op://dev/gitlab/token}. Only
+// ${VAR} and ${env:VAR} are expanded when a config loads; the rest stay as
+// written until ResolveSecret is called where the value is used, so a sync
+// only fetches the secrets of the workspaces it actually touches, and
+// `config show` prints the reference rather than the secret.
+
+// secretRefPattern matches ${scheme:argument}.
+var secretRefPattern = regexp.MustCompile(`\$\{([a-z][a-z0-9]*):([^}]*)\}`)
+
+// secretCommandTimeout bounds a ${cmd:...} password-manager call, which may
+// wait for an unlock prompt.
+const secretCommandTimeout = 2 * time.Minute
+
+// SecretResolver fetches the secret a reference names; arg is the text after
+// "scheme:".
+type SecretResolver func(ctx context.Context, arg string) (string, error)
+
+var (
+	secretMu        sync.Mutex
+	secretResolvers = map[string]SecretResolver{
+		"env":     resolveEnvSecret,
+		"file":    resolveFileSecret,
+		"keyring": resolveKeyringSecret,
+		"cmd":     resolveCommandSecret,
+	}
+	// secretCache holds resolved references, so each is fetched once per
+	// process, and feeds RedactSecrets.
+	secretCache = map[string]string{}
+)
+
+// RegisterSecretResolver adds a reference scheme, or replaces a built-in one.
+func RegisterSecretResolver(scheme string, r SecretResolver) error {
+	if !regexp.MustCompile(`^[a-z][a-z0-9]*$`).MatchString(scheme) {
+		return fmt.Errorf("invalid secret reference scheme %q: want lowercase letters and digits", scheme)
+	}
+	if r == nil {
+		return fmt.Errorf("secret reference scheme %q: resolver is nil", scheme)
+	}
+	secretMu.Lock()
+	defer secretMu.Unlock()
+	secretResolvers[scheme] = r
+	for ref := range secretCache {
+		if strings.HasPrefix(ref, "${"+scheme+":") {
+			delete(secretCache, ref)
+		}
+	}
+	return nil
+}
+
+// SecretSchemes lists the reference schemes ResolveSecret understands.
+func SecretSchemes() []string {
+	secretMu.Lock()
+	defer secretMu.Unlock()
+	schemes := make([]string, 0, len(secretResolvers))
+	for s := range secretResolvers {
+		schemes = append(schemes, s)
+	}
+	sort.Strings(schemes)
+	return schemes
+}
+
+// HasSecretRef reports whether value holds a reference that is resolved
+// only on use, anything but ${VAR} and ${env:VAR}.
+func HasSecretRef(value string) bool {
+	for _, m := range secretRefPattern.FindAllStringSubmatch(value, -1) {
+		if m[1] != "env" {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveSecret replaces every reference in value with the secret it names,
+// ${VAR} included. An empty environment variable resolves to "", as ${VAR}
+// always has; every other scheme that comes back empty is an error, since a
+// forge would otherwise see an anonymous request.
+func ResolveSecret(value string) (string, error) {
+	value = expandEnvRefs(value)
+	var firstErr error
+	resolved := secretRefPattern.ReplaceAllStringFunc(value, func(ref string) string {
+		if firstErr != nil {
+			return ref
+		}
+		secret, err := resolveRef(ref)
+		if err != nil {
+			firstErr = err
+		}
+		return secret
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+	return resolved, nil
+}
+
+func resolveRef(ref string) (string, error) {
+	m := secretRefPattern.FindStringSubmatch(ref)
+	scheme, arg := m[1], strings.TrimSpace(m[2])
+
+	secretMu.Lock()
+	if secret, ok := secretCache[ref]; ok {
+		secretMu.Unlock()
+		return secret, nil
+	}
+	resolver, ok := secretResolvers[scheme]
+	schemes := make([]string, 0, len(secretResolvers))
+	for s := range secretResolvers {
+		schemes = append(schemes, s)
+	}
+	secretMu.Unlock()
+	if !ok {
+		sort.Strings(schemes)
+		return "", fmt.Errorf("%s: unknown secret reference scheme %q (want one of: %s)", ref, scheme, strings.Join(schemes, ", "))
+	}
+
+	secret, err := resolver(context.Background(), arg)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", ref, err)
+	}
+	if secret == "" && scheme != "env" {
+		return "", fmt.Errorf("%s: resolved to an empty value", ref)
+	}
+
+	secretMu.Lock()
+	secretCache[ref] = secret
+	secretMu.Unlock()
+	return secret, nil
+}
+
+// RedactSecrets masks, in text bound for the terminal or a log, every secret
+// ResolveSecret has returned in this process.
+func RedactSecrets(text string) string {
+	secretMu.Lock()
+	secrets := make([]string, 0, len(secretCache))
+	for _, s := range secretCache {
+		if len(s) >= 4 {
+			secrets = append(secrets, s)
+		}
+	}
+	secretMu.Unlock()
+	// Longest first, so a secret containing another is masked whole.
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+	for _, s := range secrets {
+		text = strings.ReplaceAll(text, s, "***")
+	}
+	return text
+}
+
+// expandEnvRefs expands ${VAR} and ${env:VAR}, leaving other references.
+func expandEnvRefs(value string) string {
+	value = envVarPattern.ReplaceAllStringFunc(value, func(match string) string {
+		return os.Getenv(match[2 : len(match)-1])
+	})
+	return secretRefPattern.ReplaceAllStringFunc(value, func(ref string) string {
+		m := secretRefPattern.FindStringSubmatch(ref)
+		if m[1] != "env" {
+			return ref
+		}
+		return os.Getenv(strings.TrimSpace(m[2]))
+	})
+}
+
+func resolveEnvSecret(_ context.Context, name string) (string, error) {
+	return os.Getenv(name), nil
+}
+
+// resolveFileSecret reads a file. A one-line file loses its line ending; a
+// multi-line one (an SSH key) is kept as written.
+func resolveFileSecret(_ context.Context, path string) (string, error) {
+	path = expandHome(path)
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("file path must be absolute or start with ~/")
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- the user's config names the file holding their secret.
+	if err != nil {
+		return "", err
+	}
+	return trimSecret(string(data)), nil
+}
+
+// resolveKeyringSecret reads a key of the configured token store, as
+// `gz-git config token set` writes them: a provider (gitlab) or a
+// credential key (gitlab@gitlab.company.com).
+func resolveKeyringSecret(_ context.Context, key string) (string, error) {
+	token, err := DefaultTokenStore.Get(key)
+	if err != nil {
+		return "", err
+	}
+	if token == "" {
+		return "", fmt.Errorf("no token stored for %s in the %s", key, DescribeTokenStore(DefaultTokenStore))
+	}
+	return token, nil
+}
+
+// resolveCommandSecret runs a password-manager command and takes what it
+// prints. The command is split into arguments like a shell would split
+// words, but no shell runs it: pipes, variables and globs are plain text.
+func resolveCommandSecret(ctx context.Context, command string) (string, error) {
+	argv, err := splitArgv(command)
+	if err != nil {
+		return "", err
+	}
+	if len(argv) == 0 {
+		return "", errors.New("command is empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, secretCommandTimeout)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...) // #nosec G204 -- the user configured this command to print their secret; it runs without a shell.
+	cmd.Stdin = os.Stdin                                  // an unlock prompt may need the terminal
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s failed: %w: %s", argv[0], err, msg)
+		}
+		return "", fmt.Errorf("%s failed: %w", argv[0], err)
+	}
+	return trimSecret(stdout.String()), nil
+}
+
+// trimSecret drops the line ending of a one-line value. Multi-line values
+// keep theirs: OpenSSH rejects a private key without its final newline.
+func trimSecret(s string) string {
+	trimmed := strings.TrimRight(s, "\r\n")
+	if strings.Contains(trimmed, "\n") {
+		return s
+	}
+	return trimmed
+}
+
+// splitArgv splits a command line into words, honoring single and double
+// quotes and backslash escapes outside single quotes.
+func splitArgv(line string) ([]string, error) {
+	var (
+		args    []string
+		word    strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range line {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t':
+			if inWord {
+				args = append(args, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unterminated quote or escape in command %q", line)
+	}
+	if inWord {
+		args = append(args, word.String())
+	}
+	return args, nil
+}
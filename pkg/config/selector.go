@@ -0,0 +1,349 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+// Selector picks repositories by label, name, path or group. It is a
+// comma-separated list of terms that must all match:
+//
+//	backend          labelled backend, or named backend
+//	label=backend    labelled backend
+//	name=api         named api (a glob: name=api-*)
+//	path=libs/*      at a path, relative to the config's directory (a glob)
+//	group=core       in the group core
+//	!archived        negates any term; label!=archived is the same as !label=archived
+type Selector struct {
+	expr  string
+	terms []selectorTerm
+}
+
+type selectorTerm struct {
+	negate bool
+	key    string // label, name, path, group, or "" for a bare word
+	value  string
+}
+
+// ParseSelector parses a selector expression.
+func ParseSelector(expr string) (*Selector, error) {
+	sel := &Selector{expr: expr}
+	for _, raw := range strings.Split(expr, ",") {
+		term := strings.TrimSpace(raw)
+		if term == "" {
+			return nil, fmt.Errorf("invalid selector %q: empty term", expr)
+		}
+
+		var t selectorTerm
+		if rest, ok := strings.CutPrefix(term, "!"); ok {
+			t.negate, term = true, strings.TrimSpace(rest)
+		}
+		if key, value, ok := strings.Cut(term, "="); ok {
+			if k, negated := strings.CutSuffix(key, "!"); negated {
+				key, t.negate = k, !t.negate
+			}
+			t.key, t.value = strings.TrimSpace(key), strings.TrimSpace(value)
+			switch t.key {
+			case "label", "name", "path", "group":
+			default:
+				return nil, fmt.Errorf("invalid selector %q: unknown key %q (want label, name, path or group)", expr, t.key)
+			}
+		} else {
+			t.value = term
+		}
+		if t.value == "" {
+			return nil, fmt.Errorf("invalid selector %q: %q has no value", expr, raw)
+		}
+		if t.key == "name" || t.key == "path" {
+			if _, err := path.Match(t.value, ""); err != nil {
+				return nil, fmt.Errorf("invalid selector %q: bad pattern %q: %w", expr, t.value, err)
+			}
+		}
+		sel.terms = append(sel.terms, t)
+	}
+	return sel, nil
+}
+
+// String returns the expression the selector was parsed from.
+func (s *Selector) String() string { return s.expr }
+
+// SelectTarget is a repository as selectors see it.
+type SelectTarget struct {
+	// Name is the config entry's name, or the directory name of a
+	// repository the config does not list itself.
+	Name string
+	// Path is relative to the config's directory, with forward slashes.
+	Path string
+	// Labels are those of the repository's entry and of every workspace it
+	// is in.
+	Labels []string
+}
+
+// HasLabel reports whether the target carries label.
+func (t SelectTarget) HasLabel(label string) bool {
+	for _, l := range t.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// LabeledPath is a directory a config labels: a repositories entry or a
+// workspace, whose labels also apply to every repository under it.
+type LabeledPath struct {
+	Name   string
+	Path   string // absolute
+	Labels []string
+}
+
+// Selection holds what selectors are evaluated against: the labelled paths
+// and groups of one config file.
+type Selection struct {
+	// BaseDir is the directory of the config file.
+	BaseDir string
+	Paths   []LabeledPath
+	Groups  map[string][]*Selector
+}
+
+// LoadSelection reads the labels and groups of a config file: its
+// repositories entries and its workspaces, nested ones included.
+func LoadSelection(configPath string) (*Selection, error) {
+	absPath, err := filepath.Abs(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolve config path: %w", err)
+	}
+	var repos RepositoriesConfig
+	if err := unmarshalFile(absPath, &repos); err != nil {
+		return nil, fmt.Errorf("load %s: %w", configPath, err)
+	}
+	var cfg Config
+	if err := unmarshalFile(absPath, &cfg); err != nil {
+		return nil, fmt.Errorf("load %s: %w", configPath, err)
+	}
+
+	s := &Selection{BaseDir: filepath.Dir(absPath), Groups: map[string][]*Selector{}}
+	for _, r := range repos.Repositories {
+		name := r.Name
+		if name == "" {
+			name, _ = repository.ExtractRepoNameFromURL(r.URL)
+		}
+		p := r.Path
+		if p == "" {
+			p = name
+		}
+		if p == "" {
+			continue
+		}
+		abs, err := resolvePath(s.BaseDir, p)
+		if err != nil {
+			return nil, err
+		}
+		s.Paths = append(s.Paths, LabeledPath{Name: name, Path: abs, Labels: r.Labels})
+	}
+	if err := s.addWorkspaces(s.BaseDir, cfg.Workspaces); err != nil {
+		return nil, err
+	}
+
+	for name, members := range cfg.Groups {
+		for _, m := range members {
+			sel, err := ParseSelector(m)
+			if err != nil {
+				return nil, fmt.Errorf("group %s: %w", name, err)
+			}
+			s.Groups[name] = append(s.Groups[name], sel)
+		}
+	}
+	return s, nil
+}
+
+func (s *Selection) addWorkspaces(parentDir string, workspaces map[string]*Workspace) error {
+	for name, ws := range workspaces {
+		if ws == nil {
+			continue
+		}
+		p := ws.Path
+		if p == "" {
+			p = name
+		}
+		abs, err := resolvePath(parentDir, p)
+		if err != nil {
+			return err
+		}
+		s.Paths = append(s.Paths, LabeledPath{Name: name, Path: abs, Labels: ws.Labels})
+		if err := s.addWorkspaces(abs, ws.Workspaces); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Target describes the repository at repoPath (absolute): the name of the
+// entry at that path, and the labels of every entry at or above it.
+func (s *Selection) Target(repoPath string) SelectTarget {
+	repoPath = filepath.Clean(repoPath)
+	t := SelectTarget{Name: filepath.Base(repoPath), Path: filepath.ToSlash(repoPath)}
+	if rel, err := filepath.Rel(s.BaseDir, repoPath); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		t.Path = filepath.ToSlash(rel)
+	}
+
+	seen := map[string]bool{}
+	for _, lp := range s.Paths {
+		entry := filepath.Clean(lp.Path)
+		switch {
+		case entry == repoPath:
+			t.Name = lp.Name
+		case strings.HasPrefix(repoPath, entry+string(filepath.Separator)):
+		default:
+			continue
+		}
+		for _, l := range lp.Labels {
+			if !seen[l] {
+				seen[l] = true
+				t.Labels = append(t.Labels, l)
+			}
+		}
+	}
+	sort.Strings(t.Labels)
+	return t
+}
+
+// Targets describes the repositories at repoPaths together with every
+// repositories entry and workspace of the config, sorted by path.
+func (s *Selection) Targets(repoPaths []string) []SelectTarget {
+	seen := map[string]bool{}
+	var targets []SelectTarget
+	add := func(p string) {
+		p = filepath.Clean(p)
+		if !seen[p] {
+			seen[p] = true
+			targets = append(targets, s.Target(p))
+		}
+	}
+	for _, lp := range s.Paths {
+		add(lp.Path)
+	}
+	for _, p := range repoPaths {
+		add(p)
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].Path < targets[j].Path })
+	return targets
+}
+
+// Match reports whether t matches every term of sel.
+func (s *Selection) Match(sel *Selector, t SelectTarget) (bool, error) {
+	return s.match(sel, t, nil)
+}
+
+// InGroup reports whether t is in the named group.
+func (s *Selection) InGroup(group string, t SelectTarget) (bool, error) {
+	return s.inGroup(group, t, nil)
+}
+
+func (s *Selection) match(sel *Selector, t SelectTarget, stack []string) (bool, error) {
+	for _, term := range sel.terms {
+		var ok bool
+		switch term.key {
+		case "":
+			ok = t.HasLabel(term.value) || t.Name == term.value
+		case "label":
+			ok = t.HasLabel(term.value)
+		case "name":
+			ok, _ = path.Match(term.value, t.Name)
+		case "path":
+			ok, _ = path.Match(term.value, t.Path)
+		case "group":
+			var err error
+			if ok, err = s.inGroup(term.value, t, stack); err != nil {
+				return false, err
+			}
+		}
+		if ok == term.negate {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (s *Selection) inGroup(group string, t SelectTarget, stack []string) (bool, error) {
+	members, ok := s.Groups[group]
+	if !ok {
+		return false, s.unknownGroup(group)
+	}
+	for _, g := range stack {
+		if g == group {
+			return false, fmt.Errorf("group %s refers to itself: %s", group, strings.Join(append(stack, group), " → "))
+		}
+	}
+	for _, sel := range members {
+		ok, err := s.match(sel, t, append(stack, group))
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// GroupNames returns the defined groups, sorted.
+func (s *Selection) GroupNames() []string {
+	names := make([]string, 0, len(s.Groups))
+	for name := range s.Groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *Selection) unknownGroup(group string) error {
+	if len(s.Groups) == 0 {
+		return fmt.Errorf("unknown group %q: no groups are defined in %s", group, s.BaseDir)
+	}
+	return fmt.Errorf("unknown group %q (defined: %s)", group, strings.Join(s.GroupNames(), ", "))
+}
+
+// Filter keeps the repositories (absolute paths) that match sel, when it
+// is not nil, and are in at least one of groups, when there are any.
+func (s *Selection) Filter(repoPaths []string, sel *Selector, groups []string) ([]string, error) {
+	for _, g := range groups {
+		if _, ok := s.Groups[g]; !ok {
+			return nil, s.unknownGroup(g)
+		}
+	}
+
+	var kept []string
+	for _, p := range repoPaths {
+		t := s.Target(p)
+		if sel != nil {
+			ok, err := s.Match(sel, t)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+		inAny := len(groups) == 0
+		for _, g := range groups {
+			ok, err := s.InGroup(g, t)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				inAny = true
+				break
+			}
+		}
+		if inAny {
+			kept = append(kept, p)
+		}
+	}
+	return kept, nil
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseSelector(t *testing.T) {
+	for _, expr := range []string{"backend", "label=backend,!archived", "label!=archived", " name = api-* , path=libs/*", "group=core"} {
+		if _, err := ParseSelector(expr); err != nil {
+			t.Errorf("ParseSelector(%q): %v", expr, err)
+		}
+	}
+	for expr, want := range map[string]string{
+		"":               "empty term",
+		"backend,":       "empty term",
+		"owner=me":       "unknown key",
+		"label=":         "has no value",
+		"!":              "has no value",
+		"path=libs/[":    "bad pattern",
+		"label=a,,b":     "empty term",
+		"label=a,team=x": `unknown key "team"`,
+	} {
+		if _, err := ParseSelector(expr); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseSelector(%q) err = %v, want %q", expr, err, want)
+		}
+	}
+}
+
+const selectionConfig = `repositories:
+  - url: https://github.com/org/api.git
+    labels: [backend, go]
+  - url: https://github.com/org/web.git
+    path: frontend/web
+    labels: [frontend]
+  - url: https://github.com/org/legacy.git
+    labels: [backend, archived]
+workspaces:
+  services:
+    path: services
+    labels: [backend]
+    workspaces:
+      billing:
+        path: billing
+        labels: [payments]
+groups:
+  core: [api, "path=frontend/*"]
+  all-backend: ["label=backend"]
+  loop-a: ["group=loop-b"]
+  loop-b: ["group=loop-a"]
+`
+
+func loadTestSelection(t *testing.T) *Selection {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".gz-git.yaml")
+	if err := os.WriteFile(path, []byte(selectionConfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s, err := LoadSelection(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestSelectionTarget(t *testing.T) {
+	s := loadTestSelection(t)
+	base := s.BaseDir
+
+	api := s.Target(filepath.Join(base, "api"))
+	if api.Name != "api" || api.Path != "api" || strings.Join(api.Labels, ",") != "backend,go" {
+		t.Errorf("api = %+v", api)
+	}
+	// A repository the config does not list inherits its workspaces' labels.
+	inv := s.Target(filepath.Join(base, "services", "billing", "invoices"))
+	if inv.Name != "invoices" || inv.Path != "services/billing/invoices" || strings.Join(inv.Labels, ",") != "backend,payments" {
+		t.Errorf("invoices = %+v", inv)
+	}
+	if other := s.Target(filepath.Join(base, "scratch")); len(other.Labels) != 0 {
+		t.Errorf("scratch = %+v", other)
+	}
+}
+
+func TestSelectionFilter(t *testing.T) {
+	s := loadTestSelection(t)
+	base := s.BaseDir
+	var repos []string
+	for _, p := range []string{"api", "frontend/web", "legacy", "services/billing/invoices", "scratch"} {
+		repos = append(repos, filepath.Join(base, filepath.FromSlash(p)))
+	}
+
+	names := func(paths []string) string {
+		var out []string
+		for _, p := range paths {
+			out = append(out, s.Target(p).Path)
+		}
+		return strings.Join(out, " ")
+	}
+
+	for _, tc := range []struct {
+		expr   string
+		groups []string
+		want   string
+	}{
+		{"label=backend,!archived", nil, "api services/billing/invoices"},
+		{"backend,label!=go", nil, "legacy services/billing/invoices"},
+		{"name=leg*", nil, "legacy"},
+		{"", []string{"core"}, "api frontend/web"},
+		{"", []string{"core", "all-backend"}, "api frontend/web legacy services/billing/invoices"},
+		{"!archived", []string{"all-backend"}, "api services/billing/invoices"},
+		{"group=core,!frontend", nil, "api"},
+	} {
+		var sel *Selector
+		if tc.expr != "" {
+			var err error
+			if sel, err = ParseSelector(tc.expr); err != nil {
+				t.Fatal(err)
+			}
+		}
+		got, err := s.Filter(repos, sel, tc.groups)
+		if err != nil {
+			t.Errorf("Filter(%q, %v): %v", tc.expr, tc.groups, err)
+			continue
+		}
+		if names(got) != tc.want {
+			t.Errorf("Filter(%q, %v) = %q, want %q", tc.expr, tc.groups, names(got), tc.want)
+		}
+	}
+
+	if _, err := s.Filter(repos, nil, []string{"nope"}); err == nil || !strings.Contains(err.Error(), `unknown group "nope" (defined: all-backend, core, loop-a, loop-b)`) {
+		t.Errorf("unknown group: err = %v", err)
+	}
+	if _, err := s.Filter(repos, nil, []string{"loop-a"}); err == nil || !strings.Contains(err.Error(), "refers to itself: loop-a → loop-b → loop-a") {
+		t.Errorf("group cycle: err = %v", err)
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// templateFuncs is everything a templated path or URL can call. It is kept
+// to string helpers on purpose: templates see the workspace name and its
+// params, never the environment or the filesystem (use ${VAR} for those).
+var templateFuncs = template.FuncMap{
+	"lower":      strings.ToLower,
+	"upper":      strings.ToUpper,
+	"replace":    func(old, replacement, s string) string { return strings.ReplaceAll(s, old, replacement) },
+	"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+}
+
+// applyWorkspaceTemplates instantiates every workspace that names a template,
+// nested workspaces included, then evaluates the Go templates in the path
+// and URL fields of all workspaces.
+func applyWorkspaceTemplates(config *Config) error {
+	for name, tmpl := range config.WorkspaceTemplates {
+		if tmpl == nil {
+			continue
+		}
+		if tmpl.Template != "" {
+			return fmt.Errorf("workspace template %q uses template %q: templates cannot be nested", name, tmpl.Template)
+		}
+		if _, ok := tmpl.Params["name"]; ok {
+			return fmt.Errorf("workspace template %q declares param \"name\", which is reserved for the workspace name", name)
+		}
+	}
+	return expandWorkspaces(config.Workspaces, config.WorkspaceTemplates, nil, "")
+}
+
+func expandWorkspaces(workspaces map[string]*Workspace, templates map[string]*Workspace, inherited map[string]string, prefix string) error {
+	for name, ws := range workspaces {
+		if ws == nil {
+			continue
+		}
+		qualified := prefix + name
+		if ws.Template != "" {
+			if err := instantiateTemplate(ws, templates); err != nil {
+				return fmt.Errorf("workspace '%s': %w", qualified, err)
+			}
+		}
+
+		// Nested workspaces see their parents' params, under their own name.
+		data := make(map[string]string, len(inherited)+len(ws.Params)+1)
+		maps.Copy(data, inherited)
+		maps.Copy(data, ws.Params)
+		data["name"] = name
+		if err := evalWorkspaceFields(ws, data); err != nil {
+			return fmt.Errorf("workspace '%s': %w", qualified, err)
+		}
+
+		if err := expandWorkspaces(ws.Workspaces, templates, data, qualified+"/"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// instantiateTemplate replaces ws with its template overlaid by the entry's
+// own keys. Maps merge key by key, so an entry can change one sync setting
+// of the template and keep the rest; lists and scalars replace.
+func instantiateTemplate(ws *Workspace, templates map[string]*Workspace) error {
+	tmpl, ok := templates[ws.Template]
+	if !ok || tmpl == nil {
+		return fmt.Errorf("unknown workspace template %q", ws.Template)
+	}
+
+	params, err := templateParams(ws.Template, tmpl.Params, ws.Params)
+	if err != nil {
+		return err
+	}
+
+	base, err := workspaceMap(tmpl)
+	if err != nil {
+		return err
+	}
+	overlay, err := workspaceOverlay(ws)
+	if err != nil {
+		return err
+	}
+	delete(base, "params")
+	delete(overlay, "params")
+	delete(overlay, "template")
+	mergeMaps(base, overlay)
+
+	data, err := yaml.Marshal(base)
+	if err != nil {
+		return fmt.Errorf("template %q: %w", ws.Template, err)
+	}
+	var merged Workspace
+	if err := yaml.Unmarshal(data, &merged); err != nil {
+		return fmt.Errorf("template %q: %w", ws.Template, err)
+	}
+	merged.Template = ws.Template
+	merged.Params = params
+	*ws = merged
+	return nil
+}
+
+// templateParams checks an entry's params against those its template
+// declares. A declared param with an empty default must be given.
+func templateParams(name string, declared, given map[string]string) (map[string]string, error) {
+	var unknown, missing []string
+	for k := range given {
+		if _, ok := declared[k]; !ok {
+			unknown = append(unknown, k)
+		}
+	}
+	params := make(map[string]string, len(declared))
+	for k, def := range declared {
+		v, ok := given[k]
+		if !ok || v == "" {
+			v = def
+		}
+		if v == "" {
+			missing = append(missing, k)
+		}
+		params[k] = v
+	}
+	sort.Strings(unknown)
+	sort.Strings(missing)
+	switch {
+	case len(unknown) > 0:
+		return nil, fmt.Errorf("template %q has no param %s", name, strings.Join(unknown, ", "))
+	case len(missing) > 0:
+		return nil, fmt.Errorf("template %q requires param %s", name, strings.Join(missing, ", "))
+	}
+	return params, nil
+}
+
+func workspaceMap(ws *Workspace) (map[string]any, error) {
+	data, err := yaml.Marshal(ws)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]any{}
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// workspaceOverlay returns the keys an entry sets itself. Decoded from YAML,
+// that is exactly what the user wrote: re-marshaling the struct would add
+// fields without omitempty as empty strings (source.provider, source.org)
+// and drop an explicit false.
+func workspaceOverlay(ws *Workspace) (map[string]any, error) {
+	if ws.node == nil {
+		m, err := workspaceMap(ws)
+		if err == nil && ws.Path == "" {
+			delete(m, "path")
+		}
+		return m, err
+	}
+	m := map[string]any{}
+	if err := ws.node.Decode(&m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UnmarshalYAML decodes a workspace and keeps its node for workspaceOverlay.
+func (ws *Workspace) UnmarshalYAML(value *yaml.Node) error {
+	type plain Workspace
+	if err := value.Decode((*plain)(ws)); err != nil {
+		return err
+	}
+	ws.node = value
+	return nil
+}
+
+// mergeMaps overlays src onto dst, recursing into maps present in both.
+func mergeMaps(dst, src map[string]any) {
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[string]any)
+		dstMap, dstIsMap := dst[k].(map[string]any)
+		if srcIsMap && dstIsMap {
+			mergeMaps(dstMap, srcMap)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+type templatedField struct {
+	name  string
+	value *string
+}
+
+// evalWorkspaceFields evaluates the Go templates in the fields of ws that
+// name where a workspace lives or comes from.
+func evalWorkspaceFields(ws *Workspace, data map[string]string) error {
+	fields := []templatedField{
+		{"path", &ws.Path},
+		{"url", &ws.URL},
+		{"configLink", &ws.ConfigLink},
+	}
+	if ws.Source != nil {
+		fields = append(fields,
+			templatedField{"source.org", &ws.Source.Org},
+			templatedField{"source.baseURL", &ws.Source.BaseURL},
+		)
+	}
+	for _, f := range fields {
+		v, err := evalTemplate(f.name, *f.value, data)
+		if err != nil {
+			return err
+		}
+		*f.value = v
+	}
+	for remote, u := range ws.AdditionalRemotes {
+		v, err := evalTemplate("additionalRemotes."+remote, u, data)
+		if err != nil {
+			return err
+		}
+		ws.AdditionalRemotes[remote] = v
+	}
+	return nil
+}
+
+// evalTemplate evaluates one field. Strings without "{{" are returned as is,
+// so plain configs never go through text/template.
+func evalTemplate(field, text string, data map[string]string) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	t, err := template.New(field).Option("missingkey=error").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", field, err)
+	}
+	var out bytes.Buffer
+	if err := t.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("%s: %w", field, err)
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const templateConfig = `workspaceTemplates:
+  team-forge:
+    params:
+      org: ""
+      host: gitlab.example.com
+    path: ./teams/{{ .org }}
+    type: forge
+    source:
+      provider: gitlab
+      org: "{{ .org }}"
+      baseURL: https://{{ .host }}
+    sync:
+      strategy: pull
+      maxRetries: 3
+    parallel: 8
+workspaces:
+  platform:
+    template: team-forge
+    params:
+      org: platform
+    sync:
+      strategy: reset
+  mobile:
+    template: team-forge
+    params:
+      org: mobile-apps
+      host: git.internal
+    path: ./{{ .name }}
+    source:
+      includeSubgroups: true
+  tools:
+    type: git
+    url: https://github.com/org/{{ .name | upper }}.git
+    additionalRemotes:
+      fork: git@github.com:me/{{ replace "-" "_" .name }}.git
+`
+
+func TestLoadConfigRecursive_WorkspaceTemplates(t *testing.T) {
+	dir := t.TempDir()
+	writeExplainFile(t, filepath.Join(dir, ".gz-git.yaml"), templateConfig)
+
+	cfg, err := LoadConfigRecursive(dir, ".gz-git.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	platform := cfg.Workspaces["platform"]
+	if platform.Path != filepath.Join(dir, "teams", "platform") {
+		t.Errorf("platform path = %q", platform.Path)
+	}
+	if platform.Type != WorkspaceTypeForge || platform.Parallel != 8 {
+		t.Errorf("platform did not take the template: %+v", platform)
+	}
+	if platform.Source.Org != "platform" || platform.Source.BaseURL != "https://gitlab.example.com" {
+		t.Errorf("platform source = %+v", platform.Source)
+	}
+	// The entry overrides one sync key and keeps the template's other one.
+	if platform.Sync.Strategy != "reset" || platform.Sync.MaxRetries != 3 {
+		t.Errorf("platform sync = %+v", platform.Sync)
+	}
+
+	mobile := cfg.Workspaces["mobile"]
+	if mobile.Path != filepath.Join(dir, "mobile") {
+		t.Errorf("mobile path = %q", mobile.Path)
+	}
+	if mobile.Source.BaseURL != "https://git.internal" || mobile.Sync.Strategy != "pull" {
+		t.Errorf("mobile = %+v, %+v", mobile.Source, mobile.Sync)
+	}
+	// A partial source: keeps the template's provider and org.
+	if mobile.Source.Provider != "gitlab" || mobile.Source.Org != "mobile-apps" || !mobile.Source.IncludeSubgroups {
+		t.Errorf("mobile source = %+v", mobile.Source)
+	}
+	// Instances do not share the template's maps.
+	if platform.Sync == mobile.Sync || platform.Source == mobile.Source {
+		t.Error("instances share template state")
+	}
+
+	tools := cfg.Workspaces["tools"]
+	if tools.URL != "https://github.com/org/TOOLS.git" || tools.AdditionalRemotes["fork"] != "git@github.com:me/tools.git" {
+		t.Errorf("tools = %q, %v", tools.URL, tools.AdditionalRemotes)
+	}
+}
+
+func TestLoadConfigRecursive_WorkspaceTemplateErrors(t *testing.T) {
+	tests := []struct {
+		name, config, want string
+	}{
+		{
+			name: "missing param",
+			config: `workspaceTemplates:
+  t:
+    params: {org: ""}
+    path: ./{{ .org }}
+workspaces:
+  a: {template: t}
+`,
+			want: `requires param org`,
+		},
+		{
+			name: "unknown param",
+			config: `workspaceTemplates:
+  t:
+    params: {org: x}
+workspaces:
+  a: {template: t, params: {team: y}}
+`,
+			want: `has no param team`,
+		},
+		{
+			name:   "unknown template",
+			config: "workspaces:\n  a: {template: nope}\n",
+			want:   `unknown workspace template "nope"`,
+		},
+		{
+			name: "nested template",
+			config: `workspaceTemplates:
+  t: {template: u}
+`,
+			want: `cannot be nested`,
+		},
+		{
+			name:   "undefined key",
+			config: "workspaces:\n  a: {path: './{{ .org }}'}\n",
+			want:   `path:`,
+		},
+		{
+			name:   "no environment access",
+			config: "workspaces:\n  a: {path: '{{ env \"HOME\" }}'}\n",
+			want:   `function "env" not defined`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeExplainFile(t, filepath.Join(dir, ".gz-git.yaml"), tt.config)
+			_, err := LoadConfigRecursive(dir, ".gz-git.yaml")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// PlaintextToken is a token written literally into a config file rather than
+// referenced through ${VAR} or left to the token store.
+type PlaintextToken struct {
+	// File is the config file holding the token.
+	File string `json:"file"`
+	// Field locates the token inside the file, e.g. "token" or
+	// "environments.work.githubToken".
+	Field string `json:"field"`
+}
+
+func (p PlaintextToken) String() string {
+	return fmt.Sprintf("%s: %s", p.File, p.Field)
+}
+
+// isPlaintextSecret reports whether v holds anything besides ${VAR} and
+// secret references. An empty value or a pure reference keeps the secret out
+// of the file; "${PREFIX}abc" does not.
+func isPlaintextSecret(v string) bool {
+	if v == "" {
+		return false
+	}
+	v = secretRefPattern.ReplaceAllString(envVarPattern.ReplaceAllString(v, ""), "")
+	return strings.TrimSpace(v) != ""
+}
+
+// PlaintextTokens scans the profiles and the global config, before
+// environment expansion, for tokens stored in the clear. Files that cannot be
+// parsed are skipped; doctor reports those through its own config checks.
+func (m *Manager) PlaintextTokens() ([]PlaintextToken, error) {
+	var found []PlaintextToken
+
+	profiles, err := m.paths.ListProfiles()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(profiles)
+	for _, name := range profiles {
+		path := m.paths.ProfilePath(name)
+		var profile Profile
+		if err := unmarshalFile(path, &profile); err != nil {
+			continue
+		}
+		if isPlaintextSecret(profile.Token) {
+			found = append(found, PlaintextToken{File: path, Field: "token"})
+		}
+		if isPlaintextSecret(profile.SSHKeyContent) {
+			found = append(found, PlaintextToken{File: path, Field: "sshKeyContent"})
+		}
+	}
+
+	path := m.paths.GlobalConfigFile
+	if path == "" {
+		return found, nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		return found, nil //nolint:nilerr // no global config means nothing to audit
+	}
+	var global GlobalConfig
+	if err := unmarshalFile(path, &global); err != nil {
+		return found, nil //nolint:nilerr // reported by the global-config check
+	}
+	envNames := make([]string, 0, len(global.Environments))
+	for name := range global.Environments {
+		envNames = append(envNames, name)
+	}
+	sort.Strings(envNames)
+	for _, name := range envNames {
+		env := global.Environments[name]
+		for field, v := range map[string]string{
+			"githubToken": env.GitHubToken,
+			"gitlabToken": env.GitLabToken,
+			"giteaToken":  env.GiteaToken,
+		} {
+			if isPlaintextSecret(v) {
+				found = append(found, PlaintextToken{File: path, Field: fmt.Sprintf("environments.%s.%s", name, field)})
+			}
+		}
+	}
+	for i, c := range global.Credentials {
+		if isPlaintextSecret(c.Token) {
+			found = append(found, PlaintextToken{File: path, Field: fmt.Sprintf("credentials[%d].token (%s)", i, c.Host)})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].File < found[j].File || found[i].File == found[j].File && found[i].Field < found[j].Field
+	})
+	return found, nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPlaintextTokens(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	cfgDir := filepath.Join(dir, ConfigDirName)
+	if err := os.MkdirAll(filepath.Join(cfgDir, ProfilesDirName), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	write := func(rel, body string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(cfgDir, rel), []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("profiles/work.yaml", "name: work\nprovider: github\ntoken: ghp_literal\n")
+	write("profiles/ci.yaml", "name: ci\nprovider: gitlab\ntoken: ${CI_TOKEN}\n")
+	write("config.yaml", "environments:\n  home:\n    githubToken: ${HOME_TOKEN}\n    giteaToken: plain\ncredentials:\n  - host: github.example.com\n    token: lit-${SUFFIX}\n")
+
+	manager, err := NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	found, err := manager.PlaintextTokens()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range found {
+		got = append(got, filepath.Base(f.File)+":"+f.Field)
+	}
+	want := "config.yaml:credentials[0].token (github.example.com),config.yaml:environments.home.giteaToken,work.yaml:token"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("found %v\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// TokenStoreEnv selects the token store backend for one process, overriding
+// tokenStore in the global config.
+const TokenStoreEnv = "GZ_GIT_TOKEN_STORE"
+
+// Token store backends.
+const (
+	// TokenBackendKeyring is the OS keychain (default).
+	TokenBackendKeyring = "keyring"
+	// TokenBackendFile is the passphrase-encrypted file under the config dir.
+	TokenBackendFile = "file"
+)
+
+// TokenBackends lists the accepted backend names.
+var TokenBackends = []string{TokenBackendKeyring, TokenBackendFile}
+
+// TokenLister is implemented by stores that can enumerate their keys. The OS
+// keychain cannot (go-keyring has no list operation), so callers that need a
+// complete inventory fall back to KnownTokenKeys for it.
+type TokenLister interface {
+	Keys() ([]string, error)
+}
+
+// NewTokenStore builds the store for backend. An empty backend is the keyring.
+func NewTokenStore(backend string) (TokenStore, error) {
+	switch strings.ToLower(strings.TrimSpace(backend)) {
+	case "", TokenBackendKeyring:
+		return &KeyringTokenStore{}, nil
+	case TokenBackendFile:
+		path, err := DefaultTokenFilePath()
+		if err != nil {
+			return nil, err
+		}
+		return NewFileTokenStore(path, nil), nil
+	default:
+		return nil, fmt.Errorf("unknown token store %q (want one of: %s)", backend, strings.Join(TokenBackends, ", "))
+	}
+}
+
+// ConfiguredTokenBackend returns the backend the user selected and where the
+// choice came from: GZ_GIT_TOKEN_STORE, then tokenStore in the global config,
+// then the keyring default.
+func ConfiguredTokenBackend() (backend, source string) {
+	if v := strings.TrimSpace(os.Getenv(TokenStoreEnv)); v != "" {
+		return strings.ToLower(v), "env:" + TokenStoreEnv
+	}
+	if manager, err := NewManager(); err == nil {
+		if global, err := manager.LoadGlobalConfig(); err == nil && global != nil && global.TokenStore != "" {
+			return strings.ToLower(global.TokenStore), string(SourceGlobal)
+		}
+	}
+	return TokenBackendKeyring, "default"
+}
+
+// ConfigureTokenStore installs the configured backend as DefaultTokenStore.
+// On error the keyring stays in place, so a typo in the backend name degrades
+// to the old behavior instead of losing access to every token.
+func ConfigureTokenStore() error {
+	backend, source := ConfiguredTokenBackend()
+	store, err := NewTokenStore(backend)
+	if err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+	SetTokenStore(store)
+	return nil
+}
+
+// TokenBackendName names the backend behind s for messages and doctor.
+func TokenBackendName(s TokenStore) string {
+	switch s.(type) {
+	case *KeyringTokenStore:
+		return TokenBackendKeyring
+	case *FileTokenStore:
+		return TokenBackendFile
+	case *MemoryTokenStore:
+		return "memory"
+	default:
+		return fmt.Sprintf("%T", s)
+	}
+}
+
+// DescribeTokenStore is TokenBackendName in words, for "stored in ..." messages.
+func DescribeTokenStore(s TokenStore) string {
+	switch st := s.(type) {
+	case *KeyringTokenStore:
+		return "OS keychain"
+	case *FileTokenStore:
+		return "encrypted file " + st.Path()
+	default:
+		return TokenBackendName(s) + " token store"
+	}
+}
+
+// KnownTokenKeys returns every key gz-git could have stored: the bare
+// providers, each configured credential's key, and whatever the listable
+// stores in extra already hold. It is the inventory used to migrate out of
+// the keychain, which cannot be enumerated.
+func KnownTokenKeys(creds []Credential, extra ...TokenStore) []string {
+	seen := map[string]bool{}
+	for _, p := range []string{"github", "gitlab", "gitea"} {
+		seen[p] = true
+	}
+	for _, c := range creds {
+		if c.ProviderName() == "" {
+			continue
+		}
+		seen[c.Key().String()] = true
+		// A host entry narrowed by org may also have a host-wide token.
+		if c.Org != "" {
+			seen[CredentialKey{Provider: c.ProviderName(), Host: c.Host}.String()] = true
+		}
+	}
+	for _, s := range extra {
+		if l, ok := s.(TokenLister); ok {
+			if keys, err := l.Keys(); err == nil {
+				for _, k := range keys {
+					seen[k] = true
+				}
+			}
+		}
+	}
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Token migration outcomes.
+const (
+	MigrateCopied  = "copied"
+	MigrateMoved   = "moved"
+	MigrateAbsent  = "absent"
+	MigrateSame    = "unchanged"
+	MigrateSkipped = "skipped"
+)
+
+// MigrationResult records what happened to one key.
+type MigrationResult struct {
+	Key    string `json:"key"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// MigrationOptions controls MigrateTokens.
+type MigrationOptions struct {
+	// DeleteSource removes each key from the source once the destination
+	// holds the same value.
+	DeleteSource bool
+	// Overwrite replaces a different value already in the destination.
+	// Without it such keys are skipped: the destination may hold the newer
+	// token.
+	Overwrite bool
+	// DryRun reports what would happen without writing.
+	DryRun bool
+}
+
+// MigrateTokens copies keys from one store to another. It stops at the first
+// read or write error, returning the results so far; a key is only deleted
+// from the source after the destination write succeeded.
+func MigrateTokens(from, to TokenStore, keys []string, opts MigrationOptions) ([]MigrationResult, error) {
+	results := make([]MigrationResult, 0, len(keys))
+	for _, key := range keys {
+		tok, err := from.Get(key)
+		if err != nil {
+			return results, fmt.Errorf("read %s from source: %w", key, err)
+		}
+		if tok == "" {
+			results = append(results, MigrationResult{Key: key, Status: MigrateAbsent})
+			continue
+		}
+		existing, err := to.Get(key)
+		if err != nil {
+			return results, fmt.Errorf("read %s from destination: %w", key, err)
+		}
+
+		status := MigrateCopied
+		switch {
+		case existing == tok:
+			status = MigrateSame
+		case existing != "" && !opts.Overwrite:
+			results = append(results, MigrationResult{Key: key, Status: MigrateSkipped, Detail: "destination holds a different token; use --overwrite"})
+			continue
+		}
+		if !opts.DryRun && status == MigrateCopied {
+			if err := to.Set(key, tok); err != nil {
+				return results, fmt.Errorf("write %s to destination: %w", key, err)
+			}
+		}
+		if opts.DeleteSource {
+			if !opts.DryRun {
+				if err := from.Delete(key); err != nil {
+					return results, fmt.Errorf("delete %s from source: %w", key, err)
+				}
+			}
+			status = MigrateMoved
+		}
+		results = append(results, MigrationResult{Key: key, Status: status})
+	}
+	return results, nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestNewTokenStore(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	for backend, want := range map[string]string{"": TokenBackendKeyring, "keyring": TokenBackendKeyring, "FILE": TokenBackendFile} {
+		s, err := NewTokenStore(backend)
+		if err != nil {
+			t.Fatalf("NewTokenStore(%q): %v", backend, err)
+		}
+		if got := TokenBackendName(s); got != want {
+			t.Errorf("NewTokenStore(%q) = %s, want %s", backend, got, want)
+		}
+	}
+	if _, err := NewTokenStore("vault"); err == nil {
+		t.Fatal("unknown backend accepted")
+	}
+}
+
+func TestConfiguredTokenBackend(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv(TokenStoreEnv, "")
+	if backend, source := ConfiguredTokenBackend(); backend != TokenBackendKeyring || source != "default" {
+		t.Fatalf("default = %s (%s)", backend, source)
+	}
+
+	cfgDir := filepath.Join(dir, ConfigDirName)
+	if err := os.MkdirAll(cfgDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.yaml"), []byte("tokenStore: file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if backend, source := ConfiguredTokenBackend(); backend != TokenBackendFile || source != string(SourceGlobal) {
+		t.Fatalf("global = %s (%s)", backend, source)
+	}
+
+	t.Setenv(TokenStoreEnv, "keyring")
+	if backend, source := ConfiguredTokenBackend(); backend != TokenBackendKeyring || source != "env:"+TokenStoreEnv {
+		t.Fatalf("env = %s (%s)", backend, source)
+	}
+}
+
+func TestMigrateTokens(t *testing.T) {
+	from := NewMemoryTokenStore()
+	to := NewMemoryTokenStore()
+	_ = from.Set("github", "gh")
+	_ = from.Set("gitlab", "gl")
+	_ = from.Set("gitea", "mine")
+	_ = to.Set("gitlab", "gl")
+	_ = to.Set("gitea", "theirs")
+
+	keys := []string{"gitea", "github", "gitlab", "github@ghe.example.com"}
+	results, err := MigrateTokens(from, to, keys, MigrationOptions{DeleteSource: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"gitea":                  MigrateSkipped,
+		"github":                 MigrateMoved,
+		"gitlab":                 MigrateMoved,
+		"github@ghe.example.com": MigrateAbsent,
+	}
+	for _, r := range results {
+		if want[r.Key] != r.Status {
+			t.Errorf("%s: status %s, want %s", r.Key, r.Status, want[r.Key])
+		}
+	}
+	if tok, _ := to.Get("github"); tok != "gh" {
+		t.Fatalf("destination github = %q", tok)
+	}
+	if tok, _ := from.Get("github"); tok != "" {
+		t.Fatal("moved token left in source")
+	}
+	if tok, _ := from.Get("gitea"); tok != "mine" {
+		t.Fatal("skipped token deleted from source")
+	}
+	if tok, _ := to.Get("gitea"); tok != "theirs" {
+		t.Fatal("skipped token overwritten in destination")
+	}
+}
+
+func TestKnownTokenKeys(t *testing.T) {
+	file := NewFileTokenStore(filepath.Join(t.TempDir(), TokenFileName), fixedPassphrase("p"))
+	_ = file.Set("gitea@git.example.com", "x")
+	keys := KnownTokenKeys([]Credential{{Host: "github.example.com", Org: "acme"}}, file)
+	for _, k := range []string{"github", "gitlab", "gitea", "github@github.example.com/acme", "github@github.example.com", "gitea@git.example.com"} {
+		if !slices.Contains(keys, k) {
+			t.Errorf("missing %s in %v", k, keys)
+		}
+	}
+}
@@ -16,9 +16,12 @@ import (
 	"fmt"
 	"strings"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/branch"
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/identity"
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/watch"
 )
 
 // ================================================================================
@@ -72,6 +75,55 @@ type ConfigMeta struct {
 	Metadata *Metadata `yaml:"metadata,omitempty"`
 }
 
+// RepositoriesConfig is the flat `kind: repositories` layout that
+// `workspace sync` reads: one list of repositories and the sync settings that
+// apply to all of them.
+//
+// Example:
+//
+//	version: 1
+//	kind: repositories
+//	strategy: pull
+//	branch: develop,main
+//	repositories:
+//	  - url: https://github.com/org/api.git
+//	  - url: git@github.com:org/web.git
+//	    path: frontend/web
+//	    strategy: fetch
+type RepositoriesConfig struct {
+	ConfigMeta `yaml:",inline"`
+
+	Strategy       string     `yaml:"strategy,omitempty"`       // default sync strategy (default: reset)
+	Parallel       int        `yaml:"parallel,omitempty"`       // Parallel workers
+	MaxRetries     int        `yaml:"maxRetries,omitempty"`     // Retry count
+	CleanupOrphans bool       `yaml:"cleanupOrphans,omitempty"` // Delete local repos not in the list
+	CloneProto     string     `yaml:"cloneProto,omitempty"`     // ssh, https
+	SSHPort        int        `yaml:"sshPort,omitempty"`        // Custom SSH port
+	Branch         FlexBranch `yaml:"branch,omitempty"`         // default branch for every repository
+	Roots          []string   `yaml:"roots,omitempty"`          // directories orphans are looked for in
+
+	// BasePath records the directory `workspace init` scanned. Repository
+	// paths are resolved against the config file's directory regardless.
+	BasePath string `yaml:"basePath,omitempty"`
+
+	Repositories []RepositoryEntry `yaml:"repositories"`
+}
+
+// RepositoryEntry is one repository of a RepositoriesConfig.
+type RepositoryEntry struct {
+	Name              string            `yaml:"name,omitempty"`              // defaults to the name in the URL
+	Description       string            `yaml:"description,omitempty"`       // human-readable description
+	URL               string            `yaml:"url"`                         // clone URL (required)
+	AdditionalRemotes map[string]string `yaml:"additionalRemotes,omitempty"` // extra git remotes (name: url)
+	Path              string            `yaml:"path,omitempty"`              // defaults to the name
+	Strategy          string            `yaml:"strategy,omitempty"`          // overrides the top-level strategy
+	CloneProto        string            `yaml:"cloneProto,omitempty"`        // ssh, https
+	Branch            FlexBranch        `yaml:"branch,omitempty"`            // overrides the top-level branch
+	Enabled           *bool             `yaml:"enabled,omitempty"`           // false excludes it from sync (default: true)
+	AssumePresent     bool              `yaml:"assumePresent,omitempty"`     // skip the clone check
+	Labels            []string          `yaml:"labels,omitempty"`            // labels for --select
+}
+
 // ================================================================================
 // Profiles
 // ================================================================================
@@ -99,13 +151,13 @@ type Profile struct {
 	// Forge provider settings
 	Provider string `yaml:"provider,omitempty"` // github, gitlab, gitea
 	BaseURL  string `yaml:"baseURL,omitempty"`  // API endpoint
-	Token    string `yaml:"token,omitempty"`    // API token (use ${ENV_VAR})
+	Token    string `yaml:"token,omitempty"`    // API token (use ${ENV_VAR} or ${keyring:...})
 
 	// Clone settings
 	CloneProto    string `yaml:"cloneProto,omitempty"`    // ssh, https
 	SSHPort       int    `yaml:"sshPort,omitempty"`       // Custom SSH port
 	SSHKeyPath    string `yaml:"sshKeyPath,omitempty"`    // SSH private key file path (priority)
-	SSHKeyContent string `yaml:"sshKeyContent,omitempty"` // SSH private key content (use ${ENV_VAR})
+	SSHKeyContent string `yaml:"sshKeyContent,omitempty"` // SSH private key content (use ${ENV_VAR} or ${file:...})
 
 	// Bulk operation settings
 	Parallel         int    `yaml:"parallel,omitempty"`         // Parallel job count
@@ -122,6 +174,9 @@ type Profile struct {
 	Pull   *PullConfig   `yaml:"pull,omitempty"`
 	Push   *PushConfig   `yaml:"push,omitempty"`
 	Audit  *AuditConfig  `yaml:"audit,omitempty"`
+
+	// Recipes are named command sequences for 'gz-git run'.
+	Recipes map[string]*Recipe `yaml:"recipes,omitempty"`
 }
 
 // SyncConfig holds sync command defaults.
@@ -170,7 +225,7 @@ type CloneDefaults struct {
 	Proto         string `yaml:"proto,omitempty"`         // ssh, https
 	SSHPort       int    `yaml:"sshPort,omitempty"`       // Custom SSH port
 	SSHKeyPath    string `yaml:"sshKeyPath,omitempty"`    // SSH private key file path
-	SSHKeyContent string `yaml:"sshKeyContent,omitempty"` // SSH private key content (use ${ENV_VAR})
+	SSHKeyContent string `yaml:"sshKeyContent,omitempty"` // SSH private key content (use ${ENV_VAR} or ${file:...})
 }
 
 // SyncDefaults holds sync-related default settings.
@@ -451,6 +506,36 @@ type AuditConfig struct {
 	Autofix map[string]bool `yaml:"autofix,omitempty"`
 }
 
+// WatchConfig holds `watch` settings for a project.
+//
+// Example:
+//
+//	watch:
+//	  fetchInterval: 5m
+//	  autoFastForward: true
+//	  sinks:
+//	    - type: exec
+//	      command: [make, lint]
+//	      events: [modified, staged]
+//	      limit: 1
+//	      per: 30s
+//	    - type: file
+//	      path: .git/watch.ndjson
+type WatchConfig struct {
+	// FetchInterval turns on background fetching (e.g. "5m"), so watch can
+	// report incoming, diverged, upstream-gone, and foreign-work events.
+	// Empty leaves it off; --fetch-interval overrides it.
+	FetchInterval string `yaml:"fetchInterval,omitempty"`
+
+	// AutoFastForward fast-forwards a clean branch when its upstream gains
+	// commits and it has none of its own. --auto-ff overrides it.
+	AutoFastForward bool `yaml:"autoFastForward,omitempty"`
+
+	// Sinks receive every event watch prints, in addition to the terminal.
+	// See watch.SinkConfig for the fields of each entry.
+	Sinks []watch.SinkConfig `yaml:"sinks,omitempty"`
+}
+
 // GlobalConfig represents ~/.config/gz-git/config.yaml
 //
 // Example global config file:
@@ -465,6 +550,10 @@ type AuditConfig struct {
 //	    gitlabToken: ${WORK_GITLAB_TOKEN}
 //	  personal:
 //	    githubToken: ${PERSONAL_GITHUB_TOKEN}
+//	credentials:
+//	  - host: ghe.corp.example.com
+//	    provider: github
+//	    token: ${CORP_GHE_TOKEN}
 type GlobalConfig struct {
 	// ActiveProfile is the default profile to use
 	ActiveProfile string `yaml:"activeProfile,omitempty"`
@@ -479,6 +568,21 @@ type GlobalConfig struct {
 
 	// Environments define named token sets
 	Environments map[string]Environment `yaml:"environments,omitempty"`
+
+	// TokenStore selects where tokens set with `config token set` live:
+	// "keyring" (the OS keychain, default) or "file" (an encrypted file under
+	// the config directory, for machines without a keychain). The
+	// GZ_GIT_TOKEN_STORE environment variable overrides it.
+	TokenStore string `yaml:"tokenStore,omitempty"`
+
+	// Credentials route forge hosts (and optionally orgs on them) to tokens,
+	// so one machine can talk to several hosts of the same provider. An
+	// Environment holds one token per provider and cannot express that.
+	Credentials []Credential `yaml:"credentials,omitempty"`
+
+	// Daemon configures `gz-git daemon`: which roots it indexes and where
+	// its socket is.
+	Daemon *DaemonConfig `yaml:"daemon,omitempty"`
 }
 
 // Environment represents a named set of API tokens.
@@ -514,6 +618,11 @@ type ProjectConfig struct {
 	Pull   *PullConfig   `yaml:"pull,omitempty"`
 	Push   *PushConfig   `yaml:"push,omitempty"`
 	Audit  *AuditConfig  `yaml:"audit,omitempty"`
+	Watch  *WatchConfig  `yaml:"watch,omitempty"`
+
+	// Recipes are named command sequences for 'gz-git run'; they replace
+	// profile recipes of the same name.
+	Recipes map[string]*Recipe `yaml:"recipes,omitempty"`
 
 	// Metadata is optional project information
 	Metadata *ProjectMetadata `yaml:"metadata,omitempty"`
@@ -574,6 +683,15 @@ type Config struct {
 	// Supports: absolute paths, home-relative (~), relative paths.
 	Parent string `yaml:"parent,omitempty"`
 
+	// Include lists config files merged into this one, for settings, profiles,
+	// workspaces and workspace templates shared between configs. Each entry is
+	// a local path (relative to this file) or a git repository pinned to a ref:
+	// "<url>@<ref>" for its .gz-git.yaml, "<url>@<ref>//<file>" for another
+	// file. This file overrides what it includes; later includes override
+	// earlier ones. A git include brings no hooks or recipes, and may not use
+	// secret references other than ${VAR} and ${env:VAR}.
+	Include []string `yaml:"include,omitempty"`
+
 	// === This level's settings ===
 
 	// Profile specifies which profile to use at this level
@@ -593,7 +711,7 @@ type Config struct {
 
 	Provider         string `yaml:"provider,omitempty"`         // github, gitlab, gitea
 	BaseURL          string `yaml:"baseURL,omitempty"`          // API endpoint
-	Token            string `yaml:"token,omitempty"`            // API token (use ${ENV_VAR})
+	Token            string `yaml:"token,omitempty"`            // API token (use ${ENV_VAR} or ${keyring:...})
 	IncludeSubgroups bool   `yaml:"includeSubgroups,omitempty"` // GitLab subgroups
 	SubgroupMode     string `yaml:"subgroupMode,omitempty"`     // flat, nested
 
@@ -616,6 +734,16 @@ type Config struct {
 	// Workspaces is a map of named workspace configurations
 	Workspaces map[string]*Workspace `yaml:"workspaces,omitempty"`
 
+	// Groups names sets of repositories for --group. Each member is a
+	// selector such as name=api or label=backend,!archived; a repository
+	// matching any member is in the group.
+	Groups map[string][]string `yaml:"groups,omitempty"`
+
+	// WorkspaceTemplates defines reusable workspace entries. A workspace names
+	// one with template: and fills in its params; the template's params map
+	// declares them, with their defaults (an empty default makes one required).
+	WorkspaceTemplates map[string]*Workspace `yaml:"workspaceTemplates,omitempty"`
+
 	// Metadata is optional information about this level
 	Metadata *Metadata `yaml:"metadata,omitempty"`
 
@@ -678,6 +806,18 @@ type Workspace struct {
 	// Profile overrides the parent profile for this workspace
 	Profile string `yaml:"profile,omitempty"`
 
+	// Template names an entry of workspaceTemplates this workspace is built
+	// from; the workspace's own keys override the template's.
+	Template string `yaml:"template,omitempty"`
+
+	// Labels tag the workspace and every repository in it for --select.
+	Labels []string `yaml:"labels,omitempty"`
+
+	// Params are the values path, url, configLink, additionalRemotes and
+	// source.org/baseURL can use as Go templates ({{ .org }}), next to
+	// {{ .name }}, the workspace name. In a template they declare its params.
+	Params map[string]string `yaml:"params,omitempty"`
+
 	// === Git Repository Settings (for type=git) ===
 
 	// URL is the git clone URL (required for type=git sync)
@@ -727,6 +867,10 @@ type Workspace struct {
 	// ChildConfigMode controls how child config files are generated during sync.
 	// Values: "repositories" (default), "workspaces", "none"
 	ChildConfigMode ChildConfigMode `yaml:"childConfigMode,omitempty"`
+
+	// node is the YAML the workspace was decoded from, so a template is
+	// overlaid with only the keys the entry spells out.
+	node *yaml.Node
 }
 
 // WorkspaceType represents the type of workspace.
@@ -813,7 +957,8 @@ type ForgeSource struct {
 	// BaseURL is the API endpoint (optional, uses default for provider)
 	BaseURL string `yaml:"baseURL,omitempty"`
 
-	// Token overrides the profile token (use ${ENV_VAR} for security)
+	// Token overrides the profile token (use ${ENV_VAR} or a secret
+	// reference such as ${keyring:...} for security)
 	Token string `yaml:"token,omitempty"`
 
 	// IncludeSubgroups includes subgroups (GitLab only)
@@ -900,6 +1045,7 @@ type EffectiveConfig struct {
 	Pull   PullConfig
 	Push   PushConfig
 	Audit  AuditConfig
+	Watch  WatchConfig
 
 	// Metadata for debugging
 	Sources map[string]string // key -> source (e.g., "provider" -> "profile:work")
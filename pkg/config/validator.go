@@ -9,6 +9,9 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/watch"
 )
 
 var (
@@ -112,6 +115,10 @@ func (v *Validator) ValidateProfile(p *Profile) error {
 		}
 	}
 
+	if err := validateRecipes(p.Recipes); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -145,6 +152,30 @@ func (v *Validator) ValidateGlobalConfig(g *GlobalConfig) error {
 		return fmt.Errorf("invalid active profile name '%s': must contain only alphanumeric, dash, or underscore", g.ActiveProfile)
 	}
 
+	if g.TokenStore != "" {
+		if _, err := NewTokenStore(g.TokenStore); err != nil {
+			return fmt.Errorf("invalid tokenStore: %w", err)
+		}
+	}
+
+	for i, c := range g.Credentials {
+		if strings.TrimSpace(c.Host) == "" {
+			return fmt.Errorf("credentials[%d]: host is required", i)
+		}
+		if c.ProviderName() == "" {
+			return fmt.Errorf("credentials[%d]: cannot infer provider for host %q; set provider", i, c.Host)
+		}
+		if err := c.Key().Validate(); err != nil {
+			return fmt.Errorf("credentials[%d]: %w", i, err)
+		}
+	}
+
+	if g.Daemon != nil {
+		if err := g.Daemon.Validate(); err != nil {
+			return fmt.Errorf("daemon: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -166,6 +197,24 @@ func (v *Validator) ValidateProjectConfig(p *ProjectConfig) error {
 		}
 	}
 
+	if p.Watch != nil {
+		if p.Watch.FetchInterval != "" {
+			d, err := time.ParseDuration(p.Watch.FetchInterval)
+			if err != nil || d < watch.MinFetchInterval {
+				return fmt.Errorf("watch.fetchInterval: invalid duration %q (minimum %s)", p.Watch.FetchInterval, watch.MinFetchInterval)
+			}
+		}
+		for i, sink := range p.Watch.Sinks {
+			if err := sink.Validate(); err != nil {
+				return fmt.Errorf("watch.sinks[%d]: %w", i, err)
+			}
+		}
+	}
+
+	if err := validateRecipes(p.Recipes); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -220,6 +269,18 @@ func (v *Validator) ExpandEnvVarsInGlobalConfig(g *GlobalConfig) error {
 		g.Environments[envName] = env
 	}
 
+	for i := range g.Credentials {
+		var err error
+		g.Credentials[i].Token, err = v.expandString(g.Credentials[i].Token)
+		if err != nil {
+			return fmt.Errorf("failed to expand credentials[%d].token: %w", i, err)
+		}
+		g.Credentials[i].BaseURL, err = v.expandString(g.Credentials[i].BaseURL)
+		if err != nil {
+			return fmt.Errorf("failed to expand credentials[%d].baseURL: %w", i, err)
+		}
+	}
+
 	return nil
 }
 
@@ -290,29 +351,18 @@ func (v *Validator) ExpandEnvVarsInWorkspace(ws *Workspace) error {
 	return nil
 }
 
-// expandString expands environment variables in a string.
-// Returns the expanded string or an error if expansion fails.
+// expandString expands ${VAR} and ${env:VAR} references in a string. Other
+// secret references (${file:...}, ${keyring:...}, ${cmd:...}) are left as
+// written and resolved by ResolveSecret when the value is used.
+// Empty env vars are silently accepted — forge commands validate token
+// availability separately when the token is actually needed.
 //
 //nolint:unparam // error return is always nil now but kept for future expansion (e.g. strict mode for missing vars)
 func (v *Validator) expandString(s string) (string, error) {
 	if s == "" {
 		return s, nil
 	}
-
-	// Find all ${VAR} occurrences
-	result := envVarPattern.ReplaceAllStringFunc(s, func(match string) string {
-		// Extract variable name (remove ${ and })
-		varName := match[2 : len(match)-1]
-
-		// Get value from environment
-		// Empty env vars are silently accepted — forge commands validate token
-		// availability separately when the token is actually needed.
-		value := os.Getenv(varName)
-
-		return value
-	})
-
-	return result, nil
+	return expandEnvRefs(s), nil
 }
 
 // IsValidProfileName checks if a profile name is valid.
@@ -352,8 +402,11 @@ func IsValidSyncStrategy(strategy string) bool {
 	return validSyncStrategies[strategy]
 }
 
-// SanitizeToken removes credentials from URLs for safe logging.
+// SanitizeToken removes credentials from URLs, and any secret resolved from
+// a secret reference, for safe logging.
 func SanitizeToken(s string) string {
+	s = RedactSecrets(s)
+
 	// Replace common token patterns in URLs
 	s = regexp.MustCompile(`://[^:]+:[^@]+@`).ReplaceAllString(s, "://***:***@")
 	s = regexp.MustCompile(`\?.*token=[^&]+`).ReplaceAllString(s, "?token=***")
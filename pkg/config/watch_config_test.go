@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestWatchSinksUnmarshalFromProjectConfig(t *testing.T) {
+	const doc = `
+watch:
+  sinks:
+    - type: exec
+      command: [make, lint]
+      events: [modified, staged]
+      limit: 1
+      per: 30s
+    - type: webhook
+      url: https://hooks.example.com/gz-git
+      headers:
+        Authorization: Bearer ${TOKEN}
+`
+
+	var project ProjectConfig
+	if err := yaml.Unmarshal([]byte(doc), &project); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if project.Watch == nil || len(project.Watch.Sinks) != 2 {
+		t.Fatalf("watch sinks were not parsed: %+v", project.Watch)
+	}
+
+	exec := project.Watch.Sinks[0]
+	if strings.Join(exec.Command, " ") != "make lint" || exec.Limit != 1 || exec.Per != "30s" {
+		t.Errorf("exec sink = %+v", exec)
+	}
+	if got := project.Watch.Sinks[1].Headers["Authorization"]; got != "Bearer ${TOKEN}" {
+		t.Errorf("header = %q; expansion belongs to the sink, not the parser", got)
+	}
+
+	if err := NewValidator().ValidateProjectConfig(&project); err != nil {
+		t.Errorf("ValidateProjectConfig: %v", err)
+	}
+}
+
+func TestValidateProjectConfigRejectsBadSink(t *testing.T) {
+	const doc = `
+watch:
+  sinks:
+    - type: file
+    - type: pager
+`
+
+	var project ProjectConfig
+	if err := yaml.Unmarshal([]byte(doc), &project); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	err := NewValidator().ValidateProjectConfig(&project)
+	if err == nil || !strings.Contains(err.Error(), "watch.sinks[0]") {
+		t.Fatalf("ValidateProjectConfig() = %v, want watch.sinks[0] error", err)
+	}
+}
+
+func TestValidateProjectConfigWatchFetchInterval(t *testing.T) {
+	for _, interval := range []string{"5m", "90s"} {
+		project := ProjectConfig{Watch: &WatchConfig{FetchInterval: interval}}
+		if err := NewValidator().ValidateProjectConfig(&project); err != nil {
+			t.Errorf("fetchInterval %q: %v", interval, err)
+		}
+	}
+	for _, interval := range []string{"often", "-1m", "0s", "1ns", "500ms"} {
+		project := ProjectConfig{Watch: &WatchConfig{FetchInterval: interval}}
+		err := NewValidator().ValidateProjectConfig(&project)
+		if err == nil || !strings.Contains(err.Error(), "watch.fetchInterval") {
+			t.Errorf("fetchInterval %q: got %v, want watch.fetchInterval error", interval, err)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package credential implements the git credential-helper protocol on top of
+// gz-git's token storage, so the token a user stored once with
+// `gz-git config token set` authenticates both API calls and plain
+// `git fetch`/`git push` over HTTPS.
+//
+// Git runs the helper with one of three actions and a credential description
+// on stdin (see git-credential(1)): get asks for a username and password,
+// store reports a credential that worked, and erase reports one that was
+// rejected. Each description is routed by host, and by org when git sends the
+// repository path (credential.useHttpPath), through the same lookup the API
+// commands use, config.ResolveHostToken.
+package credential
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/provider"
+)
+
+// Request is one credential description as git writes it: key=value lines
+// ended by a blank line or EOF. Attributes gz-git does not use are kept in
+// Extra so nothing a newer git sends is silently dropped.
+type Request struct {
+	Protocol string
+	Host     string
+	Path     string
+	Username string
+	Password string
+	Extra    map[string]string
+}
+
+// Parse reads a credential description from r.
+func Parse(r io.Reader) (Request, error) {
+	var req Request
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return Request{}, fmt.Errorf("malformed credential line %q", line)
+		}
+		switch key {
+		case "protocol":
+			req.Protocol = value
+		case "host":
+			req.Host = value
+		case "path":
+			req.Path = value
+		case "username":
+			req.Username = value
+		case "password":
+			req.Password = value
+		default:
+			if req.Extra == nil {
+				req.Extra = make(map[string]string)
+			}
+			req.Extra[key] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Request{}, fmt.Errorf("read credential description: %w", err)
+	}
+	return req, nil
+}
+
+// Hostname returns Host without the port git includes for non-default ports.
+// Credentials are keyed by hostname, the same way remote URLs are parsed.
+func (r Request) Hostname() string {
+	host := strings.ToLower(r.Host)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+	return host
+}
+
+// Org returns the top-level namespace from Path, or "" when git did not send
+// a path.
+func (r Request) Org() string {
+	org, rest, ok := strings.Cut(strings.TrimPrefix(r.Path, "/"), "/")
+	if !ok || rest == "" {
+		// A bare "repo.git" has no namespace to scope by.
+		return ""
+	}
+	return org
+}
+
+// Helper answers git's credential requests.
+type Helper struct {
+	// Credentials are the hosts declared in the global config. They supply
+	// the provider for hosts whose name does not give it away and
+	// config-file tokens.
+	Credentials []config.Credential
+}
+
+// Get returns the credential for req, or ok=false when gz-git has nothing for
+// the host — git then asks the next helper or prompts, as if this helper were
+// not configured. err is set when the host's configured token reference
+// does not resolve; no other token is offered in its place.
+//
+// Only https is answered: a token sent over plain http is a token published.
+func (h *Helper) Get(req Request) (resp Request, ok bool, err error) {
+	providerName, host, org, routable := h.route(req)
+	if !routable {
+		return Request{}, false, nil
+	}
+	token, _, err := config.ResolveHostToken(h.Credentials, providerName, host, org)
+	if err != nil {
+		return Request{}, false, err
+	}
+	if token == "" {
+		return Request{}, false, nil
+	}
+	username := req.Username
+	if username == "" {
+		username = defaultUsername(providerName)
+	}
+	return Request{Username: username, Password: token}, true, nil
+}
+
+// Store records a credential git reports as having worked, under the host
+// (and org, when git sent a path). A credential that is already what Get
+// resolves is not written again: git calls store after every successful get,
+// and copying GITHUB_TOKEN from the environment into the keychain would
+// outlive the environment it came from.
+func (h *Helper) Store(req Request) error {
+	providerName, host, org, routable := h.route(req)
+	if !routable || req.Password == "" {
+		return nil
+	}
+	current, _, err := config.ResolveHostToken(h.Credentials, providerName, host, org)
+	if err != nil {
+		// A keychain entry would shadow the broken reference for good.
+		return fmt.Errorf("not storing a credential for %s: %w", host, err)
+	}
+	if current == req.Password {
+		return nil
+	}
+	key := config.CredentialKey{Provider: providerName, Host: host, Org: org}
+	if err := config.DefaultTokenStore.Set(key.String(), req.Password); err != nil {
+		return fmt.Errorf("store %s: %w", key, err)
+	}
+	return nil
+}
+
+// Erase forgets a credential git reports as rejected. Only the host-scoped
+// keychain entry is removed, and only when it holds the rejected password:
+// a failure on one host must not delete the provider-wide token every other
+// host still relies on, and environment or config-file tokens are not
+// gz-git's to delete.
+func (h *Helper) Erase(req Request) error {
+	providerName, host, org, routable := h.route(req)
+	if !routable {
+		return nil
+	}
+	key := config.CredentialKey{Provider: providerName, Host: host, Org: org}
+	stored, err := config.DefaultTokenStore.Get(key.String())
+	if err != nil {
+		return fmt.Errorf("read %s: %w", key, err)
+	}
+	if stored == "" || (req.Password != "" && stored != req.Password) {
+		return nil
+	}
+	if err := config.DefaultTokenStore.Delete(key.String()); err != nil {
+		return fmt.Errorf("erase %s: %w", key, err)
+	}
+	return nil
+}
+
+// route resolves the provider, hostname, and org for req. routable is false
+// for non-https requests and for every host that is neither a public forge
+// nor declared under credentials:. The helper is usually wired for all
+// HTTPS remotes, so a host is never attributed to a forge by its name alone:
+// github.attacker.io must not receive the github.com token.
+func (h *Helper) route(req Request) (providerName, host, org string, routable bool) {
+	if req.Protocol != "https" {
+		return "", "", "", false
+	}
+	host = req.Hostname()
+	if host == "" {
+		return "", "", "", false
+	}
+	org = req.Org()
+	if entry, ok := config.FindCredential(h.Credentials, host, org); ok {
+		providerName = entry.ProviderName()
+	} else {
+		providerName = provider.PublicForgeProvider(host)
+	}
+	return providerName, host, org, providerName != ""
+}
+
+// defaultUsername is the username each forge documents for token auth over
+// HTTPS. GitHub and Gitea accept any username when the password is a token;
+// GitLab documents "oauth2" for tokens passed this way.
+func defaultUsername(providerName string) string {
+	switch providerName {
+	case "gitlab":
+		return "oauth2"
+	default:
+		return "x-access-token"
+	}
+}
+
+// Write emits the attributes of r that are set, in the order git documents,
+// followed by the blank line that ends a description.
+func (r Request) Write(w io.Writer) error {
+	var b strings.Builder
+	for _, kv := range [][2]string{
+		{"protocol", r.Protocol},
+		{"host", r.Host},
+		{"path", r.Path},
+		{"username", r.Username},
+		{"password", r.Password},
+	} {
+		if kv[1] != "" {
+			fmt.Fprintf(&b, "%s=%s\n", kv[0], kv[1])
+		}
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package credential
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+)
+
+func withTokenStore(t *testing.T) *config.MemoryTokenStore {
+	t.Helper()
+	mem := config.NewMemoryTokenStore()
+	prev := config.DefaultTokenStore
+	config.SetTokenStore(mem)
+	t.Cleanup(func() { config.SetTokenStore(prev) })
+	for _, v := range []string{"GITHUB_TOKEN", "GITLAB_TOKEN", "GITEA_TOKEN", "GZ_GIT_TOKEN"} {
+		t.Setenv(v, "")
+	}
+	return mem
+}
+
+func TestParseAndWrite(t *testing.T) {
+	in := "protocol=https\nhost=git.example.com:8443\npath=acme/app.git\nwwwauth[]=Basic\n\nignored=after-blank\n"
+	req, err := Parse(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Protocol != "https" || req.Hostname() != "git.example.com" || req.Org() != "acme" {
+		t.Fatalf("parsed %+v", req)
+	}
+	if req.Extra["wwwauth[]"] != "Basic" || req.Extra["ignored"] != "" {
+		t.Fatalf("extra = %v", req.Extra)
+	}
+
+	var out bytes.Buffer
+	if err := (Request{Username: "u", Password: "p"}).Write(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "username=u\npassword=p\n\n" {
+		t.Fatalf("write = %q", out.String())
+	}
+
+	if _, err := Parse(strings.NewReader("no-equals-sign\n")); err == nil {
+		t.Fatal("expected malformed line error")
+	}
+}
+
+func TestHelperGet_RoutesByHostAndOrg(t *testing.T) {
+	mem := withTokenStore(t)
+	h := &Helper{Credentials: []config.Credential{{Host: "git.internal.example", Provider: "gitlab"}}}
+	_ = mem.Set("github", "public")
+	_ = mem.Set("github@github.com/acme", "acme")
+	_ = mem.Set("gitlab@git.internal.example", "internal")
+
+	tests := []struct {
+		name     string
+		req      Request
+		wantOK   bool
+		wantUser string
+		wantPass string
+	}{
+		{"provider-wide token", Request{Protocol: "https", Host: "github.com"}, true, "x-access-token", "public"},
+		{"org from path", Request{Protocol: "https", Host: "github.com", Path: "acme/app.git"}, true, "x-access-token", "acme"},
+		{"declared host", Request{Protocol: "https", Host: "git.internal.example"}, true, "oauth2", "internal"},
+		{"username kept", Request{Protocol: "https", Host: "github.com", Username: "me"}, true, "me", "public"},
+		{"plain http refused", Request{Protocol: "http", Host: "github.com"}, false, "", ""},
+		{"unknown host", Request{Protocol: "https", Host: "example.org"}, false, "", ""},
+		{"look-alike github host", Request{Protocol: "https", Host: "github.attacker.io"}, false, "", ""},
+		{"look-alike gitlab host", Request{Protocol: "https", Host: "evil-gitlab.net", Path: "acme/app.git"}, false, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, ok, err := h.Get(tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != tt.wantOK || resp.Username != tt.wantUser || resp.Password != tt.wantPass {
+				t.Fatalf("Get = %+v, %v", resp, ok)
+			}
+		})
+	}
+}
+
+func TestHelperStoreAndErase(t *testing.T) {
+	mem := withTokenStore(t)
+	h := &Helper{Credentials: []config.Credential{{Host: "github.example.com", Provider: "github"}}}
+	t.Setenv("GITHUB_TOKEN", "from-env")
+
+	// A credential Get already resolves is not copied into the keychain.
+	if err := h.Store(Request{Protocol: "https", Host: "github.com", Password: "from-env"}); err != nil {
+		t.Fatal(err)
+	}
+	if tok, _ := mem.Get("github@github.com"); tok != "" {
+		t.Fatalf("env token persisted: %q", tok)
+	}
+
+	if err := h.Store(Request{Protocol: "https", Host: "github.example.com", Password: "ghe"}); err != nil {
+		t.Fatal(err)
+	}
+	if tok, _ := mem.Get("github@github.example.com"); tok != "ghe" {
+		t.Fatalf("stored = %q", tok)
+	}
+
+	// Erasing a different password leaves the entry alone.
+	if err := h.Erase(Request{Protocol: "https", Host: "github.example.com", Password: "other"}); err != nil {
+		t.Fatal(err)
+	}
+	if tok, _ := mem.Get("github@github.example.com"); tok != "ghe" {
+		t.Fatalf("mismatched erase removed entry")
+	}
+
+	// The provider-wide key survives an erase on one host.
+	_ = mem.Set("github", "catch-all")
+	if err := h.Erase(Request{Protocol: "https", Host: "github.example.com", Password: "ghe"}); err != nil {
+		t.Fatal(err)
+	}
+	if tok, _ := mem.Get("github@github.example.com"); tok != "" {
+		t.Fatalf("erase left %q", tok)
+	}
+	if tok, _ := mem.Get("github"); tok != "catch-all" {
+		t.Fatalf("provider-wide token erased")
+	}
+}
+
+func TestHelperGet_UnresolvedReferenceIsAnError(t *testing.T) {
+	mem := withTokenStore(t)
+	t.Setenv("GITHUB_TOKEN", "from-env")
+	_ = mem.Set("github", "catch-all")
+	h := &Helper{Credentials: []config.Credential{
+		{Host: "github.com", Org: "acme", Token: "${file:/nonexistent/acme-token}"},
+	}}
+	req := Request{Protocol: "https", Host: "github.com", Path: "acme/app.git", Password: "typed"}
+
+	resp, ok, err := h.Get(req)
+	if err == nil || !strings.Contains(err.Error(), "github.com/acme") || ok || resp.Password != "" {
+		t.Fatalf("Get = %+v, %v, %v; want an error and no token", resp, ok, err)
+	}
+	if err := h.Store(req); err == nil {
+		t.Fatal("Store over a broken reference should fail")
+	}
+	if tok, _ := mem.Get("github@github.com/acme"); tok != "" {
+		t.Fatalf("stored %q over the broken reference", tok)
+	}
+}
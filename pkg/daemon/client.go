@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ErrNotRunning means nothing answers on the socket.
+var ErrNotRunning = errors.New("daemon is not running")
+
+// Client talks to a daemon over its socket.
+type Client struct {
+	socket string
+	http   *http.Client
+}
+
+// NewClient returns a client for the daemon at socket. It does not connect;
+// the first request does, and fails with ErrNotRunning if nobody listens.
+func NewClient(socket string) *Client {
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socket)
+		},
+	}
+	return &Client{
+		socket: socket,
+		// Queries are answered from memory; a daemon that takes longer than
+		// this is stuck, and the caller is better off scanning itself.
+		http: &http.Client{Transport: transport, Timeout: 10 * time.Second},
+	}
+}
+
+// Ping returns what the daemon says about itself.
+func (c *Client) Ping(ctx context.Context) (Ping, error) {
+	var ping Ping
+	err := c.do(ctx, http.MethodGet, "/v1/ping", nil, &ping)
+	return ping, err
+}
+
+// Repos returns the indexed repositories matching q. Check Covered before
+// trusting an empty list.
+func (c *Client) Repos(ctx context.Context, q Query) (ReposResponse, error) {
+	var resp ReposResponse
+	err := c.do(ctx, http.MethodGet, "/v1/repos", q.values(), &resp)
+	return resp, err
+}
+
+// Refresh re-checks one repository, or rescans every root when path is
+// empty, and returns once the index is current.
+func (c *Client) Refresh(ctx context.Context, path string) error {
+	var v url.Values
+	if path != "" {
+		v = url.Values{"path": {path}}
+	}
+	return c.do(ctx, http.MethodPost, "/v1/refresh", v, nil)
+}
+
+// Shutdown asks the daemon to stop.
+func (c *Client) Shutdown(ctx context.Context) error {
+	return c.do(ctx, http.MethodPost, "/v1/shutdown", nil, nil)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, out any) error {
+	// The host is ignored by the dialer; it only has to make a valid URL.
+	u := url.URL{Scheme: "http", Host: "gz-git", Path: path, RawQuery: query.Encode()}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return fmt.Errorf("%w: %s", ErrNotRunning, c.socket)
+		}
+		return fmt.Errorf("daemon request %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var body errorBody
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		if json.Unmarshal(data, &body) == nil && body.Error != "" {
+			return fmt.Errorf("daemon: %s", body.Error)
+		}
+		return fmt.Errorf("daemon: %s", resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode daemon response: %w", err)
+	}
+	return nil
+}
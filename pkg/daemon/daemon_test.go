@@ -0,0 +1,286 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/reposync"
+)
+
+func TestCovers(t *testing.T) {
+	roots := []Root{{Path: "/src", Depth: 2}}
+	tests := []struct {
+		q    Query
+		want bool
+	}{
+		{Query{Dir: "/src", Depth: 2}, true},
+		{Query{Dir: "/src", Depth: 3}, false},
+		{Query{Dir: "/src/team", Depth: 1}, true},
+		{Query{Dir: "/src/team", Depth: 2}, false},
+		{Query{Dir: "/", Depth: 1}, false},
+		{Query{Dir: "/srcx", Depth: 0}, false},
+		{Query{Dir: "/src", Depth: 1, IncludeSubmodules: true}, false},
+	}
+	for _, tt := range tests {
+		if got := covers(roots, tt.q); got != tt.want {
+			t.Errorf("covers(%+v) = %v, want %v", tt.q, got, tt.want)
+		}
+	}
+}
+
+func TestIndexQuery(t *testing.T) {
+	ix := newIndex([]Root{{Path: "/src", Depth: 2}})
+	for _, p := range []string{"/src/a", "/src/team/b", "/src/team/c"} {
+		ix.put(p, Record{Status: repository.RepositoryStatusResult{Path: p}})
+	}
+
+	resp, err := ix.query(Query{Dir: "/src", Depth: 2})
+	if err != nil || resp.Covered {
+		t.Fatalf("query before ready = %+v, %v; want uncovered", resp, err)
+	}
+	ix.setReady()
+
+	resp, err = ix.query(Query{Dir: "/src/team", Depth: 1, Exclude: "/c$"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Covered || len(resp.Repositories) != 1 || resp.Repositories[0].Status.RelativePath != "b" {
+		t.Errorf("query = %+v, want only b relative to /src/team", resp)
+	}
+
+	resp, _ = ix.query(Query{Dir: "/src", Depth: 1})
+	if len(resp.Repositories) != 1 || resp.Repositories[0].Status.Path != "/src/a" {
+		t.Errorf("depth 1 = %+v, want only /src/a", resp.Repositories)
+	}
+
+	if _, err := ix.query(Query{Dir: "/src", Depth: 1, Include: "("}); err == nil {
+		t.Error("invalid include pattern should be rejected")
+	}
+}
+
+func TestRecordRoundTripKeepsErrors(t *testing.T) {
+	status := repository.RepositoryStatusResult{Path: "/r", Status: repository.StatusError, Error: errors.New("open failed")}
+	health := reposync.RepoHealth{HealthStatus: reposync.HealthError, Error: errors.New("no info")}
+
+	data, err := json.Marshal(newRecord(status, health, time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Record
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	gotStatus, gotHealth := decoded.Results()
+	if gotStatus.Error == nil || gotStatus.Error.Error() != "open failed" {
+		t.Errorf("status error = %v", gotStatus.Error)
+	}
+	if gotHealth.Error == nil || gotHealth.Error.Error() != "no info" {
+		t.Errorf("health error = %v", gotHealth.Error)
+	}
+}
+
+func TestClientNotRunning(t *testing.T) {
+	c := NewClient(filepath.Join(t.TempDir(), "none.sock"))
+	if _, err := c.Ping(context.Background()); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("Ping() = %v, want ErrNotRunning", err)
+	}
+}
+
+func TestPrivateUmask(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no umask on windows")
+	}
+	path := filepath.Join(t.TempDir(), "f")
+	restore := privateUmask()
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o666)
+	restore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("mode under privateUmask = %04o, want 0600", perm)
+	}
+}
+
+func TestListenRestrictsSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix modes are not enforced on windows")
+	}
+	socket := filepath.Join(t.TempDir(), "d.sock")
+	listener, err := Listen(socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	info, err := os.Stat(socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("socket mode = %04o, want 0600", perm)
+	}
+}
+
+func TestServerIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	root := t.TempDir()
+	repo := filepath.Join(root, "app")
+	initTestRepo(t, repo)
+
+	socket, done := startTestServer(t, root)
+	client := NewClient(socket)
+	query := Query{Dir: root, Depth: 1}
+	statusOf := func() (string, bool) {
+		resp, err := client.Repos(context.Background(), query)
+		if err != nil || !resp.Covered || len(resp.Repositories) != 1 {
+			return "", false
+		}
+		return resp.Repositories[0].Status.Status, true
+	}
+
+	waitFor(t, "initial index", func() bool {
+		_, ok := statusOf()
+		return ok
+	})
+	if status, _ := statusOf(); status != repository.StatusNoRemote {
+		t.Errorf("status = %q, want %q", status, repository.StatusNoRemote)
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, "new.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "dirty after a watch event", func() bool {
+		status, _ := statusOf()
+		return status == repository.StatusDirty
+	})
+
+	if _, err := Listen(socket); err == nil {
+		t.Error("a second daemon on the same socket should be refused")
+	}
+
+	if err := client.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run() = %v", err)
+		}
+		done <- nil
+	case <-time.After(5 * time.Second):
+		t.Fatal("daemon did not stop")
+	}
+	if _, err := os.Stat(socket); !os.IsNotExist(err) {
+		t.Errorf("socket should be removed on exit: %v", err)
+	}
+}
+
+func TestServerIntegrationSkipsBrokenRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	root := t.TempDir()
+	repo := filepath.Join(root, "app")
+	initTestRepo(t, repo)
+	// A .git file pointing nowhere: found by the scan, unreadable by git.
+	broken := filepath.Join(root, "broken")
+	if err := os.MkdirAll(broken, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(broken, ".git"), []byte("gitdir: /nonexistent\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	socket, done := startTestServer(t, root)
+	client := NewClient(socket)
+	statusOf := func() string {
+		resp, err := client.Repos(context.Background(), Query{Dir: root, Depth: 1})
+		if err != nil {
+			return ""
+		}
+		for _, r := range resp.Repositories {
+			if r.Status.Path == repo {
+				return r.Status.Status
+			}
+		}
+		return ""
+	}
+
+	waitFor(t, "initial index", func() bool { return statusOf() != "" })
+	if err := os.WriteFile(filepath.Join(repo, "new.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "dirty after a watch event", func() bool {
+		return statusOf() == repository.StatusDirty
+	})
+	select {
+	case err := <-done:
+		done <- err
+		t.Fatalf("daemon exited: %v", err)
+	default:
+	}
+}
+
+func initTestRepo(t *testing.T, repo string) {
+	t.Helper()
+	for _, args := range [][]string{
+		{"init", "-q", repo},
+		{"-C", repo, "config", "user.name", "Test User"},
+		{"-C", repo, "config", "user.email", "test@example.com"},
+		{"-C", repo, "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil { //nolint:noctx // test setup
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+}
+
+// startTestServer runs a daemon over root until the test ends. done
+// receives Run's result; the cleanup waits for it, so a test that reads it
+// must send a value back.
+func startTestServer(t *testing.T, root string) (socket string, done chan error) {
+	t.Helper()
+	socket = filepath.Join(t.TempDir(), "d.sock")
+	server, err := NewServer(repository.NewClient(), Options{Socket: socket, Roots: []Root{{Path: root, Depth: 1}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done = make(chan error, 1)
+	go func() { done <- server.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return socket, done
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", what)
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package daemon keeps an in-memory index of the repositories under a set of
+// roots and answers status queries about them over a Unix socket.
+//
+// Without it every `gz-git status` or `gz-git info` rescans the directory tree
+// and runs git in every repository. The daemon does that once at startup,
+// then keeps each entry current from watch events: a repository is
+// re-checked only after something in it changed, and background fetches
+// (watch's --fetch-interval) keep the upstream side current. A query is a
+// read from memory.
+//
+// The API is plain HTTP with JSON bodies on a socket only the user can open:
+//
+//	GET  /v1/ping                      daemon identity and index size
+//	GET  /v1/repos?dir=&depth=&...     indexed repositories under dir
+//	POST /v1/refresh?path=             re-check one repository, or all
+//	POST /v1/shutdown                  stop the daemon
+//
+// Each indexed repository carries both the repository.RepositoryStatusResult
+// that `info` renders and the reposync.RepoHealth that `status` renders, so
+// either command can be answered without running git. A query for a
+// directory the daemon does not cover reports Covered=false, and callers
+// fall back to scanning themselves.
+package daemon
@@ -0,0 +1,158 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package daemon
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// index holds the latest record of every repository under the roots.
+type index struct {
+	mu    sync.RWMutex
+	roots []Root
+	repos map[string]Record
+	ready bool
+}
+
+func newIndex(roots []Root) *index {
+	return &index{roots: roots, repos: make(map[string]Record)}
+}
+
+func (ix *index) put(path string, r Record) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.repos[path] = r
+}
+
+// retain drops every repository not in paths, which is how a rescan forgets
+// repositories that were deleted or moved.
+func (ix *index) retain(paths []string) {
+	keep := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		keep[p] = true
+	}
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	for p := range ix.repos {
+		if !keep[p] {
+			delete(ix.repos, p)
+		}
+	}
+}
+
+func (ix *index) has(path string) bool {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	_, ok := ix.repos[path]
+	return ok
+}
+
+func (ix *index) paths() []string {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	paths := make([]string, 0, len(ix.repos))
+	for p := range ix.repos {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func (ix *index) setReady() {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.ready = true
+}
+
+func (ix *index) size() (int, bool) {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.repos), ix.ready
+}
+
+// query answers q from the index. RelativePath in each result is rewritten
+// relative to q.Dir, as a scan of q.Dir would have produced it.
+func (ix *index) query(q Query) (ReposResponse, error) {
+	var include, exclude *regexp.Regexp
+	var err error
+	if q.Include != "" {
+		if include, err = regexp.Compile(q.Include); err != nil {
+			return ReposResponse{}, fmt.Errorf("invalid include pattern: %w", err)
+		}
+	}
+	if q.Exclude != "" {
+		if exclude, err = regexp.Compile(q.Exclude); err != nil {
+			return ReposResponse{}, fmt.Errorf("invalid exclude pattern: %w", err)
+		}
+	}
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	resp := ReposResponse{Repositories: []Record{}}
+	if !ix.ready || !covers(ix.roots, q) {
+		return resp, nil
+	}
+	resp.Covered = true
+
+	for path, r := range ix.repos {
+		depth, ok := depthBelow(q.Dir, path)
+		if !ok || depth > q.Depth {
+			continue
+		}
+		if exclude != nil && exclude.MatchString(path) {
+			continue
+		}
+		if include != nil && !include.MatchString(path) {
+			continue
+		}
+		r.Status.RelativePath = relativePath(q.Dir, path)
+		resp.Repositories = append(resp.Repositories, r)
+	}
+	sort.Slice(resp.Repositories, func(i, j int) bool {
+		return resp.Repositories[i].Status.Path < resp.Repositories[j].Status.Path
+	})
+	return resp, nil
+}
+
+// covers reports whether a scan for q would find only repositories the index
+// has: q.Dir lies within a root, and q.Depth below it stays within the
+// root's depth. Submodules are never indexed.
+func covers(roots []Root, q Query) bool {
+	if q.IncludeSubmodules {
+		return false
+	}
+	for _, root := range roots {
+		if offset, ok := depthBelow(root.Path, q.Dir); ok && offset+q.Depth <= root.Depth {
+			return true
+		}
+	}
+	return false
+}
+
+// depthBelow returns how many directory levels path is below dir, and
+// whether it is below (or equal to) dir at all.
+func depthBelow(dir, path string) (int, bool) {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return 0, false
+	}
+	if rel == "." {
+		return 0, true
+	}
+	return strings.Count(rel, string(filepath.Separator)) + 1, true
+}
+
+func relativePath(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return path
+	}
+	return rel
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package daemon
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/reposync"
+)
+
+// Root is a directory the daemon indexes, scanned to Depth levels as
+// `gz-git status --scan-depth` would.
+type Root struct {
+	Path  string `json:"path"`
+	Depth int    `json:"depth"`
+}
+
+// Ping describes a running daemon.
+type Ping struct {
+	PID          int       `json:"pid"`
+	StartedAt    time.Time `json:"startedAt"`
+	Roots        []Root    `json:"roots"`
+	Repositories int       `json:"repositories"`
+	// Ready is false until the initial scan of every root has finished.
+	// Queries before then report Covered=false.
+	Ready bool `json:"ready"`
+}
+
+// Query selects indexed repositories the way the bulk flags select scanned
+// ones: under Dir, at most Depth levels down, filtered by the Include and
+// Exclude regular expressions matched against the absolute path.
+type Query struct {
+	Dir               string
+	Depth             int
+	Include           string
+	Exclude           string
+	IncludeSubmodules bool
+}
+
+func (q Query) values() url.Values {
+	v := url.Values{}
+	v.Set("dir", q.Dir)
+	v.Set("depth", strconv.Itoa(q.Depth))
+	if q.Include != "" {
+		v.Set("include", q.Include)
+	}
+	if q.Exclude != "" {
+		v.Set("exclude", q.Exclude)
+	}
+	if q.IncludeSubmodules {
+		v.Set("submodules", "true")
+	}
+	return v
+}
+
+func parseQuery(v url.Values) (Query, error) {
+	q := Query{
+		Dir:               v.Get("dir"),
+		Include:           v.Get("include"),
+		Exclude:           v.Get("exclude"),
+		IncludeSubmodules: v.Get("submodules") == "true",
+	}
+	if q.Dir == "" {
+		return q, errors.New("dir is required")
+	}
+	if d := v.Get("depth"); d != "" {
+		n, err := strconv.Atoi(d)
+		if err != nil || n < 0 {
+			return q, errors.New("depth must be a non-negative integer")
+		}
+		q.Depth = n
+	}
+	return q, nil
+}
+
+// ReposResponse answers a Query.
+type ReposResponse struct {
+	// Covered is false when the query reaches outside the indexed roots, or
+	// the initial scan has not finished. Repositories is then empty and the
+	// caller must scan for itself.
+	Covered      bool     `json:"covered"`
+	Repositories []Record `json:"repositories"`
+}
+
+// Record is one indexed repository as it travels over the socket. The
+// results' Error fields are interfaces json cannot decode, so they are
+// carried as strings beside them.
+type Record struct {
+	Status      repository.RepositoryStatusResult `json:"status"`
+	StatusError string                            `json:"statusError,omitempty"`
+	Health      reposync.RepoHealth               `json:"health"`
+	HealthError string                            `json:"healthError,omitempty"`
+	UpdatedAt   time.Time                         `json:"updatedAt"`
+}
+
+// newRecord packs the results of checking one repository.
+func newRecord(status repository.RepositoryStatusResult, health reposync.RepoHealth, at time.Time) Record {
+	r := Record{Status: status, Health: health, UpdatedAt: at}
+	if status.Error != nil {
+		r.StatusError = status.Error.Error()
+		r.Status.Error = nil
+	}
+	if health.Error != nil {
+		r.HealthError = health.Error.Error()
+		r.Health.Error = nil
+	}
+	return r
+}
+
+// Results unpacks the record into the types the status and info commands
+// render, with the errors restored.
+func (r Record) Results() (repository.RepositoryStatusResult, reposync.RepoHealth) {
+	status, health := r.Status, r.Health
+	if r.StatusError != "" {
+		status.Error = errors.New(r.StatusError)
+	}
+	if r.HealthError != "" {
+		health.Error = errors.New(r.HealthError)
+	}
+	return status, health
+}
@@ -0,0 +1,433 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/reposync"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/watch"
+)
+
+// Options configures a Server.
+type Options struct {
+	// Socket is the Unix socket to listen on. See DefaultSocketPath.
+	Socket string
+
+	// Roots are the directories to index. Paths must be absolute.
+	Roots []Root
+
+	// Parallel bounds the repositories checked at once during a scan.
+	// Defaults to 4.
+	Parallel int
+
+	// FetchInterval is passed to the watcher: how often remotes are fetched
+	// in the background so upstream counts stay current. Zero leaves remote
+	// state as of the last fetch someone else ran.
+	FetchInterval time.Duration
+
+	// RescanInterval is how often the roots are scanned again for
+	// repositories that were cloned, moved, or deleted. Defaults to five
+	// minutes. Changes inside known repositories do not wait for it; they
+	// arrive as watch events.
+	RescanInterval time.Duration
+
+	// Logger receives progress and problems. Defaults to discarding.
+	Logger watch.Logger
+}
+
+// Server is a running index and the socket that serves it.
+type Server struct {
+	client  repository.Client
+	opts    Options
+	index   *index
+	started time.Time
+
+	// rescanNow asks the main loop for a rescan; each request carries the
+	// channel its result goes to.
+	rescanNow chan chan error
+
+	shutdown     chan struct{}
+	shutdownOnce sync.Once
+
+	refreshMu  sync.Mutex
+	refreshing map[string]bool
+	dirty      map[string]bool
+}
+
+// NewServer validates opts and returns a server ready to Run.
+func NewServer(client repository.Client, opts Options) (*Server, error) {
+	if opts.Socket == "" {
+		return nil, errors.New("socket path is required")
+	}
+	if len(opts.Roots) == 0 {
+		return nil, errors.New("at least one root is required")
+	}
+	for _, root := range opts.Roots {
+		if !filepath.IsAbs(root.Path) {
+			return nil, fmt.Errorf("root %s: path must be absolute", root.Path)
+		}
+	}
+	if opts.Parallel <= 0 {
+		opts.Parallel = 4
+	}
+	if opts.RescanInterval <= 0 {
+		opts.RescanInterval = 5 * time.Minute
+	}
+	if opts.Logger == nil {
+		opts.Logger = discardLogger{}
+	}
+	return &Server{
+		client:     client,
+		opts:       opts,
+		index:      newIndex(opts.Roots),
+		rescanNow:  make(chan chan error),
+		shutdown:   make(chan struct{}),
+		refreshing: make(map[string]bool),
+		dirty:      make(map[string]bool),
+	}, nil
+}
+
+// Run serves until ctx is canceled or a client asks the daemon to stop. The
+// socket answers from the start; queries report Covered=false until the
+// first scan has finished.
+func (s *Server) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	listener, err := Listen(s.opts.Socket)
+	if err != nil {
+		return err
+	}
+	s.started = time.Now()
+
+	httpServer := &http.Server{Handler: s.handler(), ReadHeaderTimeout: 5 * time.Second}
+	serveErr := make(chan error, 1)
+	go func() {
+		if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+		}
+	}()
+	defer func() {
+		shutdownCtx, stop := context.WithTimeout(context.Background(), 5*time.Second)
+		defer stop()
+		_ = httpServer.Shutdown(shutdownCtx)
+		_ = os.Remove(s.opts.Socket)
+	}()
+
+	var (
+		watcher watch.Watcher
+		watched []string
+		events  <-chan watch.Event
+		errs    <-chan error
+	)
+	defer func() {
+		if watcher != nil {
+			_ = watcher.Stop()
+		}
+	}()
+
+	// rescan re-indexes every root and restarts the watcher when the set of
+	// repositories changed. The watcher cannot add repositories to a running
+	// watch, and a rescan that finds something new is rare. The old watcher
+	// keeps running until its replacement has started, so a failed restart
+	// loses no live updates and is retried on the next rescan.
+	rescan := func() error {
+		paths, err := s.rescan(ctx)
+		if err != nil {
+			return err
+		}
+		if slices.Equal(paths, watched) {
+			return nil
+		}
+		var w watch.Watcher
+		if len(paths) > 0 {
+			w, err = watch.NewWatcher(s.client, watch.WatchOptions{
+				Interval:         10 * time.Second,
+				DebounceDuration: 500 * time.Millisecond,
+				FetchInterval:    s.opts.FetchInterval,
+				Workers:          s.opts.Parallel,
+				SkipUnreadable:   true,
+				Logger:           s.opts.Logger,
+			})
+			if err != nil {
+				return fmt.Errorf("create watcher: %w", err)
+			}
+			if err := w.Start(ctx, paths); err != nil {
+				_ = w.Stop()
+				return fmt.Errorf("start watcher: %w", err)
+			}
+		}
+		if watcher != nil {
+			_ = watcher.Stop()
+			watcher, events, errs = nil, nil, nil
+		}
+		if w != nil {
+			watcher, events, errs = w, w.Events(), w.Errors()
+		}
+		watched = paths
+		return nil
+	}
+
+	if err := rescan(); err != nil {
+		return err
+	}
+	s.index.setReady()
+	n, _ := s.index.size()
+	s.opts.Logger.Info("indexed %d repositories under %d root(s)", n, len(s.opts.Roots))
+
+	ticker := time.NewTicker(s.opts.RescanInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.shutdown:
+			return nil
+		case err := <-serveErr:
+			return fmt.Errorf("serve %s: %w", s.opts.Socket, err)
+		case <-ticker.C:
+			if err := rescan(); err != nil {
+				s.opts.Logger.Warn("rescan: %v", err)
+			}
+		case reply := <-s.rescanNow:
+			reply <- rescan()
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			go s.refresh(ctx, event.Path)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			s.opts.Logger.Debug("watch: %v", err)
+		}
+	}
+}
+
+// rescan finds the repositories under every root, checks each, and returns
+// their paths sorted.
+func (s *Server) rescan(ctx context.Context) ([]string, error) {
+	var paths []string
+	for _, root := range s.opts.Roots {
+		found, err := s.client.ScanRepositories(ctx, repository.ScanOptions{
+			Directory: root.Path,
+			MaxDepth:  root.Depth,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scan %s: %w", root.Path, err)
+		}
+		paths = append(paths, found.Paths...)
+	}
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(s.opts.Parallel)
+	for _, path := range paths {
+		g.Go(func() error {
+			s.index.put(path, s.check(gctx, path))
+			return nil
+		})
+	}
+	_ = g.Wait()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	s.index.retain(paths)
+	return paths, nil
+}
+
+// refresh re-checks one repository after a watch event. Events for a
+// repository already being checked are coalesced into one more check once
+// the current one finishes, so a burst costs two checks, not one per event.
+func (s *Server) refresh(ctx context.Context, path string) {
+	s.refreshMu.Lock()
+	if s.refreshing[path] {
+		s.dirty[path] = true
+		s.refreshMu.Unlock()
+		return
+	}
+	s.refreshing[path] = true
+	s.refreshMu.Unlock()
+
+	for {
+		if s.index.has(path) {
+			s.index.put(path, s.check(ctx, path))
+		}
+		s.refreshMu.Lock()
+		if !s.dirty[path] || ctx.Err() != nil {
+			delete(s.refreshing, path)
+			delete(s.dirty, path)
+			s.refreshMu.Unlock()
+			return
+		}
+		delete(s.dirty, path)
+		s.refreshMu.Unlock()
+	}
+}
+
+// check runs what `info` and `status --skip-fetch` would run for path. The
+// daemon never fetches here: background fetching belongs to the watcher,
+// on its own schedule.
+func (s *Server) check(ctx context.Context, path string) Record {
+	status := s.client.RepositoryStatus(ctx, filepath.Dir(path), path)
+
+	opts := reposync.DefaultDiagnosticOptions()
+	opts.SkipFetch = true
+	opts.Parallel = 1
+	executor := reposync.DiagnosticExecutor{Client: s.client}
+	health := reposync.RepoHealth{Repo: reposync.RepoSpec{TargetPath: path}}
+	if report, err := executor.CheckHealth(ctx, []reposync.RepoSpec{{TargetPath: path}}, opts); err == nil && len(report.Results) == 1 {
+		health = report.Results[0]
+	}
+	return newRecord(status, health, time.Now())
+}
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/ping", s.handlePing)
+	mux.HandleFunc("GET /v1/repos", s.handleRepos)
+	mux.HandleFunc("POST /v1/refresh", s.handleRefresh)
+	mux.HandleFunc("POST /v1/shutdown", s.handleShutdown)
+	return mux
+}
+
+func (s *Server) handlePing(w http.ResponseWriter, _ *http.Request) {
+	n, ready := s.index.size()
+	writeJSON(w, http.StatusOK, Ping{
+		PID:          os.Getpid(),
+		StartedAt:    s.started,
+		Roots:        s.opts.Roots,
+		Repositories: n,
+		Ready:        ready,
+	})
+}
+
+func (s *Server) handleRepos(w http.ResponseWriter, r *http.Request) {
+	q, err := parseQuery(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	resp, err := s.index.query(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleRefresh re-checks one indexed repository, or rescans every root
+// when no path is given, and answers once the index reflects it.
+func (s *Server) handleRefresh(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Query().Get("path")
+	if path == "" {
+		reply := make(chan error, 1)
+		select {
+		case s.rescanNow <- reply:
+		case <-r.Context().Done():
+			return
+		}
+		if err := <-reply; err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if !s.index.has(path) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("%s is not an indexed repository", path))
+		return
+	}
+	s.index.put(path, s.check(r.Context(), path))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleShutdown(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusAccepted)
+	s.shutdownOnce.Do(func() { close(s.shutdown) })
+}
+
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, errorBody{Error: err.Error()})
+}
+
+// Listen opens the daemon socket at path, readable by the user only. The
+// socket is created under a private umask so other users cannot connect
+// before its mode is restricted. A socket left behind by a daemon that died
+// is removed; one a live daemon still answers on is an error.
+func Listen(path string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("create socket directory: %w", err)
+	}
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("a daemon is already listening on %s", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("remove stale socket: %w", err)
+		}
+	}
+	restore := privateUmask()
+	listener, err := net.Listen("unix", path)
+	restore()
+	if err != nil {
+		return nil, fmt.Errorf("listen on %s: %w", path, err)
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("restrict socket permissions: %w", err)
+	}
+	return listener, nil
+}
+
+// DefaultSocketPath is where the daemon listens unless told otherwise:
+// $XDG_RUNTIME_DIR/gz-git/daemon.sock when the session has a runtime
+// directory, else the gz-git state directory.
+func DefaultSocketPath() (string, error) {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "gz-git", "daemon.sock"), nil
+	}
+	configHome, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locate config directory: %w", err)
+	}
+	return filepath.Join(configHome, "gz-git", "state", "daemon.sock"), nil
+}
+
+type discardLogger struct{}
+
+func (discardLogger) Debug(string, ...any) {}
+func (discardLogger) Info(string, ...any)  {}
+func (discardLogger) Warn(string, ...any)  {}
+func (discardLogger) Error(string, ...any) {}
@@ -0,0 +1,12 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+//go:build !unix
+
+package daemon
+
+// privateUmask is a no-op where there is no umask; Listen still restricts the
+// socket's mode once it exists.
+func privateUmask() (restore func()) {
+	return func() {}
+}
@@ -0,0 +1,15 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+//go:build unix
+
+package daemon
+
+import "syscall"
+
+// privateUmask makes files created until restore is called private to the
+// user. The umask is process-wide, so callers keep the window short.
+func privateUmask() (restore func()) {
+	old := syscall.Umask(0o077)
+	return func() { syscall.Umask(old) }
+}
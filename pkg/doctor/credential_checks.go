@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli-gitforge/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+)
+
+// credentialHelperCommand is what credential.helper must run for git's HTTPS
+// transport to use gz-git's tokens.
+const credentialHelperCommand = "gz-git credential"
+
+// checkCredentialHelper verifies that git's credential.helper is wired to
+// `gz-git credential`, and that the gz-git it names can actually be found.
+// Without it, tokens stored for the API are invisible to git fetch/push over
+// HTTPS. SSH-only setups never need the helper, so an unwired helper is only
+// a warning when hosts are declared under credentials:.
+func checkCredentialHelper(ctx context.Context) []CheckResult {
+	executor := gitcmd.NewExecutor()
+	lines, err := executor.RunLines(ctx, "", "config", "--get-regexp", `^credential\..*helper`)
+	if err != nil {
+		// git config exits 1 when nothing matches; that is "no helper".
+		lines = nil
+	}
+	return credentialHelperResult(lines, len(config.LoadCredentials()) > 0, exec.LookPath)
+}
+
+// credentialHelperResult classifies `git config --get-regexp` output. It is
+// separate from checkCredentialHelper so the classification can be tested
+// without a git config to stage.
+func credentialHelperResult(lines []string, hostsDeclared bool, lookPath func(string) (string, error)) []CheckResult {
+	var wired, misnamed, others, programs []string
+	for _, line := range lines {
+		key, value, _ := strings.Cut(line, " ")
+		if strings.Contains(value, credentialHelperCommand) {
+			program, ok := helperProgram(value)
+			if !ok {
+				misnamed = append(misnamed, key)
+				continue
+			}
+			wired = append(wired, key)
+			programs = append(programs, program)
+			continue
+		}
+		if value != "" {
+			others = append(others, value)
+		}
+	}
+
+	if len(misnamed) > 0 {
+		return []CheckResult{{
+			Name:     "credential-helper",
+			Category: CategoryAuth,
+			Status:   StatusError,
+			Message:  fmt.Sprintf("%s runs git credential-gz-git, not gz-git", strings.Join(misnamed, ", ")),
+			Detail:   "use the shell form: git config --global credential.helper '!gz-git credential' (or an absolute path to gz-git)",
+		}}
+	}
+
+	if len(wired) == 0 {
+		status := StatusSkipped
+		if hostsDeclared {
+			status = StatusWarning
+		}
+		msg := "git credential.helper does not use gz-git; HTTPS git ignores stored tokens"
+		if len(others) > 0 {
+			msg += fmt.Sprintf(" (using: %s)", strings.Join(others, ", "))
+		}
+		return []CheckResult{{
+			Name:     "credential-helper",
+			Category: CategoryAuth,
+			Status:   status,
+			Message:  msg,
+			Detail:   "wire it with: git config --global credential.helper '!gz-git credential'",
+		}}
+	}
+
+	// exec.LookPath resolves a name on PATH and checks a path containing a
+	// separator directly, which is exactly how the shell git spawns finds it.
+	for _, program := range programs {
+		if _, err := lookPath(program); err != nil {
+			return []CheckResult{{
+				Name:     "credential-helper",
+				Category: CategoryAuth,
+				Status:   StatusError,
+				Message:  fmt.Sprintf("%s runs %s, which cannot be executed", strings.Join(wired, ", "), program),
+				Detail:   "install gz-git on PATH or use an absolute path in the helper: " + err.Error(),
+			}}
+		}
+	}
+
+	return []CheckResult{{
+		Name:     "credential-helper",
+		Category: CategoryAuth,
+		Status:   StatusOK,
+		Message:  fmt.Sprintf("git HTTPS credentials served by gz-git (%s)", strings.Join(wired, ", ")),
+	}}
+}
+
+// helperProgram returns the program a credential.helper value runs. Git runs
+// a value starting with "!" as a shell command and an absolute path as is;
+// any other value names a git-credential-<name> helper, so a plain
+// "gz-git credential" runs "git credential-gz-git credential" and ok is false.
+func helperProgram(value string) (program string, ok bool) {
+	if rest, shell := strings.CutPrefix(value, "!"); shell {
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			return "", false
+		}
+		return fields[0], true
+	}
+	fields := strings.Fields(value)
+	if len(fields) > 0 && filepath.IsAbs(fields[0]) {
+		return fields[0], true
+	}
+	return "", false
+}
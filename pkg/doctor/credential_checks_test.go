@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package doctor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCredentialHelperResult(t *testing.T) {
+	found := func(string) (string, error) { return "/usr/local/bin/gz-git", nil }
+	missing := func(string) (string, error) { return "", errors.New("not found") }
+
+	tests := []struct {
+		name       string
+		lines      []string
+		hosts      bool
+		lookPath   func(string) (string, error)
+		wantStatus Status
+		wantIn     string
+	}{
+		{name: "no helper, ssh-only setup", wantStatus: StatusSkipped, lookPath: found},
+		{name: "no helper, hosts declared", hosts: true, wantStatus: StatusWarning, lookPath: found},
+		{
+			name:       "other helper named",
+			lines:      []string{"credential.helper osxkeychain"},
+			hosts:      true,
+			lookPath:   found,
+			wantStatus: StatusWarning,
+			wantIn:     "osxkeychain",
+		},
+		{
+			name:       "wired per host",
+			lines:      []string{"credential.helper osxkeychain", "credential.https://ghe.corp.example.com.helper !gz-git credential"},
+			lookPath:   found,
+			wantStatus: StatusOK,
+			wantIn:     "credential.https://ghe.corp.example.com.helper",
+		},
+		{
+			name:       "absolute path without shell form",
+			lines:      []string{"credential.helper /usr/local/bin/gz-git credential"},
+			lookPath:   found,
+			wantStatus: StatusOK,
+			wantIn:     "credential.helper",
+		},
+		{
+			name:       "bare name runs git credential-gz-git",
+			lines:      []string{"credential.helper gz-git credential"},
+			lookPath:   found,
+			wantStatus: StatusError,
+			wantIn:     "git credential-gz-git",
+		},
+		{
+			name:       "bare name next to a working entry",
+			lines:      []string{"credential.helper !gz-git credential", "credential.https://ghe.corp.example.com.helper gz-git credential"},
+			lookPath:   found,
+			wantStatus: StatusError,
+			wantIn:     "credential.https://ghe.corp.example.com.helper",
+		},
+		{
+			name:       "wired but binary missing",
+			lines:      []string{"credential.helper !/opt/gz-git credential"},
+			lookPath:   missing,
+			wantStatus: StatusError,
+			wantIn:     "/opt/gz-git",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := credentialHelperResult(tt.lines, tt.hosts, tt.lookPath)
+			if len(results) != 1 {
+				t.Fatalf("got %d results", len(results))
+			}
+			if results[0].Status != tt.wantStatus {
+				t.Fatalf("status = %s, want %s (%s)", results[0].Status, tt.wantStatus, results[0].Message)
+			}
+			if !strings.Contains(results[0].Message, tt.wantIn) {
+				t.Fatalf("message = %q, want substring %q", results[0].Message, tt.wantIn)
+			}
+		})
+	}
+}
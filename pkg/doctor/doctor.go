@@ -41,10 +41,14 @@ func Run(ctx context.Context, opts Options) *Report {
 	// Auth checks (from profile)
 	checks = append(checks, checkSSHKeys()...)
 	checks = append(checks, checkTokenSources()...)
+	checks = append(checks, checkTokenStore()...)
+	checks = append(checks, checkPlaintextTokens()...)
+	checks = append(checks, checkCredentialHelper(ctx)...)
 
 	// Forge checks
 	if !opts.SkipForge {
 		checks = append(checks, checkForgeConnectivity(ctx)...)
+		checks = append(checks, checkHostCredentials(ctx)...)
 	}
 
 	// Repository checks
@@ -349,9 +353,12 @@ func checkProfiles(verbose bool) []CheckResult {
 			continue
 		}
 
-		// Check for empty token env var references
-		if profile.Token != "" && strings.HasPrefix(profile.Token, "${") {
+		// Check for empty token env var references. Other secret references
+		// (${keyring:...}, ${cmd:...}) are not resolved here: doctor should
+		// not run password managers.
+		if profile.Token != "" && strings.HasPrefix(profile.Token, "${") && !config.HasSecretRef(profile.Token) {
 			envVar := strings.TrimSuffix(strings.TrimPrefix(profile.Token, "${"), "}")
+			envVar = strings.TrimPrefix(envVar, "env:")
 			if os.Getenv(envVar) == "" {
 				results = append(results, CheckResult{
 					Name:     fmt.Sprintf("profile:%s", name),
@@ -590,69 +597,138 @@ func checkForgeConnectivity(ctx context.Context) []CheckResult {
 			continue
 		}
 
-		p, err := createProvider(profile.Provider, profile.Token, profile.BaseURL) //nolint:contextcheck // provider constructors don't accept context
-		if err != nil {
+		subject := fmt.Sprintf("profile '%s'", name)
+		results = append(results, validateForgeToken(ctx, "forge:"+name, subject, profile.Provider, profile.Token, profile.BaseURL))
+	}
+
+	return results
+}
+
+// checkHostCredentials validates every host listed under "credentials:" in
+// the global config. Profiles name one account each; the credentials list is
+// where a machine with several hosts of the same forge declares them, so each
+// gets its own token check.
+func checkHostCredentials(ctx context.Context) []CheckResult {
+	manager, err := config.NewManager()
+	if err != nil {
+		return nil
+	}
+	global, err := manager.LoadGlobalConfig()
+	if err != nil || global == nil {
+		return nil
+	}
+
+	var results []CheckResult
+	for _, c := range global.Credentials {
+		key := c.Key()
+		checkName := "forge-host:" + key.String()
+		subject := fmt.Sprintf("host '%s'", key.String())
+
+		token, source := c.Token, "config"
+		if token == "" {
+			tok, err := config.DefaultTokenStore.Get(key.String())
+			if err != nil {
+				results = append(results, CheckResult{
+					Name:     checkName,
+					Category: CategoryForge,
+					Status:   StatusWarning,
+					Message:  fmt.Sprintf("%s: keychain unavailable, token not checked", subject),
+					Detail:   err.Error(),
+				})
+				continue
+			}
+			token, source = tok, "keychain"
+		}
+		if token == "" {
+			hint := fmt.Sprintf("gz-git config token set %s <token> --host %s", key.Provider, c.Host)
+			if c.Org != "" {
+				hint += " --org " + c.Org
+			}
 			results = append(results, CheckResult{
-				Name:     fmt.Sprintf("forge:%s", name),
+				Name:     checkName,
 				Category: CategoryForge,
-				Status:   StatusError,
-				Message:  fmt.Sprintf("profile '%s': cannot create %s provider", name, profile.Provider),
-				Detail:   err.Error(),
+				Status:   StatusWarning,
+				Message:  fmt.Sprintf("%s: no token configured", subject),
+				Detail:   hint,
 			})
 			continue
 		}
 
-		// Validate token
-		checkCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-		valid, err := p.ValidateToken(checkCtx)
-		cancel()
+		result := validateForgeToken(ctx, checkName, subject, key.Provider, token, c.APIBaseURL())
+		if result.Status == StatusOK {
+			result.Message += fmt.Sprintf(" [%s]", source)
+		}
+		results = append(results, result)
+	}
+	return results
+}
 
-		if err != nil || !valid {
-			results = append(results, tokenValidationResult(name, profile.Provider, err))
-			continue
+// validateForgeToken checks one token against its forge and reports the rate
+// limit when the token is good. subject names what is being checked in the
+// message ("profile 'work'", "host 'github@ghe.corp'").
+func validateForgeToken(ctx context.Context, checkName, subject, providerName, token, baseURL string) CheckResult {
+	p, err := createProvider(providerName, token, baseURL) //nolint:contextcheck // provider constructors don't accept context
+	if err != nil {
+		return CheckResult{
+			Name:     checkName,
+			Category: CategoryForge,
+			Status:   StatusError,
+			Message:  fmt.Sprintf("%s: cannot create %s provider", subject, providerName),
+			Detail:   err.Error(),
 		}
+	}
 
-		// Check rate limit
-		rateCtx, rateCancel := context.WithTimeout(ctx, 10*time.Second)
-		rl, err := p.GetRateLimit(rateCtx)
-		rateCancel()
+	// Validate token
+	checkCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	valid, err := p.ValidateToken(checkCtx)
+	cancel()
 
-		msg := fmt.Sprintf("profile '%s': %s token valid", name, profile.Provider)
-		if err == nil && rl != nil && rl.Limit > 0 {
-			pct := float64(rl.Remaining) / float64(rl.Limit) * 100
-			msg += fmt.Sprintf(" (rate limit: %d/%d, %.0f%%)", rl.Remaining, rl.Limit, pct)
+	if err != nil || !valid {
+		result := classifyTokenValidation(subject, providerName, err)
+		result.Name = checkName
+		return result
+	}
 
-			if pct < 10 {
-				results = append(results, CheckResult{
-					Name:     fmt.Sprintf("forge:%s", name),
-					Category: CategoryForge,
-					Status:   StatusWarning,
-					Message:  fmt.Sprintf("profile '%s': %s rate limit low (%.0f%% remaining)", name, profile.Provider, pct),
-					Detail:   fmt.Sprintf("Resets at %s", rl.Reset.Format(time.RFC3339)),
-				})
-				continue
+	// Check rate limit
+	rateCtx, rateCancel := context.WithTimeout(ctx, 10*time.Second)
+	rl, err := p.GetRateLimit(rateCtx)
+	rateCancel()
+
+	msg := fmt.Sprintf("%s: %s token valid", subject, providerName)
+	if err == nil && rl != nil && rl.Limit > 0 {
+		pct := float64(rl.Remaining) / float64(rl.Limit) * 100
+		msg += fmt.Sprintf(" (rate limit: %d/%d, %.0f%%)", rl.Remaining, rl.Limit, pct)
+
+		if pct < 10 {
+			return CheckResult{
+				Name:     checkName,
+				Category: CategoryForge,
+				Status:   StatusWarning,
+				Message:  fmt.Sprintf("%s: %s rate limit low (%.0f%% remaining)", subject, providerName, pct),
+				Detail:   fmt.Sprintf("Resets at %s", rl.Reset.Format(time.RFC3339)),
 			}
 		}
-
-		results = append(results, CheckResult{
-			Name:     fmt.Sprintf("forge:%s", name),
-			Category: CategoryForge,
-			Status:   StatusOK,
-			Message:  msg,
-		})
 	}
 
-	return results
+	return CheckResult{
+		Name:     checkName,
+		Category: CategoryForge,
+		Status:   StatusOK,
+		Message:  msg,
+	}
 }
 
 func tokenValidationResult(profileName, providerName string, err error) CheckResult {
-	result := CheckResult{
-		Name:     fmt.Sprintf("forge:%s", profileName),
-		Category: CategoryForge,
-	}
+	result := classifyTokenValidation(fmt.Sprintf("profile '%s'", profileName), providerName, err)
+	result.Name = fmt.Sprintf("forge:%s", profileName)
+	return result
+}
+
+func classifyTokenValidation(subject, providerName string, err error) CheckResult {
+	result := CheckResult{Category: CategoryForge}
 	if err == nil {
 		result.Status = StatusError
-		result.Message = fmt.Sprintf("profile '%s': %s token is invalid or expired", profileName, providerName)
+		result.Message = fmt.Sprintf("%s: %s token is invalid or expired", subject, providerName)
 		return result
 	}
 
@@ -660,24 +736,24 @@ func tokenValidationResult(profileName, providerName string, err error) CheckRes
 	switch {
 	case errors.Is(err, provider.ErrTokenForbidden):
 		result.Status = StatusError
-		result.Message = fmt.Sprintf("profile '%s': %s token is invalid or forbidden", profileName, providerName)
+		result.Message = fmt.Sprintf("%s: %s token is invalid or forbidden", subject, providerName)
 	case errors.Is(err, provider.ErrTokenValidationRateLimited):
 		result.Status = StatusWarning
-		result.Message = fmt.Sprintf("profile '%s': %s API rate limited while validating token", profileName, providerName)
+		result.Message = fmt.Sprintf("%s: %s API rate limited while validating token", subject, providerName)
 	case errors.Is(err, provider.ErrTokenValidationUnreachable):
 		result.Status = StatusUnreachable
-		result.Message = fmt.Sprintf("profile '%s': %s API unreachable", profileName, providerName)
+		result.Message = fmt.Sprintf("%s: %s API unreachable", subject, providerName)
 	case errors.Is(err, provider.ErrTokenValidationAPI):
 		result.Status = StatusError
-		result.Message = fmt.Sprintf("profile '%s': %s API returned a validation error", profileName, providerName)
+		result.Message = fmt.Sprintf("%s: %s API returned a validation error", subject, providerName)
 	case errors.Is(err, provider.ErrTokenValidationCanceled):
 		result.Status = StatusWarning
-		result.Message = fmt.Sprintf("profile '%s': %s token validation canceled", profileName, providerName)
+		result.Message = fmt.Sprintf("%s: %s token validation canceled", subject, providerName)
 	default:
 		// Preserve the legacy interpretation for external ProviderWithAuth
 		// implementations that return an unclassified error.
 		result.Status = StatusUnreachable
-		result.Message = fmt.Sprintf("profile '%s': %s API unreachable", profileName, providerName)
+		result.Message = fmt.Sprintf("%s: %s API unreachable", subject, providerName)
 	}
 	return result
 }
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+)
+
+// checkTokenStore reports which backend holds stored tokens and whether it
+// can be read right now. A keychain that is unavailable is a warning, since
+// env tokens still work; an encrypted file that was selected but cannot be
+// opened is an error, because every token in it is out of reach.
+func checkTokenStore() []CheckResult {
+	return tokenStoreResult(config.DefaultTokenStore)
+}
+
+func tokenStoreResult(store config.TokenStore) []CheckResult {
+	_, source := config.ConfiguredTokenBackend()
+	result := CheckResult{Name: "token-store", Category: CategoryAuth}
+
+	switch s := store.(type) {
+	case *config.FileTokenStore:
+		keys, err := s.Keys()
+		switch {
+		case errors.Is(err, config.ErrTokenStoreLocked):
+			result.Status = StatusError
+			result.Message = "token store: encrypted file selected but locked"
+			result.Detail = err.Error()
+			return []CheckResult{result}
+		case err != nil:
+			result.Status = StatusError
+			result.Message = "token store: cannot open encrypted file " + s.Path()
+			result.Detail = err.Error()
+			return []CheckResult{result}
+		}
+		result.Status = StatusOK
+		result.Message = fmt.Sprintf("token store: encrypted file %s (%d tokens, selected by %s)", s.Path(), len(keys), source)
+		if info, err := os.Stat(s.Path()); err == nil && runtime.GOOS != "windows" && info.Mode().Perm()&0o077 != 0 {
+			result.Status = StatusWarning
+			result.Message = fmt.Sprintf("token store: %s has loose permissions: %04o (must be 0600)", s.Path(), info.Mode().Perm())
+		}
+		return []CheckResult{result}
+
+	default:
+		// Probing with a read is the only way to learn whether the keychain
+		// backend exists; Available reflects the last operation.
+		_, err := store.Get("github")
+		if err != nil || !store.Available() {
+			result.Status = StatusWarning
+			result.Message = fmt.Sprintf("token store: %s unavailable", config.DescribeTokenStore(store))
+			result.Detail = fmt.Sprintf("use the encrypted file instead: set %s and %s=file, then gz-git config token migrate --to file",
+				config.PassphraseEnv, config.TokenStoreEnv)
+			if err != nil {
+				result.Detail = err.Error() + "; " + result.Detail
+			}
+			return []CheckResult{result}
+		}
+		result.Status = StatusOK
+		result.Message = fmt.Sprintf("token store: %s (selected by %s)", config.DescribeTokenStore(store), source)
+		return []CheckResult{result}
+	}
+}
+
+// checkPlaintextTokens warns about tokens written literally into profiles or
+// the global config, where backups, dotfile repositories, and screen shares
+// pick them up. ${VAR} references and stored tokens are fine.
+func checkPlaintextTokens() []CheckResult {
+	manager, err := config.NewManager()
+	if err != nil {
+		return nil
+	}
+	found, err := manager.PlaintextTokens()
+	if err != nil {
+		return []CheckResult{{
+			Name:     "plaintext-tokens",
+			Category: CategoryAuth,
+			Status:   StatusWarning,
+			Message:  "cannot scan config files for plaintext tokens",
+			Detail:   err.Error(),
+		}}
+	}
+	return plaintextTokensResult(found)
+}
+
+func plaintextTokensResult(found []config.PlaintextToken) []CheckResult {
+	if len(found) == 0 {
+		return []CheckResult{{
+			Name:     "plaintext-tokens",
+			Category: CategoryAuth,
+			Status:   StatusOK,
+			Message:  "no plaintext tokens in config files",
+		}}
+	}
+	locations := make([]string, 0, len(found))
+	for _, f := range found {
+		locations = append(locations, f.String())
+	}
+	return []CheckResult{{
+		Name:     "plaintext-tokens",
+		Category: CategoryAuth,
+		Status:   StatusWarning,
+		Message:  fmt.Sprintf("%d plaintext token(s) in config files", len(found)),
+		Detail: strings.Join(locations, "; ") +
+			" — move them with gz-git config token set, or reference an env var as ${VAR}",
+	}}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package doctor
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+)
+
+func TestTokenStoreResult(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(config.TokenStoreEnv, "file")
+	path := filepath.Join(t.TempDir(), config.TokenFileName)
+
+	unlocked := config.NewFileTokenStore(path, func() (string, error) { return "p", nil })
+	if err := unlocked.Set("github", "x"); err != nil {
+		t.Fatal(err)
+	}
+	got := tokenStoreResult(unlocked)[0]
+	if got.Status != StatusOK || !strings.Contains(got.Message, "1 tokens") || !strings.Contains(got.Message, "env:"+config.TokenStoreEnv) {
+		t.Fatalf("unlocked = %+v", got)
+	}
+
+	locked := config.NewFileTokenStore(path, func() (string, error) { return "", config.ErrTokenStoreLocked })
+	if got := tokenStoreResult(locked)[0]; got.Status != StatusError || !strings.Contains(got.Message, "locked") {
+		t.Fatalf("locked = %+v", got)
+	}
+
+	mem := config.NewMemoryTokenStore()
+	mem.SetAvailable(false)
+	if got := tokenStoreResult(mem)[0]; got.Status != StatusWarning || !strings.Contains(got.Detail, config.PassphraseEnv) {
+		t.Fatalf("unavailable keychain = %+v", got)
+	}
+}
+
+func TestPlaintextTokensResult(t *testing.T) {
+	if got := plaintextTokensResult(nil)[0]; got.Status != StatusOK {
+		t.Fatalf("none = %+v", got)
+	}
+	got := plaintextTokensResult([]config.PlaintextToken{{File: "/cfg/profiles/work.yaml", Field: "token"}})[0]
+	if got.Status != StatusWarning || !strings.Contains(got.Detail, "/cfg/profiles/work.yaml: token") {
+		t.Fatalf("found = %+v", got)
+	}
+}
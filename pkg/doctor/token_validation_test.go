@@ -4,10 +4,14 @@
 package doctor
 
 import (
+	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/provider"
 )
 
@@ -38,3 +42,36 @@ func TestTokenValidationResult_ClassifiesProviderErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckHostCredentials_ReportsEachHost(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	cfgDir := filepath.Join(dir, config.ConfigDirName)
+	if err := os.MkdirAll(cfgDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	global := "credentials:\n  - host: github.corp.example.com\n  - host: git.internal.example\n    provider: gitlab\n    org: platform\n"
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.yaml"), []byte(global), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	mem := config.NewMemoryTokenStore()
+	prev := config.DefaultTokenStore
+	config.SetTokenStore(mem)
+	t.Cleanup(func() { config.SetTokenStore(prev) })
+
+	results := checkHostCredentials(context.Background())
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want one per host: %+v", len(results), results)
+	}
+	for _, r := range results {
+		if r.Status != StatusWarning || !strings.Contains(r.Message, "no token") {
+			t.Errorf("result = %+v, want a missing-token warning", r)
+		}
+	}
+	if results[1].Name != "forge-host:gitlab@git.internal.example/platform" {
+		t.Errorf("name = %q", results[1].Name)
+	}
+	if !strings.Contains(results[1].Detail, "--org platform") {
+		t.Errorf("hint = %q", results[1].Detail)
+	}
+}
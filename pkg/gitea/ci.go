@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/provider"
+)
+
+// CommitCIStatus reports the combined commit status for ref. Gitea Actions and
+// external CI (Woodpecker, Drone) both post commit statuses, so the combined
+// status is the complete picture on this forge.
+func (p *Provider) CommitCIStatus(ctx context.Context, owner, repo, ref string) (*provider.CIStatus, error) {
+	out := &provider.CIStatus{Ref: ref, State: provider.CIStateNone}
+
+	client, err := p.contextClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	combined, resp, err := client.GetCombinedStatus(owner, repo, ref)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return out, nil
+		}
+		return nil, fmt.Errorf("get combined status: %w", err)
+	}
+	out.SHA = combined.SHA
+	for _, st := range combined.Statuses {
+		if st == nil {
+			continue
+		}
+		out.Checks = append(out.Checks, provider.CICheck{
+			Name:  st.Context,
+			State: statusState(st.State),
+			URL:   st.TargetURL,
+		})
+	}
+	out.State = provider.RollupCIChecks(out.Checks)
+	return out, nil
+}
+
+// statusState maps a Gitea commit status to CIState. A warning is a passing
+// result with a remark, so it does not turn a branch red.
+func statusState(state gitea.StatusState) provider.CIState {
+	switch state {
+	case gitea.StatusSuccess, gitea.StatusWarning:
+		return provider.CIStateSuccess
+	case gitea.StatusPending:
+		return provider.CIStatePending
+	case gitea.StatusFailure, gitea.StatusError:
+		return provider.CIStateFailure
+	default:
+		return provider.CIStateNone
+	}
+}
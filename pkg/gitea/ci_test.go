@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package gitea
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/provider"
+)
+
+func TestCommitCIStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/commits/main/status") {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = io.WriteString(w, `{"state":"pending","sha":"123","statuses":[
+			{"status":"success","context":"build"},
+			{"status":"warning","context":"lint"},
+			{"status":"pending","context":"deploy"}]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	p, err := NewProvider("token", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := p.CommitCIStatus(context.Background(), "acme", "app", "main")
+	if err != nil {
+		t.Fatalf("CommitCIStatus: %v", err)
+	}
+	if got.State != provider.CIStatePending || got.SHA != "123" || len(got.Checks) != 3 {
+		t.Fatalf("status = %+v", got)
+	}
+
+	missing, err := p.CommitCIStatus(context.Background(), "acme", "app", "gone")
+	if err != nil {
+		t.Fatalf("CommitCIStatus(missing): %v", err)
+	}
+	if missing.State != provider.CIStateNone {
+		t.Fatalf("missing state = %s", missing.State)
+	}
+}
+
+func TestCommitCIStatusHonoursContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"state":"success","sha":"123","statuses":[]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	p, err := NewProvider("token", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.CommitCIStatus(ctx, "acme", "app", "main"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("CommitCIStatus with a cancelled context: err = %v", err)
+	}
+}
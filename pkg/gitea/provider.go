@@ -78,6 +78,25 @@ func (p *Provider) initClient() error {
 	return nil
 }
 
+// contextClient returns a client whose requests use ctx. The SDK keeps one
+// context per Client, so setting it on p.client would race concurrent
+// callers; a client is cheap to build and construction is offline.
+func (p *Provider) contextClient(ctx context.Context) (*gitea.Client, error) {
+	p.mu.RLock()
+	token, baseURL := p.token, p.baseURL
+	p.mu.RUnlock()
+
+	opts := []gitea.ClientOption{gitea.SetGiteaVersion(""), gitea.SetContext(ctx)}
+	if token != "" {
+		opts = append(opts, gitea.SetToken(token))
+	}
+	client, err := gitea.NewClient(baseURL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Gitea client: %w", err)
+	}
+	return client, nil
+}
+
 // Name returns the provider name.
 func (p *Provider) Name() string {
 	return "gitea"
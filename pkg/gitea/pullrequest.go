@@ -55,6 +55,17 @@ func (p *Provider) FindPullRequest(ctx context.Context, owner, repo, head, base
 	return nil, provider.ErrPullRequestNotFound
 }
 
+// UpdatePullRequestBase points an open PR at a new base branch. Gitea
+// supports this from 1.12.
+func (p *Provider) UpdatePullRequestBase(ctx context.Context, owner, repo string, number int, base string) (*provider.PullRequest, error) {
+	_ = ctx
+	pr, _, err := p.client.EditPullRequest(owner, repo, int64(number), gitea.EditPullRequestOption{Base: base})
+	if err != nil {
+		return nil, fmt.Errorf("update pull request base: %w", err)
+	}
+	return convertGiteaPR(pr), nil
+}
+
 func convertGiteaPR(pr *gitea.PullRequest) *provider.PullRequest {
 	out := &provider.PullRequest{
 		Number: int(pr.Index),
@@ -50,3 +50,34 @@ func TestCreatePullRequest(t *testing.T) {
 		t.Fatalf("created = %+v", got)
 	}
 }
+
+func TestUpdatePullRequestBase(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/version"):
+			_, _ = io.WriteString(w, `{"version":"1.21.0"}`)
+		case r.Method == http.MethodPatch && strings.HasSuffix(r.URL.Path, "/repos/acme/app/pulls/6"):
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["base"] != "main" {
+				t.Errorf("payload = %#v", body)
+			}
+			_, _ = io.WriteString(w, `{"number":6,"html_url":"https://gitea.example/acme/app/pulls/6","head":{"label":"child"},"base":{"label":"main"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	p, err := NewProvider("token", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := p.UpdatePullRequestBase(context.Background(), "acme", "app", 6, "main")
+	if err != nil {
+		t.Fatalf("UpdatePullRequestBase: %v", err)
+	}
+	if got.Number != 6 || got.Base != "main" {
+		t.Fatalf("updated = %+v", got)
+	}
+}
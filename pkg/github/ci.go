@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	gh "github.com/google/go-github/v88/github"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/provider"
+)
+
+// CommitCIStatus combines the two ways GitHub reports CI on a commit: check
+// runs (Actions and GitHub Apps) and legacy commit statuses (external CI
+// posting to the statuses API). Repositories commonly use both, so reading
+// only one would report green while the other is red.
+func (p *Provider) CommitCIStatus(ctx context.Context, owner, repo, ref string) (*provider.CIStatus, error) {
+	out := &provider.CIStatus{Ref: ref, State: provider.CIStateNone}
+
+	runOpts := &gh.ListCheckRunsOptions{
+		Filter:      gh.Ptr("latest"),
+		ListOptions: gh.ListOptions{PerPage: 100},
+	}
+	for {
+		runs, resp, err := p.client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, runOpts)
+		if err != nil {
+			if isUnknownRef(resp, err) {
+				break
+			}
+			return nil, fmt.Errorf("list check runs: %w", err)
+		}
+		for _, run := range runs.CheckRuns {
+			if out.SHA == "" {
+				out.SHA = run.GetHeadSHA()
+			}
+			out.Checks = append(out.Checks, provider.CICheck{
+				Name:  run.GetName(),
+				State: checkRunState(run.GetStatus(), run.GetConclusion()),
+				URL:   run.GetHTMLURL(),
+			})
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		runOpts.Page = resp.NextPage
+	}
+
+	statusOpts := &gh.ListOptions{PerPage: 100}
+	for {
+		combined, resp, err := p.client.Repositories.GetCombinedStatus(ctx, owner, repo, ref, statusOpts)
+		if err != nil {
+			if isUnknownRef(resp, err) {
+				break
+			}
+			return nil, fmt.Errorf("get combined status: %w", err)
+		}
+		if out.SHA == "" {
+			out.SHA = combined.GetSHA()
+		}
+		for _, st := range combined.Statuses {
+			out.Checks = append(out.Checks, provider.CICheck{
+				Name:  st.GetContext(),
+				State: commitStatusState(st.GetState()),
+				URL:   st.GetTargetURL(),
+			})
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		statusOpts.Page = resp.NextPage
+	}
+
+	out.State = provider.RollupCIChecks(out.Checks)
+	return out, nil
+}
+
+// checkRunState maps a check run to CIState. Anything not completed is
+// pending; neutral and skipped count as passing, matching how GitHub itself
+// decides whether a required check blocks a merge.
+func checkRunState(status, conclusion string) provider.CIState {
+	if status != "completed" {
+		return provider.CIStatePending
+	}
+	switch conclusion {
+	case "success", "neutral", "skipped":
+		return provider.CIStateSuccess
+	case "stale":
+		return provider.CIStateNone
+	default: // failure, cancelled, timed_out, action_required, startup_failure
+		return provider.CIStateFailure
+	}
+}
+
+// commitStatusState maps a legacy commit status state to CIState.
+func commitStatusState(state string) provider.CIState {
+	switch state {
+	case "success":
+		return provider.CIStateSuccess
+	case "pending":
+		return provider.CIStatePending
+	case "failure", "error":
+		return provider.CIStateFailure
+	default:
+		return provider.CIStateNone
+	}
+}
+
+// isUnknownRef reports a 404 or 422 for a ref GitHub does not know — a branch
+// that was never pushed or a local-only commit — which is "no CI", not an
+// error worth failing the whole report over.
+func isUnknownRef(resp *gh.Response, err error) bool {
+	var ghErr *gh.ErrorResponse
+	if errors.As(err, &ghErr) && ghErr.Response != nil {
+		code := ghErr.Response.StatusCode
+		return code == http.StatusNotFound || code == http.StatusUnprocessableEntity
+	}
+	return resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/provider"
+)
+
+func TestCommitCIStatusCombinesCheckRunsAndStatuses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/commits/main/check-runs"):
+			_, _ = io.WriteString(w, `{"total_count":2,"check_runs":[
+				{"name":"build","head_sha":"abc","status":"completed","conclusion":"success"},
+				{"name":"lint","head_sha":"abc","status":"in_progress"}]}`)
+		case strings.HasSuffix(r.URL.Path, "/commits/main/status"):
+			_, _ = io.WriteString(w, `{"state":"failure","sha":"abc","statuses":[{"context":"ci/jenkins","state":"error","target_url":"https://ci.example/1"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	p := mustNewProvider(t, "token", server.URL)
+	got, err := p.CommitCIStatus(context.Background(), "acme", "app", "main")
+	if err != nil {
+		t.Fatalf("CommitCIStatus: %v", err)
+	}
+	if got.State != provider.CIStateFailure || got.SHA != "abc" || len(got.Checks) != 3 {
+		t.Fatalf("status = %+v", got)
+	}
+	if failed := got.Failed(); len(failed) != 1 || failed[0] != "ci/jenkins" {
+		t.Fatalf("failed = %v", failed)
+	}
+}
+
+func TestCommitCIStatusFollowsPages(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/commits/main/check-runs") && page == "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=2&per_page=100>; rel="next"`, server.URL, r.URL.Path))
+			_, _ = io.WriteString(w, `{"total_count":2,"check_runs":[
+				{"name":"build","head_sha":"abc","status":"completed","conclusion":"success"}]}`)
+		case strings.HasSuffix(r.URL.Path, "/commits/main/check-runs") && page == "2":
+			_, _ = io.WriteString(w, `{"total_count":2,"check_runs":[
+				{"name":"e2e","head_sha":"abc","status":"completed","conclusion":"failure"}]}`)
+		case strings.HasSuffix(r.URL.Path, "/commits/main/status") && page == "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=2&per_page=100>; rel="next"`, server.URL, r.URL.Path))
+			_, _ = io.WriteString(w, `{"state":"pending","sha":"abc","statuses":[{"context":"ci/a","state":"success"}]}`)
+		case strings.HasSuffix(r.URL.Path, "/commits/main/status") && page == "2":
+			_, _ = io.WriteString(w, `{"state":"pending","sha":"abc","statuses":[{"context":"ci/b","state":"pending"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	p := mustNewProvider(t, "token", server.URL)
+	got, err := p.CommitCIStatus(context.Background(), "acme", "app", "main")
+	if err != nil {
+		t.Fatalf("CommitCIStatus: %v", err)
+	}
+	if len(got.Checks) != 4 || got.State != provider.CIStateFailure {
+		t.Fatalf("status = %+v, want 4 checks and failure from page 2", got)
+	}
+	if failed := got.Failed(); len(failed) != 1 || failed[0] != "e2e" {
+		t.Fatalf("failed = %v", failed)
+	}
+}
+
+func TestCommitCIStatusUnknownRefIsNone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = io.WriteString(w, `{"message":"No commit found for SHA: nope"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	p := mustNewProvider(t, "token", server.URL)
+	got, err := p.CommitCIStatus(context.Background(), "acme", "app", "nope")
+	if err != nil {
+		t.Fatalf("CommitCIStatus: %v", err)
+	}
+	if got.State != provider.CIStateNone {
+		t.Fatalf("state = %s, want none", got.State)
+	}
+}
+
+func TestCheckRunState(t *testing.T) {
+	tests := []struct {
+		status, conclusion string
+		want               provider.CIState
+	}{
+		{"queued", "", provider.CIStatePending},
+		{"completed", "success", provider.CIStateSuccess},
+		{"completed", "skipped", provider.CIStateSuccess},
+		{"completed", "timed_out", provider.CIStateFailure},
+		{"completed", "cancelled", provider.CIStateFailure},
+	}
+	for _, tt := range tests {
+		if got := checkRunState(tt.status, tt.conclusion); got != tt.want {
+			t.Errorf("checkRunState(%q, %q) = %s, want %s", tt.status, tt.conclusion, got, tt.want)
+		}
+	}
+}
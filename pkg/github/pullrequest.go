@@ -77,6 +77,17 @@ func (p *Provider) FindPullRequest(ctx context.Context, owner, repo, head, base
 	return nil, provider.ErrPullRequestNotFound
 }
 
+// UpdatePullRequestBase points an open PR at a new base branch.
+func (p *Provider) UpdatePullRequestBase(ctx context.Context, owner, repo string, number int, base string) (*provider.PullRequest, error) {
+	pr, _, err := p.client.PullRequests.Edit(ctx, owner, repo, number, &gh.PullRequest{
+		Base: &gh.PullRequestBranch{Ref: gh.Ptr(base)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update pull request base: %w", err)
+	}
+	return convertPullRequest(pr), nil
+}
+
 func convertPullRequest(pr *gh.PullRequest) *provider.PullRequest {
 	return &provider.PullRequest{
 		Number: pr.GetNumber(),
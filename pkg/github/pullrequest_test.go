@@ -72,3 +72,30 @@ func TestCreateAndFindPullRequest(t *testing.T) {
 		t.Fatalf("missing PR error = %v", err)
 	}
 }
+
+func TestUpdatePullRequestBase(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || !strings.HasSuffix(r.URL.Path, "/repos/acme/app/pulls/8") {
+			http.NotFound(w, r)
+			return
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode edit body: %v", err)
+		}
+		if body["base"] != "main" {
+			t.Errorf("edit payload = %#v", body)
+		}
+		_, _ = io.WriteString(w, `{"number":8,"html_url":"https://github.com/acme/app/pull/8","head":{"ref":"child"},"base":{"ref":"main"}}`)
+	}))
+	t.Cleanup(server.Close)
+
+	p := mustNewProvider(t, "token", server.URL)
+	got, err := p.UpdatePullRequestBase(context.Background(), "acme", "app", 8, "main")
+	if err != nil {
+		t.Fatalf("UpdatePullRequestBase: %v", err)
+	}
+	if got.Base != "main" || got.Head != "child" {
+		t.Fatalf("updated = %+v", got)
+	}
+}
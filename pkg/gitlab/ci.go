@@ -0,0 +1,79 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/provider"
+)
+
+// fullSHA matches a complete object name. GitLab filters pipelines by sha and
+// by ref through separate parameters, so the caller's ref has to be classified
+// before it can be sent.
+var fullSHA = regexp.MustCompile(`^[0-9a-f]{40}([0-9a-f]{24})?$`)
+
+// CommitCIStatus reports the most recent pipeline for ref. GitLab runs one
+// pipeline per push, so the latest pipeline is the verdict on the tip; older
+// pipelines for the same ref describe commits that are no longer there.
+func (p *Provider) CommitCIStatus(ctx context.Context, owner, repo, ref string) (*provider.CIStatus, error) {
+	out := &provider.CIStatus{Ref: ref, State: provider.CIStateNone}
+
+	opts := &gitlab.ListProjectPipelinesOptions{
+		OrderBy:     gitlab.Ptr("id"),
+		Sort:        gitlab.Ptr("desc"),
+		ListOptions: gitlab.ListOptions{PerPage: 1},
+	}
+	if fullSHA.MatchString(ref) {
+		opts.SHA = gitlab.Ptr(ref)
+	} else {
+		opts.Ref = gitlab.Ptr(ref)
+	}
+	pipelines, resp, err := p.client.Pipelines.ListProjectPipelines(projectID(owner, repo), opts, gitlab.WithContext(ctx))
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return out, nil
+		}
+		return nil, fmt.Errorf("list pipelines: %w", err)
+	}
+	if len(pipelines) == 0 {
+		return out, nil
+	}
+
+	latest := pipelines[0]
+	name := latest.Name
+	if name == "" {
+		name = fmt.Sprintf("pipeline #%d", latest.ID)
+	}
+	out.SHA = latest.SHA
+	out.URL = latest.WebURL
+	out.Checks = []provider.CICheck{{
+		Name:  name,
+		State: pipelineState(latest.Status),
+		URL:   latest.WebURL,
+	}}
+	out.State = provider.RollupCIChecks(out.Checks)
+	return out, nil
+}
+
+// pipelineState maps a GitLab pipeline status to CIState. "manual" is a
+// pipeline waiting for someone to press play; it has not failed, but it is not
+// finished either, so it reads as pending.
+func pipelineState(status string) provider.CIState {
+	switch status {
+	case "success", "skipped":
+		return provider.CIStateSuccess
+	case "failed", "canceled", "canceling":
+		return provider.CIStateFailure
+	case "created", "waiting_for_resource", "preparing", "pending", "running", "scheduled", "manual", "waiting_for_callback":
+		return provider.CIStatePending
+	default:
+		return provider.CIStateNone
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/provider"
+)
+
+func TestCommitCIStatusUsesLatestPipeline(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/pipelines") {
+			http.NotFound(w, r)
+			return
+		}
+		query = r.URL.RawQuery
+		_, _ = io.WriteString(w, `[{"id":42,"status":"failed","ref":"develop","sha":"def","web_url":"https://gitlab.example/acme/app/-/pipelines/42"}]`)
+	}))
+	t.Cleanup(server.Close)
+
+	p, err := NewProvider("token", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := p.CommitCIStatus(context.Background(), "acme", "app", "develop")
+	if err != nil {
+		t.Fatalf("CommitCIStatus: %v", err)
+	}
+	if got.State != provider.CIStateFailure || got.SHA != "def" || got.URL == "" {
+		t.Fatalf("status = %+v", got)
+	}
+	if !strings.Contains(query, "ref=develop") || strings.Contains(query, "sha=") {
+		t.Fatalf("branch query = %q", query)
+	}
+
+	sha := strings.Repeat("a", 40)
+	if _, err := p.CommitCIStatus(context.Background(), "acme", "app", sha); err != nil {
+		t.Fatalf("CommitCIStatus(sha): %v", err)
+	}
+	if !strings.Contains(query, "sha="+sha) {
+		t.Fatalf("sha query = %q", query)
+	}
+}
+
+func TestCommitCIStatusNoPipelinesIsNone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `[]`)
+	}))
+	t.Cleanup(server.Close)
+
+	p, err := NewProvider("token", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := p.CommitCIStatus(context.Background(), "acme", "app", "main")
+	if err != nil {
+		t.Fatalf("CommitCIStatus: %v", err)
+	}
+	if got.State != provider.CIStateNone || len(got.Checks) != 0 {
+		t.Fatalf("status = %+v", got)
+	}
+}
@@ -71,6 +71,17 @@ func (p *Provider) FindPullRequest(ctx context.Context, owner, repo, head, base
 	return nil, provider.ErrPullRequestNotFound
 }
 
+// UpdatePullRequestBase points an open MR at a new target branch.
+func (p *Provider) UpdatePullRequestBase(ctx context.Context, owner, repo string, number int, base string) (*provider.PullRequest, error) {
+	mr, _, err := p.client.MergeRequests.UpdateMergeRequest(projectID(owner, repo), int64(number), &gitlab.UpdateMergeRequestOptions{
+		TargetBranch: gitlab.Ptr(base),
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("update merge request target: %w", err)
+	}
+	return convertMergeRequest(mr), nil
+}
+
 func convertMergeRequest(mr *gitlab.MergeRequest) *provider.PullRequest {
 	return &provider.PullRequest{
 		Number: int(mr.IID),
@@ -66,3 +66,33 @@ func TestCreateAndFindMergeRequest(t *testing.T) {
 		t.Fatalf("missing error = %v", err)
 	}
 }
+
+func TestUpdateMergeRequestTarget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || !strings.HasSuffix(r.URL.EscapedPath(), "/merge_requests/5") {
+			http.NotFound(w, r)
+			return
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		if body["target_branch"] != "develop" {
+			t.Errorf("payload = %#v", body)
+		}
+		_, _ = io.WriteString(w, `{"iid":5,"web_url":"https://gitlab.example/acme/app/-/merge_requests/5","source_branch":"child","target_branch":"develop"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	p, err := NewProvider("token", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := p.UpdatePullRequestBase(context.Background(), "acme", "app", 5, "develop")
+	if err != nil {
+		t.Fatalf("UpdatePullRequestBase: %v", err)
+	}
+	if got.Base != "develop" || got.Number != 5 {
+		t.Fatalf("updated = %+v", got)
+	}
+}
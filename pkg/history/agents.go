@@ -0,0 +1,316 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package history
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/identity"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+// AgentAnalyzer reads the Device and Agent trailers that
+// identity.Identity.AppendTrailers writes, to tell which agent on which
+// machine made each commit. Callers run Collect on one repository or fan it
+// out over a workspace, and combine the results with MergeAgents.
+type AgentAnalyzer interface {
+	Collect(ctx context.Context, repo *repository.Repository, opts AgentOptions) (*RepoAgentHistory, error)
+}
+
+type agentAnalyzer struct {
+	executor GitExecutor
+}
+
+// NewAgentAnalyzer creates a new agent analyzer.
+func NewAgentAnalyzer(executor GitExecutor) AgentAnalyzer {
+	return &agentAnalyzer{
+		executor: executor,
+	}
+}
+
+// agentCommit is one commit with the identity its message was signed with.
+// protected is the protected branch it was made on directly, if any.
+type agentCommit struct {
+	hash      string
+	date      time.Time
+	author    string
+	subject   string
+	id        identity.Identity
+	added     int
+	deleted   int
+	protected string
+}
+
+// Collect reads the repository's non-merge commits inside the window, from
+// every local and remote-tracking branch unless opts.Branch names one, and
+// marks the agent commits found on a protected branch's first-parent line.
+func (a *agentAnalyzer) Collect(ctx context.Context, repo *repository.Repository, opts AgentOptions) (*RepoAgentHistory, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("repository cannot be nil")
+	}
+	if !opts.Since.IsZero() && !opts.Until.IsZero() && opts.Since.After(opts.Until) {
+		return nil, ErrInvalidDateRange
+	}
+	window := opts.windowArgs()
+
+	// %B is the whole message, for identity.FromMessage; \x1d ends it, so
+	// the numstat lines after it are not mistaken for message lines.
+	args := append([]string{"log", "--no-merges", "--numstat", "--format=%x1e%H%x1f%ct%x1f%aN%x1f%s%n%B%x1d"}, window...)
+	if opts.Branch != "" {
+		args = append(args, opts.Branch)
+	} else {
+		args = append(args, "--branches", "--remotes")
+	}
+	out, err := a.run(ctx, repo.Path, args...)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := parseAgentLog(out)
+	if err != nil {
+		return nil, err
+	}
+
+	byHash := make(map[string]*agentCommit, len(commits))
+	for _, c := range commits {
+		byHash[c.hash] = c
+	}
+	refs, err := a.protectedRefs(ctx, repo.Path, opts.Protected)
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range refs {
+		args := append([]string{"log", "--first-parent", "--no-merges", "--format=%H"}, window...)
+		out, err := a.run(ctx, repo.Path, append(args, ref)...)
+		if err != nil {
+			return nil, err
+		}
+		for _, hash := range strings.Fields(out) {
+			if c := byHash[hash]; c != nil && c.id.Agent != "" && c.protected == "" {
+				c.protected = ref
+			}
+		}
+	}
+
+	return &RepoAgentHistory{Path: repo.Path, commits: commits}, nil
+}
+
+func (o AgentOptions) windowArgs() []string {
+	var args []string
+	if !o.Since.IsZero() {
+		args = append(args, fmt.Sprintf("--since=%s", o.Since.Format(time.RFC3339)))
+	}
+	if !o.Until.IsZero() {
+		args = append(args, fmt.Sprintf("--until=%s", o.Until.Format(time.RFC3339)))
+	}
+	return args
+}
+
+// protectedRefs lists the local and remote-tracking branches that match the
+// protected patterns, local ones first, in short form ("main",
+// "origin/main"). A remote-tracking branch matches by its name on the remote.
+func (a *agentAnalyzer) protectedRefs(ctx context.Context, dir string, patterns []string) ([]string, error) {
+	if len(patterns) == 0 {
+		patterns = repository.ProtectedBranches
+	}
+	out, err := a.run(ctx, dir, "for-each-ref", "--format=%(refname)", "refs/heads", "refs/remotes")
+	if err != nil {
+		return nil, err
+	}
+	var local, remote []string
+	for _, ref := range strings.Fields(out) {
+		switch {
+		case strings.HasPrefix(ref, "refs/heads/"):
+			name := strings.TrimPrefix(ref, "refs/heads/")
+			if repository.MatchesBranchPattern(name, patterns) {
+				local = append(local, name)
+			}
+		case strings.HasPrefix(ref, "refs/remotes/"):
+			short := strings.TrimPrefix(ref, "refs/remotes/")
+			_, name, ok := strings.Cut(short, "/")
+			if ok && name != "HEAD" && repository.MatchesBranchPattern(name, patterns) {
+				remote = append(remote, short)
+			}
+		}
+	}
+	return append(local, remote...), nil
+}
+
+func (a *agentAnalyzer) run(ctx context.Context, dir string, args ...string) (string, error) {
+	result, err := a.executor.Run(ctx, dir, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to run git %s: %w", args[0], err)
+	}
+	if result.ExitCode != 0 {
+		return "", fmt.Errorf("git %s failed: %s", args[0], strings.TrimSpace(result.Stderr))
+	}
+	return result.Stdout, nil
+}
+
+// parseAgentLog parses the log pass: per commit a \x1e-prefixed,
+// \x1f-separated header line, the message up to \x1d, then numstat lines.
+func parseAgentLog(output string) ([]*agentCommit, error) {
+	var commits []*agentCommit
+	for _, record := range strings.Split(output, "\x1e") {
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+		header, rest, _ := strings.Cut(record, "\n")
+		fields := strings.Split(header, "\x1f")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("unexpected log header %q", header)
+		}
+		ts, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected commit time %q", fields[1])
+		}
+		message, stats, _ := strings.Cut(rest, "\x1d")
+		commit := &agentCommit{
+			hash:    fields[0],
+			date:    time.Unix(ts, 0),
+			author:  fields[2],
+			subject: fields[3],
+			id:      identity.FromMessage(message),
+		}
+		for _, line := range strings.Split(stats, "\n") {
+			parts := strings.SplitN(line, "\t", 3)
+			if len(parts) != 3 {
+				continue
+			}
+			added, _ := strconv.Atoi(parts[0])   //nolint:errcheck // binary files return "-"
+			deleted, _ := strconv.Atoi(parts[1]) //nolint:errcheck // binary files return "-"
+			commit.added += added
+			commit.deleted += deleted
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// activityTally accumulates one agent or device.
+type activityTally struct {
+	activity *AgentActivity
+	repos    map[string]bool
+	related  map[string]bool
+}
+
+func (t *activityTally) add(repo string, c *agentCommit, related string) {
+	a := t.activity
+	a.Commits++
+	if c.id.Agent != "" {
+		a.AgentCommits++
+	}
+	a.LinesAdded += c.added
+	a.LinesDeleted += c.deleted
+	if a.FirstCommit.IsZero() || c.date.Before(a.FirstCommit) {
+		a.FirstCommit = c.date
+	}
+	if c.date.After(a.LastCommit) {
+		a.LastCommit = c.date
+	}
+	t.repos[repo] = true
+	if related != "" {
+		t.related[related] = true
+	}
+}
+
+// MergeAgents combines repositories' attributed commits into one report:
+// human and agent shares, activity per agent and per device, and the agent
+// commits made directly on protected branches, newest first. A commit is an
+// agent's when its message carries an Agent trailer; a commit without a
+// Device trailer counts toward no device.
+func MergeAgents(histories []*RepoAgentHistory) *AgentReport {
+	report := &AgentReport{
+		Failed:    []*RepoError{},
+		Human:     &AuthorshipShare{},
+		Agent:     &AuthorshipShare{},
+		Protected: []*ProtectedCommit{},
+	}
+	agents := map[string]*activityTally{}
+	devices := map[string]*activityTally{}
+	tally := func(m map[string]*activityTally, name string) *activityTally {
+		t := m[name]
+		if t == nil {
+			t = &activityTally{activity: &AgentActivity{Name: name}, repos: map[string]bool{}, related: map[string]bool{}}
+			m[name] = t
+		}
+		return t
+	}
+
+	for _, h := range histories {
+		if h.Err != nil {
+			report.Failed = append(report.Failed, &RepoError{Path: h.Path, Error: h.Err.Error()})
+			continue
+		}
+		report.Repositories++
+		for _, c := range h.commits {
+			report.Commits++
+			share := report.Human
+			if c.id.Agent != "" {
+				share = report.Agent
+				tally(agents, c.id.Agent).add(h.Path, c, c.id.Device)
+			}
+			share.Commits++
+			share.LinesAdded += c.added
+			share.LinesDeleted += c.deleted
+			if c.id.Device != "" {
+				tally(devices, c.id.Device).add(h.Path, c, c.id.Agent)
+			}
+			if c.protected != "" {
+				report.Protected = append(report.Protected, &ProtectedCommit{
+					Repository: h.Path,
+					Branch:     c.protected,
+					Hash:       c.hash,
+					Date:       c.date,
+					Author:     c.author,
+					Agent:      c.id.Agent,
+					Device:     c.id.Device,
+					Subject:    c.subject,
+				})
+			}
+		}
+	}
+
+	if report.Commits > 0 {
+		report.Human.Percent = float64(report.Human.Commits) * 100 / float64(report.Commits)
+		report.Agent.Percent = float64(report.Agent.Commits) * 100 / float64(report.Commits)
+	}
+	report.Agents = finishActivities(agents, func(a *AgentActivity, related []string) { a.Devices = related })
+	report.Devices = finishActivities(devices, func(a *AgentActivity, related []string) { a.Agents = related })
+	sort.SliceStable(report.Protected, func(i, j int) bool {
+		return report.Protected[i].Date.After(report.Protected[j].Date)
+	})
+	return report
+}
+
+// finishActivities sorts the tallies, most commits first, and fills in
+// their sorted repository and related-name lists.
+func finishActivities(tallies map[string]*activityTally, setRelated func(*AgentActivity, []string)) []*AgentActivity {
+	activities := make([]*AgentActivity, 0, len(tallies))
+	for _, t := range tallies {
+		t.activity.Repositories = sortedKeys(t.repos)
+		setRelated(t.activity, sortedKeys(t.related))
+		activities = append(activities, t.activity)
+	}
+	sort.Slice(activities, func(i, j int) bool {
+		if activities[i].Commits != activities[j].Commits {
+			return activities[i].Commits > activities[j].Commits
+		}
+		return activities[i].Name < activities[j].Name
+	})
+	return activities
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
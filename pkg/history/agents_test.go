@@ -0,0 +1,155 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package history
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+// agentFixture is the log pass in the analyzer's format, with "#" for the
+// record separator, "|" for the field separator and "$" for the end of the
+// message.
+const agentFixture = `#c4|1700400000|Dana|Checkpoint
+Checkpoint
+
+Device: laptop
+Agent: hermes
+$
+5	1	a.go
+#c3|1700300000|Dana|Fix typo
+Fix typo
+
+Device: desk
+$
+1	1	README.md
+#c2|1700200000|Dana|Refactor
+Refactor
+
+Device: desk
+Agent: hermes
+$
+20	10	b.go
+-	-	logo.png
+#c1|1700100000|Dana|Initial
+Initial
+$
+100	0	a.go
+`
+
+func agentExecutor(t *testing.T) *mockExecutor {
+	t.Helper()
+	return &mockExecutor{
+		runFunc: func(_ context.Context, _ string, args ...string) (*gitcmd.Result, error) {
+			switch {
+			case args[0] == "for-each-ref":
+				return &gitcmd.Result{Stdout: "refs/heads/feature\nrefs/heads/main\nrefs/remotes/origin/HEAD\nrefs/remotes/origin/main\n"}, nil
+			case args[0] == "log" && args[1] == "--first-parent":
+				switch args[len(args)-1] {
+				case "main":
+					// c4 landed through a merge; c2 was committed on main.
+					return &gitcmd.Result{Stdout: "c3\nc2\nc1\n"}, nil
+				case "origin/main":
+					return &gitcmd.Result{Stdout: "c2\nc1\n"}, nil
+				}
+				t.Errorf("first-parent log of %v", args)
+				return &gitcmd.Result{}, nil
+			case args[0] == "log":
+				out := strings.NewReplacer("#", "\x1e", "|", "\x1f", "$", "\x1d").Replace(agentFixture)
+				return &gitcmd.Result{Stdout: out}, nil
+			}
+			t.Errorf("unexpected git %v", args)
+			return &gitcmd.Result{}, nil
+		},
+	}
+}
+
+func TestAgentAnalyzer(t *testing.T) {
+	analyzer := NewAgentAnalyzer(agentExecutor(t))
+	h, err := analyzer.Collect(context.Background(), &repository.Repository{Path: "/repo"}, AgentOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Path = "api"
+	report := MergeAgents([]*RepoAgentHistory{h, {Path: "broken", Err: errors.New("not a git repository")}})
+
+	if report.Repositories != 1 || len(report.Failed) != 1 || report.Commits != 4 {
+		t.Fatalf("report = %+v", report)
+	}
+	if report.Agent.Commits != 2 || report.Agent.LinesAdded != 25 || report.Agent.Percent != 50 {
+		t.Errorf("agent share = %+v", report.Agent)
+	}
+	if report.Human.Commits != 2 || report.Human.LinesAdded != 101 {
+		t.Errorf("human share = %+v", report.Human)
+	}
+
+	if len(report.Agents) != 1 {
+		t.Fatalf("agents = %+v", report.Agents)
+	}
+	hermes := report.Agents[0]
+	if hermes.Name != "hermes" || hermes.Commits != 2 || strings.Join(hermes.Devices, ",") != "desk,laptop" || strings.Join(hermes.Repositories, ",") != "api" {
+		t.Errorf("hermes = %+v", hermes)
+	}
+
+	if len(report.Devices) != 2 {
+		t.Fatalf("devices = %+v", report.Devices)
+	}
+	desk := report.Devices[0]
+	if desk.Name != "desk" || desk.Commits != 2 || desk.AgentCommits != 1 || strings.Join(desk.Agents, ",") != "hermes" {
+		t.Errorf("desk = %+v", desk)
+	}
+
+	// Only c2 is both an agent's and on main's first-parent line; the
+	// local branch wins over the remote-tracking one.
+	if len(report.Protected) != 1 {
+		t.Fatalf("protected = %+v", report.Protected)
+	}
+	p := report.Protected[0]
+	if p.Hash != "c2" || p.Branch != "main" || p.Agent != "hermes" || p.Device != "desk" || p.Subject != "Refactor" || p.Repository != "api" {
+		t.Errorf("protected = %+v", p)
+	}
+}
+
+func TestAgentAnalyzer_ProtectedPatterns(t *testing.T) {
+	analyzer := NewAgentAnalyzer(agentExecutor(t))
+	h, err := analyzer.Collect(context.Background(), &repository.Repository{Path: "/repo"}, AgentOptions{Protected: []string{"release/*"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report := MergeAgents([]*RepoAgentHistory{h}); len(report.Protected) != 0 {
+		t.Errorf("main is not protected by release/*: %+v", report.Protected)
+	}
+}
+
+func TestFormatter_Agents(t *testing.T) {
+	h, err := NewAgentAnalyzer(agentExecutor(t)).Collect(context.Background(), &repository.Repository{Path: "api"}, AgentOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := MergeAgents([]*RepoAgentHistory{h})
+
+	for _, format := range []OutputFormat{FormatTable, FormatJSON, FormatCSV, FormatMarkdown, FormatLLM} {
+		if out, err := NewFormatter(format).FormatAgents(report); err != nil || len(out) == 0 {
+			t.Errorf("%s: %v", format, err)
+		}
+	}
+	out, _ := NewFormatter(FormatTable).FormatAgents(report)
+	for _, want := range []string{"Agent           2   50.0%", "hermes", "Protected Branches (1)", "c2 "} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("table missing %q:\n%s", want, out)
+		}
+	}
+	out, _ = NewFormatter(FormatCSV).FormatAgents(report)
+	if !strings.Contains(string(out), "protected,hermes,1,,,api,") {
+		t.Errorf("csv:\n%s", out)
+	}
+	if _, err := NewFormatter(FormatTable).FormatAgents(nil); err == nil {
+		t.Error("FormatAgents(nil) should return error")
+	}
+}
@@ -0,0 +1,426 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package history
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+// ChurnAnalyzer derives code-ownership and hotspot analytics from the
+// per-file change log. Each method reads the history with a single
+// `git log --numstat` pass.
+type ChurnAnalyzer interface {
+	// Hotspots ranks files by how often they change times how big they are.
+	Hotspots(ctx context.Context, repo *repository.Repository, opts ChurnOptions) ([]*Hotspot, error)
+
+	// Ownership reports the primary authors and bus factor of each directory.
+	Ownership(ctx context.Context, repo *repository.Repository, opts ChurnOptions) ([]*Ownership, error)
+
+	// Coupling lists pairs of files that are changed in the same commits.
+	Coupling(ctx context.Context, repo *repository.Repository, opts ChurnOptions) ([]*Coupling, error)
+}
+
+type churnAnalyzer struct {
+	executor GitExecutor
+}
+
+// NewChurnAnalyzer creates a new churn analyzer.
+func NewChurnAnalyzer(executor GitExecutor) ChurnAnalyzer {
+	return &churnAnalyzer{
+		executor: executor,
+	}
+}
+
+// Default thresholds for ChurnOptions fields left at zero.
+const (
+	DefaultOwnershipDepth    = 1
+	DefaultCouplingMinShared = 3
+	DefaultCouplingMaxFiles  = 30
+)
+
+// churnCommit is one commit of the log pass.
+type churnCommit struct {
+	hash  string
+	date  time.Time
+	name  string
+	email string
+	files []churnChange
+}
+
+// churnChange is one numstat line. path is the file's name at the analyzed
+// revision, after following renames.
+type churnChange struct {
+	path    string
+	added   int
+	deleted int
+	binary  bool
+}
+
+// churnLog is the parsed history: the commits inside the requested window,
+// and the size of every file that exists at the analyzed revision.
+type churnLog struct {
+	commits []*churnCommit
+	lines   map[string]int
+}
+
+// collect runs the log pass. The whole history is read, even with Since or
+// MaxCommits set, so that file sizes (the sum of every change) are right;
+// the window only limits which commits are counted.
+func (c *churnAnalyzer) collect(ctx context.Context, repo *repository.Repository, opts ChurnOptions) (*churnLog, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("repository cannot be nil")
+	}
+	if !opts.Since.IsZero() && !opts.Until.IsZero() && opts.Since.After(opts.Until) {
+		return nil, ErrInvalidDateRange
+	}
+
+	args := []string{"log", "--no-merges", "-M", "--numstat", "--format=%x1e%H%x1f%at%x1f%an%x1f%ae"}
+	if opts.Branch != "" {
+		args = append(args, opts.Branch)
+	}
+	if opts.Path != "" {
+		args = append(args, "--", opts.Path)
+	}
+	result, err := c.executor.Run(ctx, repo.Path, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get change log: %w", err)
+	}
+	if result.ExitCode != 0 {
+		if strings.Contains(result.Stderr, "does not have any commits") {
+			return nil, ErrEmptyHistory
+		}
+		return nil, fmt.Errorf("git log failed: %s", strings.TrimSpace(result.Stderr))
+	}
+	return parseChurnLog(result.Stdout, opts)
+}
+
+// parseChurnLog parses the log pass, newest commit first.
+func parseChurnLog(output string, opts ChurnOptions) (*churnLog, error) {
+	log := &churnLog{lines: map[string]int{}}
+	// renamed maps a file's older names to its name at the analyzed
+	// revision. Walking newest first, a rename is seen before the commits
+	// that used the old name.
+	renamed := map[string]string{}
+	current := func(p string) string {
+		if to, ok := renamed[p]; ok {
+			return to
+		}
+		return p
+	}
+
+	for _, record := range strings.Split(output, "\x1e") {
+		record = strings.TrimSpace(record)
+		if record == "" {
+			continue
+		}
+		header, body, _ := strings.Cut(record, "\n")
+		fields := strings.Split(header, "\x1f")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("unexpected log header %q", header)
+		}
+		ts, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected commit time %q", fields[1])
+		}
+		commit := &churnCommit{
+			hash:  fields[0],
+			date:  time.Unix(ts, 0),
+			name:  fields[2],
+			email: strings.ToLower(fields[3]),
+		}
+
+		for _, line := range strings.Split(body, "\n") {
+			parts := strings.SplitN(line, "\t", 3)
+			if len(parts) != 3 {
+				continue
+			}
+			change := churnChange{binary: parts[0] == "-" || parts[1] == "-"}
+			if !change.binary {
+				change.added, _ = strconv.Atoi(parts[0])
+				change.deleted, _ = strconv.Atoi(parts[1])
+			}
+			oldPath, newPath := splitRenamePath(parts[2])
+			change.path = current(newPath)
+			if oldPath != newPath {
+				renamed[oldPath] = change.path
+			}
+			log.lines[change.path] += change.added - change.deleted
+			commit.files = append(commit.files, change)
+		}
+
+		if opts.inWindow(commit.date) && (opts.MaxCommits <= 0 || len(log.commits) < opts.MaxCommits) {
+			log.commits = append(log.commits, commit)
+		}
+	}
+	for p, n := range log.lines {
+		if n <= 0 {
+			// Deleted since, or binary: not part of the analyzed tree.
+			delete(log.lines, p)
+		}
+	}
+	return log, nil
+}
+
+// splitRenamePath splits a numstat path, which reads "old => new" or
+// "dir/{old => new}/file" for a rename.
+func splitRenamePath(p string) (oldPath, newPath string) {
+	if !strings.Contains(p, " => ") {
+		return p, p
+	}
+	open, closing := strings.Index(p, "{"), strings.LastIndex(p, "}")
+	if open < 0 || closing < open {
+		from, to, _ := strings.Cut(p, " => ")
+		return from, to
+	}
+	prefix, suffix := p[:open], p[closing+1:]
+	from, to, _ := strings.Cut(p[open+1:closing], " => ")
+	clean := func(s string) string { return strings.ReplaceAll(s, "//", "/") }
+	return clean(prefix + from + suffix), clean(prefix + to + suffix)
+}
+
+func (o ChurnOptions) inWindow(t time.Time) bool {
+	if !o.Since.IsZero() && t.Before(o.Since) {
+		return false
+	}
+	if !o.Until.IsZero() && t.After(o.Until) {
+		return false
+	}
+	return true
+}
+
+// Hotspots ranks the files of the analyzed revision by changes × lines.
+// Score is that product scaled so the top file is 100.
+func (c *churnAnalyzer) Hotspots(ctx context.Context, repo *repository.Repository, opts ChurnOptions) ([]*Hotspot, error) {
+	log, err := c.collect(ctx, repo, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	byPath := map[string]*Hotspot{}
+	authors := map[string]map[string]bool{}
+	for _, commit := range log.commits {
+		for _, change := range commit.files {
+			lines, exists := log.lines[change.path]
+			if !exists || change.binary {
+				continue
+			}
+			h := byPath[change.path]
+			if h == nil {
+				h = &Hotspot{Path: change.path, Lines: lines, LastChanged: commit.date}
+				byPath[change.path] = h
+				authors[change.path] = map[string]bool{}
+			}
+			h.Changes++
+			h.LinesAdded += change.added
+			h.LinesDeleted += change.deleted
+			authors[change.path][commit.email] = true
+		}
+	}
+
+	hotspots := make([]*Hotspot, 0, len(byPath))
+	maxRaw := 0.0
+	for p, h := range byPath {
+		h.Authors = len(authors[p])
+		h.Score = float64(h.Changes) * float64(h.Lines)
+		if h.Score > maxRaw {
+			maxRaw = h.Score
+		}
+		hotspots = append(hotspots, h)
+	}
+	for _, h := range hotspots {
+		if maxRaw > 0 {
+			h.Score = h.Score * 100 / maxRaw
+		}
+	}
+	sort.Slice(hotspots, func(i, j int) bool {
+		if hotspots[i].Score != hotspots[j].Score {
+			return hotspots[i].Score > hotspots[j].Score
+		}
+		return hotspots[i].Path < hotspots[j].Path
+	})
+	for i, h := range hotspots {
+		h.Rank = i + 1
+	}
+	return limitTop(hotspots, opts.Top), nil
+}
+
+// Ownership attributes each directory's changed lines to their authors.
+// BusFactor is the fewest authors who together made half the changes.
+func (c *churnAnalyzer) Ownership(ctx context.Context, repo *repository.Repository, opts ChurnOptions) ([]*Ownership, error) {
+	log, err := c.collect(ctx, repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	depth := opts.Depth
+	if depth <= 0 {
+		depth = DefaultOwnershipDepth
+	}
+
+	type dirStats struct {
+		commits map[string]bool
+		shares  map[string]*AuthorShare
+	}
+	dirs := map[string]*dirStats{}
+	for _, commit := range log.commits {
+		for _, change := range commit.files {
+			if _, exists := log.lines[change.path]; !exists {
+				continue
+			}
+			dir := directoryAt(change.path, depth)
+			d := dirs[dir]
+			if d == nil {
+				d = &dirStats{commits: map[string]bool{}, shares: map[string]*AuthorShare{}}
+				dirs[dir] = d
+			}
+			d.commits[commit.hash] = true
+			share := d.shares[commit.email]
+			if share == nil {
+				// Commits are newest first, so this is the latest name.
+				share = &AuthorShare{Name: commit.name, Email: commit.email}
+				d.shares[commit.email] = share
+			}
+			share.Commits++
+			// A binary change still counts, as one line.
+			share.Lines += max(change.added+change.deleted, 1)
+		}
+	}
+
+	owners := make([]*Ownership, 0, len(dirs))
+	for dir, d := range dirs {
+		o := &Ownership{Path: dir, Commits: len(d.commits)}
+		total := 0
+		for _, share := range d.shares {
+			o.Authors = append(o.Authors, share)
+			total += share.Lines
+		}
+		sort.Slice(o.Authors, func(i, j int) bool {
+			if o.Authors[i].Lines != o.Authors[j].Lines {
+				return o.Authors[i].Lines > o.Authors[j].Lines
+			}
+			return o.Authors[i].Email < o.Authors[j].Email
+		})
+		covered := 0
+		for _, share := range o.Authors {
+			share.Percent = float64(share.Lines) * 100 / float64(total)
+			if covered*2 < total {
+				covered += share.Lines
+				o.BusFactor++
+			}
+		}
+		o.PrimaryAuthor = o.Authors[0].Name
+		o.PrimaryPercent = o.Authors[0].Percent
+		owners = append(owners, o)
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		if owners[i].BusFactor != owners[j].BusFactor {
+			return owners[i].BusFactor < owners[j].BusFactor
+		}
+		if owners[i].Commits != owners[j].Commits {
+			return owners[i].Commits > owners[j].Commits
+		}
+		return owners[i].Path < owners[j].Path
+	})
+	return limitTop(owners, opts.Top), nil
+}
+
+// directoryAt is the directory of p, cut to depth levels; "." for files at
+// the top.
+func directoryAt(p string, depth int) string {
+	dir := path.Dir(p)
+	if dir == "." {
+		return "."
+	}
+	parts := strings.Split(dir, "/")
+	if len(parts) > depth {
+		parts = parts[:depth]
+	}
+	return strings.Join(parts, "/")
+}
+
+// Coupling counts, for each pair of files of the analyzed revision, the
+// commits that changed both. Commits touching more than MaxFiles files are
+// skipped: mass renames and reformatting couple everything with everything.
+// Degree is the shared commits as a percentage of the pair's average
+// number of changes.
+func (c *churnAnalyzer) Coupling(ctx context.Context, repo *repository.Repository, opts ChurnOptions) ([]*Coupling, error) {
+	log, err := c.collect(ctx, repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	minShared := opts.MinShared
+	if minShared <= 0 {
+		minShared = DefaultCouplingMinShared
+	}
+	maxFiles := opts.MaxFiles
+	if maxFiles <= 0 {
+		maxFiles = DefaultCouplingMaxFiles
+	}
+
+	changes := map[string]int{}
+	shared := map[[2]string]int{}
+	for _, commit := range log.commits {
+		var files []string
+		seen := map[string]bool{}
+		for _, change := range commit.files {
+			if _, exists := log.lines[change.path]; exists && !seen[change.path] {
+				seen[change.path] = true
+				files = append(files, change.path)
+			}
+		}
+		for _, f := range files {
+			changes[f]++
+		}
+		if len(files) < 2 || len(files) > maxFiles {
+			continue
+		}
+		sort.Strings(files)
+		for i := range files {
+			for j := i + 1; j < len(files); j++ {
+				shared[[2]string{files[i], files[j]}]++
+			}
+		}
+	}
+
+	couplings := make([]*Coupling, 0)
+	for pair, n := range shared {
+		if n < minShared {
+			continue
+		}
+		a, b := changes[pair[0]], changes[pair[1]]
+		couplings = append(couplings, &Coupling{
+			FileA:    pair[0],
+			FileB:    pair[1],
+			Shared:   n,
+			ChangesA: a,
+			ChangesB: b,
+			Degree:   float64(n) * 200 / float64(a+b),
+		})
+	}
+	sort.Slice(couplings, func(i, j int) bool {
+		ci, cj := couplings[i], couplings[j]
+		if ci.Degree != cj.Degree {
+			return ci.Degree > cj.Degree
+		}
+		if ci.Shared != cj.Shared {
+			return ci.Shared > cj.Shared
+		}
+		return ci.FileA+ci.FileB < cj.FileA+cj.FileB
+	})
+	return limitTop(couplings, opts.Top), nil
+}
+
+func limitTop[T any](items []T, top int) []T {
+	if top > 0 && len(items) > top {
+		return items[:top]
+	}
+	return items
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package history
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-gitforge/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+// churnFixture is `git log --numstat` in the analyzer's format, newest
+// first, with "|" for the field separator and "#" for the record separator.
+//
+// core.go grows over four commits by two authors; util.go was renamed from
+// helpers.go; old.go was added and then deleted; logo.png is binary.
+const churnFixture = `#c5|1700400000|Bob|BOB@example.com
+4	1	pkg/core.go
+2	0	pkg/{helpers.go => util.go}
+#c4|1700300000|Alice|alice@example.com
+0	10	old.go
+10	0	pkg/core.go
+#c3|1700200000|Alice|alice@example.com
+10	0	old.go
+-	-	logo.png
+20	0	pkg/core.go
+3	0	pkg/helpers.go
+#c2|1700100000|Alice|alice@example.com
+50	0	pkg/core.go
+5	0	pkg/helpers.go
+#c1|1700000000|Alice|alice@example.com
+1	0	README.md
+`
+
+func churnExecutor(t *testing.T) *mockExecutor {
+	t.Helper()
+	return &mockExecutor{
+		runFunc: func(_ context.Context, _ string, args ...string) (*gitcmd.Result, error) {
+			if args[0] != "log" || !contains(args, "--numstat") {
+				t.Errorf("unexpected git %v", args)
+			}
+			out := strings.NewReplacer("#", "\x1e", "|", "\x1f").Replace(churnFixture)
+			return &gitcmd.Result{Stdout: out}, nil
+		},
+	}
+}
+
+func contains(args []string, want string) bool {
+	for _, a := range args {
+		if a == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestChurnAnalyzer_Hotspots(t *testing.T) {
+	analyzer := NewChurnAnalyzer(churnExecutor(t))
+	repo := &repository.Repository{Path: "/repo"}
+
+	hotspots, err := analyzer.Hotspots(context.Background(), repo, ChurnOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hotspots) != 3 {
+		t.Fatalf("hotspots = %d, want core.go, util.go and README.md", len(hotspots))
+	}
+	top := hotspots[0]
+	if top.Path != "pkg/core.go" || top.Changes != 4 || top.Lines != 83 || top.Score != 100 || top.Authors != 2 {
+		t.Errorf("top = %+v", top)
+	}
+	util := hotspots[1]
+	if util.Path != "pkg/util.go" || util.Changes != 3 || util.Lines != 10 {
+		t.Errorf("renamed file should keep its history: %+v", util)
+	}
+
+	// The window counts only c4 and c5, but sizes still come from all.
+	hotspots, err = analyzer.Hotspots(context.Background(), repo, ChurnOptions{Since: time.Unix(1700250000, 0), Top: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hotspots) != 1 || hotspots[0].Changes != 2 || hotspots[0].Lines != 83 {
+		t.Errorf("windowed = %+v", hotspots[0])
+	}
+}
+
+func TestChurnAnalyzer_Ownership(t *testing.T) {
+	analyzer := NewChurnAnalyzer(churnExecutor(t))
+	owners, err := analyzer.Ownership(context.Background(), &repository.Repository{Path: "/repo"}, ChurnOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	byDir := map[string]*Ownership{}
+	for _, o := range owners {
+		byDir[o.Path] = o
+	}
+	pkg := byDir["pkg"]
+	if pkg == nil || byDir["."] == nil || len(owners) != 2 {
+		t.Fatalf("owners = %+v", owners)
+	}
+	// Alice changed 88 of pkg's 95 lines.
+	if pkg.PrimaryAuthor != "Alice" || pkg.BusFactor != 1 || pkg.Commits != 4 || len(pkg.Authors) != 2 {
+		t.Errorf("pkg = %+v", pkg)
+	}
+	if bob := pkg.Authors[1]; bob.Email != "bob@example.com" || bob.Lines != 7 {
+		t.Errorf("bob = %+v", bob)
+	}
+}
+
+func TestChurnAnalyzer_Coupling(t *testing.T) {
+	analyzer := NewChurnAnalyzer(churnExecutor(t))
+	couplings, err := analyzer.Coupling(context.Background(), &repository.Repository{Path: "/repo"}, ChurnOptions{MinShared: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(couplings) != 1 {
+		t.Fatalf("couplings = %+v", couplings)
+	}
+	c := couplings[0]
+	if c.FileA != "pkg/core.go" || c.FileB != "pkg/util.go" || c.Shared != 3 || c.ChangesA != 4 || c.ChangesB != 3 {
+		t.Errorf("coupling = %+v", c)
+	}
+	if c.Degree < 85 || c.Degree > 86 {
+		t.Errorf("degree = %.2f, want 3/3.5", c.Degree)
+	}
+}
+
+func TestSplitRenamePath(t *testing.T) {
+	tests := []struct{ in, from, to string }{
+		{"a.go", "a.go", "a.go"},
+		{"a.go => b.go", "a.go", "b.go"},
+		{"pkg/{a.go => b.go}", "pkg/a.go", "pkg/b.go"},
+		{"{old => new}/x.go", "old/x.go", "new/x.go"},
+		{"src/{ => sub}/x.go", "src/x.go", "src/sub/x.go"},
+	}
+	for _, tt := range tests {
+		from, to := splitRenamePath(tt.in)
+		if from != tt.from || to != tt.to {
+			t.Errorf("splitRenamePath(%q) = %q, %q", tt.in, from, to)
+		}
+	}
+}
+
+func TestFormatter_Churn(t *testing.T) {
+	hotspots := []*Hotspot{{Rank: 1, Path: "pkg/core.go", Changes: 4, Lines: 83, Score: 100, Authors: 2}}
+	owners := []*Ownership{{Path: "pkg", Commits: 4, PrimaryAuthor: "Alice", PrimaryPercent: 92.6, BusFactor: 1,
+		Authors: []*AuthorShare{{Name: "Alice", Email: "alice@example.com", Commits: 3, Lines: 88, Percent: 92.6}}}}
+	couplings := []*Coupling{{FileA: "a.go", FileB: "b.go", Shared: 3, ChangesA: 4, ChangesB: 3, Degree: 85.7}}
+
+	for _, format := range []OutputFormat{FormatTable, FormatJSON, FormatCSV, FormatMarkdown, FormatLLM} {
+		f := NewFormatter(format)
+		for name, render := range map[string]func() ([]byte, error){
+			"hotspots":  func() ([]byte, error) { return f.FormatHotspots(hotspots) },
+			"ownership": func() ([]byte, error) { return f.FormatOwnership(owners) },
+			"coupling":  func() ([]byte, error) { return f.FormatCoupling(couplings) },
+		} {
+			out, err := render()
+			if err != nil || len(out) == 0 {
+				t.Errorf("%s %s: %v", format, name, err)
+			}
+		}
+	}
+
+	out, _ := NewFormatter(FormatTable).FormatOwnership(owners)
+	if !strings.Contains(string(out), "Alice") || !strings.Contains(string(out), "93%") {
+		t.Errorf("ownership table:\n%s", out)
+	}
+	out, _ = NewFormatter(FormatCSV).FormatCoupling(couplings)
+	if !strings.HasPrefix(string(out), "File A,File B,Shared") || !strings.Contains(string(out), "a.go,b.go,3,4,3,85.7") {
+		t.Errorf("coupling csv:\n%s", out)
+	}
+	if _, err := NewFormatter(FormatTable).FormatHotspots(nil); err == nil {
+		t.Error("FormatHotspots(nil) should return error")
+	}
+	if _, err := NewFormatter("xml").FormatCoupling(couplings); err != ErrInvalidFormat {
+		t.Errorf("invalid format: %v", err)
+	}
+}
@@ -12,6 +12,9 @@
 //   - Commit frequency analysis
 //   - File evolution tracking
 //   - Blame analysis
+//   - Hotspots, ownership and bus factor, change coupling
+//   - Workspace reports merged across repositories, with mailmap identities
+//   - Agent and device attribution from identity trailers
 //
 // # Usage
 //
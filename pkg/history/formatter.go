@@ -19,6 +19,12 @@ type Formatter interface {
 	FormatCommitStats(stats *CommitStats) ([]byte, error)
 	FormatContributors(contributors []*Contributor) ([]byte, error)
 	FormatFileHistory(history []*FileCommit) ([]byte, error)
+	FormatHotspots(hotspots []*Hotspot) ([]byte, error)
+	FormatOwnership(owners []*Ownership) ([]byte, error)
+	FormatCoupling(couplings []*Coupling) ([]byte, error)
+	FormatWorkspaceStats(report *WorkspaceReport) ([]byte, error)
+	FormatWorkspaceContributors(contributors []*WorkspaceContributor) ([]byte, error)
+	FormatAgents(report *AgentReport) ([]byte, error)
 }
 
 type formatter struct {
@@ -103,21 +109,26 @@ func (f *formatter) formatStatsTable(stats *CommitStats) []byte {
 
 	b.WriteString("Commit Statistics\n")
 	b.WriteString("==================\n\n")
-	fmt.Fprintf(&b, "Total Commits:    %d\n", stats.TotalCommits)
-	fmt.Fprintf(&b, "Unique Authors:   %d\n", stats.UniqueAuthors)
-	fmt.Fprintf(&b, "Total Additions:  %d lines\n", stats.TotalAdditions)
-	fmt.Fprintf(&b, "Total Deletions:  %d lines\n", stats.TotalDeletions)
-	fmt.Fprintf(&b, "First Commit:     %s\n", formatTime(stats.FirstCommit))
-	fmt.Fprintf(&b, "Last Commit:      %s\n", formatTime(stats.LastCommit))
-	fmt.Fprintf(&b, "Date Range:       %s\n", formatDuration(stats.DateRange))
-	fmt.Fprintf(&b, "Avg Per Day:      %.2f commits\n", stats.AvgPerDay)
-	fmt.Fprintf(&b, "Avg Per Week:     %.2f commits\n", stats.AvgPerWeek)
-	fmt.Fprintf(&b, "Avg Per Month:    %.2f commits\n", stats.AvgPerMonth)
-	fmt.Fprintf(&b, "Peak Day:         %s (%d commits)\n", formatDate(stats.PeakDay), stats.PeakCount)
+	writeStatsTableRows(&b, stats)
 
 	return []byte(b.String())
 }
 
+// writeStatsTableRows writes the statistics lines of the table format.
+func writeStatsTableRows(b *strings.Builder, stats *CommitStats) {
+	fmt.Fprintf(b, "Total Commits:    %d\n", stats.TotalCommits)
+	fmt.Fprintf(b, "Unique Authors:   %d\n", stats.UniqueAuthors)
+	fmt.Fprintf(b, "Total Additions:  %d lines\n", stats.TotalAdditions)
+	fmt.Fprintf(b, "Total Deletions:  %d lines\n", stats.TotalDeletions)
+	fmt.Fprintf(b, "First Commit:     %s\n", formatTime(stats.FirstCommit))
+	fmt.Fprintf(b, "Last Commit:      %s\n", formatTime(stats.LastCommit))
+	fmt.Fprintf(b, "Date Range:       %s\n", formatDuration(stats.DateRange))
+	fmt.Fprintf(b, "Avg Per Day:      %.2f commits\n", stats.AvgPerDay)
+	fmt.Fprintf(b, "Avg Per Week:     %.2f commits\n", stats.AvgPerWeek)
+	fmt.Fprintf(b, "Avg Per Month:    %.2f commits\n", stats.AvgPerMonth)
+	fmt.Fprintf(b, "Peak Day:         %s (%d commits)\n", formatDate(stats.PeakDay), stats.PeakCount)
+}
+
 func writeCSV(rows [][]string) ([]byte, error) {
 	var b strings.Builder
 	w := csv.NewWriter(&b)
@@ -155,23 +166,28 @@ func (f *formatter) formatStatsMarkdown(stats *CommitStats) []byte {
 	var b strings.Builder
 
 	b.WriteString("# Commit Statistics\n\n")
-	b.WriteString("| Metric | Value |\n")
-	b.WriteString("|--------|-------|\n")
-	fmt.Fprintf(&b, "| Total Commits | %d |\n", stats.TotalCommits)
-	fmt.Fprintf(&b, "| Unique Authors | %d |\n", stats.UniqueAuthors)
-	fmt.Fprintf(&b, "| Total Additions | %d lines |\n", stats.TotalAdditions)
-	fmt.Fprintf(&b, "| Total Deletions | %d lines |\n", stats.TotalDeletions)
-	fmt.Fprintf(&b, "| First Commit | %s |\n", formatTime(stats.FirstCommit))
-	fmt.Fprintf(&b, "| Last Commit | %s |\n", formatTime(stats.LastCommit))
-	fmt.Fprintf(&b, "| Date Range | %s |\n", formatDuration(stats.DateRange))
-	fmt.Fprintf(&b, "| Avg Per Day | %.2f commits |\n", stats.AvgPerDay)
-	fmt.Fprintf(&b, "| Avg Per Week | %.2f commits |\n", stats.AvgPerWeek)
-	fmt.Fprintf(&b, "| Avg Per Month | %.2f commits |\n", stats.AvgPerMonth)
-	fmt.Fprintf(&b, "| Peak Day | %s (%d commits) |\n", formatDate(stats.PeakDay), stats.PeakCount)
+	writeStatsMarkdownRows(&b, stats)
 
 	return []byte(b.String())
 }
 
+// writeStatsMarkdownRows writes the metric table of the markdown format.
+func writeStatsMarkdownRows(b *strings.Builder, stats *CommitStats) {
+	b.WriteString("| Metric | Value |\n")
+	b.WriteString("|--------|-------|\n")
+	fmt.Fprintf(b, "| Total Commits | %d |\n", stats.TotalCommits)
+	fmt.Fprintf(b, "| Unique Authors | %d |\n", stats.UniqueAuthors)
+	fmt.Fprintf(b, "| Total Additions | %d lines |\n", stats.TotalAdditions)
+	fmt.Fprintf(b, "| Total Deletions | %d lines |\n", stats.TotalDeletions)
+	fmt.Fprintf(b, "| First Commit | %s |\n", formatTime(stats.FirstCommit))
+	fmt.Fprintf(b, "| Last Commit | %s |\n", formatTime(stats.LastCommit))
+	fmt.Fprintf(b, "| Date Range | %s |\n", formatDuration(stats.DateRange))
+	fmt.Fprintf(b, "| Avg Per Day | %.2f commits |\n", stats.AvgPerDay)
+	fmt.Fprintf(b, "| Avg Per Week | %.2f commits |\n", stats.AvgPerWeek)
+	fmt.Fprintf(b, "| Avg Per Month | %.2f commits |\n", stats.AvgPerMonth)
+	fmt.Fprintf(b, "| Peak Day | %s (%d commits) |\n", formatDate(stats.PeakDay), stats.PeakCount)
+}
+
 func (f *formatter) formatContributorsTable(contributors []*Contributor) []byte {
 	var b strings.Builder
 
@@ -0,0 +1,186 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// FormatAgents formats an agent attribution report.
+func (f *formatter) FormatAgents(report *AgentReport) ([]byte, error) {
+	if report == nil {
+		return nil, fmt.Errorf("report cannot be nil")
+	}
+
+	switch f.format {
+	case FormatTable:
+		return f.formatAgentsTable(report), nil
+	case FormatJSON:
+		return json.MarshalIndent(report, "", "  ")
+	case FormatCSV:
+		return f.formatAgentsCSV(report)
+	case FormatMarkdown:
+		return f.formatAgentsMarkdown(report), nil
+	case FormatLLM:
+		return f.formatLLM(report)
+	default:
+		return nil, ErrInvalidFormat
+	}
+}
+
+// shortHash is the first seven characters of a commit hash.
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+func (f *formatter) formatAgentsTable(report *AgentReport) []byte {
+	var b strings.Builder
+
+	b.WriteString("Agent Attribution\n")
+	b.WriteString("=================\n\n")
+	repos := fmt.Sprintf("%d", report.Repositories)
+	if n := len(report.Failed); n > 0 {
+		repos += fmt.Sprintf(" (%d failed)", n)
+	}
+	fmt.Fprintf(&b, "Repositories:     %s\n", repos)
+	fmt.Fprintf(&b, "Total Commits:    %d\n\n", report.Commits)
+
+	fmt.Fprintf(&b, "%-8s %8s %7s %10s %10s\n", "Author", "Commits", "Share", "Additions", "Deletions")
+	b.WriteString(strings.Repeat("-", 47) + "\n")
+	for _, row := range []struct {
+		name  string
+		share *AuthorshipShare
+	}{{"Human", report.Human}, {"Agent", report.Agent}} {
+		fmt.Fprintf(&b, "%-8s %8d %6.1f%% %10d %10d\n",
+			row.name, row.share.Commits, row.share.Percent, row.share.LinesAdded, row.share.LinesDeleted)
+	}
+
+	writeActivityTable(&b, "Agents", "Devices", report.Agents, func(a *AgentActivity) []string { return a.Devices })
+	writeActivityTable(&b, "Devices", "Agents", report.Devices, func(a *AgentActivity) []string { return a.Agents })
+
+	fmt.Fprintf(&b, "\nAgent Commits on Protected Branches (%d)\n", len(report.Protected))
+	b.WriteString("----------------------------------------\n")
+	for _, p := range report.Protected {
+		fmt.Fprintf(&b, "%s %-24s %-16s %s %-16s %s\n",
+			shortHash(p.Hash),
+			truncateLeft(p.Repository, 24),
+			truncate(p.Branch, 16),
+			formatDate(p.Date),
+			truncate(p.Agent, 16),
+			truncate(p.Subject, 50))
+	}
+
+	for _, e := range report.Failed {
+		fmt.Fprintf(&b, "\n✗ %s: %s", e.Path, e.Error)
+	}
+	if len(report.Failed) > 0 {
+		b.WriteString("\n")
+	}
+
+	return []byte(b.String())
+}
+
+func writeActivityTable(b *strings.Builder, title, relatedTitle string, activities []*AgentActivity, related func(*AgentActivity) []string) {
+	fmt.Fprintf(b, "\n%s\n%s\n", title, strings.Repeat("-", len(title)))
+	if len(activities) == 0 {
+		b.WriteString("(none)\n")
+		return
+	}
+	fmt.Fprintf(b, "%-24s %8s %10s %10s %5s %-10s %s\n", "Name", "Commits", "Additions", "Deletions", "Repos", "Last", relatedTitle)
+	for _, a := range activities {
+		fmt.Fprintf(b, "%-24s %8d %10d %10d %5d %-10s %s\n",
+			truncate(a.Name, 24),
+			a.Commits,
+			a.LinesAdded,
+			a.LinesDeleted,
+			len(a.Repositories),
+			formatDate(a.LastCommit),
+			strings.Join(related(a), ", "))
+	}
+}
+
+// formatAgentsCSV writes one row per authorship share, agent, device and
+// protected-branch commit, told apart by the Kind column.
+func (f *formatter) formatAgentsCSV(report *AgentReport) ([]byte, error) {
+	rows := [][]string{{"Kind", "Name", "Commits", "Additions", "Deletions", "Repositories", "Last Commit", "Branch", "Commit"}}
+	for _, row := range []struct {
+		name  string
+		share *AuthorshipShare
+	}{{"human", report.Human}, {"agent", report.Agent}} {
+		rows = append(rows, []string{
+			"authorship",
+			row.name,
+			fmt.Sprintf("%d", row.share.Commits),
+			fmt.Sprintf("%d", row.share.LinesAdded),
+			fmt.Sprintf("%d", row.share.LinesDeleted),
+			"", "", "", "",
+		})
+	}
+	for _, group := range []struct {
+		kind       string
+		activities []*AgentActivity
+	}{{"agent", report.Agents}, {"device", report.Devices}} {
+		for _, a := range group.activities {
+			rows = append(rows, []string{
+				group.kind,
+				a.Name,
+				fmt.Sprintf("%d", a.Commits),
+				fmt.Sprintf("%d", a.LinesAdded),
+				fmt.Sprintf("%d", a.LinesDeleted),
+				strings.Join(a.Repositories, ";"),
+				formatTime(a.LastCommit),
+				"", "",
+			})
+		}
+	}
+	for _, p := range report.Protected {
+		rows = append(rows, []string{"protected", p.Agent, "1", "", "", p.Repository, formatTime(p.Date), p.Branch, p.Hash})
+	}
+	return writeCSV(rows)
+}
+
+func (f *formatter) formatAgentsMarkdown(report *AgentReport) []byte {
+	var b strings.Builder
+
+	b.WriteString("# Agent Attribution\n\n")
+	fmt.Fprintf(&b, "Repositories: %d, commits: %d\n\n", report.Repositories, report.Commits)
+	b.WriteString("| Author | Commits | Share | Additions | Deletions |\n")
+	b.WriteString("|--------|---------|-------|-----------|-----------|\n")
+	fmt.Fprintf(&b, "| Human | %d | %.1f%% | %d | %d |\n", report.Human.Commits, report.Human.Percent, report.Human.LinesAdded, report.Human.LinesDeleted)
+	fmt.Fprintf(&b, "| Agent | %d | %.1f%% | %d | %d |\n", report.Agent.Commits, report.Agent.Percent, report.Agent.LinesAdded, report.Agent.LinesDeleted)
+
+	for _, group := range []struct {
+		title, related string
+		activities     []*AgentActivity
+		names          func(*AgentActivity) []string
+	}{
+		{"Agents", "Devices", report.Agents, func(a *AgentActivity) []string { return a.Devices }},
+		{"Devices", "Agents", report.Devices, func(a *AgentActivity) []string { return a.Agents }},
+	} {
+		fmt.Fprintf(&b, "\n## %s\n\n", group.title)
+		fmt.Fprintf(&b, "| Name | Commits | Additions | Deletions | Repositories | %s |\n", group.related)
+		b.WriteString("|------|---------|-----------|-----------|--------------|------|\n")
+		for _, a := range group.activities {
+			fmt.Fprintf(&b, "| %s | %d | %d | %d | %d | %s |\n",
+				a.Name, a.Commits, a.LinesAdded, a.LinesDeleted, len(a.Repositories), strings.Join(group.names(a), ", "))
+		}
+	}
+
+	fmt.Fprintf(&b, "\n## Agent Commits on Protected Branches (%d)\n\n", len(report.Protected))
+	if len(report.Protected) > 0 {
+		b.WriteString("| Commit | Repository | Branch | Date | Agent | Device | Subject |\n")
+		b.WriteString("|--------|------------|--------|------|-------|--------|---------|\n")
+		for _, p := range report.Protected {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s | %s |\n",
+				shortHash(p.Hash), p.Repository, p.Branch, formatDate(p.Date), p.Agent, p.Device, p.Subject)
+		}
+	}
+
+	return []byte(b.String())
+}
@@ -0,0 +1,259 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// FormatHotspots formats hotspot rankings.
+func (f *formatter) FormatHotspots(hotspots []*Hotspot) ([]byte, error) {
+	if hotspots == nil {
+		return nil, fmt.Errorf("hotspots cannot be nil")
+	}
+
+	switch f.format {
+	case FormatTable:
+		return f.formatHotspotsTable(hotspots), nil
+	case FormatJSON:
+		return json.MarshalIndent(hotspots, "", "  ")
+	case FormatCSV:
+		return f.formatHotspotsCSV(hotspots)
+	case FormatMarkdown:
+		return f.formatHotspotsMarkdown(hotspots), nil
+	case FormatLLM:
+		return f.formatLLM(hotspots)
+	default:
+		return nil, ErrInvalidFormat
+	}
+}
+
+// FormatOwnership formats per-directory ownership.
+func (f *formatter) FormatOwnership(owners []*Ownership) ([]byte, error) {
+	if owners == nil {
+		return nil, fmt.Errorf("ownership cannot be nil")
+	}
+
+	switch f.format {
+	case FormatTable:
+		return f.formatOwnershipTable(owners), nil
+	case FormatJSON:
+		return json.MarshalIndent(owners, "", "  ")
+	case FormatCSV:
+		return f.formatOwnershipCSV(owners)
+	case FormatMarkdown:
+		return f.formatOwnershipMarkdown(owners), nil
+	case FormatLLM:
+		return f.formatLLM(owners)
+	default:
+		return nil, ErrInvalidFormat
+	}
+}
+
+// FormatCoupling formats change coupling between files.
+func (f *formatter) FormatCoupling(couplings []*Coupling) ([]byte, error) {
+	if couplings == nil {
+		return nil, fmt.Errorf("couplings cannot be nil")
+	}
+
+	switch f.format {
+	case FormatTable:
+		return f.formatCouplingTable(couplings), nil
+	case FormatJSON:
+		return json.MarshalIndent(couplings, "", "  ")
+	case FormatCSV:
+		return f.formatCouplingCSV(couplings)
+	case FormatMarkdown:
+		return f.formatCouplingMarkdown(couplings), nil
+	case FormatLLM:
+		return f.formatLLM(couplings)
+	default:
+		return nil, ErrInvalidFormat
+	}
+}
+
+func (f *formatter) formatHotspotsTable(hotspots []*Hotspot) []byte {
+	var b strings.Builder
+
+	b.WriteString("Hotspots\n")
+	b.WriteString("========\n\n")
+	fmt.Fprintf(&b, "%-4s %-44s %7s %7s %6s %7s\n", "Rank", "File", "Changes", "Lines", "Score", "Authors")
+	b.WriteString(strings.Repeat("-", 80) + "\n")
+
+	for _, h := range hotspots {
+		fmt.Fprintf(&b, "%-4d %-44s %7d %7d %6.1f %7d\n",
+			h.Rank,
+			truncateLeft(h.Path, 44),
+			h.Changes,
+			h.Lines,
+			h.Score,
+			h.Authors)
+	}
+
+	return []byte(b.String())
+}
+
+func (f *formatter) formatHotspotsCSV(hotspots []*Hotspot) ([]byte, error) {
+	rows := make([][]string, 0, len(hotspots)+1)
+	rows = append(rows, []string{"Rank", "File", "Changes", "Lines", "Score", "Authors", "Additions", "Deletions", "Last Changed"})
+	for _, h := range hotspots {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", h.Rank),
+			h.Path,
+			fmt.Sprintf("%d", h.Changes),
+			fmt.Sprintf("%d", h.Lines),
+			fmt.Sprintf("%.1f", h.Score),
+			fmt.Sprintf("%d", h.Authors),
+			fmt.Sprintf("%d", h.LinesAdded),
+			fmt.Sprintf("%d", h.LinesDeleted),
+			formatTime(h.LastChanged),
+		})
+	}
+	return writeCSV(rows)
+}
+
+func (f *formatter) formatHotspotsMarkdown(hotspots []*Hotspot) []byte {
+	var b strings.Builder
+
+	b.WriteString("# Hotspots\n\n")
+	b.WriteString("| Rank | File | Changes | Lines | Score | Authors |\n")
+	b.WriteString("|------|------|---------|-------|-------|---------|\n")
+
+	for _, h := range hotspots {
+		fmt.Fprintf(&b, "| %d | %s | %d | %d | %.1f | %d |\n",
+			h.Rank,
+			h.Path,
+			h.Changes,
+			h.Lines,
+			h.Score,
+			h.Authors)
+	}
+
+	return []byte(b.String())
+}
+
+func (f *formatter) formatOwnershipTable(owners []*Ownership) []byte {
+	var b strings.Builder
+
+	b.WriteString("Ownership\n")
+	b.WriteString("=========\n\n")
+	fmt.Fprintf(&b, "%-30s %7s %-26s %6s %10s\n", "Directory", "Commits", "Primary Author", "Share", "Bus Factor")
+	b.WriteString(strings.Repeat("-", 84) + "\n")
+
+	for _, o := range owners {
+		fmt.Fprintf(&b, "%-30s %7d %-26s %5.0f%% %10d\n",
+			truncateLeft(o.Path, 30),
+			o.Commits,
+			truncate(o.PrimaryAuthor, 26),
+			o.PrimaryPercent,
+			o.BusFactor)
+	}
+
+	return []byte(b.String())
+}
+
+func (f *formatter) formatOwnershipCSV(owners []*Ownership) ([]byte, error) {
+	rows := make([][]string, 0, len(owners)+1)
+	rows = append(rows, []string{"Directory", "Commits", "Bus Factor", "Author", "Email", "Author Commits", "Lines", "Percent"})
+	for _, o := range owners {
+		for _, a := range o.Authors {
+			rows = append(rows, []string{
+				o.Path,
+				fmt.Sprintf("%d", o.Commits),
+				fmt.Sprintf("%d", o.BusFactor),
+				a.Name,
+				a.Email,
+				fmt.Sprintf("%d", a.Commits),
+				fmt.Sprintf("%d", a.Lines),
+				fmt.Sprintf("%.1f", a.Percent),
+			})
+		}
+	}
+	return writeCSV(rows)
+}
+
+func (f *formatter) formatOwnershipMarkdown(owners []*Ownership) []byte {
+	var b strings.Builder
+
+	b.WriteString("# Ownership\n\n")
+	b.WriteString("| Directory | Commits | Primary Author | Share | Bus Factor |\n")
+	b.WriteString("|-----------|---------|----------------|-------|------------|\n")
+
+	for _, o := range owners {
+		fmt.Fprintf(&b, "| %s | %d | %s | %.0f%% | %d |\n",
+			o.Path,
+			o.Commits,
+			o.PrimaryAuthor,
+			o.PrimaryPercent,
+			o.BusFactor)
+	}
+
+	return []byte(b.String())
+}
+
+func (f *formatter) formatCouplingTable(couplings []*Coupling) []byte {
+	var b strings.Builder
+
+	b.WriteString("Change Coupling\n")
+	b.WriteString("===============\n\n")
+	fmt.Fprintf(&b, "%-32s %-32s %6s %6s\n", "File", "Coupled With", "Shared", "Degree")
+	b.WriteString(strings.Repeat("-", 80) + "\n")
+
+	for _, c := range couplings {
+		fmt.Fprintf(&b, "%-32s %-32s %6d %5.0f%%\n",
+			truncateLeft(c.FileA, 32),
+			truncateLeft(c.FileB, 32),
+			c.Shared,
+			c.Degree)
+	}
+
+	return []byte(b.String())
+}
+
+func (f *formatter) formatCouplingCSV(couplings []*Coupling) ([]byte, error) {
+	rows := make([][]string, 0, len(couplings)+1)
+	rows = append(rows, []string{"File A", "File B", "Shared", "Changes A", "Changes B", "Degree"})
+	for _, c := range couplings {
+		rows = append(rows, []string{
+			c.FileA,
+			c.FileB,
+			fmt.Sprintf("%d", c.Shared),
+			fmt.Sprintf("%d", c.ChangesA),
+			fmt.Sprintf("%d", c.ChangesB),
+			fmt.Sprintf("%.1f", c.Degree),
+		})
+	}
+	return writeCSV(rows)
+}
+
+func (f *formatter) formatCouplingMarkdown(couplings []*Coupling) []byte {
+	var b strings.Builder
+
+	b.WriteString("# Change Coupling\n\n")
+	b.WriteString("| File | Coupled With | Shared | Degree |\n")
+	b.WriteString("|------|--------------|--------|--------|\n")
+
+	for _, c := range couplings {
+		fmt.Fprintf(&b, "| %s | %s | %d | %.0f%% |\n",
+			c.FileA,
+			c.FileB,
+			c.Shared,
+			c.Degree)
+	}
+
+	return []byte(b.String())
+}
+
+// truncateLeft shortens a path from the front, keeping the file name.
+func truncateLeft(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	if maxLen <= 3 {
+		return s[len(s)-maxLen:]
+	}
+	return "..." + s[len(s)-maxLen+3:]
+}
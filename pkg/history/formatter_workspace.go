@@ -0,0 +1,273 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// workspaceTrendMonths is how many of the latest months the table and
+// markdown reports chart.
+const workspaceTrendMonths = 12
+
+// FormatWorkspaceStats formats a workspace report: the combined statistics,
+// the monthly trend, and the activity of each repository.
+func (f *formatter) FormatWorkspaceStats(report *WorkspaceReport) ([]byte, error) {
+	if report == nil {
+		return nil, fmt.Errorf("report cannot be nil")
+	}
+
+	switch f.format {
+	case FormatTable:
+		return f.formatWorkspaceStatsTable(report), nil
+	case FormatJSON:
+		return json.MarshalIndent(report, "", "  ")
+	case FormatCSV:
+		return f.formatRepoActivityCSV(report.Repositories)
+	case FormatMarkdown:
+		return f.formatWorkspaceStatsMarkdown(report), nil
+	case FormatLLM:
+		return f.formatLLM(report)
+	default:
+		return nil, ErrInvalidFormat
+	}
+}
+
+// FormatWorkspaceContributors formats contributors merged across a
+// workspace.
+func (f *formatter) FormatWorkspaceContributors(contributors []*WorkspaceContributor) ([]byte, error) {
+	if contributors == nil {
+		return nil, fmt.Errorf("contributors cannot be nil")
+	}
+
+	switch f.format {
+	case FormatTable:
+		return f.formatWorkspaceContributorsTable(contributors), nil
+	case FormatJSON:
+		return json.MarshalIndent(contributors, "", "  ")
+	case FormatCSV:
+		return f.formatWorkspaceContributorsCSV(contributors)
+	case FormatMarkdown:
+		return f.formatWorkspaceContributorsMarkdown(contributors), nil
+	case FormatLLM:
+		return f.formatLLM(contributors)
+	default:
+		return nil, ErrInvalidFormat
+	}
+}
+
+// repositoriesSummary reads "80 (3 dormant, 1 failed)".
+func repositoriesSummary(report *WorkspaceReport) string {
+	var notes []string
+	if report.Dormant > 0 {
+		notes = append(notes, fmt.Sprintf("%d dormant", report.Dormant))
+	}
+	if report.Failed > 0 {
+		notes = append(notes, fmt.Sprintf("%d failed", report.Failed))
+	}
+	s := fmt.Sprintf("%d", len(report.Repositories))
+	if len(notes) > 0 {
+		s += " (" + strings.Join(notes, ", ") + ")"
+	}
+	return s
+}
+
+// recentMonths returns the latest months of the trend, oldest first.
+func recentMonths(trends *CommitTrends) []string {
+	if trends == nil {
+		return nil
+	}
+	months := make([]string, 0, len(trends.Monthly))
+	for m := range trends.Monthly {
+		months = append(months, m)
+	}
+	sort.Strings(months)
+	if len(months) > workspaceTrendMonths {
+		months = months[len(months)-workspaceTrendMonths:]
+	}
+	return months
+}
+
+// repoStatus is "dormant", an error, or empty for an active repository.
+func repoStatus(r *RepoActivity) string {
+	switch {
+	case r.Error != "":
+		return "error: " + r.Error
+	case r.Dormant:
+		return "dormant"
+	default:
+		return ""
+	}
+}
+
+func (f *formatter) formatWorkspaceStatsTable(report *WorkspaceReport) []byte {
+	var b strings.Builder
+
+	b.WriteString("Workspace Commit Statistics\n")
+	b.WriteString("===========================\n\n")
+	fmt.Fprintf(&b, "Repositories:     %s\n", repositoriesSummary(report))
+	if report.Stats != nil {
+		writeStatsTableRows(&b, report.Stats)
+	} else {
+		b.WriteString("Total Commits:    0\n")
+	}
+
+	if months := recentMonths(report.Trends); len(months) > 0 {
+		peak := 0
+		for _, m := range months {
+			peak = max(peak, report.Trends.Monthly[m])
+		}
+		b.WriteString("\nMonthly Commits\n")
+		b.WriteString("---------------\n")
+		for _, m := range months {
+			n := report.Trends.Monthly[m]
+			fmt.Fprintf(&b, "%s %6d %s\n", m, n, strings.Repeat("█", (n*40+peak-1)/peak))
+		}
+	}
+
+	b.WriteString("\nRepository Activity\n")
+	b.WriteString("-------------------\n")
+	fmt.Fprintf(&b, "%-4s %-32s %7s %7s %8s %8s %-10s %s\n", "Rank", "Repository", "Commits", "Authors", "Added", "Deleted", "Last", "Status")
+	b.WriteString(strings.Repeat("-", 90) + "\n")
+	for _, r := range report.Repositories {
+		rank := "-"
+		if r.Rank > 0 {
+			rank = fmt.Sprintf("%d", r.Rank)
+		}
+		fmt.Fprintf(&b, "%-4s %-32s %7d %7d %8d %8d %-10s %s\n",
+			rank,
+			truncateLeft(r.Path, 32),
+			r.Commits,
+			r.Authors,
+			r.LinesAdded,
+			r.LinesDeleted,
+			formatDate(r.LastCommit),
+			repoStatus(r))
+	}
+
+	return []byte(b.String())
+}
+
+func (f *formatter) formatRepoActivityCSV(repos []*RepoActivity) ([]byte, error) {
+	rows := make([][]string, 0, len(repos)+1)
+	rows = append(rows, []string{"Rank", "Repository", "Commits", "Authors", "Additions", "Deletions", "Last Commit", "Dormant", "Error"})
+	for _, r := range repos {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", r.Rank),
+			r.Path,
+			fmt.Sprintf("%d", r.Commits),
+			fmt.Sprintf("%d", r.Authors),
+			fmt.Sprintf("%d", r.LinesAdded),
+			fmt.Sprintf("%d", r.LinesDeleted),
+			formatTime(r.LastCommit),
+			fmt.Sprintf("%t", r.Dormant),
+			r.Error,
+		})
+	}
+	return writeCSV(rows)
+}
+
+func (f *formatter) formatWorkspaceStatsMarkdown(report *WorkspaceReport) []byte {
+	var b strings.Builder
+
+	b.WriteString("# Workspace Commit Statistics\n\n")
+	fmt.Fprintf(&b, "Repositories: %s\n\n", repositoriesSummary(report))
+	if report.Stats != nil {
+		writeStatsMarkdownRows(&b, report.Stats)
+	}
+
+	if months := recentMonths(report.Trends); len(months) > 0 {
+		b.WriteString("\n## Monthly Commits\n\n")
+		b.WriteString("| Month | Commits |\n")
+		b.WriteString("|-------|---------|\n")
+		for _, m := range months {
+			fmt.Fprintf(&b, "| %s | %d |\n", m, report.Trends.Monthly[m])
+		}
+	}
+
+	b.WriteString("\n## Repository Activity\n\n")
+	b.WriteString("| Rank | Repository | Commits | Authors | Additions | Deletions | Last Commit | Status |\n")
+	b.WriteString("|------|------------|---------|---------|-----------|-----------|-------------|--------|\n")
+	for _, r := range report.Repositories {
+		fmt.Fprintf(&b, "| %d | %s | %d | %d | %d | %d | %s | %s |\n",
+			r.Rank,
+			r.Path,
+			r.Commits,
+			r.Authors,
+			r.LinesAdded,
+			r.LinesDeleted,
+			formatDate(r.LastCommit),
+			repoStatus(r))
+	}
+
+	return []byte(b.String())
+}
+
+func (f *formatter) formatWorkspaceContributorsTable(contributors []*WorkspaceContributor) []byte {
+	var b strings.Builder
+
+	b.WriteString("Workspace Contributors\n")
+	b.WriteString("======================\n\n")
+	fmt.Fprintf(&b, "%-4s %-24s %-28s %7s %9s %9s %5s\n", "Rank", "Name", "Email", "Commits", "Additions", "Deletions", "Repos")
+	b.WriteString(strings.Repeat("-", 92) + "\n")
+
+	for _, c := range contributors {
+		fmt.Fprintf(&b, "%-4d %-24s %-28s %7d %9d %9d %5d\n",
+			c.Rank,
+			truncate(c.Name, 24),
+			truncate(c.Email, 28),
+			c.TotalCommits,
+			c.LinesAdded,
+			c.LinesDeleted,
+			len(c.Repositories))
+	}
+
+	return []byte(b.String())
+}
+
+func (f *formatter) formatWorkspaceContributorsCSV(contributors []*WorkspaceContributor) ([]byte, error) {
+	rows := make([][]string, 0, len(contributors)+1)
+	rows = append(rows, []string{"Rank", "Name", "Email", "Commits", "Additions", "Deletions", "Files", "Active Days", "Commits/Week", "First Commit", "Last Commit", "Repositories"})
+	for _, c := range contributors {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", c.Rank),
+			c.Name,
+			c.Email,
+			fmt.Sprintf("%d", c.TotalCommits),
+			fmt.Sprintf("%d", c.LinesAdded),
+			fmt.Sprintf("%d", c.LinesDeleted),
+			fmt.Sprintf("%d", c.FilesTouched),
+			fmt.Sprintf("%d", c.ActiveDays),
+			fmt.Sprintf("%.2f", c.CommitsPerWeek),
+			formatTime(c.FirstCommit),
+			formatTime(c.LastCommit),
+			strings.Join(c.Repositories, ";"),
+		})
+	}
+	return writeCSV(rows)
+}
+
+func (f *formatter) formatWorkspaceContributorsMarkdown(contributors []*WorkspaceContributor) []byte {
+	var b strings.Builder
+
+	b.WriteString("# Workspace Contributors\n\n")
+	b.WriteString("| Rank | Name | Email | Commits | Additions | Deletions | Repositories |\n")
+	b.WriteString("|------|------|-------|---------|-----------|-----------|--------------|\n")
+
+	for _, c := range contributors {
+		fmt.Fprintf(&b, "| %d | %s | %s | %d | %d | %d | %s |\n",
+			c.Rank,
+			c.Name,
+			c.Email,
+			c.TotalCommits,
+			c.LinesAdded,
+			c.LinesDeleted,
+			strings.Join(c.Repositories, ", "))
+	}
+
+	return []byte(b.String())
+}
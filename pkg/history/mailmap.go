@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package history
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Mailmap maps the identities commits were made with to canonical ones. It
+// reads git's .mailmap format, so one file can merge a person's aliases
+// across every repository of a workspace, on top of each repository's own
+// .mailmap.
+type Mailmap struct {
+	entries map[string][]mailmapEntry // by lower-cased commit email
+}
+
+// mailmapEntry is one line of a mailmap. commitName is empty when the line
+// matches every name used with commitEmail.
+type mailmapEntry struct {
+	properName  string
+	properEmail string
+	commitName  string
+}
+
+// LoadMailmap reads a mailmap file.
+func LoadMailmap(path string) (*Mailmap, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mailmap: %w", err)
+	}
+	defer f.Close()
+
+	m, err := ParseMailmap(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
+
+// ParseMailmap parses mailmap lines, in any of git's forms:
+//
+//	Proper Name <commit@email>
+//	<proper@email> <commit@email>
+//	Proper Name <proper@email> <commit@email>
+//	Proper Name <proper@email> Commit Name <commit@email>
+func ParseMailmap(r io.Reader) (*Mailmap, error) {
+	m := &Mailmap{entries: map[string][]mailmapEntry{}}
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name1, email1, rest, ok := cutIdentity(line)
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected <email>: %q", lineNo, line)
+		}
+		name2, email2, _, ok := cutIdentity(rest)
+		if !ok {
+			// Proper Name <commit@email>
+			if name1 == "" {
+				return nil, fmt.Errorf("line %d: nothing to map %s to", lineNo, email1)
+			}
+			m.add(email1, mailmapEntry{properName: name1})
+			continue
+		}
+		m.add(email2, mailmapEntry{properName: name1, properEmail: email1, commitName: name2})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read mailmap: %w", err)
+	}
+	return m, nil
+}
+
+// cutIdentity splits "Name <email> rest" into its parts.
+func cutIdentity(s string) (name, email, rest string, ok bool) {
+	open := strings.Index(s, "<")
+	if open < 0 {
+		return "", "", s, false
+	}
+	closing := strings.Index(s[open:], ">")
+	if closing < 0 {
+		return "", "", s, false
+	}
+	closing += open
+	return strings.TrimSpace(s[:open]), strings.TrimSpace(s[open+1 : closing]), s[closing+1:], true
+}
+
+func (m *Mailmap) add(commitEmail string, e mailmapEntry) {
+	key := strings.ToLower(commitEmail)
+	m.entries[key] = append(m.entries[key], e)
+}
+
+// Resolve returns the canonical name and email for a commit identity. As in
+// git, a line naming the commit's name wins over one matching the email
+// alone, and the parts a line leaves out are kept. A nil Mailmap maps every
+// identity to itself.
+func (m *Mailmap) Resolve(name, email string) (string, string) {
+	if m == nil {
+		return name, email
+	}
+	entries := m.entries[strings.ToLower(email)]
+	var match *mailmapEntry
+	for i, e := range entries {
+		if e.commitName != "" && !strings.EqualFold(e.commitName, name) {
+			continue
+		}
+		if match == nil || (match.commitName == "" && e.commitName != "") {
+			match = &entries[i]
+		}
+	}
+	if match == nil {
+		return name, email
+	}
+	if match.properName != "" {
+		name = match.properName
+	}
+	if match.properEmail != "" {
+		email = match.properEmail
+	}
+	return name, email
+}
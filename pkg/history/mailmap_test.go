@@ -0,0 +1,50 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package history
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMailmap_Resolve(t *testing.T) {
+	m, err := ParseMailmap(strings.NewReader(`# team aliases
+Jane Doe <JANE@old.example.com>
+<jane@example.com> <jane@laptop.local>
+Jane Doe <jane@example.com> <jd@example.com>
+Build Bot <bot@example.com> ci <shared@example.com>
+Shared Account <shared@example.com>
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct{ name, email, wantName, wantEmail string }{
+		{"jane", "jane@old.example.com", "Jane Doe", "jane@old.example.com"},
+		{"jane", "jane@laptop.local", "jane", "jane@example.com"},
+		{"J. Doe", "JD@example.com", "Jane Doe", "jane@example.com"},
+		{"ci", "shared@example.com", "Build Bot", "bot@example.com"},
+		{"someone", "shared@example.com", "Shared Account", "shared@example.com"},
+		{"Other", "other@example.com", "Other", "other@example.com"},
+	}
+	for _, tt := range tests {
+		name, email := m.Resolve(tt.name, tt.email)
+		if name != tt.wantName || email != tt.wantEmail {
+			t.Errorf("Resolve(%q, %q) = %q, %q", tt.name, tt.email, name, email)
+		}
+	}
+
+	var none *Mailmap
+	if name, email := none.Resolve("a", "b"); name != "a" || email != "b" {
+		t.Error("a nil mailmap should keep identities")
+	}
+}
+
+func TestParseMailmap_Invalid(t *testing.T) {
+	for _, in := range []string{"Jane Doe jane@example.com", "<jane@example.com>"} {
+		if _, err := ParseMailmap(strings.NewReader(in)); err == nil {
+			t.Errorf("ParseMailmap(%q) should fail", in)
+		}
+	}
+}
@@ -119,6 +119,192 @@ type HistoryOptions struct {
 	Author   string
 }
 
+// ChurnOptions configures hotspot, ownership and coupling analysis.
+type ChurnOptions struct {
+	Since      time.Time
+	Until      time.Time
+	Branch     string
+	Path       string // limit to a path or pathspec
+	MaxCommits int    // count only the newest N commits
+	Top        int    // keep only the first N results
+
+	Depth     int // ownership: directory levels to group by (default 1)
+	MinShared int // coupling: minimum commits a pair shares (default 3)
+	MaxFiles  int // coupling: skip commits touching more files (default 30)
+}
+
+// Hotspot is a file that is both large and often changed.
+type Hotspot struct {
+	Rank         int
+	Path         string
+	Changes      int     // commits that changed the file
+	Lines        int     // size at the analyzed revision: the complexity proxy
+	Score        float64 // Changes × Lines, scaled so the top file is 100
+	Authors      int
+	LinesAdded   int
+	LinesDeleted int
+	LastChanged  time.Time
+}
+
+// Ownership is who changed a directory, and how concentrated that is.
+type Ownership struct {
+	Path           string
+	Commits        int
+	PrimaryAuthor  string
+	PrimaryPercent float64
+	BusFactor      int // fewest authors who made half the changed lines
+	Authors        []*AuthorShare
+}
+
+// AuthorShare is one author's part of a directory's changed lines.
+type AuthorShare struct {
+	Name    string
+	Email   string
+	Commits int
+	Lines   int
+	Percent float64
+}
+
+// Coupling is a pair of files that tend to change together.
+type Coupling struct {
+	FileA    string
+	FileB    string
+	Shared   int     // commits that changed both
+	ChangesA int     // commits that changed FileA
+	ChangesB int     // commits that changed FileB
+	Degree   float64 // Shared as a percentage of the average of ChangesA and ChangesB
+}
+
+// WorkspaceOptions configures history reports across the repositories of a
+// workspace.
+type WorkspaceOptions struct {
+	Since  time.Time
+	Until  time.Time
+	Branch string
+	Author string
+
+	Mailmap      *Mailmap      // identities to merge across repositories
+	DormantAfter time.Duration // no commits for this long is dormant (default 180 days)
+	Now          time.Time     // reference time for dormancy (default: now)
+
+	MinCommits int
+	SortBy     ContributorSortBy
+	Top        int // keep only the first N contributors
+}
+
+// RepoHistory is one repository's commits, read for a workspace report.
+type RepoHistory struct {
+	Path       string
+	LastCommit time.Time // newest commit on the branch, whatever the window
+	Err        error     // the repository could not be read
+	commits    []*workspaceCommit
+}
+
+// WorkspaceReport merges the history of the repositories of a workspace.
+type WorkspaceReport struct {
+	Stats        *CommitStats // nil when no repository has commits in the window
+	Trends       *CommitTrends
+	Repositories []*RepoActivity // most active first
+	Contributors []*WorkspaceContributor
+	Dormant      int
+	Failed       int
+}
+
+// RepoActivity is one repository's activity within a workspace.
+type RepoActivity struct {
+	Rank         int // 0 when the repository could not be read
+	Path         string
+	Commits      int
+	Authors      int
+	LinesAdded   int
+	LinesDeleted int
+	LastCommit   time.Time
+	Dormant      bool
+	Error        string
+}
+
+// WorkspaceContributor is a contributor whose identities are merged across
+// the repositories of a workspace.
+type WorkspaceContributor struct {
+	Contributor
+	Repositories []string
+}
+
+// AgentOptions configures agent and device attribution analysis.
+type AgentOptions struct {
+	Since  time.Time
+	Until  time.Time
+	Branch string // one branch; default every local and remote-tracking branch
+
+	// Protected lists the branch names and trailing-* patterns whose agent
+	// commits are flagged. Empty means repository.ProtectedBranches.
+	Protected []string
+}
+
+// RepoAgentHistory is one repository's attributed commits, read for an
+// agent report.
+type RepoAgentHistory struct {
+	Path    string
+	Err     error // the repository could not be read
+	commits []*agentCommit
+}
+
+// AgentReport attributes commits to the agents and devices named in their
+// identity trailers.
+type AgentReport struct {
+	Repositories int
+	Failed       []*RepoError
+
+	Commits int
+	Human   *AuthorshipShare // commits without an Agent trailer
+	Agent   *AuthorshipShare // commits with one
+
+	Agents    []*AgentActivity // most commits first
+	Devices   []*AgentActivity // most commits first
+	Protected []*ProtectedCommit
+}
+
+// RepoError is a repository a workspace report could not read.
+type RepoError struct {
+	Path  string
+	Error string
+}
+
+// AuthorshipShare is the part of the commits one kind of author made.
+type AuthorshipShare struct {
+	Commits      int
+	LinesAdded   int
+	LinesDeleted int
+	Percent      float64 // of all commits
+}
+
+// AgentActivity is what one agent, or one device, committed.
+type AgentActivity struct {
+	Name         string
+	Commits      int
+	AgentCommits int // for a device: commits an agent made there
+	LinesAdded   int
+	LinesDeleted int
+	Repositories []string
+	Agents       []string // for a device: the agents that ran there
+	Devices      []string // for an agent: the devices it ran on
+	FirstCommit  time.Time
+	LastCommit   time.Time
+}
+
+// ProtectedCommit is an agent's commit made directly on a protected branch:
+// it is on the branch's first-parent line rather than merged into it.
+type ProtectedCommit struct {
+	Repository string
+	Branch     string
+	Hash       string
+	Date       time.Time
+	Author     string
+	Agent      string
+	Device     string
+	Subject    string
+}
+
 // OutputFormat defines the output format for analysis results.
 type OutputFormat string
 
@@ -0,0 +1,418 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package history
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+// DefaultDormantAfter is how long a repository may go without commits before
+// a workspace report calls it dormant.
+const DefaultDormantAfter = 180 * 24 * time.Hour
+
+// WorkspaceAnalyzer reads the history of the repositories of a workspace.
+// Callers fan Collect out over the scanned repositories, in parallel, and
+// combine the results with MergeWorkspace.
+type WorkspaceAnalyzer interface {
+	Collect(ctx context.Context, repo *repository.Repository, opts WorkspaceOptions) (*RepoHistory, error)
+}
+
+type workspaceAnalyzer struct {
+	executor GitExecutor
+}
+
+// NewWorkspaceAnalyzer creates a new workspace analyzer.
+func NewWorkspaceAnalyzer(executor GitExecutor) WorkspaceAnalyzer {
+	return &workspaceAnalyzer{
+		executor: executor,
+	}
+}
+
+// workspaceCommit is one commit of a repository's log. name and email are as
+// git reports them, after the repository's own .mailmap.
+type workspaceCommit struct {
+	date    time.Time
+	name    string
+	email   string
+	added   int
+	deleted int
+	files   []string
+}
+
+// Collect reads one repository's commits inside the window, and its newest
+// commit overall. A repository without commits is not an error: it comes
+// back empty, and counts as dormant.
+func (w *workspaceAnalyzer) Collect(ctx context.Context, repo *repository.Repository, opts WorkspaceOptions) (*RepoHistory, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("repository cannot be nil")
+	}
+	if !opts.Since.IsZero() && !opts.Until.IsZero() && opts.Since.After(opts.Until) {
+		return nil, ErrInvalidDateRange
+	}
+	rh := &RepoHistory{Path: repo.Path}
+
+	args := []string{"log", "-1", "--format=%ct"}
+	if opts.Branch != "" {
+		args = append(args, opts.Branch)
+	}
+	last, err := w.run(ctx, repo.Path, args...)
+	if errors.Is(err, ErrEmptyHistory) {
+		return rh, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	ts, err := strconv.ParseInt(strings.TrimSpace(last), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected commit time %q", strings.TrimSpace(last))
+	}
+	rh.LastCommit = time.Unix(ts, 0)
+
+	// %aN and %aE apply the repository's .mailmap.
+	args = []string{"log", "--numstat", "--format=%x1e%ct%x1f%aN%x1f%aE"}
+	if !opts.Since.IsZero() {
+		args = append(args, fmt.Sprintf("--since=%s", opts.Since.Format(time.RFC3339)))
+	}
+	if !opts.Until.IsZero() {
+		args = append(args, fmt.Sprintf("--until=%s", opts.Until.Format(time.RFC3339)))
+	}
+	if opts.Author != "" {
+		args = append(args, fmt.Sprintf("--author=%s", opts.Author))
+	}
+	if opts.Branch != "" {
+		args = append(args, opts.Branch)
+	}
+	out, err := w.run(ctx, repo.Path, args...)
+	if err != nil {
+		return nil, err
+	}
+	if rh.commits, err = parseWorkspaceLog(out); err != nil {
+		return nil, err
+	}
+	return rh, nil
+}
+
+func (w *workspaceAnalyzer) run(ctx context.Context, dir string, args ...string) (string, error) {
+	result, err := w.executor.Run(ctx, dir, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit log: %w", err)
+	}
+	if result.ExitCode != 0 {
+		if strings.Contains(result.Stderr, "does not have any commits") {
+			return "", ErrEmptyHistory
+		}
+		return "", fmt.Errorf("git log failed: %s", strings.TrimSpace(result.Stderr))
+	}
+	return result.Stdout, nil
+}
+
+// parseWorkspaceLog parses `git log --numstat` with one \x1e-prefixed,
+// \x1f-separated header per commit.
+func parseWorkspaceLog(output string) ([]*workspaceCommit, error) {
+	var commits []*workspaceCommit
+	for _, record := range strings.Split(output, "\x1e") {
+		record = strings.TrimSpace(record)
+		if record == "" {
+			continue
+		}
+		header, body, _ := strings.Cut(record, "\n")
+		fields := strings.Split(header, "\x1f")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("unexpected log header %q", header)
+		}
+		ts, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected commit time %q", fields[0])
+		}
+		commit := &workspaceCommit{date: time.Unix(ts, 0), name: fields[1], email: fields[2]}
+
+		for _, line := range strings.Split(body, "\n") {
+			parts := strings.SplitN(line, "\t", 3)
+			if len(parts) != 3 {
+				continue
+			}
+			// Binary files show "-": they count as touched, with no lines.
+			added, _ := strconv.Atoi(parts[0])   //nolint:errcheck // binary files return "-"
+			deleted, _ := strconv.Atoi(parts[1]) //nolint:errcheck // binary files return "-"
+			_, file := splitRenamePath(parts[2])
+			commit.added += added
+			commit.deleted += deleted
+			commit.files = append(commit.files, file)
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// identityTally accumulates one merged contributor.
+type identityTally struct {
+	names   map[string]int // name -> commits, to pick the most used
+	email   string
+	commits int
+	first   time.Time
+	last    time.Time
+	added   int
+	deleted int
+	files   map[string]bool // repository path + "\x00" + file
+	days    map[string]bool
+	repos   map[string]bool
+}
+
+// MergeWorkspace combines the histories of a workspace's repositories into
+// one report: commit statistics and trends over all of them, each
+// repository's activity, most active first, and contributors merged by
+// email. Identities are resolved with opts.Mailmap before merging; emails
+// are compared case-insensitively.
+func MergeWorkspace(histories []*RepoHistory, opts WorkspaceOptions) *WorkspaceReport {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	dormantAfter := opts.DormantAfter
+	if dormantAfter <= 0 {
+		dormantAfter = DefaultDormantAfter
+	}
+
+	report := &WorkspaceReport{
+		Trends: &CommitTrends{
+			Daily:   make(map[string]int),
+			Weekly:  make(map[string]int),
+			Monthly: make(map[string]int),
+			Hourly:  make(map[int]int),
+		},
+		Repositories: make([]*RepoActivity, 0, len(histories)),
+	}
+	stats := &CommitStats{}
+	identities := map[string]*identityTally{}
+
+	for _, h := range histories {
+		activity := &RepoActivity{Path: h.Path, LastCommit: h.LastCommit}
+		report.Repositories = append(report.Repositories, activity)
+		if h.Err != nil {
+			activity.Error = h.Err.Error()
+			report.Failed++
+			continue
+		}
+		if h.LastCommit.IsZero() || now.Sub(h.LastCommit) > dormantAfter {
+			activity.Dormant = true
+			report.Dormant++
+		}
+
+		authors := map[string]bool{}
+		for _, c := range h.commits {
+			name, email := opts.Mailmap.Resolve(c.name, c.email)
+			key := identityKey(name, email)
+			authors[key] = true
+
+			activity.Commits++
+			activity.LinesAdded += c.added
+			activity.LinesDeleted += c.deleted
+			addCommitToStats(stats, report.Trends, c)
+
+			t := identities[key]
+			if t == nil {
+				t = &identityTally{
+					names: map[string]int{},
+					email: email,
+					files: map[string]bool{},
+					days:  map[string]bool{},
+					repos: map[string]bool{},
+				}
+				identities[key] = t
+			}
+			t.names[name]++
+			t.commits++
+			t.added += c.added
+			t.deleted += c.deleted
+			if t.first.IsZero() || c.date.Before(t.first) {
+				t.first = c.date
+			}
+			if c.date.After(t.last) {
+				t.last = c.date
+			}
+			t.days[c.date.Format("2006-01-02")] = true
+			t.repos[h.Path] = true
+			for _, f := range c.files {
+				t.files[h.Path+"\x00"+f] = true
+			}
+		}
+		activity.Authors = len(authors)
+	}
+
+	if stats.TotalCommits > 0 {
+		finishWorkspaceStats(stats, report.Trends, identities)
+		report.Stats = stats
+	}
+	rankRepositories(report.Repositories)
+	report.Contributors = mergedContributors(identities, opts)
+	return report
+}
+
+// identityKey is the merge key of a contributor: the email, or the name for
+// commits without one.
+func identityKey(name, email string) string {
+	if email == "" {
+		return "name:" + strings.ToLower(name)
+	}
+	return strings.ToLower(email)
+}
+
+func addCommitToStats(stats *CommitStats, trends *CommitTrends, c *workspaceCommit) {
+	stats.TotalCommits++
+	stats.TotalAdditions += c.added
+	stats.TotalDeletions += c.deleted
+	if stats.FirstCommit.IsZero() || c.date.Before(stats.FirstCommit) {
+		stats.FirstCommit = c.date
+	}
+	if c.date.After(stats.LastCommit) {
+		stats.LastCommit = c.date
+	}
+
+	trends.Daily[c.date.Format("2006-01-02")]++
+	year, week := c.date.ISOWeek()
+	trends.Weekly[fmt.Sprintf("%04d-W%02d", year, week)]++
+	trends.Monthly[c.date.Format("2006-01")]++
+	trends.Hourly[c.date.Hour()]++
+}
+
+// finishWorkspaceStats derives the averages, peak day and distinct counts,
+// the same way HistoryAnalyzer does for one repository.
+func finishWorkspaceStats(stats *CommitStats, trends *CommitTrends, identities map[string]*identityTally) {
+	stats.UniqueAuthors = len(identities)
+	files := map[string]bool{}
+	for _, t := range identities {
+		for f := range t.files {
+			files[f] = true
+		}
+	}
+	stats.TotalFiles = len(files)
+
+	stats.DateRange = stats.LastCommit.Sub(stats.FirstCommit)
+	days := stats.DateRange.Hours() / 24
+	if days < 1 {
+		days = 1
+	}
+	stats.AvgPerDay = float64(stats.TotalCommits) / days
+	stats.AvgPerWeek = stats.AvgPerDay * 7
+	stats.AvgPerMonth = stats.AvgPerDay * 30
+
+	// The earliest of the busiest days, so the result does not depend on
+	// map order.
+	for date, count := range trends.Daily {
+		peak := stats.PeakDay.Format("2006-01-02")
+		if count > stats.PeakCount || (count == stats.PeakCount && date < peak) {
+			if t, err := time.Parse("2006-01-02", date); err == nil {
+				stats.PeakDay, stats.PeakCount = t, count
+			}
+		}
+	}
+}
+
+// rankRepositories orders repositories by commits, then lines changed, and
+// numbers them. Repositories that could not be read go last, unranked.
+func rankRepositories(repos []*RepoActivity) {
+	sort.SliceStable(repos, func(i, j int) bool {
+		a, b := repos[i], repos[j]
+		if (a.Error == "") != (b.Error == "") {
+			return a.Error == ""
+		}
+		if a.Commits != b.Commits {
+			return a.Commits > b.Commits
+		}
+		if la, lb := a.LinesAdded+a.LinesDeleted, b.LinesAdded+b.LinesDeleted; la != lb {
+			return la > lb
+		}
+		return a.Path < b.Path
+	})
+	for i, r := range repos {
+		if r.Error == "" {
+			r.Rank = i + 1
+		}
+	}
+}
+
+func mergedContributors(identities map[string]*identityTally, opts WorkspaceOptions) []*WorkspaceContributor {
+	contributors := make([]*WorkspaceContributor, 0, len(identities))
+	for _, t := range identities {
+		if t.commits < opts.MinCommits {
+			continue
+		}
+		c := &WorkspaceContributor{
+			Contributor: Contributor{
+				Name:         mostUsedName(t.names),
+				Email:        t.email,
+				TotalCommits: t.commits,
+				FirstCommit:  t.first,
+				LastCommit:   t.last,
+				LinesAdded:   t.added,
+				LinesDeleted: t.deleted,
+				FilesTouched: len(t.files),
+				ActiveDays:   len(t.days),
+			},
+		}
+		weeks := t.last.Sub(t.first).Hours() / 24 / 7
+		if weeks < 1 {
+			weeks = 1
+		}
+		c.CommitsPerWeek = float64(t.commits) / weeks
+		for repo := range t.repos {
+			c.Repositories = append(c.Repositories, repo)
+		}
+		sort.Strings(c.Repositories)
+		contributors = append(contributors, c)
+	}
+
+	sortWorkspaceContributors(contributors, opts.SortBy)
+	if opts.Top > 0 && len(contributors) > opts.Top {
+		contributors = contributors[:opts.Top]
+	}
+	for i, c := range contributors {
+		c.Rank = i + 1
+	}
+	return contributors
+}
+
+// mostUsedName picks the name a merged contributor committed with most, the
+// alphabetically first on a tie.
+func mostUsedName(names map[string]int) string {
+	best, bestCount := "", 0
+	for name, count := range names {
+		if count > bestCount || (count == bestCount && name < best) {
+			best, bestCount = name, count
+		}
+	}
+	return best
+}
+
+// sortWorkspaceContributors orders merged contributors like
+// ContributorAnalyzer orders one repository's, with the email as a final
+// tie-breaker so the order is stable.
+func sortWorkspaceContributors(contributors []*WorkspaceContributor, sortBy ContributorSortBy) {
+	key := func(c *WorkspaceContributor) int {
+		switch sortBy {
+		case SortByLinesAdded:
+			return c.LinesAdded
+		case SortByLinesDeleted:
+			return c.LinesDeleted
+		case SortByRecent:
+			return int(c.LastCommit.Unix())
+		default:
+			return c.TotalCommits
+		}
+	}
+	sort.Slice(contributors, func(i, j int) bool {
+		if ki, kj := key(contributors[i]), key(contributors[j]); ki != kj {
+			return ki > kj
+		}
+		return contributors[i].Email < contributors[j].Email
+	})
+}
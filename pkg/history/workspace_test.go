@@ -0,0 +1,210 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package history
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-gitforge/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+// workspaceLog renders a log in the analyzer's format, with "|" for the
+// field separator and "#" for the record separator.
+func workspaceLog(s string) string {
+	return strings.NewReplacer("#", "\x1e", "|", "\x1f").Replace(s)
+}
+
+// day is noon UTC on the given day of November 2023.
+func day(d int) time.Time {
+	return time.Date(2023, time.November, d, 12, 0, 0, 0, time.UTC)
+}
+
+func repoHistory(t *testing.T, path, log string) *RepoHistory {
+	t.Helper()
+	commits, err := parseWorkspaceLog(workspaceLog(log))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &RepoHistory{Path: path, commits: commits}
+	if len(commits) > 0 {
+		h.LastCommit = commits[0].date
+	}
+	return h
+}
+
+func TestWorkspaceAnalyzer_Collect(t *testing.T) {
+	var calls [][]string
+	executor := &mockExecutor{
+		runFunc: func(_ context.Context, _ string, args ...string) (*gitcmd.Result, error) {
+			calls = append(calls, args)
+			if args[1] == "-1" {
+				return &gitcmd.Result{Stdout: "1700000000\n"}, nil
+			}
+			return &gitcmd.Result{Stdout: workspaceLog("#1700000000|Alice|alice@example.com\n3\t1\tpkg/{a.go => b.go}\n-\t-\tlogo.png\n")}, nil
+		},
+	}
+	h, err := NewWorkspaceAnalyzer(executor).Collect(context.Background(), &repository.Repository{Path: "/repo"},
+		WorkspaceOptions{Since: day(1), Branch: "main", Author: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !h.LastCommit.Equal(time.Unix(1700000000, 0)) || len(h.commits) != 1 {
+		t.Fatalf("history = %+v", h)
+	}
+	c := h.commits[0]
+	if c.added != 3 || c.deleted != 1 || strings.Join(c.files, ",") != "pkg/b.go,logo.png" {
+		t.Errorf("commit = %+v", c)
+	}
+	log := strings.Join(calls[1], " ")
+	for _, want := range []string{"--since=2023-11-01T12:00:00Z", "--author=alice", "main"} {
+		if !strings.Contains(log, want) {
+			t.Errorf("git %s: missing %s", log, want)
+		}
+	}
+}
+
+func TestWorkspaceAnalyzer_CollectEmptyRepository(t *testing.T) {
+	executor := &mockExecutor{
+		runFunc: func(_ context.Context, _ string, args ...string) (*gitcmd.Result, error) {
+			return &gitcmd.Result{ExitCode: 128, Stderr: "fatal: your current branch 'main' does not have any commits yet"}, nil
+		},
+	}
+	h, err := NewWorkspaceAnalyzer(executor).Collect(context.Background(), &repository.Repository{Path: "/repo"}, WorkspaceOptions{})
+	if err != nil || !h.LastCommit.IsZero() || len(h.commits) != 0 {
+		t.Errorf("empty repository: %+v, %v", h, err)
+	}
+
+	executor.runFunc = func(context.Context, string, ...string) (*gitcmd.Result, error) {
+		return &gitcmd.Result{ExitCode: 128, Stderr: "fatal: bad revision 'nope'"}, nil
+	}
+	if _, err := NewWorkspaceAnalyzer(executor).Collect(context.Background(), &repository.Repository{Path: "/repo"}, WorkspaceOptions{Branch: "nope"}); err == nil {
+		t.Error("a bad revision should fail")
+	}
+}
+
+func TestMergeWorkspace(t *testing.T) {
+	// Alice commits to both repositories; in api also as
+	// al@old.example.com, which the mailmap maps to alice@example.com.
+	api := repoHistory(t, "api", `#`+unix(day(3))+`|Alice|ALICE@example.com
+10	2	main.go
+#`+unix(day(2))+`|Bob|bob@example.com
+5	0	main.go
+#`+unix(day(1))+`|Al|al@old.example.com
+1	0	README.md
+`)
+	web := repoHistory(t, "web", `#`+unix(day(2))+`|Alice Smith|alice@example.com
+4	4	index.html
+`)
+	old := &RepoHistory{Path: "old", LastCommit: day(1).AddDate(-1, 0, 0)}
+	broken := &RepoHistory{Path: "broken", Err: errors.New("not a git repository")}
+
+	mailmap, err := ParseMailmap(strings.NewReader("Alice <alice@example.com> <al@old.example.com>\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := MergeWorkspace([]*RepoHistory{old, web, broken, api}, WorkspaceOptions{
+		Mailmap: mailmap,
+		Now:     day(10),
+	})
+
+	stats := report.Stats
+	if stats.TotalCommits != 4 || stats.UniqueAuthors != 2 || stats.TotalAdditions != 20 || stats.TotalDeletions != 6 || stats.TotalFiles != 3 {
+		t.Errorf("stats = %+v", stats)
+	}
+	if !stats.FirstCommit.Equal(day(1)) || !stats.LastCommit.Equal(day(3)) || stats.PeakCount != 2 || stats.PeakDay.Day() != 2 {
+		t.Errorf("stats dates = %+v", stats)
+	}
+	if report.Trends.Monthly["2023-11"] != 4 {
+		t.Errorf("monthly = %v", report.Trends.Monthly)
+	}
+
+	var order []string
+	for _, r := range report.Repositories {
+		order = append(order, r.Path)
+	}
+	if strings.Join(order, ",") != "api,web,old,broken" {
+		t.Errorf("ranking = %v", order)
+	}
+	if r := report.Repositories[0]; r.Rank != 1 || r.Commits != 3 || r.Authors != 2 || r.Dormant {
+		t.Errorf("api = %+v", r)
+	}
+	if r := report.Repositories[2]; !r.Dormant || r.Rank != 3 {
+		t.Errorf("old should be dormant: %+v", r)
+	}
+	if r := report.Repositories[3]; r.Rank != 0 || r.Error == "" || r.Dormant {
+		t.Errorf("broken = %+v", r)
+	}
+	if report.Dormant != 1 || report.Failed != 1 {
+		t.Errorf("dormant = %d, failed = %d", report.Dormant, report.Failed)
+	}
+
+	if len(report.Contributors) != 2 {
+		t.Fatalf("contributors = %+v", report.Contributors)
+	}
+	alice := report.Contributors[0]
+	if alice.Name != "Alice" || alice.TotalCommits != 3 || alice.ActiveDays != 3 || alice.FilesTouched != 3 ||
+		strings.Join(alice.Repositories, ",") != "api,web" || alice.Rank != 1 {
+		t.Errorf("alice = %+v", alice)
+	}
+}
+
+func TestMergeWorkspace_ContributorOptions(t *testing.T) {
+	h := repoHistory(t, "api", `#`+unix(day(3))+`|Bob|bob@example.com
+1	0	a.go
+#`+unix(day(2))+`|Alice|alice@example.com
+50	0	a.go
+#`+unix(day(1))+`|Bob|bob@example.com
+1	0	a.go
+`)
+	report := MergeWorkspace([]*RepoHistory{h}, WorkspaceOptions{SortBy: SortByLinesAdded, Top: 1, Now: day(4)})
+	if len(report.Contributors) != 1 || report.Contributors[0].Name != "Alice" {
+		t.Errorf("top by additions = %+v", report.Contributors)
+	}
+	report = MergeWorkspace([]*RepoHistory{h}, WorkspaceOptions{MinCommits: 2, Now: day(4)})
+	if len(report.Contributors) != 1 || report.Contributors[0].Name != "Bob" {
+		t.Errorf("min commits = %+v", report.Contributors)
+	}
+	if report := MergeWorkspace(nil, WorkspaceOptions{}); report.Stats != nil || report.Contributors == nil {
+		t.Errorf("empty workspace = %+v", report)
+	}
+}
+
+func unix(t time.Time) string {
+	return strconv.FormatInt(t.Unix(), 10)
+}
+
+func TestFormatter_Workspace(t *testing.T) {
+	h := repoHistory(t, "api", "#"+unix(day(2))+"|Alice|alice@example.com\n3\t0\ta.go\n")
+	report := MergeWorkspace([]*RepoHistory{h, {Path: "old"}}, WorkspaceOptions{Now: day(4)})
+
+	for _, format := range []OutputFormat{FormatTable, FormatJSON, FormatCSV, FormatMarkdown, FormatLLM} {
+		f := NewFormatter(format)
+		if out, err := f.FormatWorkspaceStats(report); err != nil || len(out) == 0 {
+			t.Errorf("%s stats: %v", format, err)
+		}
+		if out, err := f.FormatWorkspaceContributors(report.Contributors); err != nil || len(out) == 0 {
+			t.Errorf("%s contributors: %v", format, err)
+		}
+	}
+
+	out, _ := NewFormatter(FormatTable).FormatWorkspaceStats(report)
+	for _, want := range []string{"Repositories:     2 (1 dormant)", "Total Commits:    1", "2023-11", "dormant"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("stats table missing %q:\n%s", want, out)
+		}
+	}
+	out, _ = NewFormatter(FormatCSV).FormatWorkspaceContributors(report.Contributors)
+	if !strings.Contains(string(out), ",api\n") {
+		t.Errorf("contributors csv should list repositories:\n%s", out)
+	}
+	if _, err := NewFormatter(FormatTable).FormatWorkspaceStats(nil); err == nil {
+		t.Error("FormatWorkspaceStats(nil) should return error")
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package merge
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Resolution is how one conflict hunk, or one whole file, is resolved.
+type Resolution string
+
+// Resolution values. Ours and theirs are git's sides: during a merge "ours"
+// is the branch being merged into; during a rebase it is the upstream being
+// rebased onto and "theirs" is the commit being replayed.
+const (
+	Unresolved         Resolution = ""
+	ResolveOurs        Resolution = "ours"
+	ResolveTheirs      Resolution = "theirs"
+	ResolveBoth        Resolution = "both"     // ours, then theirs
+	ResolveEdited      Resolution = "edited"   // text supplied by the user
+	ResolveWorkingTree Resolution = "worktree" // keep the working-tree file as it is (whole files only)
+)
+
+// Conflict marker prefixes, as git writes them with the default
+// conflict-marker-size of 7.
+const (
+	markerOurs   = "<<<<<<<"
+	markerBase   = "|||||||"
+	markerSep    = "======="
+	markerTheirs = ">>>>>>>"
+)
+
+// Hunk is one conflicted region of a file: the lines each side has there,
+// and the merge base's lines when they are known.
+type Hunk struct {
+	Ours   []string `json:"ours"`
+	Base   []string `json:"base,omitempty"`
+	Theirs []string `json:"theirs"`
+
+	// Labels are the text after each marker ("HEAD", "feature", a commit
+	// subject during a rebase).
+	OursLabel   string `json:"ours_label,omitempty"`
+	BaseLabel   string `json:"base_label,omitempty"`
+	TheirsLabel string `json:"theirs_label,omitempty"`
+
+	// HasBase is set when Base is known: the file was written with diff3 or
+	// zdiff3 markers, or the base was recovered from the index stages.
+	HasBase bool `json:"has_base,omitempty"`
+
+	Resolution Resolution `json:"resolution,omitempty"`
+
+	// Edited holds the text for ResolveEdited.
+	Edited []string `json:"edited,omitempty"`
+}
+
+// Resolve sets how the hunk is resolved. Use Edit for ResolveEdited.
+func (h *Hunk) Resolve(res Resolution) {
+	h.Resolution = res
+}
+
+// Edit resolves the hunk with lines the user wrote.
+func (h *Hunk) Edit(lines []string) {
+	h.Resolution = ResolveEdited
+	h.Edited = lines
+}
+
+// Lines returns the hunk's resolved text, nil while it is unresolved.
+func (h *Hunk) Lines() []string {
+	switch h.Resolution {
+	case ResolveOurs:
+		return h.Ours
+	case ResolveTheirs:
+		return h.Theirs
+	case ResolveBoth:
+		return append(append([]string{}, h.Ours...), h.Theirs...)
+	case ResolveEdited:
+		return h.Edited
+	case Unresolved, ResolveWorkingTree:
+	}
+	return nil
+}
+
+// Markers renders the hunk back into conflict markers, as git wrote it.
+func (h *Hunk) Markers() []string {
+	lines := make([]string, 0, len(h.Ours)+len(h.Base)+len(h.Theirs)+4)
+	lines = append(lines, withLabel(markerOurs, h.OursLabel))
+	lines = append(lines, h.Ours...)
+	if h.HasBase {
+		lines = append(lines, withLabel(markerBase, h.BaseLabel))
+		lines = append(lines, h.Base...)
+	}
+	lines = append(lines, markerSep)
+	lines = append(lines, h.Theirs...)
+	return append(lines, withLabel(markerTheirs, h.TheirsLabel))
+}
+
+func withLabel(marker, label string) string {
+	if label == "" {
+		return marker
+	}
+	return marker + " " + label
+}
+
+// segment is a stretch of a conflicted file: plain lines, or one hunk.
+type segment struct {
+	lines []string
+	hunk  *Hunk
+}
+
+// ConflictFile is one unmerged path, loaded for resolution.
+type ConflictFile struct {
+	Path string
+
+	// Hunks are the conflicted regions, in file order. Empty for whole-file
+	// conflicts.
+	Hunks []*Hunk
+
+	// Whole is set when the conflict can only be resolved for the file as a
+	// whole: a binary file, a modify/delete conflict, or a file whose
+	// working-tree copy has no markers left because rerere or the user
+	// already resolved it. Reason says which.
+	Whole  bool
+	Reason string
+
+	// Choice is the resolution of a Whole file.
+	Choice Resolution
+
+	// HasOurs and HasTheirs report which sides have the path in the index;
+	// a side without it deleted the file.
+	HasOurs   bool
+	HasTheirs bool
+
+	// Rerere is set when rerere resolved the file from a recorded
+	// resolution: it is Whole, and ResolveWorkingTree keeps that result.
+	Rerere bool
+
+	segments        []segment
+	trailingNewline bool
+}
+
+// Remaining returns how many hunks (or, for a Whole file, whether the file)
+// still need a resolution.
+func (f *ConflictFile) Remaining() int {
+	if f.Whole {
+		if f.Choice == Unresolved {
+			return 1
+		}
+		return 0
+	}
+	n := 0
+	for _, h := range f.Hunks {
+		if h.Resolution == Unresolved {
+			n++
+		}
+	}
+	return n
+}
+
+// Resolved reports whether every hunk has a resolution.
+func (f *ConflictFile) Resolved() bool {
+	return f.Remaining() == 0
+}
+
+// TakeAll resolves every hunk, or the whole file, with res.
+func (f *ConflictFile) TakeAll(res Resolution) {
+	if f.Whole {
+		f.Choice = res
+		return
+	}
+	for _, h := range f.Hunks {
+		h.Resolve(res)
+	}
+}
+
+// Content renders the file with every resolved hunk replaced by its text.
+// Unresolved hunks keep their markers, so saving part-way through loses
+// nothing.
+func (f *ConflictFile) Content() []byte {
+	var lines []string
+	for _, seg := range f.segments {
+		switch {
+		case seg.hunk == nil:
+			lines = append(lines, seg.lines...)
+		case seg.hunk.Resolution == Unresolved:
+			lines = append(lines, seg.hunk.Markers()...)
+		default:
+			lines = append(lines, seg.hunk.Lines()...)
+		}
+	}
+	out := strings.Join(lines, "\n")
+	if f.trailingNewline && len(lines) > 0 {
+		out += "\n"
+	}
+	return []byte(out)
+}
+
+// parseConflictMarkers splits file content into plain segments and hunks.
+// A file without markers yields no hunks.
+func parseConflictMarkers(content string) (segments []segment, hunks []*Hunk, trailingNewline bool, err error) {
+	trailingNewline = strings.HasSuffix(content, "\n")
+	content = strings.TrimSuffix(content, "\n")
+	if content == "" {
+		return nil, nil, trailingNewline, nil
+	}
+
+	const (
+		inText = iota
+		inOurs
+		inBase
+		inTheirs
+	)
+	state := inText
+	var plain []string
+	var h *Hunk
+	start := 0
+
+	for i, line := range strings.Split(content, "\n") {
+		switch state {
+		case inText:
+			if label, ok := markerLabel(line, markerOurs); ok {
+				if len(plain) > 0 {
+					segments = append(segments, segment{lines: plain})
+					plain = nil
+				}
+				h = &Hunk{OursLabel: label}
+				state, start = inOurs, i+1
+				continue
+			}
+			plain = append(plain, line)
+
+		case inOurs, inBase:
+			if label, ok := markerLabel(line, markerBase); ok && state == inOurs {
+				h.HasBase, h.BaseLabel = true, label
+				state = inBase
+				continue
+			}
+			if isSeparator(line) {
+				state = inTheirs
+				continue
+			}
+			if state == inOurs {
+				h.Ours = append(h.Ours, line)
+			} else {
+				h.Base = append(h.Base, line)
+			}
+
+		case inTheirs:
+			if label, ok := markerLabel(line, markerTheirs); ok {
+				h.TheirsLabel = label
+				segments = append(segments, segment{hunk: h})
+				hunks = append(hunks, h)
+				h = nil
+				state = inText
+				continue
+			}
+			h.Theirs = append(h.Theirs, line)
+		}
+	}
+
+	if state != inText {
+		return nil, nil, false, fmt.Errorf("unterminated conflict starting at line %d", start)
+	}
+	if len(plain) > 0 {
+		segments = append(segments, segment{lines: plain})
+	}
+	return segments, hunks, trailingNewline, nil
+}
+
+// markerLabel reports whether line is the given marker, alone or followed
+// by a space and a label. A trailing CR (CRLF files) is ignored.
+func markerLabel(line, marker string) (string, bool) {
+	line = strings.TrimSuffix(line, "\r")
+	rest, ok := strings.CutPrefix(line, marker)
+	switch {
+	case !ok:
+		return "", false
+	case rest == "":
+		return "", true
+	case rest[0] == ' ':
+		return rest[1:], true
+	}
+	return "", false
+}
+
+func isSeparator(line string) bool {
+	return strings.TrimSuffix(line, "\r") == markerSep
+}
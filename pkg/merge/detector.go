@@ -38,7 +38,11 @@ func NewConflictDetector(executor GitExecutor) ConflictDetector {
 	return &conflictDetector{executor: executor}
 }
 
-// Detect analyzes potential conflicts between source and target.
+// Detect analyzes potential conflicts between source and target. It runs
+// the merge in memory with `git merge-tree --write-tree`, so the report
+// lists exactly the paths a real merge of source into target would leave
+// conflicted, with each text conflict's hunks. Neither the working tree nor
+// any ref is touched.
 func (d *conflictDetector) Detect(ctx context.Context, repo *repository.Repository, opts DetectOptions) (*ConflictReport, error) {
 	// Validate branches
 	if err := d.validateBranch(ctx, repo, opts.Source); err != nil {
@@ -49,25 +53,23 @@ func (d *conflictDetector) Detect(ctx context.Context, repo *repository.Reposito
 	}
 
 	// Find merge base
-	mergeBase, err := d.findMergeBase(ctx, repo, opts.Source, opts.Target)
-	if err != nil {
-		return nil, err
-	}
-
-	// Get changed files in both branches
-	sourceChanges, err := d.getChangedFiles(ctx, repo, mergeBase, opts.Source)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get source changes: %w", err)
+	mergeBase := opts.BaseCommit
+	if mergeBase != "" {
+		if err := d.validateBranch(ctx, repo, mergeBase); err != nil {
+			return nil, fmt.Errorf("invalid base commit: %w", err)
+		}
+	} else {
+		var err error
+		if mergeBase, err = d.findMergeBase(ctx, repo, opts.Source, opts.Target); err != nil {
+			return nil, err
+		}
 	}
 
-	targetChanges, err := d.getChangedFiles(ctx, repo, mergeBase, opts.Target)
+	conflicts, heuristic, err := d.mergeTreeConflicts(ctx, repo, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get target changes: %w", err)
+		return nil, err
 	}
 
-	// Detect conflicts
-	conflicts := d.detectConflicts(sourceChanges, targetChanges, opts.IncludeBinary)
-
 	// Calculate difficulty and auto-resolve count
 	canAutoResolve := 0
 	for _, c := range conflicts {
@@ -86,9 +88,101 @@ func (d *conflictDetector) Detect(ctx context.Context, repo *repository.Reposito
 		Conflicts:      conflicts,
 		CanAutoResolve: canAutoResolve,
 		Difficulty:     difficulty,
+		Heuristic:      heuristic,
 	}, nil
 }
 
+// mergeTreeConflicts merges source into target in memory and reports the
+// conflicts. On git older than 2.38, which has no `merge-tree --write-tree`,
+// it falls back to flagging the files both sides changed, and says so.
+func (d *conflictDetector) mergeTreeConflicts(ctx context.Context, repo *repository.Repository, opts DetectOptions) (conflicts []*Conflict, heuristic bool, err error) {
+	// diff3 markers put the merge base's lines in each hunk.
+	args := []string{"-c", "merge.conflictStyle=diff3", "merge-tree", "--write-tree", "-z"}
+	if opts.BaseCommit != "" {
+		args = append(args, "--merge-base="+opts.BaseCommit)
+	}
+	args = append(args, opts.Target, opts.Source)
+
+	result, err := d.executor.Run(ctx, repo.Path, args...)
+	if err != nil {
+		return nil, false, fmt.Errorf("merge-tree: %w", err)
+	}
+
+	switch result.ExitCode {
+	case 0, 1: // clean, conflicted
+	case 129: // usage: this git does not know --write-tree or --merge-base
+		if opts.BaseCommit != "" {
+			return nil, false, fmt.Errorf("a base commit needs git 2.40 or newer: %s", firstLine(result.Stderr))
+		}
+		conflicts, err := d.changedOnBothSides(ctx, repo, opts)
+		return conflicts, true, err
+	default:
+		return nil, false, fmt.Errorf("merge-tree failed: %s", firstLine(result.Stderr))
+	}
+
+	out, err := parseMergeTree(result.Stdout)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, c := range out.conflicts() {
+		if c.ConflictType == ConflictBinary && !opts.IncludeBinary {
+			continue
+		}
+		if c.ConflictType == ConflictContent || c.ConflictType == ConflictAddAdd {
+			c.Hunks = d.mergedHunks(ctx, repo, out.tree, c.FilePath)
+		}
+		conflicts = append(conflicts, c)
+	}
+	return conflicts, false, nil
+}
+
+// mergedHunks reads path from the merged tree and returns its conflict
+// hunks. It returns nil when the file cannot be read or parsed; the
+// conflict itself is still reported.
+func (d *conflictDetector) mergedHunks(ctx context.Context, repo *repository.Repository, tree, path string) []*Hunk {
+	result, err := d.executor.Run(ctx, repo.Path, "cat-file", "blob", tree+":"+path)
+	if err != nil || result.ExitCode != 0 {
+		return nil
+	}
+	_, hunks, _, err := parseConflictMarkers(result.Stdout)
+	if err != nil {
+		return nil
+	}
+	return hunks
+}
+
+// changedOnBothSides is the pre-merge-tree estimate: every file changed on
+// both sides since the merge base is a potential conflict.
+func (d *conflictDetector) changedOnBothSides(ctx context.Context, repo *repository.Repository, opts DetectOptions) ([]*Conflict, error) {
+	mergeBase, err := d.findMergeBase(ctx, repo, opts.Source, opts.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get changed files in both branches
+	sourceChanges, err := d.getChangedFiles(ctx, repo, mergeBase, opts.Source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source changes: %w", err)
+	}
+
+	targetChanges, err := d.getChangedFiles(ctx, repo, mergeBase, opts.Target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target changes: %w", err)
+	}
+
+	return d.detectConflicts(sourceChanges, targetChanges, opts.IncludeBinary), nil
+}
+
+// firstLine returns the first non-empty line of git's output.
+func firstLine(s string) string {
+	for line := range strings.SplitSeq(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return "no output"
+}
+
 // Preview shows what will happen during merge.
 func (d *conflictDetector) Preview(ctx context.Context, repo *repository.Repository, source, target string) (*MergePreview, error) {
 	// Check if fast-forward is possible
@@ -3,6 +3,7 @@ package merge
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
 	"testing"
 
@@ -21,6 +22,8 @@ func (m *mockExecutor) Run(ctx context.Context, repoPath string, args ...string)
 	return &gitcmd.Result{Stdout: "", Stderr: "", ExitCode: 0}, nil
 }
 
+// TestConflictDetector_Detect covers the fallback for git before 2.38, which
+// has no `merge-tree --write-tree`: files changed on both sides are flagged.
 func TestConflictDetector_Detect(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -110,6 +113,11 @@ func TestConflictDetector_Detect(t *testing.T) {
 						return &gitcmd.Result{Stdout: tt.mergeBase + "\n", Stderr: "", ExitCode: 0}, nil
 					}
 
+					// An old git rejects --write-tree with a usage error.
+					if slices.Contains(args, "merge-tree") {
+						return &gitcmd.Result{Stderr: "usage: git merge-tree", ExitCode: 129}, nil
+					}
+
 					// Handle diff --name-status
 					if len(args) > 0 && args[0] == "diff" {
 						diffRange := args[2]
@@ -150,6 +158,10 @@ func TestConflictDetector_Detect(t *testing.T) {
 			if report.MergeBase != tt.mergeBase {
 				t.Errorf("MergeBase = %s, want %s", report.MergeBase, tt.mergeBase)
 			}
+
+			if !report.Heuristic {
+				t.Error("Heuristic should be set when merge-tree is unavailable")
+			}
 		})
 	}
 }
@@ -218,6 +230,11 @@ func TestConflictDetector_Preview(t *testing.T) {
 						return &gitcmd.Result{Stdout: "abc123\n", Stderr: "", ExitCode: 0}, nil
 					}
 
+					// A clean in-memory merge prints only the tree.
+					if slices.Contains(args, "merge-tree") {
+						return &gitcmd.Result{Stdout: "4b825dc642cb6eb9a060e54bf8d69288fbee4904\x00", ExitCode: 0}, nil
+					}
+
 					// Handle diff
 					if len(args) > 0 && args[0] == "diff" {
 						return &gitcmd.Result{Stdout: tt.changes, Stderr: "", ExitCode: 0}, nil
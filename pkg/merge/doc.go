@@ -3,17 +3,23 @@
 
 // Package merge provides merge conflict detection and analysis.
 //
-// This package detects potential merge conflicts between branches
-// before attempting the actual merge operation. Merge/rebase execution
-// is intentionally out of scope (use plain git); gz-git's value is
-// bulk-first diagnostics via ConflictDetector.
+// This package detects merge conflicts between branches before attempting
+// the actual merge operation, by merging in memory with
+// `git merge-tree --write-tree`, and helps resolve the
+// conflicts a merge or rebase actually stopped on. Starting a merge or
+// rebase is intentionally out of scope (use plain git or bulk update);
+// gz-git's value is bulk-first diagnostics via ConflictDetector and
+// finishing what stopped via Resolver.
 //
 // # Features
 //
-//   - Conflict detection between branches
+//   - Conflict detection between branches, with rename/delete details and
+//     the hunks of each text conflict
 //   - Conflict file listing
 //   - Merge base calculation
 //   - Fast-forward checks and merge previews
+//   - Per-hunk resolution (ours, theirs, both, edited) of a stopped merge,
+//     rebase, cherry-pick or revert, with continue and abort
 //
 // # Usage
 //
@@ -28,4 +34,15 @@
 //	if report.TotalConflicts > 0 {
 //	    // inspect report.Conflicts
 //	}
+//
+// Resolving a stopped rebase:
+//
+//	resolver := merge.NewResolver(gitcmd.NewExecutor(), repository.NewClient())
+//	state, _ := resolver.Inspect(ctx, repoPath)
+//	for _, path := range state.Unmerged {
+//	    file, _ := resolver.Load(ctx, repoPath, path)
+//	    file.TakeAll(merge.ResolveTheirs)
+//	    _, _ = resolver.Save(ctx, repoPath, file)
+//	}
+//	err := resolver.Continue(ctx, repoPath)
 package merge
@@ -0,0 +1,217 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package merge
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// mergeTreeOutput is what `git merge-tree --write-tree -z` reports: the
+// merged tree (with conflict markers in conflicted text files), the index
+// stages each conflicted path would have, and git's conflict messages.
+type mergeTreeOutput struct {
+	tree     string
+	stages   map[string]map[int]bool
+	messages []mergeTreeMessage
+}
+
+// mergeTreeMessage is one informational message. Kind is git's stable
+// machine-readable type ("CONFLICT (contents)", "Auto-merging", ...); Text
+// is the human-readable line.
+type mergeTreeMessage struct {
+	paths []string
+	kind  string
+	text  string
+}
+
+// parseMergeTree parses `git merge-tree --write-tree -z` output. The
+// sections are NUL-separated: the tree OID, "<mode> <oid> <stage>\t<path>"
+// for each conflicted entry, an empty field, then the messages as
+// "<count>, <path>..., <kind>, <text>".
+func parseMergeTree(out string) (*mergeTreeOutput, error) {
+	fields := strings.Split(out, "\x00")
+	if len(fields) == 0 || strings.TrimSpace(fields[0]) == "" {
+		return nil, fmt.Errorf("merge-tree printed no tree")
+	}
+	result := &mergeTreeOutput{tree: strings.TrimSpace(fields[0]), stages: make(map[string]map[int]bool)}
+
+	i := 1
+	for ; i < len(fields) && fields[i] != ""; i++ {
+		info, path, ok := strings.Cut(fields[i], "\t")
+		parts := strings.Fields(info)
+		if !ok || len(parts) != 3 {
+			return nil, fmt.Errorf("unexpected merge-tree entry %q", fields[i])
+		}
+		stage, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("unexpected merge-tree stage %q", parts[2])
+		}
+		if result.stages[path] == nil {
+			result.stages[path] = make(map[int]bool)
+		}
+		result.stages[path][stage] = true
+	}
+
+	// Skip the empty field closing the entries; a clean merge ends here.
+	for i++; i < len(fields) && fields[i] != ""; {
+		n, err := strconv.Atoi(fields[i])
+		if err != nil || n < 0 || i+n+2 >= len(fields) {
+			return nil, fmt.Errorf("unexpected merge-tree message at %q", fields[i])
+		}
+		result.messages = append(result.messages, mergeTreeMessage{
+			paths: fields[i+1 : i+1+n],
+			kind:  fields[i+1+n],
+			text:  strings.TrimSpace(fields[i+2+n]),
+		})
+		i += n + 3
+	}
+	return result, nil
+}
+
+// conflictKind maps git's conflict type to a ConflictType. ok is false for
+// messages that are not conflicts ("Auto-merging").
+func conflictKind(msg mergeTreeMessage) (kind ConflictType, ok bool) {
+	name, found := strings.CutPrefix(msg.kind, "CONFLICT (")
+	if !found {
+		return "", false
+	}
+	name = strings.TrimSuffix(name, ")")
+	switch {
+	case name == "contents" && strings.Contains(msg.text, "(add/add)"):
+		return ConflictAddAdd, true
+	case name == "contents":
+		return ConflictContent, true
+	case name == "binary":
+		return ConflictBinary, true
+	case strings.Contains(name, "rename"):
+		return ConflictRename, true
+	case strings.Contains(name, "delete"):
+		return ConflictDelete, true
+	case name == "file/directory" || name == "distinct types":
+		return ConflictFileDirectory, true
+	}
+	return ConflictOther, true
+}
+
+// conflictRank orders the types reported for one path: git can say a file
+// is both a binary and a content conflict, or both renamed and deleted, and
+// the report keeps the more specific one.
+var conflictRank = map[ConflictType]int{
+	ConflictOther:         0,
+	ConflictContent:       1,
+	ConflictAddAdd:        2,
+	ConflictDelete:        3,
+	ConflictFileDirectory: 4,
+	ConflictRename:        5,
+	ConflictBinary:        6,
+}
+
+// conflictSeverity is how hard each type is to resolve by hand.
+func conflictSeverity(kind ConflictType) ConflictSeverity {
+	switch kind {
+	case ConflictBinary, ConflictDelete, ConflictFileDirectory:
+		return SeverityHigh
+	case ConflictContent, ConflictAddAdd, ConflictRename, ConflictOther:
+	}
+	return SeverityMedium
+}
+
+// conflicts turns the messages into one Conflict per path. merge-tree runs
+// target first, so stage 2 is the target's side and stage 3 the source's.
+func (o *mergeTreeOutput) conflicts() []*Conflict {
+	var order []string
+	byPath := make(map[string]*Conflict)
+	involved := make(map[string][]string) // every path git named, per conflict
+
+	for _, msg := range o.messages {
+		kind, ok := conflictKind(msg)
+		if !ok || len(msg.paths) == 0 {
+			continue
+		}
+		path, oldPath := o.conflictPaths(msg.paths)
+
+		if prev, seen := byPath[path]; seen {
+			if conflictRank[kind] > conflictRank[prev.ConflictType] {
+				prev.ConflictType, prev.Severity, prev.Description = kind, conflictSeverity(kind), msg.text
+				prev.OldPath = oldPath
+				involved[path] = msg.paths
+			}
+			continue
+		}
+		byPath[path] = &Conflict{
+			FilePath:     path,
+			OldPath:      oldPath,
+			ConflictType: kind,
+			Severity:     conflictSeverity(kind),
+			Description:  msg.text,
+		}
+		involved[path] = msg.paths
+		order = append(order, path)
+	}
+
+	conflicts := make([]*Conflict, 0, len(order))
+	for _, path := range order {
+		c := byPath[path]
+		// A rename moves the path, so each side is looked up across every
+		// path the conflict names, not just the one it is reported under.
+		paths := []string{path}
+		if c.ConflictType == ConflictRename {
+			paths = involved[path]
+		}
+		inBase := o.stages[path][1] || c.OldPath != ""
+		renamed := c.ConflictType == ConflictRename
+		c.TargetChange = sideChange(inBase, o.hasStage(paths, 2), renamed)
+		c.SourceChange = sideChange(inBase, o.hasStage(paths, 3), renamed)
+		conflicts = append(conflicts, c)
+	}
+	return conflicts
+}
+
+// hasStage reports whether any of paths has the stage.
+func (o *mergeTreeOutput) hasStage(paths []string, stage int) bool {
+	for _, p := range paths {
+		if o.stages[p][stage] {
+			return true
+		}
+	}
+	return false
+}
+
+// conflictPaths picks the path a conflict is reported under and, for
+// renames, the original path. The reported path is the first one still
+// present on a side; the original is one only the merge base has.
+func (o *mergeTreeOutput) conflictPaths(paths []string) (path, oldPath string) {
+	path = paths[0]
+	for _, p := range paths {
+		if s := o.stages[p]; s[2] || s[3] {
+			path = p
+			break
+		}
+	}
+	if len(paths) > 1 {
+		for _, p := range paths {
+			if s := o.stages[p]; p != path && !s[2] && !s[3] {
+				oldPath = p
+				break
+			}
+		}
+	}
+	return path, oldPath
+}
+
+// sideChange is what one side did to a conflicted path, from whether the
+// base and that side have it.
+func sideChange(inBase, onSide, renamed bool) ChangeType {
+	switch {
+	case !onSide:
+		return ChangeDeleted
+	case renamed:
+		return ChangeRenamed
+	case !inBase:
+		return ChangeAdded
+	}
+	return ChangeModified
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package merge
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+// mergeTreeFixture is `git merge-tree --write-tree -z main feature` output,
+// captured from git 2.39, with NULs written as "|".
+const mergeTreeFixture = "b099ae33016627c061f4cc893b5989fc9b165a07|" +
+	"100644 4cb29ea38f70d7c61b2a3a25b02e3bdf44905402 1\ta.txt|" +
+	"100644 484a63bcfc8a053ae262fab7b170e90d5baae69a 2\ta.txt|" +
+	"100644 b16314c7a0510ae07d5a4eb0c341614a5e5288d8 3\ta.txt|" +
+	"100644 e45c9c2666d44e0327c1f9c239a74c508336053e 2\tadded.txt|" +
+	"100644 3e757656cf36eca53338e520d134963a44f793f8 3\tadded.txt|" +
+	"100644 e8996621d8f762f3d6df65c95f7b7dc049436695 1\tb.bin|" +
+	"100644 826ff77118dee303a0e1a1bf5b471ba123e05f83 2\tb.bin|" +
+	"100644 09b1b278a449708af88aee2d097eb75a2d4bf8af 3\tb.bin|" +
+	"100644 2fa992c0b8b5c6acd2bdd4fa31de29d29799bdd5 1\tdel.txt|" +
+	"100644 fe5841d90e218ad805244a4c5770601ae956987f 2\tdel.txt|" +
+	"100644 c96fe16b4f347677b80c73329cfc6aac737352cd 3\tren-feature.txt|" +
+	"100644 c96fe16b4f347677b80c73329cfc6aac737352cd 2\tren-main.txt|" +
+	"100644 c96fe16b4f347677b80c73329cfc6aac737352cd 1\tren.txt||" +
+	"1|a.txt|Auto-merging|Auto-merging a.txt\n|" +
+	"1|a.txt|CONFLICT (contents)|CONFLICT (content): Merge conflict in a.txt\n|" +
+	"1|added.txt|Auto-merging|Auto-merging added.txt\n|" +
+	"1|added.txt|CONFLICT (contents)|CONFLICT (add/add): Merge conflict in added.txt\n|" +
+	"1|b.bin|CONFLICT (binary)|warning: Cannot merge binary files: b.bin (main vs. feature)\n|" +
+	"1|b.bin|Auto-merging|Auto-merging b.bin\n|" +
+	"1|b.bin|CONFLICT (contents)|CONFLICT (content): Merge conflict in b.bin\n|" +
+	"1|del.txt|CONFLICT (modify/delete)|CONFLICT (modify/delete): del.txt deleted in feature and modified in main.  Version main of del.txt left in tree.\n|" +
+	"3|ren.txt|ren-main.txt|ren-feature.txt|CONFLICT (rename/rename)|CONFLICT (rename/rename): ren.txt renamed to ren-main.txt in main and to ren-feature.txt in feature.\n|"
+
+func TestParseMergeTree(t *testing.T) {
+	out, err := parseMergeTree(strings.ReplaceAll(mergeTreeFixture, "|", "\x00"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.tree != "b099ae33016627c061f4cc893b5989fc9b165a07" || len(out.messages) != 9 {
+		t.Fatalf("tree %s, %d messages", out.tree, len(out.messages))
+	}
+
+	got := make(map[string]*Conflict)
+	for _, c := range out.conflicts() {
+		got[c.FilePath] = c
+	}
+	want := []struct {
+		path           string
+		kind           ConflictType
+		source, target ChangeType
+		oldPath        string
+	}{
+		{"a.txt", ConflictContent, ChangeModified, ChangeModified, ""},
+		{"added.txt", ConflictAddAdd, ChangeAdded, ChangeAdded, ""},
+		{"b.bin", ConflictBinary, ChangeModified, ChangeModified, ""},
+		{"del.txt", ConflictDelete, ChangeDeleted, ChangeModified, ""},
+		{"ren-main.txt", ConflictRename, ChangeRenamed, ChangeRenamed, "ren.txt"},
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %d conflicts, want %d", len(got), len(want))
+	}
+	for _, w := range want {
+		c := got[w.path]
+		if c == nil {
+			t.Errorf("%s: not reported", w.path)
+			continue
+		}
+		if c.ConflictType != w.kind || c.SourceChange != w.source || c.TargetChange != w.target || c.OldPath != w.oldPath {
+			t.Errorf("%s = %+v", w.path, c)
+		}
+	}
+	if !strings.Contains(got["del.txt"].Description, "deleted in feature") {
+		t.Errorf("description should be git's message: %q", got["del.txt"].Description)
+	}
+}
+
+func TestParseMergeTreeClean(t *testing.T) {
+	out, err := parseMergeTree("4b825dc642cb6eb9a060e54bf8d69288fbee4904\x00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out.conflicts()) != 0 {
+		t.Error("a clean merge has no conflicts")
+	}
+	if _, err := parseMergeTree(""); err == nil {
+		t.Error("empty output should be an error")
+	}
+}
+
+func TestConflictDetectorMergeTree(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	dir := conflictRepo(t)
+
+	// Changed on both sides but in different places: merges cleanly, and
+	// the old changed-on-both-sides check reported it anyway.
+	git(t, dir, "checkout", "-q", "main")
+	write(t, dir, "b.txt", "1\n2\n3\n4\n5\n6\n7\n8\n")
+	write(t, dir, "moved.txt", "m1\nm2\nm3\n")
+	git(t, dir, "add", ".")
+	git(t, dir, "commit", "-q", "-m", "b and moved")
+	git(t, dir, "checkout", "-q", "-b", "topic")
+	write(t, dir, "b.txt", "1-topic\n2\n3\n4\n5\n6\n7\n8\n")
+	git(t, dir, "mv", "moved.txt", "moved-topic.txt")
+	git(t, dir, "commit", "-q", "-am", "topic")
+	git(t, dir, "checkout", "-q", "main")
+	write(t, dir, "b.txt", "1\n2\n3\n4\n5\n6\n7\n8-main\n")
+	git(t, dir, "rm", "-q", "moved.txt")
+	git(t, dir, "commit", "-q", "-am", "main edits")
+
+	detector := NewConflictDetector(gitcmd.NewExecutor())
+	repo := &repository.Repository{Path: dir}
+
+	report, err := detector.Detect(context.Background(), repo, DetectOptions{Source: "topic", Target: "main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Heuristic {
+		t.Skip("git is too old for merge-tree --write-tree")
+	}
+	if report.TotalConflicts != 1 {
+		t.Fatalf("conflicts = %+v", report.Conflicts)
+	}
+	c := report.Conflicts[0]
+	if c.ConflictType != ConflictRename || c.FilePath != "moved-topic.txt" || c.OldPath != "moved.txt" {
+		t.Errorf("rename/delete = %+v", c)
+	}
+	if c.SourceChange != ChangeRenamed || c.TargetChange != ChangeDeleted {
+		t.Errorf("sides = %s / %s", c.SourceChange, c.TargetChange)
+	}
+
+	// The content conflict between main and feature, with its two hunks and
+	// their base lines.
+	report, err = detector.Detect(context.Background(), repo, DetectOptions{Source: "feature", Target: "main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.TotalConflicts != 1 || report.Conflicts[0].FilePath != "a.txt" {
+		t.Fatalf("conflicts = %+v", report.Conflicts)
+	}
+	hunks := report.Conflicts[0].Hunks
+	if len(hunks) != 2 {
+		t.Fatalf("hunks = %d", len(hunks))
+	}
+	if h := hunks[0]; h.Ours[0] != "TWO-main" || h.Theirs[0] != "TWO-feature" || !h.HasBase || h.Base[0] != "two" {
+		t.Errorf("first hunk = %+v", h)
+	}
+	if status := git(t, dir, "status", "--porcelain"); status != "" {
+		t.Errorf("detect must not touch the working tree:\n%s", status)
+	}
+}
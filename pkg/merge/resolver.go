@@ -0,0 +1,430 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package merge
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli-gitforge/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+// Operation is the git command a repository is stopped in the middle of.
+type Operation string
+
+// Operation values, in the order Inspect checks for them.
+const (
+	OperationNone       Operation = ""
+	OperationRebase     Operation = "rebase"
+	OperationMerge      Operation = "merge"
+	OperationCherryPick Operation = "cherry-pick"
+	OperationRevert     Operation = "revert"
+)
+
+// ErrStoppedAgain means Continue got further but the operation stopped on
+// new conflicts, as a rebase does on the next commit that conflicts. Inspect
+// the repository again for the new unmerged paths.
+var ErrStoppedAgain = errors.New("stopped on new conflicts")
+
+// RepoConflicts describes a repository stopped by conflicts.
+type RepoConflicts struct {
+	Path string
+
+	// Operation is what stopped, OperationNone when only unmerged paths are
+	// left (a `git stash pop` or `git checkout -m` conflict).
+	Operation Operation
+
+	// Unmerged are the paths still unmerged in the index.
+	Unmerged []string
+
+	// Rerere is set when git's rerere is on for the repository: recorded
+	// resolutions are replayed into the working tree, and the resolutions
+	// saved here are recorded for next time.
+	Rerere bool
+}
+
+// Stuck reports whether there is anything to resolve, continue or abort.
+func (r *RepoConflicts) Stuck() bool {
+	return r.Operation != OperationNone || len(r.Unmerged) > 0
+}
+
+// ResolveExecutor runs git, with extra environment when needed:
+// continuing a merge or rebase must not open an editor.
+type ResolveExecutor interface {
+	GitExecutor
+	RunWithEnv(ctx context.Context, repoPath string, env []string, args ...string) (*gitcmd.Result, error)
+}
+
+// Resolver resolves the conflicts a merge, rebase, cherry-pick or revert
+// stopped on, and continues or aborts it. Unlike ConflictDetector it works
+// on conflicts git has already written to the index and working tree.
+type Resolver interface {
+	// Inspect reports the operation in progress and the unmerged paths.
+	Inspect(ctx context.Context, repoPath string) (*RepoConflicts, error)
+
+	// Load reads one unmerged path for resolution.
+	Load(ctx context.Context, repoPath, path string) (*ConflictFile, error)
+
+	// Save writes file's resolutions to the working tree. Once every hunk is
+	// resolved the path is staged, and recorded for rerere when it is on;
+	// staged reports whether that happened.
+	Save(ctx context.Context, repoPath string, file *ConflictFile) (staged bool, err error)
+
+	// Continue continues the operation once no path is unmerged.
+	Continue(ctx context.Context, repoPath string) error
+
+	// Abort abandons the operation and restores the state before it.
+	Abort(ctx context.Context, repoPath string) error
+}
+
+type resolver struct {
+	executor ResolveExecutor
+	client   repository.Client
+}
+
+// NewResolver creates a resolver. client supplies the unmerged path list, so
+// it matches what status and commit report.
+func NewResolver(executor ResolveExecutor, client repository.Client) Resolver {
+	return &resolver{executor: executor, client: client}
+}
+
+// Inspect reports the operation in progress and the unmerged paths.
+func (r *resolver) Inspect(ctx context.Context, repoPath string) (*RepoConflicts, error) {
+	op, err := r.operation(ctx, repoPath)
+	if err != nil {
+		return nil, err
+	}
+	return &RepoConflicts{
+		Path:      repoPath,
+		Operation: op,
+		Unmerged:  r.client.ConflictedPaths(ctx, repoPath),
+		Rerere:    r.rerereEnabled(ctx, repoPath),
+	}, nil
+}
+
+// operationMarkers maps the files git leaves in the git directory while an
+// operation is stopped. rebase-merge and rebase-apply are the two rebase
+// backends.
+var operationMarkers = []struct {
+	path string
+	op   Operation
+}{
+	{"rebase-merge", OperationRebase},
+	{"rebase-apply", OperationRebase},
+	{"MERGE_HEAD", OperationMerge},
+	{"CHERRY_PICK_HEAD", OperationCherryPick},
+	{"REVERT_HEAD", OperationRevert},
+}
+
+// operation finds the stopped operation. --git-path is used instead of
+// joining ".git" so linked worktrees and separate git dirs work.
+func (r *resolver) operation(ctx context.Context, repoPath string) (Operation, error) {
+	args := []string{"rev-parse"}
+	for _, m := range operationMarkers {
+		args = append(args, "--git-path", m.path)
+	}
+	result, err := r.executor.Run(ctx, repoPath, args...)
+	if err != nil {
+		return OperationNone, fmt.Errorf("failed to locate git directory: %w", err)
+	}
+	if result.ExitCode != 0 {
+		return OperationNone, fmt.Errorf("not a git repository: %s", strings.TrimSpace(result.Stderr))
+	}
+
+	paths := strings.Split(strings.TrimSpace(result.Stdout), "\n")
+	for i, m := range operationMarkers {
+		if i >= len(paths) {
+			break
+		}
+		p := paths[i]
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(repoPath, p)
+		}
+		if _, err := os.Stat(p); err == nil {
+			return m.op, nil
+		}
+	}
+	return OperationNone, nil
+}
+
+// rerereEnabled follows git's rule: rerere.enabled when set, otherwise on
+// exactly when an rr-cache directory exists.
+func (r *resolver) rerereEnabled(ctx context.Context, repoPath string) bool {
+	result, err := r.executor.Run(ctx, repoPath, "config", "--bool", "rerere.enabled")
+	if err == nil && result.ExitCode == 0 {
+		return strings.TrimSpace(result.Stdout) == "true"
+	}
+	result, err = r.executor.Run(ctx, repoPath, "rev-parse", "--git-path", "rr-cache")
+	if err != nil || result.ExitCode != 0 {
+		return false
+	}
+	p := strings.TrimSpace(result.Stdout)
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(repoPath, p)
+	}
+	info, err := os.Stat(p)
+	return err == nil && info.IsDir()
+}
+
+// Load reads one unmerged path for resolution.
+func (r *resolver) Load(ctx context.Context, repoPath, path string) (*ConflictFile, error) {
+	stages, err := r.stages(ctx, repoPath, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(stages) == 0 {
+		return nil, fmt.Errorf("%s is not unmerged", path)
+	}
+	file := &ConflictFile{Path: path, HasOurs: stages[2], HasTheirs: stages[3]}
+
+	switch {
+	case !file.HasOurs:
+		file.Whole, file.Reason = true, "deleted by us"
+		return file, nil
+	case !file.HasTheirs:
+		file.Whole, file.Reason = true, "deleted by them"
+		return file, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(repoPath, path))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		file.Whole, file.Reason = true, "missing from the working tree"
+		return file, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	case bytes.IndexByte(data, 0) >= 0:
+		// git leaves our version of a binary file in place, without markers.
+		file.Whole, file.Reason = true, "binary"
+		return file, nil
+	}
+
+	segments, hunks, trailing, err := parseConflictMarkers(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(hunks) == 0 {
+		file.Whole, file.Reason = true, "no conflict markers left"
+		if r.rerereEnabled(ctx, repoPath) && !r.rerereRemaining(ctx, repoPath, path) {
+			file.Rerere, file.Reason = true, "resolved by rerere"
+		}
+		return file, nil
+	}
+	file.Hunks, file.segments, file.trailingNewline = hunks, segments, trailing
+
+	if stages[1] && !hunks[0].HasBase {
+		r.recoverBase(ctx, repoPath, path, hunks)
+	}
+	return file, nil
+}
+
+// stages returns which index stages (1 base, 2 ours, 3 theirs) hold path.
+func (r *resolver) stages(ctx context.Context, repoPath, path string) (map[int]bool, error) {
+	result, err := r.executor.Run(ctx, repoPath, "ls-files", "--unmerged", "-z", "--", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index stages: %w", err)
+	}
+	if result.ExitCode != 0 {
+		return nil, fmt.Errorf("failed to read index stages: %s", strings.TrimSpace(result.Stderr))
+	}
+
+	stages := make(map[int]bool, 3)
+	for record := range strings.SplitSeq(result.Stdout, "\x00") {
+		// <mode> <object> <stage>\t<path>
+		meta, _, ok := strings.Cut(record, "\t")
+		if !ok {
+			continue
+		}
+		if fields := strings.Fields(meta); len(fields) == 3 && len(fields[2]) == 1 {
+			stages[int(fields[2][0]-'0')] = true
+		}
+	}
+	return stages, nil
+}
+
+// rerereRemaining reports whether rerere lists path as one it could not
+// resolve.
+func (r *resolver) rerereRemaining(ctx context.Context, repoPath, path string) bool {
+	result, err := r.executor.Run(ctx, repoPath, "rerere", "remaining")
+	if err != nil || result.ExitCode != 0 {
+		return true
+	}
+	return slices.Contains(strings.Split(strings.TrimSpace(result.Stdout), "\n"), path)
+}
+
+// recoverBase fills in each hunk's base when the file was written with
+// plain merge markers. It re-merges the three index stages with diff3
+// markers, in memory, and copies the base over only when the re-merge
+// produced the same hunks; if the user already edited the file, the hunks
+// will not line up and the base is left unknown rather than guessed.
+func (r *resolver) recoverBase(ctx context.Context, repoPath, path string, hunks []*Hunk) {
+	dir, err := os.MkdirTemp("", "gz-git-resolve-")
+	if err != nil {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	files := make([]string, 3)
+	for i, stage := range []string{"2", "1", "3"} {
+		result, err := r.executor.Run(ctx, repoPath, "cat-file", "blob", ":"+stage+":"+path)
+		if err != nil || result.ExitCode != 0 {
+			return
+		}
+		files[i] = filepath.Join(dir, stage)
+		if err := os.WriteFile(files[i], []byte(result.Stdout), 0o600); err != nil {
+			return
+		}
+	}
+
+	// merge-file exits with the number of conflicts, so only a negative
+	// exit code is a failure.
+	result, err := r.executor.Run(ctx, repoPath, "merge-file", "-p", "--diff3", files[0], files[1], files[2])
+	if err != nil || result.ExitCode < 0 {
+		return
+	}
+	_, remerged, _, err := parseConflictMarkers(result.Stdout)
+	if err != nil || len(remerged) != len(hunks) {
+		return
+	}
+	for i, h := range hunks {
+		if !slices.Equal(h.Ours, remerged[i].Ours) || !slices.Equal(h.Theirs, remerged[i].Theirs) {
+			return
+		}
+	}
+	for i, h := range hunks {
+		h.Base, h.HasBase, h.BaseLabel = remerged[i].Base, true, "base"
+	}
+}
+
+// Save writes file's resolutions and stages the file once it is resolved.
+func (r *resolver) Save(ctx context.Context, repoPath string, file *ConflictFile) (bool, error) {
+	if file.Whole {
+		if file.Choice == Unresolved {
+			return false, nil
+		}
+		if err := r.saveWhole(ctx, repoPath, file); err != nil {
+			return false, err
+		}
+	} else {
+		full := filepath.Join(repoPath, file.Path)
+		mode := os.FileMode(0o644)
+		if info, err := os.Stat(full); err == nil {
+			mode = info.Mode().Perm()
+		}
+		if err := os.WriteFile(full, file.Content(), mode); err != nil {
+			return false, fmt.Errorf("failed to write %s: %w", file.Path, err)
+		}
+		if !file.Resolved() {
+			return false, nil
+		}
+		if err := r.git(ctx, repoPath, "add", "--", file.Path); err != nil {
+			return false, err
+		}
+	}
+
+	if r.rerereEnabled(ctx, repoPath) {
+		// Record the postimage now rather than at commit time, so the
+		// resolution is kept even if the operation is aborted later.
+		_ = r.git(ctx, repoPath, "rerere")
+	}
+	return true, nil
+}
+
+// saveWhole resolves a whole-file conflict: check out the chosen side, or
+// remove the file when that side deleted it.
+func (r *resolver) saveWhole(ctx context.Context, repoPath string, file *ConflictFile) error {
+	switch file.Choice {
+	case ResolveOurs, ResolveTheirs:
+		present := file.HasOurs
+		if file.Choice == ResolveTheirs {
+			present = file.HasTheirs
+		}
+		if !present {
+			return r.git(ctx, repoPath, "rm", "--quiet", "--", file.Path)
+		}
+		if err := r.git(ctx, repoPath, "checkout", "--"+string(file.Choice), "--", file.Path); err != nil {
+			return err
+		}
+		return r.git(ctx, repoPath, "add", "--", file.Path)
+
+	case ResolveWorkingTree:
+		if _, err := os.Lstat(filepath.Join(repoPath, file.Path)); errors.Is(err, os.ErrNotExist) {
+			return r.git(ctx, repoPath, "rm", "--quiet", "--", file.Path)
+		}
+		return r.git(ctx, repoPath, "add", "--", file.Path)
+
+	case ResolveBoth, ResolveEdited, Unresolved:
+	}
+	return fmt.Errorf("%s (%s) can only take ours, theirs or the working tree", file.Path, file.Reason)
+}
+
+// Continue continues the operation once no path is unmerged.
+func (r *resolver) Continue(ctx context.Context, repoPath string) error {
+	state, err := r.Inspect(ctx, repoPath)
+	if err != nil {
+		return err
+	}
+	if n := len(state.Unmerged); n > 0 {
+		return fmt.Errorf("%d path(s) still unmerged", n)
+	}
+	if state.Operation == OperationNone {
+		return fmt.Errorf("no merge, rebase, cherry-pick or revert in progress")
+	}
+
+	// GIT_EDITOR=true accepts the prepared message. The user chose their
+	// resolutions here; an editor popping up mid-way through a batch of
+	// repositories would stall it.
+	result, err := r.executor.RunWithEnv(ctx, repoPath, []string{"GIT_EDITOR=true"}, string(state.Operation), "--continue")
+	if err != nil {
+		return fmt.Errorf("%s --continue: %w", state.Operation, err)
+	}
+	if result.ExitCode == 0 {
+		return nil
+	}
+	if after, err := r.Inspect(ctx, repoPath); err == nil && after.Operation != OperationNone && len(after.Unmerged) > 0 {
+		return ErrStoppedAgain
+	}
+	return fmt.Errorf("%s --continue failed: %s", state.Operation, gitMessage(result))
+}
+
+// Abort abandons the operation and restores the state before it.
+func (r *resolver) Abort(ctx context.Context, repoPath string) error {
+	op, err := r.operation(ctx, repoPath)
+	if err != nil {
+		return err
+	}
+	if op == OperationNone {
+		return fmt.Errorf("no merge, rebase, cherry-pick or revert in progress")
+	}
+	return r.git(ctx, repoPath, string(op), "--abort")
+}
+
+// git runs a command that must succeed.
+func (r *resolver) git(ctx context.Context, repoPath string, args ...string) error {
+	result, err := r.executor.Run(ctx, repoPath, args...)
+	if err != nil {
+		return fmt.Errorf("git %s: %w", args[0], err)
+	}
+	if result.ExitCode != 0 {
+		return fmt.Errorf("git %s failed: %s", args[0], gitMessage(result))
+	}
+	return nil
+}
+
+// gitMessage is the most useful line of a failed command's output.
+func gitMessage(result *gitcmd.Result) string {
+	for _, out := range []string{result.Stderr, result.Stdout} {
+		if msg := strings.TrimSpace(out); msg != "" {
+			return msg
+		}
+	}
+	return fmt.Sprintf("exit status %d", result.ExitCode)
+}
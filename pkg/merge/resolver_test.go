@@ -0,0 +1,289 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package merge
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+func TestParseConflictMarkers(t *testing.T) {
+	content := "a\n<<<<<<< HEAD\nours\n||||||| base\nold\n=======\ntheirs\n>>>>>>> feature\nb\n<<<<<<< HEAD\nx\n=======\n>>>>>>> feature\n"
+
+	segments, hunks, trailing, err := parseConflictMarkers(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hunks) != 2 || len(segments) != 4 || !trailing {
+		t.Fatalf("hunks = %d, segments = %d, trailing = %v", len(hunks), len(segments), trailing)
+	}
+	h := hunks[0]
+	if h.OursLabel != "HEAD" || h.TheirsLabel != "feature" || !h.HasBase || h.Base[0] != "old" {
+		t.Errorf("first hunk = %+v", h)
+	}
+	if hunks[1].HasBase || len(hunks[1].Theirs) != 0 {
+		t.Errorf("second hunk = %+v", hunks[1])
+	}
+
+	file := &ConflictFile{Hunks: hunks, segments: segments, trailingNewline: trailing}
+	if got := string(file.Content()); got != content {
+		t.Errorf("unresolved content should round-trip:\n%s", got)
+	}
+
+	hunks[0].Resolve(ResolveBoth)
+	hunks[1].Edit([]string{"mine"})
+	if got, want := string(file.Content()), "a\nours\ntheirs\nb\nmine\n"; got != want {
+		t.Errorf("content = %q, want %q", got, want)
+	}
+	if !file.Resolved() {
+		t.Error("every hunk is resolved")
+	}
+}
+
+func TestParseConflictMarkersRejectsUnterminated(t *testing.T) {
+	if _, _, _, err := parseConflictMarkers("<<<<<<< HEAD\nx\n=======\ny\n"); err == nil {
+		t.Error("a hunk without >>>>>>> should be an error")
+	}
+	// A line that merely starts with the marker characters is text.
+	_, hunks, _, err := parseConflictMarkers("<<<<<<<<<< not a marker\n")
+	if err != nil || len(hunks) != 0 {
+		t.Errorf("hunks = %d, err = %v", len(hunks), err)
+	}
+}
+
+// conflictRepo is a repository with a main and a feature branch that both
+// changed the same lines of a.txt.
+func conflictRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	git(t, dir, "init", "-q", "-b", "main")
+	git(t, dir, "config", "user.name", "Test User")
+	git(t, dir, "config", "user.email", "test@example.com")
+	write(t, dir, "a.txt", "one\ntwo\nthree\nx1\nx2\nx3\nx4\nx5\nx6\nfour\nfive\n")
+	git(t, dir, "add", ".")
+	git(t, dir, "commit", "-q", "-m", "base")
+
+	git(t, dir, "checkout", "-q", "-b", "feature")
+	write(t, dir, "a.txt", "one\nTWO-feature\nthree\nx1\nx2\nx3\nx4\nx5\nx6\nfour\nFIVE-feature\n")
+	git(t, dir, "commit", "-q", "-am", "feature")
+
+	git(t, dir, "checkout", "-q", "main")
+	write(t, dir, "a.txt", "one\nTWO-main\nthree\nx1\nx2\nx3\nx4\nx5\nx6\nfour\nFIVE-main\n")
+	git(t, dir, "commit", "-q", "-am", "main")
+	return dir
+}
+
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput() //nolint:noctx // test setup
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return string(out)
+}
+
+func gitMayFail(dir string, args ...string) {
+	_ = exec.Command("git", append([]string{"-C", dir}, args...)...).Run() //nolint:noctx // test setup
+}
+
+func write(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestResolver() Resolver {
+	return NewResolver(gitcmd.NewExecutor(), repository.NewClient())
+}
+
+func TestResolverMerge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	ctx := context.Background()
+	dir := conflictRepo(t)
+	gitMayFail(dir, "merge", "feature")
+
+	r := newTestResolver()
+	state, err := r.Inspect(ctx, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Operation != OperationMerge || len(state.Unmerged) != 1 || state.Unmerged[0] != "a.txt" {
+		t.Fatalf("state = %+v", state)
+	}
+	if err := r.Continue(ctx, dir); err == nil {
+		t.Error("Continue with unmerged paths should fail")
+	}
+
+	file, err := r.Load(ctx, dir, "a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Whole || len(file.Hunks) != 2 {
+		t.Fatalf("file = %+v", file)
+	}
+	// The file has plain merge markers; the base comes from the index.
+	if h := file.Hunks[0]; !h.HasBase || h.Base[0] != "two" || h.Ours[0] != "TWO-main" || h.Theirs[0] != "TWO-feature" {
+		t.Errorf("first hunk = %+v", h)
+	}
+
+	// Half-way: saved, but not staged, and the second hunk keeps its markers.
+	file.Hunks[0].Resolve(ResolveTheirs)
+	if staged, err := r.Save(ctx, dir, file); err != nil || staged {
+		t.Fatalf("Save() = %v, %v; want unstaged", staged, err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "a.txt"))
+	if !strings.Contains(string(data), "TWO-feature\nthree") || !strings.Contains(string(data), "<<<<<<<") {
+		t.Errorf("partial save = %q", data)
+	}
+
+	file, err = r.Load(ctx, dir, "a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(file.Hunks) != 1 {
+		t.Fatalf("hunks after a partial save = %d", len(file.Hunks))
+	}
+	file.Hunks[0].Resolve(ResolveOurs)
+	if staged, err := r.Save(ctx, dir, file); err != nil || !staged {
+		t.Fatalf("Save() = %v, %v; want staged", staged, err)
+	}
+	if err := r.Continue(ctx, dir); err != nil {
+		t.Fatalf("Continue: %v", err)
+	}
+
+	data, _ = os.ReadFile(filepath.Join(dir, "a.txt"))
+	if string(data) != "one\nTWO-feature\nthree\nx1\nx2\nx3\nx4\nx5\nx6\nfour\nFIVE-main\n" {
+		t.Errorf("merged file = %q", data)
+	}
+	if state, _ := r.Inspect(ctx, dir); state.Stuck() {
+		t.Errorf("still stuck after continue: %+v", state)
+	}
+}
+
+func TestResolverRebaseStopsAgain(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	ctx := context.Background()
+	dir := conflictRepo(t)
+	// A second feature commit that conflicts again with main.
+	git(t, dir, "checkout", "-q", "feature")
+	write(t, dir, "a.txt", "one\nTWO-feature\nthree\nx1\nx2\nx3\nx4\nx5\nx6\nFOUR-feature\nFIVE-feature\n")
+	git(t, dir, "commit", "-q", "-am", "feature 2")
+	git(t, dir, "checkout", "-q", "main")
+	write(t, dir, "a.txt", "one\nTWO-main\nthree\nx1\nx2\nx3\nx4\nx5\nx6\nFOUR-main\nFIVE-main\n")
+	git(t, dir, "commit", "-q", "-am", "main 2")
+	git(t, dir, "checkout", "-q", "feature")
+	gitMayFail(dir, "rebase", "main")
+
+	r := newTestResolver()
+	state, err := r.Inspect(ctx, dir)
+	if err != nil || state.Operation != OperationRebase || len(state.Unmerged) != 1 {
+		t.Fatalf("state = %+v, %v", state, err)
+	}
+
+	file, _ := r.Load(ctx, dir, "a.txt")
+	file.TakeAll(ResolveOurs)
+	if _, err := r.Save(ctx, dir, file); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Continue(ctx, dir); !errors.Is(err, ErrStoppedAgain) {
+		t.Fatalf("Continue() = %v, want ErrStoppedAgain", err)
+	}
+
+	if err := r.Abort(ctx, dir); err != nil {
+		t.Fatalf("Abort: %v", err)
+	}
+	if state, _ := r.Inspect(ctx, dir); state.Stuck() {
+		t.Errorf("still stuck after abort: %+v", state)
+	}
+	if branch := strings.TrimSpace(git(t, dir, "rev-parse", "--abbrev-ref", "HEAD")); branch != "feature" {
+		t.Errorf("branch after abort = %s", branch)
+	}
+}
+
+func TestResolverDeleteConflict(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	ctx := context.Background()
+	dir := conflictRepo(t)
+	git(t, dir, "checkout", "-q", "feature")
+	git(t, dir, "rm", "-q", "a.txt")
+	git(t, dir, "commit", "-q", "-m", "delete")
+	git(t, dir, "checkout", "-q", "main")
+	gitMayFail(dir, "merge", "feature")
+
+	r := newTestResolver()
+	file, err := r.Load(ctx, dir, "a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !file.Whole || file.Reason != "deleted by them" || file.HasTheirs {
+		t.Fatalf("file = %+v", file)
+	}
+	file.TakeAll(ResolveTheirs)
+	if staged, err := r.Save(ctx, dir, file); err != nil || !staged {
+		t.Fatalf("Save() = %v, %v", staged, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "a.txt")); !os.IsNotExist(err) {
+		t.Error("taking the deleting side should remove the file")
+	}
+	if err := r.Continue(ctx, dir); err != nil {
+		t.Fatalf("Continue: %v", err)
+	}
+}
+
+func TestResolverRerere(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	ctx := context.Background()
+	dir := conflictRepo(t)
+	git(t, dir, "config", "rerere.enabled", "true")
+	gitMayFail(dir, "merge", "feature")
+
+	r := newTestResolver()
+	state, _ := r.Inspect(ctx, dir)
+	if !state.Rerere {
+		t.Fatal("rerere.enabled should be reported")
+	}
+	file, _ := r.Load(ctx, dir, "a.txt")
+	file.TakeAll(ResolveBoth)
+	if _, err := r.Save(ctx, dir, file); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Abort(ctx, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	// The same conflict again: rerere replays the recorded resolution.
+	gitMayFail(dir, "merge", "feature")
+	file, err := r.Load(ctx, dir, "a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !file.Whole || !file.Rerere {
+		t.Fatalf("file = %+v, want resolved by rerere", file)
+	}
+	file.TakeAll(ResolveWorkingTree)
+	if _, err := r.Save(ctx, dir, file); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "a.txt"))
+	if !strings.Contains(string(data), "TWO-main\nTWO-feature") {
+		t.Errorf("replayed resolution = %q", data)
+	}
+}
@@ -5,24 +5,36 @@ package merge
 
 // ConflictReport contains detected conflicts from merge analysis.
 type ConflictReport struct {
-	Source         string
-	Target         string
-	MergeBase      string
-	TotalConflicts int
-	Conflicts      []*Conflict
-	CanAutoResolve int
-	Difficulty     MergeDifficulty
+	Source         string          `json:"source"`
+	Target         string          `json:"target"`
+	MergeBase      string          `json:"merge_base"`
+	TotalConflicts int             `json:"total_conflicts"`
+	Conflicts      []*Conflict     `json:"conflicts"`
+	CanAutoResolve int             `json:"can_auto_resolve"`
+	Difficulty     MergeDifficulty `json:"difficulty"`
+
+	// Heuristic is set when git is too old for `merge-tree --write-tree`
+	// (before 2.38) and conflicts were estimated from the files both sides
+	// changed instead of from a real merge.
+	Heuristic bool `json:"heuristic,omitempty"`
 }
 
 // Conflict represents a single merge conflict.
 type Conflict struct {
-	FilePath       string
-	ConflictType   ConflictType
-	SourceChange   ChangeType
-	TargetChange   ChangeType
-	Severity       ConflictSeverity
-	AutoResolvable bool
-	Description    string
+	FilePath       string           `json:"file_path"`
+	ConflictType   ConflictType     `json:"conflict_type"`
+	SourceChange   ChangeType       `json:"source_change"`
+	TargetChange   ChangeType       `json:"target_change"`
+	Severity       ConflictSeverity `json:"severity"`
+	AutoResolvable bool             `json:"auto_resolvable"`
+	Description    string           `json:"description"`
+
+	// OldPath is the path before a rename, for rename conflicts.
+	OldPath string `json:"old_path,omitempty"`
+
+	// Hunks are the conflicting regions of a text file, as the merge would
+	// leave them, with the merge base's lines.
+	Hunks []*Hunk `json:"hunks,omitempty"`
 }
 
 // ConflictType defines the type of conflict.
@@ -34,6 +46,10 @@ const (
 	ConflictRename  ConflictType = "rename"  // Rename conflicts
 	ConflictDelete  ConflictType = "delete"  // Delete/modify conflicts
 	ConflictBinary  ConflictType = "binary"  // Binary file conflicts
+
+	ConflictAddAdd        ConflictType = "add/add"        // Both sides added the path
+	ConflictFileDirectory ConflictType = "file/directory" // A file on one side, a directory or other type on the other
+	ConflictOther         ConflictType = "other"          // Anything else git reports (modes, submodules)
 )
 
 // ChangeType defines the type of change.
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import "context"
+
+// CIState is the forge-neutral outcome of the checks reported for one commit.
+// Every forge has its own vocabulary (GitHub check-run conclusions, GitLab
+// pipeline statuses, Gitea commit-status states); providers fold theirs into
+// these four so callers never branch on the forge.
+type CIState string
+
+// CIState values, ordered from least to most severe by CIStateSeverity.
+const (
+	// CIStateNone means the forge reported nothing for the commit: no
+	// workflow ran, or the repository has no CI at all.
+	CIStateNone CIState = "none"
+	// CIStateSuccess means every reported check passed (or was skipped).
+	CIStateSuccess CIState = "success"
+	// CIStatePending means at least one check is queued or running and none
+	// has failed yet.
+	CIStatePending CIState = "pending"
+	// CIStateFailure means at least one check failed, errored, was canceled,
+	// or timed out.
+	CIStateFailure CIState = "failure"
+)
+
+// CICheck is one named check, status context, or pipeline on a commit.
+type CICheck struct {
+	Name  string  `json:"name"`
+	State CIState `json:"state"`
+	URL   string  `json:"url,omitempty"`
+}
+
+// CIStatus is the rolled-up CI state of a single commit or branch tip.
+type CIStatus struct {
+	// Ref is the ref the caller asked about (a SHA or branch name).
+	Ref string `json:"ref"`
+	// SHA is the commit the forge evaluated, when it reports one.
+	SHA string `json:"sha,omitempty"`
+	// State is the worst state across Checks, or CIStateNone without checks.
+	State  CIState   `json:"state"`
+	URL    string    `json:"url,omitempty"`
+	Checks []CICheck `json:"checks,omitempty"`
+}
+
+// Failed returns the names of the checks in CIStateFailure.
+func (s *CIStatus) Failed() []string {
+	if s == nil {
+		return nil
+	}
+	var names []string
+	for _, c := range s.Checks {
+		if c.State == CIStateFailure {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+// CIStatusReader reports the CI state of a commit. It is a sibling of
+// Provider, like PullRequester, so listing-only mocks stay valid.
+type CIStatusReader interface {
+	// CommitCIStatus returns the rolled-up CI state for ref, which may be a
+	// commit SHA or a branch name. A ref the forge has never seen is not an
+	// error: it is reported as CIStateNone.
+	CommitCIStatus(ctx context.Context, owner, repo, ref string) (*CIStatus, error)
+}
+
+// CIStateSeverity ranks states so the worst one can be chosen: failure
+// outranks pending, which outranks success, which outranks none.
+func CIStateSeverity(s CIState) int {
+	switch s {
+	case CIStateFailure:
+		return 3
+	case CIStatePending:
+		return 2
+	case CIStateSuccess:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// WorstCIState returns the most severe of states, or CIStateNone when empty.
+func WorstCIState(states ...CIState) CIState {
+	worst := CIStateNone
+	for _, s := range states {
+		if CIStateSeverity(s) > CIStateSeverity(worst) {
+			worst = s
+		}
+	}
+	return worst
+}
+
+// RollupCIChecks derives the State of a status from its checks.
+func RollupCIChecks(checks []CICheck) CIState {
+	states := make([]CIState, 0, len(checks))
+	for _, c := range checks {
+		states = append(states, c.State)
+	}
+	return WorstCIState(states...)
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import "testing"
+
+func TestWorstCIState(t *testing.T) {
+	tests := []struct {
+		name   string
+		states []CIState
+		want   CIState
+	}{
+		{"empty", nil, CIStateNone},
+		{"all green", []CIState{CIStateSuccess, CIStateSuccess}, CIStateSuccess},
+		{"pending beats success", []CIState{CIStateSuccess, CIStatePending}, CIStatePending},
+		{"failure beats everything", []CIState{CIStatePending, CIStateFailure, CIStateSuccess}, CIStateFailure},
+		{"none does not mask success", []CIState{CIStateNone, CIStateSuccess}, CIStateSuccess},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WorstCIState(tt.states...); got != tt.want {
+				t.Errorf("WorstCIState() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
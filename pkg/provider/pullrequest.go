@@ -41,3 +41,11 @@ type PullRequester interface {
 	CreatePullRequest(ctx context.Context, in CreatePullRequestInput) (*PullRequest, error)
 	FindPullRequest(ctx context.Context, owner, repo, head, base string) (*PullRequest, error)
 }
+
+// PullRequestBaseUpdater retargets an open pull request / merge request at a
+// different base branch. Stacked branches need it when a parent lands and
+// its child's PR must point at the parent's own base instead. It is a
+// separate interface so PullRequester implementations need not support it.
+type PullRequestBaseUpdater interface {
+	UpdatePullRequestBase(ctx context.Context, owner, repo string, number int, base string) (*PullRequest, error)
+}
@@ -57,7 +57,7 @@ func ParseForgeRemote(raw string) (ForgeRemote, error) {
 		Host:     host,
 		Owner:    owner,
 		Repo:     repo,
-		Provider: classifyForgeHost(host),
+		Provider: ClassifyForgeHost(host),
 	}
 	if out.Provider != "github" || host != "github.com" {
 		if out.Provider == "gitlab" && host == "gitlab.com" {
@@ -70,7 +70,35 @@ func ParseForgeRemote(raw string) (ForgeRemote, error) {
 	return out, nil
 }
 
-func classifyForgeHost(host string) string {
+// Org returns the top-level namespace of the repository: the user or
+// organization on GitHub and Gitea, the root group on GitLab. Credentials are
+// scoped by it because a GitLab subgroup belongs to its root group's account.
+func (r ForgeRemote) Org() string {
+	org, _, _ := strings.Cut(r.Owner, "/")
+	return org
+}
+
+// PublicForgeProvider returns the provider of a public forge host
+// (github.com, gitlab.com, gitea.com, codeberg.org), or "" for any other
+// host. Unlike ClassifyForgeHost it never guesses from the name: it decides
+// which hosts may receive a provider-wide token, and github.attacker.io must
+// not.
+func PublicForgeProvider(host string) string {
+	switch strings.ToLower(host) {
+	case "github.com":
+		return "github"
+	case "gitlab.com":
+		return "gitlab"
+	case "gitea.com", "codeberg.org":
+		return "gitea"
+	default:
+		return ""
+	}
+}
+
+// ClassifyForgeHost guesses the forge software behind host from its name, or
+// returns "" when the name gives nothing away.
+func ClassifyForgeHost(host string) string {
 	switch {
 	case host == "github.com" || strings.HasPrefix(host, "github.") || strings.HasSuffix(host, ".ghe.com"):
 		return "github"
@@ -19,7 +19,13 @@ var ProtectedBranches = []string{
 
 // IsProtected reports whether name matches a built-in protected branch pattern.
 func IsProtected(name string) bool {
-	for _, pattern := range ProtectedBranches {
+	return MatchesBranchPattern(name, ProtectedBranches)
+}
+
+// MatchesBranchPattern reports whether name matches any of patterns, in the
+// syntax of ProtectedBranches.
+func MatchesBranchPattern(name string, patterns []string) bool {
+	for _, pattern := range patterns {
 		if matchBranchPattern(name, pattern) {
 			return true
 		}
@@ -19,6 +19,19 @@ func TestIsProtected(t *testing.T) {
 	}
 }
 
+// TestMatchesBranchPattern covers caller-supplied pattern lists.
+func TestMatchesBranchPattern(t *testing.T) {
+	patterns := []string{"trunk", "stable/*"}
+	for name, want := range map[string]bool{"trunk": true, "stable/2": true, "main": false, "stable": false} {
+		if got := MatchesBranchPattern(name, patterns); got != want {
+			t.Errorf("MatchesBranchPattern(%q) = %v, want %v", name, got, want)
+		}
+	}
+	if MatchesBranchPattern("main", nil) {
+		t.Error("no patterns should match nothing")
+	}
+}
+
 // TestBulkIsProtectedUsesSharedSource proves the bulk cleanup predicate resolves
 // built-in protection through the shared ProtectedBranches source: appending a
 // pattern there makes the bulk path refuse a matching branch with no change to
@@ -2554,6 +2554,11 @@ func (c *client) processStatusRepositories(ctx context.Context, rootDir string,
 	return results, nil
 }
 
+// RepositoryStatus implements Client.
+func (c *client) RepositoryStatus(ctx context.Context, rootDir, repoPath string) RepositoryStatusResult {
+	return c.processStatusRepository(ctx, rootDir, repoPath, BulkStatusOptions{}, NewNoopLogger())
+}
+
 // processStatusRepository processes a single repository status check.
 func (c *client) processStatusRepository(ctx context.Context, rootDir, repoPath string, _ BulkStatusOptions, logger Logger) RepositoryStatusResult {
 	startTime := time.Now()
@@ -2687,6 +2692,13 @@ func calculateStatusSummary(results []RepositoryStatusResult) map[string]int {
 	return calculateSummaryGeneric(results)
 }
 
+// SummarizeStatus counts results by status, as BulkStatusResult.Summary does.
+// It lets a caller that assembled results itself, such as from the daemon's
+// index, build the same summary.
+func SummarizeStatus(results []RepositoryStatusResult) map[string]int {
+	return calculateStatusSummary(results)
+}
+
 // ============================================================================
 // Common Bulk Operation Helpers
 // ============================================================================
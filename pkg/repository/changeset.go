@@ -400,6 +400,11 @@ func addUntrackedAdditions(repoPath string, cs *ChangeSet) {
 	}
 }
 
+// ConflictedPaths implements Client.
+func (c *client) ConflictedPaths(ctx context.Context, repoPath string) []string {
+	return c.collectConflictedPaths(ctx, repoPath)
+}
+
 // collectConflictedPaths returns the unmerged paths recorded in the index.
 //
 // `git ls-files --unmerged` is preferred over `git status --porcelain` for the
@@ -52,6 +52,15 @@ type Client interface {
 	// This is useful for checking the working tree status of multiple repositories at once.
 	BulkStatus(ctx context.Context, opts BulkStatusOptions) (*BulkStatusResult, error)
 
+	// RepositoryStatus checks one repository the way BulkStatus checks each
+	// repository it finds, with RelativePath relative to rootDir. It never
+	// fails; errors are recorded in the result, as they are in BulkStatus.
+	RepositoryStatus(ctx context.Context, rootDir, repoPath string) RepositoryStatusResult
+
+	// ConflictedPaths returns the unmerged paths in the index of the
+	// repository at repoPath, nil when there are none.
+	ConflictedPaths(ctx context.Context, repoPath string) []string
+
 	// BulkSwitch scans for repositories and switches their branches in parallel.
 	// This is useful for switching branches across multiple repositories at once.
 	BulkSwitch(ctx context.Context, opts BulkSwitchOptions) (*BulkSwitchResult, error)
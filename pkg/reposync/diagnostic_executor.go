@@ -379,6 +379,12 @@ func generateRecommendation(health RepoHealth) string {
 	return ""
 }
 
+// SummarizeHealth counts results by health status, as CheckHealth does for
+// HealthReport.Summary.
+func SummarizeHealth(results []RepoHealth) HealthSummary {
+	return calculateSummary(results)
+}
+
 func calculateSummary(results []RepoHealth) HealthSummary {
 	var summary HealthSummary
 	summary.Total = len(results)
@@ -285,6 +285,15 @@ func (e GitExecutor) runCloneOrUpdate(ctx context.Context, client repo.Client, l
 			time.Sleep(delay)
 		}
 	}
+
+	// Pin to the locked commit while the auth env is still valid, since a
+	// commit no branch points to any more has to be fetched by SHA.
+	var pinMsg string
+	var pinErr error
+	if err == nil && action.Repo.Commit != "" {
+		pinMsg, pinErr = pinCommit(ctx, action.Repo, authResult.Env, logger)
+	}
+
 	if authResult.TempKeyPath != "" {
 		if cleanupErr := removeTempSSHKey(authResult.TempKeyPath); cleanupErr != nil {
 			err = errors.Join(err, cleanupErr)
@@ -306,8 +315,21 @@ func (e GitExecutor) runCloneOrUpdate(ctx context.Context, client repo.Client, l
 		msg = fmt.Sprintf("%s %s", result.Action, action.Repo.TargetPath)
 	}
 
-	// Checkout branch if specified
-	if action.Repo.Branch != "" {
+	if pinErr != nil {
+		res := ActionResult{
+			Action:  action,
+			Message: fmt.Sprintf("%s (locked commit checkout failed: %v)", msg, pinErr),
+			Error:   pinErr,
+		}
+		sink.OnComplete(res)
+		return res, pinErr
+	}
+	if pinMsg != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, pinMsg)
+	}
+
+	// Checkout branch if specified (a pinned commit has been checked out already)
+	if action.Repo.Branch != "" && action.Repo.Commit == "" {
 		branchMsg, branchErr := checkoutBranch(ctx, action.Repo.TargetPath, action.Repo.Branch, logger)
 		if branchErr != nil {
 			if action.Repo.StrictBranchCheckout {
@@ -445,6 +467,11 @@ func toUpdateStrategy(action Action) repo.UpdateStrategy {
 	if action.Type == ActionClone {
 		return repo.StrategyClone
 	}
+	// a pinned commit is checked out after the update; only fetch so that
+	// no branch is moved to its remote tip first
+	if action.Repo.Commit != "" {
+		return repo.StrategyFetch
+	}
 
 	switch action.Strategy {
 	case StrategyPull:
@@ -543,6 +570,59 @@ func checkoutBranch(ctx context.Context, repoPath, branch string, logger repo.Lo
 	return "", fmt.Errorf("none of the specified branches exist: %s", branch)
 }
 
+// pinCommit checks out the commit a lockfile recorded for spec: detached,
+// or as spec.Branch reset to it with CommitCheckoutBranch. A commit the
+// update did not bring in is fetched by SHA, which works as long as the
+// remote still has it.
+func pinCommit(ctx context.Context, spec RepoSpec, env []string, logger repo.Logger) (string, error) {
+	sha, path := spec.Commit, spec.TargetPath
+	if !isCommitSHA(sha) {
+		return "", fmt.Errorf("invalid commit %q: want a full SHA", sha)
+	}
+
+	if !commitExists(ctx, path, sha) {
+		cmd := exec.CommandContext(ctx, "git", "-C", path, "fetch", "--quiet", "origin", sha) // #nosec G204 -- sha is validated as hex by isCommitSHA.
+		cmd.Env = append(os.Environ(), env...)
+		output, err := cmd.CombinedOutput()
+		if err != nil || !commitExists(ctx, path, sha) {
+			return "", fmt.Errorf("commit %s is not on the remote (force-pushed or deleted?): %s", shortSHA(sha), strings.TrimSpace(string(output)))
+		}
+		logger.Debug("fetched locked commit: %s -> %s", path, sha)
+	}
+
+	if spec.CommitCheckout == CommitCheckoutBranch && spec.Branch != "" {
+		branch := spec.Branch
+		if err := gitcmd.SanitizeBranchName(branch); err != nil {
+			return "", fmt.Errorf("invalid branch name %q: %w", branch, err)
+		}
+		// Moving the branch back must not drop commits only it has.
+		local := "refs/heads/" + branch
+		if commitExists(ctx, path, local) {
+			cmd := exec.CommandContext(ctx, "git", "-C", path, "merge-base", "--is-ancestor", local, sha) // #nosec G204 -- branch is validated by SanitizeBranchName; sha is hex.
+			if cmd.Run() != nil {
+				return "", fmt.Errorf("local branch %s has commits not in %s; push or move them, or use --lock-checkout detach", branch, shortSHA(sha))
+			}
+		}
+		cmd := exec.CommandContext(ctx, "git", "-C", path, "checkout", "-B", branch, sha) // #nosec G204 -- branch is validated by SanitizeBranchName; sha is hex.
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return "", fmt.Errorf("git checkout -B %s %s failed: %w (output: %s)", branch, shortSHA(sha), err, strings.TrimSpace(string(output)))
+		}
+		return fmt.Sprintf("%s at %s", branch, shortSHA(sha)), nil
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "-C", path, "checkout", "--detach", sha) // #nosec G204 -- sha is validated as hex by isCommitSHA.
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("git checkout --detach %s failed: %w (output: %s)", shortSHA(sha), err, strings.TrimSpace(string(output)))
+	}
+	return fmt.Sprintf("detached at %s", shortSHA(sha)), nil
+}
+
+// commitExists reports whether rev names a commit in the repository.
+func commitExists(ctx context.Context, repoPath, rev string) bool {
+	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "rev-parse", "--verify", "--quiet", rev+"^{commit}") // #nosec G204 -- rev is a validated SHA or branch ref.
+	return cmd.Run() == nil
+}
+
 // branchExists checks if a branch exists locally or as a remote tracking branch.
 func branchExists(ctx context.Context, repoPath, branch string) bool {
 	// Check local branch
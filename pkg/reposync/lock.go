@@ -0,0 +1,347 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package reposync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LockfileName is the lockfile written next to a workspace config.
+const LockfileName = ".gz-git.lock"
+
+// LockfileVersion is the lockfile format this package reads and writes.
+const LockfileVersion = 1
+
+const lockfileHeader = "# Generated by 'gz-git workspace lock'. Do not edit by hand.\n" +
+	"# 'gz-git workspace sync --locked' checks out exactly these commits.\n"
+
+// Lockfile records the exact commit of every repository in a workspace, so
+// that a locked sync reproduces the same tree on another machine or day.
+type Lockfile struct {
+	Version      int          `yaml:"version"`
+	Repositories []LockedRepo `yaml:"repositories"`
+}
+
+// LockedRepo is one repository pinned by a lockfile.
+type LockedRepo struct {
+	// Path is the checkout path, relative to the lockfile's directory.
+	Path      string `yaml:"path"`
+	Name      string `yaml:"name,omitempty"`
+	URL       string `yaml:"url"`
+	Branch    string `yaml:"branch,omitempty"`
+	Commit    string `yaml:"commit"`
+	Workspace string `yaml:"workspace,omitempty"`
+}
+
+// CommitCheckout selects how a pinned commit is checked out.
+type CommitCheckout string
+
+// CommitCheckout values.
+const (
+	// CommitCheckoutDetach checks out the commit as a detached HEAD.
+	CommitCheckoutDetach CommitCheckout = "detach"
+	// CommitCheckoutBranch points the locked branch at the commit and checks
+	// it out, refusing when that would drop local commits.
+	CommitCheckoutBranch CommitCheckout = "branch"
+)
+
+// Validate reports whether m is a known checkout mode.
+func (m CommitCheckout) Validate() error {
+	switch m {
+	case CommitCheckoutDetach, CommitCheckoutBranch:
+		return nil
+	default:
+		return fmt.Errorf("unknown lock checkout mode %q (want detach or branch)", string(m))
+	}
+}
+
+// LoadLockfile reads and checks a lockfile.
+func LoadLockfile(path string) (*Lockfile, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is the lockfile next to the selected workspace config.
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("no lockfile at %s: create it with 'gz-git workspace lock'", path)
+		}
+		return nil, fmt.Errorf("read lockfile: %w", err)
+	}
+
+	var lock Lockfile
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("parse lockfile %s: %w", path, err)
+	}
+	if lock.Version > LockfileVersion {
+		return nil, fmt.Errorf("lockfile %s has version %d; this gz-git reads version %d, upgrade gz-git", path, lock.Version, LockfileVersion)
+	}
+
+	seen := make(map[string]bool, len(lock.Repositories))
+	for i, r := range lock.Repositories {
+		switch {
+		case r.Path == "":
+			return nil, fmt.Errorf("lockfile %s: repositories[%d] has no path", path, i)
+		case !isCommitSHA(r.Commit):
+			return nil, fmt.Errorf("lockfile %s: %s: commit %q is not a full SHA", path, r.Path, r.Commit)
+		case seen[r.Path]:
+			return nil, fmt.Errorf("lockfile %s: %s is listed twice", path, r.Path)
+		}
+		seen[r.Path] = true
+	}
+	return &lock, nil
+}
+
+// Save writes the lockfile with its repositories sorted by path.
+func (l *Lockfile) Save(path string) error {
+	l.Version = LockfileVersion
+	l.sort()
+
+	var buf bytes.Buffer
+	buf.WriteString(lockfileHeader)
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(l); err != nil {
+		return fmt.Errorf("marshal lockfile: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("marshal lockfile: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil { // #nosec G306 -- the lockfile is meant to be committed and shared.
+		return fmt.Errorf("write lockfile: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("move lockfile: %w", err)
+	}
+	return nil
+}
+
+// Lookup returns the entry for a lockfile-relative path, or nil.
+func (l *Lockfile) Lookup(path string) *LockedRepo {
+	for i := range l.Repositories {
+		if l.Repositories[i].Path == path {
+			return &l.Repositories[i]
+		}
+	}
+	return nil
+}
+
+// Apply pins every clone and update action to its locked commit. Paths are
+// matched relative to baseDir, the lockfile's directory. Repositories the
+// lockfile does not know are an error, since syncing them to their branch
+// tips would defeat the lock.
+func (l *Lockfile) Apply(actions []Action, baseDir string, mode CommitCheckout) error {
+	if err := mode.Validate(); err != nil {
+		return err
+	}
+
+	var missing []string
+	for i := range actions {
+		a := &actions[i]
+		if a.Type != ActionClone && a.Type != ActionUpdate {
+			continue
+		}
+		rel := LockPath(baseDir, a.Repo.TargetPath)
+		entry := l.Lookup(rel)
+		if entry == nil {
+			missing = append(missing, rel)
+			continue
+		}
+		a.Repo.Commit = entry.Commit
+		a.Repo.Branch = entry.Branch
+		a.Repo.CommitCheckout = mode
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("not in the lockfile: %s; run 'gz-git workspace lock' to add them", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func (l *Lockfile) sort() {
+	sort.Slice(l.Repositories, func(i, j int) bool {
+		return l.Repositories[i].Path < l.Repositories[j].Path
+	})
+}
+
+// LockPath is the lockfile key for a checkout: its path relative to baseDir,
+// with forward slashes so that lockfiles diff cleanly across platforms.
+func LockPath(baseDir, targetPath string) string {
+	abs, err := filepath.Abs(targetPath)
+	if err != nil {
+		abs = targetPath
+	}
+	rel, err := filepath.Rel(baseDir, abs)
+	if err != nil {
+		rel = targetPath
+	}
+	return filepath.ToSlash(rel)
+}
+
+// ReadLockedRepo records the checkout of a planned repository: the commit
+// at HEAD and the branch it is on. A detached HEAD, as a locked sync leaves
+// behind, keeps the branch from the plan. HEAD must be on a remote branch,
+// or nobody else could check it out.
+func ReadLockedRepo(ctx context.Context, action Action, baseDir string) (LockedRepo, error) {
+	path := action.Repo.TargetPath
+	entry := LockedRepo{
+		Path:      LockPath(baseDir, path),
+		Name:      action.Repo.Name,
+		URL:       StripURLCredentials(action.Repo.CloneURL),
+		Workspace: action.Workspace,
+	}
+
+	commit, err := gitOutput(ctx, path, "rev-parse", "--verify", "HEAD^{commit}")
+	if err != nil {
+		if _, statErr := os.Stat(filepath.Join(path, ".git")); statErr != nil {
+			return entry, fmt.Errorf("%s is not cloned: run 'gz-git workspace sync' first", entry.Path)
+		}
+		return entry, fmt.Errorf("%s: read HEAD: %w", entry.Path, err)
+	}
+	entry.Commit = commit
+
+	if entry.URL == "" {
+		if origin, err := gitOutput(ctx, path, "remote", "get-url", "origin"); err == nil {
+			entry.URL = StripURLCredentials(origin)
+		}
+	}
+
+	if branch, err := gitOutput(ctx, path, "symbolic-ref", "--short", "-q", "HEAD"); err == nil && branch != "" {
+		entry.Branch = branch
+	} else if b, _, _ := strings.Cut(action.Repo.Branch, ","); b != "" {
+		entry.Branch = strings.TrimSpace(b)
+	}
+
+	remotes, err := gitOutput(ctx, path, "branch", "-r", "--contains", commit)
+	if err != nil {
+		return entry, fmt.Errorf("%s: check remote branches: %w", entry.Path, err)
+	}
+	if remotes == "" {
+		return entry, fmt.Errorf("%s: HEAD %s is not on any remote branch: push it before locking", entry.Path, shortSHA(commit))
+	}
+	return entry, nil
+}
+
+// LockChange is one difference between two lockfiles. Old is nil for an
+// added repository and New is nil for a removed one.
+type LockChange struct {
+	Path string
+	Old  *LockedRepo
+	New  *LockedRepo
+}
+
+// DiffLockfiles lists the repositories added, removed or re-pinned between
+// two lockfiles, sorted by path. A nil old lockfile is empty.
+func DiffLockfiles(before, after *Lockfile) []LockChange {
+	index := func(l *Lockfile) map[string]*LockedRepo {
+		m := map[string]*LockedRepo{}
+		if l == nil {
+			return m
+		}
+		for i := range l.Repositories {
+			m[l.Repositories[i].Path] = &l.Repositories[i]
+		}
+		return m
+	}
+	oldRepos, newRepos := index(before), index(after)
+
+	var changes []LockChange
+	for path, n := range newRepos {
+		o := oldRepos[path]
+		if o == nil || o.Commit != n.Commit || o.Branch != n.Branch || o.URL != n.URL {
+			changes = append(changes, LockChange{Path: path, Old: o, New: n})
+		}
+	}
+	for path, o := range oldRepos {
+		if newRepos[path] == nil {
+			changes = append(changes, LockChange{Path: path, Old: o})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// String renders the change as one line of a lockfile diff.
+func (c LockChange) String() string {
+	switch {
+	case c.Old == nil:
+		return fmt.Sprintf("+ %s %s (%s)", c.Path, shortSHA(c.New.Commit), c.New.Branch)
+	case c.New == nil:
+		return fmt.Sprintf("- %s %s", c.Path, shortSHA(c.Old.Commit))
+	}
+	line := fmt.Sprintf("~ %s %s → %s", c.Path, shortSHA(c.Old.Commit), shortSHA(c.New.Commit))
+	if c.Old.Branch != c.New.Branch {
+		line += fmt.Sprintf(" (branch %s → %s)", c.Old.Branch, c.New.Branch)
+	}
+	if c.Old.URL != c.New.URL {
+		line += fmt.Sprintf(" (url %s → %s)", c.Old.URL, c.New.URL)
+	}
+	return line
+}
+
+func gitOutput(ctx context.Context, repoPath string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", repoPath}, args...)...) // #nosec G204 -- fixed git queries; commit SHAs come from rev-parse.
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// isCommitSHA reports whether s is a full SHA-1 or SHA-256 object name.
+func isCommitSHA(s string) bool {
+	if len(s) != 40 && len(s) != 64 {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
+
+// StripURLCredentials drops a password or token embedded in a URL, so it
+// never lands in a file meant to be shared. Unlike MaskTokenInURL, the
+// result is still a usable clone URL.
+//
+// Over HTTP(S) any userinfo is dropped: a bare user is usually the token
+// itself (https://TOKEN@host/...). An ssh:// URL keeps its user, which names
+// the account (git@), and loses only a password; scp-style git@host:path
+// URLs carry no secret and are returned unchanged.
+func StripURLCredentials(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "ssh", "git+ssh", "ssh+git":
+		if _, hasPassword := u.User.Password(); !hasPassword {
+			return raw
+		}
+		u.User = url.User(u.User.Username())
+	default:
+		u.User = nil
+	}
+	return u.String()
+}
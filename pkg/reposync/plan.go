@@ -52,6 +52,12 @@ type RepoSpec struct {
 	Enabled              *bool // if false, repo is excluded from sync (default: true, nil = true)
 	AssumePresent        bool  // if true, planner treats repo as already present (skip clone check)
 
+	// Commit pins the repository to an exact commit, as a lockfile records it.
+	// When set, updates only fetch and the commit is checked out afterwards
+	// instead of Branch; CommitCheckout says how (default: detached).
+	Commit         string
+	CommitCheckout CommitCheckout
+
 	// Auth contains authentication config for this repo's clone operation.
 	// If empty, system defaults are used (git credential helper, ssh-agent).
 	Auth AuthConfig
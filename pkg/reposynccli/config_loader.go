@@ -665,9 +665,14 @@ func (l FileSpecLoader) loadForgeWorkspace( //nolint:gocyclo // complex provider
 		baseURL = profile.BaseURL
 	}
 
-	token := expandEnvVar(src.Token)
+	token, err := expandEnvVar(src.Token)
+	if err != nil {
+		return nil, fmt.Errorf("token: %w", err)
+	}
 	if token == "" && profile != nil {
-		token = expandEnvVar(profile.Token)
+		if token, err = expandEnvVar(profile.Token); err != nil {
+			return nil, fmt.Errorf("profile token: %w", err)
+		}
 	}
 
 	cloneProto := ws.CloneProto
@@ -772,13 +777,17 @@ func (l FileSpecLoader) loadConfigWorkspace(ctx context.Context, wsPath string)
 	return scanGitRepos(wsPath)
 }
 
-// expandEnvVar expands ${VAR} syntax in a string.
-func expandEnvVar(s string) string {
+// expandEnvVar expands ${VAR} syntax in a string, and resolves secret
+// references such as ${keyring:gitlab} or ${cmd:...}.
+func expandEnvVar(s string) (string, error) {
+	if config.HasSecretRef(s) || strings.Contains(s, "${env:") {
+		return config.ResolveSecret(s)
+	}
 	if strings.HasPrefix(s, "${") && strings.HasSuffix(s, "}") {
 		varName := s[2 : len(s)-1]
-		return os.Getenv(varName)
+		return os.Getenv(varName), nil
 	}
-	return os.ExpandEnv(s)
+	return os.ExpandEnv(s), nil
 }
 
 // scanGitRepos scans a directory for git repositories (depth 1).
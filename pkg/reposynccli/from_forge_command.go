@@ -363,6 +363,7 @@ func CreateForgeProviderRaw(providerName, token, baseURL string, sshPort int) (r
 // CreateProviderFromSource creates a forge provider from config types with profile fallback.
 // This handles the common pattern of extracting provider settings from ForgeSource,
 // falling back to Workspace settings, then to profile settings from the config chain.
+// A token holding a secret reference is resolved only here.
 func CreateProviderFromSource(src *config.ForgeSource, ws *config.Workspace, cfg *config.Config) (reposync.ForgeProvider, error) { //nolint:gocognit // multi-layer profile fallback chain — extracting helpers would obscure the precedence logic
 	// Extract values from source
 	token := src.Token
@@ -419,5 +420,12 @@ func CreateProviderFromSource(src *config.ForgeSource, ws *config.Workspace, cfg
 		}
 	}
 
+	// Secret references are resolved here, once the token is known to be
+	// needed, rather than when the config loads.
+	token, err := config.ResolveSecret(token)
+	if err != nil {
+		return nil, fmt.Errorf("token: %w", err)
+	}
+
 	return CreateForgeProviderRaw(providerName, token, baseURL, sshPort)
 }
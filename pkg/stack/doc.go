@@ -0,0 +1,26 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package stack manages stacks of dependent branches: each branch is cut
+// from, and reviewed against, the branch below it.
+//
+// The parent of a stacked branch is recorded in its own git config section,
+// as branch.<name>.gz-stack-parent, next to branch.<name>.gz-stack-base, the
+// parent commit the branch was last stacked on. Keeping both in the branch
+// section means `git branch -m` carries them along and `git branch -D`
+// removes them, so the records cannot outlive the branch.
+//
+// The recorded base is what makes restacking safe after a parent is amended
+// or squash-merged: the child is replayed with
+// `git rebase --onto <parent> <base>`, which drops the parent's old commits
+// instead of replaying them. Runs of branches that are already stacked on
+// each other are rebased as one, from the top of the run, with
+// --update-refs so git moves every branch in between.
+//
+// # Usage
+//
+//	mgr := stack.NewManager()
+//	_, err := mgr.Create(ctx, repo, stack.CreateOptions{Name: "feat/api"})
+//	result, err := mgr.Restack(ctx, repo, stack.RestackOptions{})
+//	outcomes, err := mgr.Submit(ctx, repo, requester, stack.SubmitOptions{Owner: "acme", Repo: "app"})
+package stack
@@ -0,0 +1,484 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package stack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli-gitforge/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/branch"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/provider"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+// Config keys, under branch.<name>.
+const (
+	parentKey = "gz-stack-parent"
+	baseKey   = "gz-stack-base"
+)
+
+// Manager records stacked branches and keeps them on top of each other.
+type Manager interface {
+	// Create creates a branch stacked on a parent, or records the parent of
+	// an existing branch.
+	Create(ctx context.Context, repo *repository.Repository, opts CreateOptions) (*Branch, error)
+
+	// List returns the stacks in the repository, one tree per branch the
+	// stacks are rooted on (usually the default branch).
+	List(ctx context.Context, repo *repository.Repository) ([]*Branch, error)
+
+	// Restack rebases every branch of a stack onto its parent, moving
+	// branches off parents that landed first.
+	Restack(ctx context.Context, repo *repository.Repository, opts RestackOptions) (*RestackResult, error)
+
+	// Submit pushes each branch of a stack and opens or retargets its pull
+	// request so its base is the branch's parent.
+	Submit(ctx context.Context, repo *repository.Repository, requester provider.PullRequester, opts SubmitOptions) ([]SubmitOutcome, error)
+}
+
+// manager implements Manager.
+type manager struct {
+	executor *gitcmd.Executor
+	branches branch.BranchManager
+}
+
+// NewManager creates a new stack Manager.
+func NewManager() Manager {
+	return NewManagerWithExecutor(gitcmd.NewExecutor())
+}
+
+// NewManagerWithExecutor creates a new stack Manager with a custom executor.
+func NewManagerWithExecutor(executor *gitcmd.Executor) Manager {
+	return &manager{
+		executor: executor,
+		branches: branch.NewManagerWithExecutor(executor),
+	}
+}
+
+// record is what the config says about one stacked branch.
+type record struct {
+	parent string
+	base   string
+}
+
+// graph is every stacked branch in a repository.
+type graph struct {
+	records  map[string]*record
+	children map[string][]string
+}
+
+// roots are the tracked branches whose parent is not tracked.
+func (g *graph) roots() []string {
+	var roots []string
+	for name, rec := range g.records {
+		if g.records[rec.parent] == nil {
+			roots = append(roots, name)
+		}
+	}
+	sort.Strings(roots)
+	return roots
+}
+
+// root walks up from name to the bottom of its stack.
+func (g *graph) root(name string) (string, error) {
+	seen := map[string]bool{}
+	for {
+		rec := g.records[name]
+		if rec == nil || g.records[rec.parent] == nil {
+			return name, nil
+		}
+		if seen[name] {
+			return "", fmt.Errorf("%w at %s", ErrCycle, name)
+		}
+		seen[name] = true
+		name = rec.parent
+	}
+}
+
+// descendants lists the branches stacked on name, parents before children.
+func (g *graph) descendants(name string) []string {
+	var out []string
+	queue := append([]string(nil), g.children[name]...)
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		out = append(out, next)
+		queue = append(queue, g.children[next]...)
+	}
+	return out
+}
+
+// selectStack picks the stacked branches opts refer to, parents first. name
+// may be a stacked branch, selecting its stack, or the branch stacks are
+// rooted on, selecting all of them.
+func (g *graph) selectStack(name string, all bool) ([]string, error) {
+	var roots []string
+	switch {
+	case all:
+		roots = g.roots()
+	case g.records[name] != nil:
+		root, err := g.root(name)
+		if err != nil {
+			return nil, err
+		}
+		roots = []string{root}
+	case len(g.children[name]) > 0:
+		roots = g.children[name]
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrNotStacked, name)
+	}
+
+	var members []string
+	for _, root := range roots {
+		members = append(members, root)
+		members = append(members, g.descendants(root)...)
+	}
+	return members, nil
+}
+
+func (m *manager) run(ctx context.Context, dir string, args ...string) (string, error) {
+	result, err := m.executor.Run(ctx, dir, args...)
+	if err != nil {
+		return "", err
+	}
+	if result.ExitCode != 0 {
+		return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), strings.TrimSpace(result.Stderr))
+	}
+	return strings.TrimSpace(result.Stdout), nil
+}
+
+// succeeds runs a git query whose answer is the exit code.
+func (m *manager) succeeds(ctx context.Context, dir string, args ...string) (bool, error) {
+	result, err := m.executor.Run(ctx, dir, args...)
+	if err != nil {
+		return false, err
+	}
+	return result.ExitCode == 0, nil
+}
+
+// load reads every branch.<name>.gz-stack-* entry.
+func (m *manager) load(ctx context.Context, dir string) (*graph, error) {
+	result, err := m.executor.Run(ctx, dir, "config", "--local", "--get-regexp", `^branch\..*\.gz-stack-`)
+	if err != nil {
+		return nil, err
+	}
+	// Exit 1 means no entries.
+	if result.ExitCode > 1 {
+		return nil, fmt.Errorf("read stack config: %s", strings.TrimSpace(result.Stderr))
+	}
+
+	g := &graph{records: map[string]*record{}, children: map[string][]string{}}
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+		name, field, ok := splitKey(key)
+		if !ok {
+			continue
+		}
+		rec := g.records[name]
+		if rec == nil {
+			rec = &record{}
+			g.records[name] = rec
+		}
+		switch field {
+		case parentKey:
+			rec.parent = value
+		case baseKey:
+			rec.base = value
+		}
+	}
+	for name, rec := range g.records {
+		if rec.parent == "" {
+			delete(g.records, name)
+			continue
+		}
+		g.children[rec.parent] = append(g.children[rec.parent], name)
+	}
+	for _, kids := range g.children {
+		sort.Strings(kids)
+	}
+	return g, nil
+}
+
+// splitKey splits "branch.<name>.<field>"; the name may itself contain dots.
+func splitKey(key string) (name, field string, ok bool) {
+	rest, found := strings.CutPrefix(key, "branch.")
+	if !found {
+		return "", "", false
+	}
+	i := strings.LastIndex(rest, ".")
+	if i <= 0 {
+		return "", "", false
+	}
+	return rest[:i], rest[i+1:], true
+}
+
+func (m *manager) setConfig(ctx context.Context, dir, name, field, value string) error {
+	_, err := m.run(ctx, dir, "config", "--local", "branch."+name+"."+field, value)
+	return err
+}
+
+// forget removes a branch's stack records, leaving the rest of its config.
+func (m *manager) forget(ctx context.Context, dir, name string) error {
+	for _, field := range []string{parentKey, baseKey} {
+		// Exit 5 means the key was not set.
+		result, err := m.executor.Run(ctx, dir, "config", "--local", "--unset", "branch."+name+"."+field)
+		if err != nil {
+			return err
+		}
+		if result.ExitCode != 0 && result.ExitCode != 5 {
+			return fmt.Errorf("unset stack config of %s: %s", name, strings.TrimSpace(result.Stderr))
+		}
+	}
+	return nil
+}
+
+// Create creates a branch stacked on a parent.
+func (m *manager) Create(ctx context.Context, repo *repository.Repository, opts CreateOptions) (*Branch, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("repository cannot be nil")
+	}
+	if opts.Name == "" {
+		return nil, fmt.Errorf("branch name is required")
+	}
+
+	parent := opts.Parent
+	if parent == "" {
+		current, err := m.branches.Current(ctx, repo)
+		if err != nil {
+			return nil, fmt.Errorf("cannot stack on the current branch: %w", err)
+		}
+		parent = current.Name
+	}
+	if parent == opts.Name {
+		return nil, fmt.Errorf("a branch cannot be stacked on itself")
+	}
+	if ok, err := m.branches.Exists(ctx, repo, parent); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, fmt.Errorf("parent %s: %w", parent, branch.ErrBranchNotFound)
+	}
+
+	g, err := m.load(ctx, repo.Path)
+	if err != nil {
+		return nil, err
+	}
+	for p := parent; g.records[p] != nil; p = g.records[p].parent {
+		if p == opts.Name {
+			return nil, fmt.Errorf("%w: %s is below %s", ErrCycle, opts.Name, parent)
+		}
+	}
+
+	base, err := m.run(ctx, repo.Path, "rev-parse", "--verify", "refs/heads/"+parent)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Adopt {
+		exists, err := m.branches.Exists(ctx, repo, opts.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, fmt.Errorf("%s: %w", opts.Name, branch.ErrBranchNotFound)
+		}
+		// The branch may have been cut from an older parent commit.
+		base, err = m.run(ctx, repo.Path, "merge-base", "refs/heads/"+parent, "refs/heads/"+opts.Name)
+		if err != nil {
+			return nil, fmt.Errorf("%s shares no history with %s: %w", opts.Name, parent, err)
+		}
+		if opts.Checkout {
+			if _, err := m.run(ctx, repo.Path, "checkout", "-q", opts.Name); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		if err := m.branches.Create(ctx, repo, branch.CreateOptions{
+			Name:     opts.Name,
+			StartRef: parent,
+			Checkout: opts.Checkout,
+			Validate: true,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.setConfig(ctx, repo.Path, opts.Name, parentKey, parent); err != nil {
+		return nil, err
+	}
+	if err := m.setConfig(ctx, repo.Path, opts.Name, baseKey, base); err != nil {
+		return nil, err
+	}
+	return &Branch{Name: opts.Name, Parent: parent, Base: base, Current: opts.Checkout}, nil
+}
+
+// List returns the stacks, grouped under the branches they are rooted on.
+func (m *manager) List(ctx context.Context, repo *repository.Repository) ([]*Branch, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("repository cannot be nil")
+	}
+	g, err := m.load(ctx, repo.Path)
+	if err != nil {
+		return nil, err
+	}
+	current := ""
+	if b, err := m.branches.Current(ctx, repo); err == nil {
+		current = b.Name
+	} else if !errors.Is(err, branch.ErrDetachedHead) {
+		return nil, err
+	}
+	gone, err := m.goneBranches(ctx, repo.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := map[string]*Branch{}
+	var build func(name, parent string) (*Branch, error)
+	build = func(name, parent string) (*Branch, error) {
+		if nodes[name] != nil {
+			return nil, fmt.Errorf("%w at %s", ErrCycle, name)
+		}
+		node := &Branch{Name: name, Parent: parent, Current: name == current}
+		nodes[name] = node
+		if rec := g.records[name]; rec != nil {
+			node.Base = rec.base
+			landed, err := m.landed(ctx, repo.Path, name, rec, gone)
+			if err != nil {
+				return nil, err
+			}
+			stacked, err := m.stacked(ctx, repo.Path, name, rec.parent)
+			if err != nil {
+				return nil, err
+			}
+			node.Landed = landed
+			node.NeedsRestack = !stacked || (nodes[parent] != nil && nodes[parent].Landed)
+		}
+		for _, child := range g.children[name] {
+			kid, err := build(child, name)
+			if err != nil {
+				return nil, err
+			}
+			node.Children = append(node.Children, kid)
+		}
+		return node, nil
+	}
+
+	var trunks []string
+	seen := map[string]bool{}
+	for _, root := range g.roots() {
+		if p := g.records[root].parent; !seen[p] {
+			seen[p] = true
+			trunks = append(trunks, p)
+		}
+	}
+	sort.Strings(trunks)
+
+	out := make([]*Branch, 0, len(trunks))
+	for _, trunk := range trunks {
+		node, err := build(trunk, "")
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, node)
+	}
+	if len(nodes) < len(g.records)+len(trunks) {
+		// Anything not reached from a trunk sits on a loop of parents.
+		for name := range g.records {
+			if nodes[name] == nil {
+				return nil, fmt.Errorf("%w at %s", ErrCycle, name)
+			}
+		}
+	}
+	return out, nil
+}
+
+// exists reports whether refs/heads/name exists.
+func (m *manager) exists(ctx context.Context, dir, name string) (bool, error) {
+	return m.succeeds(ctx, dir, "rev-parse", "--verify", "-q", "refs/heads/"+name)
+}
+
+// stacked reports whether name contains its parent's tip. A parent that no
+// longer exists counts as not stacked.
+func (m *manager) stacked(ctx context.Context, dir, name, parent string) (bool, error) {
+	if ok, err := m.exists(ctx, dir, parent); err != nil || !ok {
+		return false, err
+	}
+	return m.succeeds(ctx, dir, "merge-base", "--is-ancestor", "refs/heads/"+parent, "refs/heads/"+name)
+}
+
+// landed reports whether a stacked branch has been merged into its parent:
+// either its remote branch was deleted after merging, or it has commits of
+// its own and they are all in the parent. A squash merge is only seen the
+// first way.
+func (m *manager) landed(ctx context.Context, dir, name string, rec *record, gone map[string]bool) (bool, error) {
+	if gone[name] {
+		return true, nil
+	}
+	if rec.base == "" {
+		return false, nil
+	}
+	if ok, err := m.exists(ctx, dir, rec.parent); err != nil || !ok {
+		return false, err
+	}
+	own, err := m.run(ctx, dir, "rev-list", "--count", rec.base+"..refs/heads/"+name)
+	if err != nil || own == "0" {
+		return false, err
+	}
+	return m.succeeds(ctx, dir, "merge-base", "--is-ancestor", "refs/heads/"+name, "refs/heads/"+rec.parent)
+}
+
+// goneBranches returns the local branches whose upstream is gone.
+func (m *manager) goneBranches(ctx context.Context, dir string) (map[string]bool, error) {
+	out, err := m.run(ctx, dir, "for-each-ref", "--format=%(refname:short) %(upstream:track)", "refs/heads")
+	if err != nil {
+		return nil, err
+	}
+	gone := map[string]bool{}
+	for _, line := range strings.Split(out, "\n") {
+		if name, track, ok := strings.Cut(line, " "); ok && strings.Contains(track, "[gone]") {
+			gone[name] = true
+		}
+	}
+	return gone, nil
+}
+
+// trunk is the branch to stack on when a recorded parent no longer exists:
+// the remote's default branch, else main or master.
+func (m *manager) trunk(ctx context.Context, dir, remote string) (string, error) {
+	if remote == "" {
+		remote = "origin"
+	}
+	if ref, err := m.run(ctx, dir, "symbolic-ref", "--short", "refs/remotes/"+remote+"/HEAD"); err == nil {
+		name := strings.TrimPrefix(ref, remote+"/")
+		if ok, _ := m.exists(ctx, dir, name); ok {
+			return name, nil
+		}
+	}
+	for _, name := range []string{"main", "master"} {
+		if ok, err := m.exists(ctx, dir, name); err != nil {
+			return "", err
+		} else if ok {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("cannot tell which branch to stack on: no %s/HEAD, main or master", remote)
+}
+
+// currentName is the checked-out branch, or "" when HEAD is detached.
+func (m *manager) currentName(ctx context.Context, repo *repository.Repository) (string, error) {
+	b, err := m.branches.Current(ctx, repo)
+	if errors.Is(err, branch.ErrDetachedHead) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return b.Name, nil
+}
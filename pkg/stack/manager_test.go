@@ -0,0 +1,234 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package stack
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// commit writes name and commits it on the current branch.
+func commit(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git(t, dir, "add", name)
+	git(t, dir, "commit", "-q", "-m", "add "+name)
+}
+
+func newRepo(t *testing.T) (string, *repository.Repository) {
+	t.Helper()
+	dir := t.TempDir()
+	git(t, dir, "init", "-q", "-b", "main")
+	git(t, dir, "config", "user.name", "Test User")
+	git(t, dir, "config", "user.email", "test@example.com")
+	commit(t, dir, "base.txt", "base\n")
+	return dir, &repository.Repository{Path: dir}
+}
+
+// newStack builds main <- a <- b, one commit each, with b checked out.
+func newStack(t *testing.T) (string, *repository.Repository, Manager) {
+	t.Helper()
+	dir, repo := newRepo(t)
+	mgr := NewManager()
+	ctx := context.Background()
+	for _, name := range []string{"a", "b"} {
+		if _, err := mgr.Create(ctx, repo, CreateOptions{Name: name, Checkout: true}); err != nil {
+			t.Fatal(err)
+		}
+		commit(t, dir, name+".txt", name+"\n")
+	}
+	return dir, repo, mgr
+}
+
+func isAncestor(t *testing.T, dir, ancestor, of string) bool {
+	t.Helper()
+	return exec.Command("git", "-C", dir, "merge-base", "--is-ancestor", ancestor, of).Run() == nil
+}
+
+func TestCreateAndList(t *testing.T) {
+	dir, repo, mgr := newStack(t)
+	ctx := context.Background()
+
+	if got := git(t, dir, "config", "branch.b.gz-stack-parent"); got != "a" {
+		t.Errorf("b's parent = %q", got)
+	}
+	if _, err := mgr.Create(ctx, repo, CreateOptions{Name: "a", Parent: "b", Adopt: true}); !errors.Is(err, ErrCycle) {
+		t.Errorf("stacking a on its own child: err = %v", err)
+	}
+
+	git(t, dir, "branch", "side", "a")
+	if _, err := mgr.Create(ctx, repo, CreateOptions{Name: "side", Parent: "a", Adopt: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	trees, err := mgr.List(ctx, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trees) != 1 || trees[0].Name != "main" || len(trees[0].Children) != 1 {
+		t.Fatalf("trees = %+v", trees)
+	}
+	a := trees[0].Children[0]
+	if a.Name != "a" || len(a.Children) != 2 || a.Children[0].Name != "b" || a.Children[1].Name != "side" {
+		t.Fatalf("a = %+v", a)
+	}
+	if !a.Children[0].Current || a.NeedsRestack {
+		t.Errorf("b should be current and a stacked: %+v", a)
+	}
+
+	// Moving main leaves a behind.
+	git(t, dir, "checkout", "-q", "main")
+	commit(t, dir, "main.txt", "main\n")
+	trees, err = mgr.List(ctx, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := trees[0].Children[0]; !a.NeedsRestack || a.Children[0].NeedsRestack {
+		t.Errorf("only a needs a restack: %+v", a)
+	}
+}
+
+func TestRestackAfterParentAmend(t *testing.T) {
+	dir, repo, mgr := newStack(t)
+	ctx := context.Background()
+	if _, err := mgr.Create(ctx, repo, CreateOptions{Name: "c", Checkout: true}); err != nil {
+		t.Fatal(err)
+	}
+	commit(t, dir, "c.txt", "c\n")
+
+	// Amend a, and move main: a needs replaying onto main, and b and c
+	// onto the new a without a's old commit.
+	git(t, dir, "checkout", "-q", "a")
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a amended\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git(t, dir, "commit", "-q", "-a", "--amend", "-m", "add a.txt")
+	git(t, dir, "checkout", "-q", "main")
+	commit(t, dir, "main.txt", "main\n")
+	git(t, dir, "checkout", "-q", "b")
+
+	result, err := mgr.Restack(ctx, repo, RestackOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(result.Rebased, ",") != "a,b,c" {
+		t.Errorf("rebased = %v", result.Rebased)
+	}
+	for _, pair := range [][2]string{{"main", "a"}, {"a", "b"}, {"b", "c"}} {
+		if !isAncestor(t, dir, pair[0], pair[1]) {
+			t.Errorf("%s is not on %s", pair[1], pair[0])
+		}
+	}
+	if n := git(t, dir, "rev-list", "--count", "main..c"); n != "3" {
+		t.Errorf("c has %s commits over main, want 3 (the old a must not be replayed)", n)
+	}
+	if got := git(t, dir, "rev-parse", "--abbrev-ref", "HEAD"); got != "b" {
+		t.Errorf("restack should return to b, on %s", got)
+	}
+	if got := git(t, dir, "config", "branch.c.gz-stack-base"); got != git(t, dir, "rev-parse", "b") {
+		t.Error("c's base should be b's new tip")
+	}
+
+	// Nothing to do the second time.
+	result, err = mgr.Restack(ctx, repo, RestackOptions{})
+	if err != nil || len(result.Rebased) != 0 {
+		t.Errorf("second restack: %v, %+v", err, result)
+	}
+}
+
+func TestRestackConflictStops(t *testing.T) {
+	dir, repo, mgr := newStack(t)
+	ctx := context.Background()
+
+	git(t, dir, "checkout", "-q", "main")
+	commit(t, dir, "a.txt", "main's a\n")
+
+	_, err := mgr.Restack(ctx, repo, RestackOptions{Branch: "a"})
+	if !errors.Is(err, ErrRestackStopped) {
+		t.Fatalf("err = %v", err)
+	}
+	git(t, dir, "rebase", "--abort")
+}
+
+func TestRestackLandedParent(t *testing.T) {
+	dir, repo, mgr := newStack(t)
+	ctx := context.Background()
+
+	// a is squash-merged into main; b still carries a's original commit.
+	git(t, dir, "checkout", "-q", "main")
+	git(t, dir, "merge", "-q", "--squash", "a")
+	git(t, dir, "commit", "-q", "-m", "a (#1)")
+	git(t, dir, "checkout", "-q", "b")
+
+	// A squash merge is only visible as a's remote branch being deleted.
+	remote := t.TempDir()
+	git(t, remote, "init", "-q", "--bare")
+	git(t, dir, "remote", "add", "origin", remote)
+	git(t, dir, "push", "-q", "-u", "origin", "main", "a", "b")
+	git(t, dir, "push", "-q", "origin", "--delete", "a")
+
+	result, err := mgr.Restack(ctx, repo, RestackOptions{Fetch: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Landed) != 1 || result.Landed[0] != "a" {
+		t.Errorf("landed = %v", result.Landed)
+	}
+	if len(result.Reparented) != 1 || result.Reparented[0] != (Reparent{Branch: "b", From: "a", To: "main"}) {
+		t.Errorf("reparented = %+v", result.Reparented)
+	}
+	if got := git(t, dir, "config", "branch.b.gz-stack-parent"); got != "main" {
+		t.Errorf("b's parent = %q", got)
+	}
+	if n := git(t, dir, "rev-list", "--count", "main..b"); n != "1" {
+		t.Errorf("b has %s commits over main, want only its own", n)
+	}
+	if out, err := exec.Command("git", "-C", dir, "config", "branch.a.gz-stack-parent").Output(); err == nil {
+		t.Errorf("a should no longer be stacked, parent %s", out)
+	}
+}
+
+func TestRestackMovesRunWithUpdateRefs(t *testing.T) {
+	dir, repo, mgr := newStack(t)
+	ctx := context.Background()
+	git(t, dir, "checkout", "-q", "main")
+	commit(t, dir, "main.txt", "main\n")
+	oldA := git(t, dir, "rev-parse", "a")
+
+	// a and b are still on top of each other: one rebase of b, with
+	// --update-refs moving a.
+	result, err := mgr.Restack(ctx, repo, RestackOptions{Branch: "main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(result.Rebased, ",") != "a,b" {
+		t.Errorf("rebased = %v", result.Rebased)
+	}
+	if git(t, dir, "rev-parse", "a") == oldA || !isAncestor(t, dir, "main", "a") || !isAncestor(t, dir, "a", "b") {
+		t.Error("a and b should both be on the new main")
+	}
+	if got := git(t, dir, "rev-parse", "--abbrev-ref", "HEAD"); got != "main" {
+		t.Errorf("restack should return to main, on %s", got)
+	}
+}
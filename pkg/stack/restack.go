@@ -0,0 +1,275 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package stack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+// Restack rebases every branch of the selected stacks onto its parent.
+//
+// Branches whose parent landed are moved onto the parent's own parent first,
+// and the landed branch stops being stacked. Then each stack is walked from
+// the bottom. A branch already on top of its parent is left alone; a run of
+// branches that need replaying is rebased in one go, from the top of the
+// run, with --update-refs moving the branches in between. A conflict stops
+// the restack with ErrRestackStopped and the rebase left in progress; once it
+// is continued, restacking again picks up where it stopped.
+func (m *manager) Restack(ctx context.Context, repo *repository.Repository, opts RestackOptions) (*RestackResult, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("repository cannot be nil")
+	}
+	dir := repo.Path
+
+	if opts.Fetch {
+		remote := opts.Remote
+		if remote == "" {
+			remote = "origin"
+		}
+		if _, err := m.run(ctx, dir, "fetch", "--prune", "--quiet", remote); err != nil {
+			return nil, err
+		}
+	}
+
+	current, err := m.currentName(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	head, err := m.run(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := m.load(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	target := opts.Branch
+	if target == "" {
+		target = current
+	}
+	members, err := g.selectStack(target, opts.All)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RestackResult{}
+	roots, err := m.dropLanded(ctx, dir, g, members, opts.Remote, result)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, root := range roots {
+		if err := m.restackChain(ctx, dir, g, root, result); err != nil {
+			return result, err
+		}
+	}
+
+	// The rebases leave the last replayed branch checked out.
+	restore := []string{"checkout", "-q", current}
+	if current == "" {
+		restore = []string{"checkout", "-q", "--detach", head}
+	}
+	if _, err := m.run(ctx, dir, restore...); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// dropLanded moves the children of landed branches onto the landed branch's
+// parent, parents first so a run of landed branches collapses, and returns
+// the bottom branches of the stacks that are left.
+func (m *manager) dropLanded(ctx context.Context, dir string, g *graph, members []string, remote string, result *RestackResult) ([]string, error) {
+	gone, err := m.goneBranches(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var roots []string
+	for _, name := range members {
+		rec := g.records[name]
+		if rec == nil {
+			continue
+		}
+
+		parentExists, err := m.exists(ctx, dir, rec.parent)
+		if err != nil {
+			return nil, err
+		}
+		if !parentExists {
+			// Deleting a branch deletes its stack records with it, so
+			// where a deleted parent was stacked is unknown.
+			to, err := m.trunk(ctx, dir, remote)
+			if err != nil {
+				return nil, fmt.Errorf("parent %s of %s is gone: %w", rec.parent, name, err)
+			}
+			if err := m.reparent(ctx, dir, g, name, to, result); err != nil {
+				return nil, err
+			}
+		}
+
+		landed, err := m.landed(ctx, dir, name, rec, gone)
+		if err != nil {
+			return nil, err
+		}
+		if !landed {
+			if g.records[rec.parent] == nil {
+				roots = append(roots, name)
+			}
+			continue
+		}
+
+		result.Landed = append(result.Landed, name)
+		for _, child := range append([]string(nil), g.children[name]...) {
+			if err := m.reparent(ctx, dir, g, child, rec.parent, result); err != nil {
+				return nil, err
+			}
+		}
+		if err := m.forget(ctx, dir, name); err != nil {
+			return nil, err
+		}
+		g.remove(name)
+	}
+	return roots, nil
+}
+
+// reparent records a new parent for name, keeping its base: the base is the
+// old parent's commit, so the next rebase drops the old parent's commits.
+func (m *manager) reparent(ctx context.Context, dir string, g *graph, name, to string, result *RestackResult) error {
+	rec := g.records[name]
+	if err := m.setConfig(ctx, dir, name, parentKey, to); err != nil {
+		return err
+	}
+	result.Reparented = append(result.Reparented, Reparent{Branch: name, From: rec.parent, To: to})
+	g.unlink(name)
+	rec.parent = to
+	g.children[to] = append(g.children[to], name)
+	return nil
+}
+
+// unlink removes name from its parent's children.
+func (g *graph) unlink(name string) {
+	parent := g.records[name].parent
+	kids := g.children[parent][:0]
+	for _, kid := range g.children[parent] {
+		if kid != name {
+			kids = append(kids, kid)
+		}
+	}
+	g.children[parent] = kids
+}
+
+// remove drops a branch that is no longer stacked.
+func (g *graph) remove(name string) {
+	g.unlink(name)
+	delete(g.records, name)
+	delete(g.children, name)
+}
+
+// restackChain restacks start and everything stacked on it. The chain is
+// start and the branches above it that have no siblings; it ends at a
+// branch with several children, each of which starts a chain of its own.
+func (m *manager) restackChain(ctx context.Context, dir string, g *graph, start string, result *RestackResult) error {
+	chain := []string{start}
+	for kids := g.children[start]; len(kids) == 1; kids = g.children[kids[0]] {
+		chain = append(chain, kids[0])
+	}
+
+	for i := 0; i < len(chain); {
+		stacked, err := m.stacked(ctx, dir, chain[i], g.records[chain[i]].parent)
+		if err != nil {
+			return err
+		}
+		if stacked {
+			if err := m.refreshBase(ctx, dir, g, chain[i]); err != nil {
+				return err
+			}
+			i++
+			continue
+		}
+
+		// Extend the run over the branches still on top of each other.
+		j := i
+		for j+1 < len(chain) {
+			ok, err := m.stacked(ctx, dir, chain[j+1], chain[j])
+			if err != nil {
+				return err
+			}
+			if !ok {
+				break
+			}
+			j++
+		}
+		if err := m.rebase(ctx, dir, g, chain[i], chain[j]); err != nil {
+			return err
+		}
+		for _, name := range chain[i : j+1] {
+			result.Rebased = append(result.Rebased, name)
+			if err := m.refreshBase(ctx, dir, g, name); err != nil {
+				return err
+			}
+		}
+		i = j + 1
+	}
+
+	last := chain[len(chain)-1]
+	for _, kid := range append([]string(nil), g.children[last]...) {
+		if err := m.restackChain(ctx, dir, g, kid, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rebase replays first..top onto first's parent, dropping everything up to
+// first's recorded base.
+func (m *manager) rebase(ctx context.Context, dir string, g *graph, first, top string) error {
+	rec := g.records[first]
+	upstream := rec.base
+	if upstream == "" {
+		base, err := m.run(ctx, dir, "merge-base", "refs/heads/"+rec.parent, "refs/heads/"+first)
+		if err != nil {
+			return err
+		}
+		upstream = base
+	}
+
+	result, err := m.executor.Run(ctx, dir, "rebase", "--quiet", "--update-refs", "--onto", rec.parent, upstream, top)
+	if err != nil {
+		return err
+	}
+	if result.ExitCode == 0 {
+		return nil
+	}
+	if stopped, _ := m.succeeds(ctx, dir, "rev-parse", "-q", "--verify", "REBASE_HEAD"); stopped {
+		return fmt.Errorf("%w restacking %s onto %s: resolve them, run git rebase --continue, then restack again", ErrRestackStopped, first, rec.parent)
+	}
+	return fmt.Errorf("restack %s onto %s: %s", first, rec.parent, firstLine(result.Stderr))
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
+
+// refreshBase records the parent's current tip as name's base.
+func (m *manager) refreshBase(ctx context.Context, dir string, g *graph, name string) error {
+	rec := g.records[name]
+	tip, err := m.run(ctx, dir, "rev-parse", "--verify", "refs/heads/"+rec.parent)
+	if err != nil {
+		return err
+	}
+	if tip == rec.base {
+		return nil
+	}
+	if err := m.setConfig(ctx, dir, name, baseKey, tip); err != nil {
+		return err
+	}
+	rec.base = tip
+	return nil
+}
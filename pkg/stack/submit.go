@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package stack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/provider"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+// Submit opens a pull request for each branch of the selected stacks with
+// the branch's parent as its base, parents first. A branch that already has
+// an open pull request against another base - its parent landed and it was
+// restacked onto the parent's parent - has it retargeted, which needs a
+// requester that is also a provider.PullRequestBaseUpdater.
+//
+// The stack must be restacked first: a branch that is not on top of its
+// parent, or whose parent landed, fails the submit with ErrNeedsRestack
+// before anything is pushed. The outcomes of the branches handled before an
+// error are returned with it.
+func (m *manager) Submit(ctx context.Context, repo *repository.Repository, requester provider.PullRequester, opts SubmitOptions) ([]SubmitOutcome, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("repository cannot be nil")
+	}
+	if requester == nil {
+		return nil, fmt.Errorf("pull requester cannot be nil")
+	}
+	dir := repo.Path
+	remote := opts.Remote
+	if remote == "" {
+		remote = "origin"
+	}
+
+	g, err := m.load(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	target := opts.Branch
+	if target == "" {
+		if target, err = m.currentName(ctx, repo); err != nil {
+			return nil, err
+		}
+	}
+	members, err := g.selectStack(target, opts.All)
+	if err != nil {
+		return nil, err
+	}
+
+	gone, err := m.goneBranches(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	var behind []string
+	for _, name := range members {
+		rec := g.records[name]
+		stacked, err := m.stacked(ctx, dir, name, rec.parent)
+		if err != nil {
+			return nil, err
+		}
+		landed := false
+		if parent := g.records[rec.parent]; parent != nil {
+			if landed, err = m.landed(ctx, dir, rec.parent, parent, gone); err != nil {
+				return nil, err
+			}
+		}
+		if !stacked || landed {
+			behind = append(behind, name)
+		}
+	}
+	if len(behind) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNeedsRestack, strings.Join(behind, ", "))
+	}
+
+	updater, canRetarget := requester.(provider.PullRequestBaseUpdater)
+	urls := map[string]string{}
+	outcomes := make([]SubmitOutcome, 0, len(members))
+	for _, name := range members {
+		parent := g.records[name].parent
+		out := SubmitOutcome{Branch: name, Base: parent}
+
+		if !opts.NoPush && !opts.DryRun {
+			if _, err := m.run(ctx, dir, "push", "--quiet", "--force-with-lease", "--set-upstream", remote, name); err != nil {
+				return outcomes, fmt.Errorf("push %s: %w", name, err)
+			}
+		}
+
+		existing, err := requester.FindPullRequest(ctx, opts.Owner, opts.Repo, name, "")
+		switch {
+		case err == nil && existing.Base == parent:
+			out.Action = "exists"
+		case err == nil && opts.DryRun:
+			out.Action = "would retarget"
+		case err == nil:
+			if !canRetarget {
+				return outcomes, fmt.Errorf("%s: pull request #%d targets %s, and the provider cannot change its base", name, existing.Number, existing.Base)
+			}
+			if existing, err = updater.UpdatePullRequestBase(ctx, opts.Owner, opts.Repo, existing.Number, parent); err != nil {
+				return outcomes, fmt.Errorf("%s: %w", name, err)
+			}
+			out.Action = "retargeted"
+		case !errors.Is(err, provider.ErrPullRequestNotFound):
+			return outcomes, fmt.Errorf("%s: %w", name, err)
+		case opts.DryRun:
+			out.Action = "would create"
+		default:
+			title, err := m.title(ctx, dir, name, g.records[name].base)
+			if err != nil {
+				return outcomes, err
+			}
+			body := "Stacked on `" + parent + "`."
+			if url := urls[parent]; url != "" {
+				body = "Stacked on " + url + "."
+			}
+			existing, err = requester.CreatePullRequest(ctx, provider.CreatePullRequestInput{
+				Owner: opts.Owner,
+				Repo:  opts.Repo,
+				Title: title,
+				Body:  body,
+				Head:  name,
+				Base:  parent,
+				Draft: opts.Draft,
+			})
+			if err != nil {
+				return outcomes, fmt.Errorf("%s: %w", name, err)
+			}
+			out.Action = "created"
+		}
+
+		if existing != nil {
+			out.Number, out.URL = existing.Number, existing.URL
+			urls[name] = existing.URL
+		}
+		outcomes = append(outcomes, out)
+	}
+	return outcomes, nil
+}
+
+// title is the subject of the branch's first commit of its own, or the
+// branch name when it has none.
+func (m *manager) title(ctx context.Context, dir, name, base string) (string, error) {
+	if base == "" {
+		return name, nil
+	}
+	subjects, err := m.run(ctx, dir, "log", "--reverse", "--format=%s", base+"..refs/heads/"+name)
+	if err != nil {
+		return "", err
+	}
+	if first := firstLine(subjects); first != "" {
+		return first, nil
+	}
+	return name, nil
+}
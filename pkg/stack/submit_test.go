@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package stack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/provider"
+)
+
+// fakeForge keeps open pull requests by head branch.
+type fakeForge struct {
+	prs        map[string]*provider.PullRequest
+	created    []provider.CreatePullRequestInput
+	retargeted []string
+}
+
+func (f *fakeForge) CreatePullRequest(_ context.Context, in provider.CreatePullRequestInput) (*provider.PullRequest, error) {
+	f.created = append(f.created, in)
+	pr := &provider.PullRequest{Number: len(f.prs) + 1, Head: in.Head, Base: in.Base, Title: in.Title}
+	pr.URL = fmt.Sprintf("https://forge.example/acme/app/pull/%d", pr.Number)
+	f.prs[in.Head] = pr
+	return pr, nil
+}
+
+func (f *fakeForge) FindPullRequest(_ context.Context, _, _, head, base string) (*provider.PullRequest, error) {
+	if pr := f.prs[head]; pr != nil && (base == "" || pr.Base == base) {
+		return pr, nil
+	}
+	return nil, provider.ErrPullRequestNotFound
+}
+
+func (f *fakeForge) UpdatePullRequestBase(_ context.Context, _, _ string, number int, base string) (*provider.PullRequest, error) {
+	for _, pr := range f.prs {
+		if pr.Number == number {
+			pr.Base = base
+			f.retargeted = append(f.retargeted, pr.Head)
+			return pr, nil
+		}
+	}
+	return nil, provider.ErrPullRequestNotFound
+}
+
+func TestSubmit(t *testing.T) {
+	dir, repo, mgr := newStack(t)
+	ctx := context.Background()
+	forge := &fakeForge{prs: map[string]*provider.PullRequest{}}
+	opts := SubmitOptions{Owner: "acme", Repo: "app", NoPush: true}
+
+	outcomes, err := mgr.Submit(ctx, repo, forge, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outcomes) != 2 || outcomes[0].Action != "created" || outcomes[1].Base != "a" {
+		t.Fatalf("outcomes = %+v", outcomes)
+	}
+	if in := forge.created[1]; in.Base != "a" || in.Title != "add b.txt" || in.Body != "Stacked on https://forge.example/acme/app/pull/1." {
+		t.Errorf("b's pull request = %+v", in)
+	}
+
+	outcomes, err = mgr.Submit(ctx, repo, forge, opts)
+	if err != nil || outcomes[0].Action != "exists" || outcomes[1].Action != "exists" {
+		t.Fatalf("resubmit: %v %+v", err, outcomes)
+	}
+
+	// a lands; b is behind until restacked, then its PR is retargeted.
+	git(t, dir, "checkout", "-q", "main")
+	git(t, dir, "merge", "-q", "--no-ff", "-m", "Merge a", "a")
+	git(t, dir, "checkout", "-q", "b")
+	delete(forge.prs, "a")
+	if _, err := mgr.Submit(ctx, repo, forge, opts); !errors.Is(err, ErrNeedsRestack) {
+		t.Fatalf("submit before restack: err = %v", err)
+	}
+	if _, err := mgr.Restack(ctx, repo, RestackOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	dry := opts
+	dry.DryRun = true
+	outcomes, err = mgr.Submit(ctx, repo, forge, dry)
+	if err != nil || len(outcomes) != 1 || outcomes[0].Action != "would retarget" || len(forge.retargeted) != 0 {
+		t.Fatalf("dry run: %v %+v", err, outcomes)
+	}
+	outcomes, err = mgr.Submit(ctx, repo, forge, opts)
+	if err != nil || outcomes[0].Action != "retargeted" || forge.prs["b"].Base != "main" {
+		t.Fatalf("retarget: %v %+v", err, outcomes)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package stack
+
+import "errors"
+
+var (
+	// ErrNotStacked indicates the branch has no recorded parent.
+	ErrNotStacked = errors.New("branch is not part of a stack")
+
+	// ErrRestackStopped indicates a restack rebase stopped on conflicts.
+	ErrRestackStopped = errors.New("restack stopped on conflicts")
+
+	// ErrNeedsRestack indicates a branch is not on top of its parent.
+	ErrNeedsRestack = errors.New("stack needs a restack")
+
+	// ErrCycle indicates the recorded parents form a loop.
+	ErrCycle = errors.New("stack parents form a cycle")
+)
+
+// Branch is one stacked branch and the branches stacked on it.
+type Branch struct {
+	Name   string `json:"name"`
+	Parent string `json:"parent"`
+
+	// Base is the parent commit the branch was last stacked on.
+	Base string `json:"base,omitempty"`
+
+	// NeedsRestack is set when the parent has moved since, or has landed.
+	NeedsRestack bool `json:"needsRestack,omitempty"`
+
+	// Landed is set when the branch itself has been merged into its parent.
+	Landed bool `json:"landed,omitempty"`
+
+	Current  bool      `json:"current,omitempty"`
+	Children []*Branch `json:"children,omitempty"`
+}
+
+// CreateOptions configures creating a stacked branch.
+type CreateOptions struct {
+	// Name is the branch to create.
+	Name string
+
+	// Parent is the branch to stack on (default: the current branch).
+	Parent string
+
+	// Checkout switches to the new branch.
+	Checkout bool
+
+	// Adopt records Parent for an existing branch instead of creating one.
+	Adopt bool
+}
+
+// RestackOptions configures a restack.
+type RestackOptions struct {
+	// Branch selects the stack to restack by any branch in it (default: the
+	// current branch).
+	Branch string
+
+	// All restacks every stack in the repository.
+	All bool
+
+	// Fetch runs `git fetch --prune` first, so branches whose remote branch
+	// was deleted after merging are recognized as landed.
+	Fetch bool
+
+	// Remote to fetch from, and whose default branch a stack falls back to
+	// when a parent was deleted (default: origin).
+	Remote string
+}
+
+// Reparent records a branch moved off a parent that landed.
+type Reparent struct {
+	Branch string `json:"branch"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+// RestackResult reports what a restack did.
+type RestackResult struct {
+	// Rebased lists branches that were replayed onto their parent, including
+	// those moved by --update-refs.
+	Rebased []string `json:"rebased,omitempty"`
+
+	// Reparented lists branches whose parent had landed.
+	Reparented []Reparent `json:"reparented,omitempty"`
+
+	// Landed lists branches that were merged and are no longer stacked.
+	Landed []string `json:"landed,omitempty"`
+}
+
+// SubmitOptions configures submitting a stack as pull requests.
+type SubmitOptions struct {
+	Owner string
+	Repo  string
+
+	// Branch and All select stacks as in RestackOptions.
+	Branch string
+	All    bool
+
+	// Remote to push to (default: origin). NoPush skips pushing.
+	Remote string
+	NoPush bool
+
+	Draft  bool
+	DryRun bool
+}
+
+// SubmitOutcome is what happened to one branch's pull request.
+type SubmitOutcome struct {
+	Branch string `json:"branch"`
+	Base   string `json:"base"`
+
+	// Action is "created", "exists", "retargeted", or "would create" /
+	// "would retarget" on a dry run.
+	Action string `json:"action"`
+	Number int    `json:"number,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
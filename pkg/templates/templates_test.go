@@ -6,6 +6,8 @@ package templates
 import (
 	"strings"
 	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
 )
 
 func TestGetRaw(t *testing.T) {
@@ -214,3 +216,59 @@ func TestRender_ForgeGenerated(t *testing.T) {
 		}
 	}
 }
+
+// TestRender_MatchesSchema keeps generated configs free of schema errors, so
+// `workspace validate` and editors accept what gz-git itself writes.
+func TestRender_MatchesSchema(t *testing.T) {
+	tests := []struct {
+		name TemplateName
+		kind config.SchemaKind
+		data any
+	}{
+		{RepositoriesBasic, config.SchemaKindConfig, RepositoriesBasicData{
+			Name: "devbox", Team: "backend", Description: "Test devbox", Strategy: "pull", Parallel: 4,
+			Repositories: []RepoData{{Name: "a", URL: "git@github.com:org/a.git", Path: "./a", Branch: "develop", Description: "A"}},
+		}},
+		{RepositoriesScanned, config.SchemaKindConfig, ScannedData{
+			CommonScannedConfig: CommonScannedConfig{ScannedAt: "2025-01-23T10:00:00Z", Count: 1, Strategy: "reset", Parallel: 4, MaxRetries: 3, CloneProto: "ssh"},
+			BasePath:            ".",
+			Repositories:        []ScannedRepoData{{Name: "a", Path: "./a", URL: "git@github.com:org/a.git", AdditionalRemotes: map[string]string{"backup": "git@gitlab.com:org/a.git"}}},
+		}},
+		{RepositoriesForge, config.SchemaKindConfig, ForgeGeneratedData{
+			GeneratedAt: "2025-01-23T10:00:00Z", Provider: "gitlab", Organization: "myorg", Strategy: "reset", Parallel: 4, MaxRetries: 3, CloneProto: "ssh", SSHPort: 2224,
+			Repositories: []ForgeRepoData{{Name: "a", URL: "git@gitlab.com:myorg/a.git", Path: "./myorg/a"}},
+		}},
+		{WorkspaceScanned, config.SchemaKindConfig, WorkspaceScannedData{
+			CommonScannedConfig: CommonScannedConfig{ScannedAt: "2025-01-23T10:00:00Z", Count: 1, Strategy: "reset", Parallel: 4, MaxRetries: 3, CloneProto: "ssh"},
+			Name:                "devbox",
+			Workspaces:          []WorkspaceScannedEntry{{Name: "a", Path: "a", URL: "git@github.com:org/a.git", Type: "git", Branch: "main"}},
+		}},
+		{Profile, config.SchemaKindProfile, map[string]any{
+			"Name": "work", "Provider": "gitlab", "Token": "${TOKEN}", "CloneProto": "ssh", "Parallel": 4,
+			"Sync": &SyncData{Strategy: "pull", MaxRetries: 3},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.name), func(t *testing.T) {
+			out, err := Render(tt.name, tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			schema, err := config.GenerateSchema(tt.kind)
+			if err != nil {
+				t.Fatal(err)
+			}
+			errs, err := schema.ValidateYAML([]byte(out))
+			if err != nil {
+				t.Fatalf("%v\n%s", err, out)
+			}
+			for _, e := range errs {
+				t.Errorf("%s", e)
+			}
+			if t.Failed() {
+				t.Logf("rendered:\n%s", out)
+			}
+		})
+	}
+}
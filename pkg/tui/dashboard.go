@@ -0,0 +1,597 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/identity"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/watch"
+)
+
+// DashboardFilter narrows the dashboard's repository list.
+type DashboardFilter string
+
+// DashboardFilter values, bound to the number keys 0-5.
+const (
+	DashboardAll        DashboardFilter = ""            // every repository
+	DashboardDirty      DashboardFilter = "dirty"       // uncommitted or untracked changes
+	DashboardAhead      DashboardFilter = "ahead"       // unpushed commits
+	DashboardBehind     DashboardFilter = "behind"      // upstream commits not pulled
+	DashboardConflict   DashboardFilter = "conflict"    // unmerged paths or a merge/rebase in progress
+	DashboardStaleStash DashboardFilter = "stale-stash" // oldest stash older than StaleStashAfter
+)
+
+// DefaultStaleStashAfter is the stash age the stale-stash filter uses when
+// DashboardOptions leaves it unset. It matches info --audit.
+const DefaultStaleStashAfter = 14 * 24 * time.Hour
+
+// DashboardOptions configures the dashboard. Directory, MaxDepth,
+// IncludeSubmodules and the patterns describe the scan the rows came from;
+// every Bulk* call the dashboard makes repeats that scan, narrowed to the
+// selected repositories.
+type DashboardOptions struct {
+	Directory         string
+	MaxDepth          int
+	Parallel          int
+	IncludeSubmodules bool
+	IncludePattern    string
+	ExcludePattern    string
+
+	// StaleStashAfter is how old a repository's oldest stash must be for the
+	// stale-stash filter to show it. Zero means DefaultStaleStashAfter.
+	StaleStashAfter time.Duration
+
+	// Events, when set, streams watcher events. Each one re-checks the
+	// repository it names, which is what keeps the list live without
+	// re-scanning the tree.
+	Events <-chan watch.Event
+
+	// PushPolicy and Identity guard pushes exactly as they do for
+	// `gz-git push`.
+	PushPolicy *repository.PushPolicy
+	Identity   identity.Identity
+}
+
+// dashboardMode is which screen the dashboard shows.
+type dashboardMode int
+
+const (
+	modeRepos   dashboardMode = iota // repository list
+	modeFiles                        // changed files of one repository
+	modeDiff                         // diff of one file
+	modePrompt                       // text input for commit or switch
+	modeConfirm                      // y/n before a destructive action
+)
+
+// dashboardFile is one changed file in the drill-down view.
+type dashboardFile struct {
+	Path   string
+	Status string // git's short code: M, A, D, R, ?? ...
+}
+
+// logEntry is one line of the command log pane.
+type logEntry struct {
+	At     time.Time
+	Text   string
+	Failed bool
+}
+
+// maxLogEntries bounds the command log; the pane only ever shows the tail.
+const maxLogEntries = 200
+
+// DashboardModel is the bubbletea model behind `gz-git tui`. Unlike
+// StatusModel, which hands the selection back to its caller and exits, the
+// dashboard runs actions itself and stays open: every action is one Bulk*
+// call over the selected repositories, its outcome goes to the command log,
+// and the affected rows are re-checked when it finishes.
+type DashboardModel struct {
+	ctx    context.Context
+	client repository.Client
+	opts   DashboardOptions
+
+	rows     []repository.RepositoryStatusResult // every repository, scan order
+	visible  []int                               // indexes into rows after the filter
+	selected map[string]bool                     // repo path -> selected
+	cursor   int                                 // index into visible
+	filter   DashboardFilter
+
+	mode dashboardMode
+
+	// Drill-down state.
+	filesRepo  string // path of the repository being inspected
+	files      []dashboardFile
+	fileCursor int
+	diffText   string // full diff of filesRepo
+	diffLines  []string
+	diffOffset int
+
+	// Prompt and confirm state.
+	pending     string   // action waiting for input: "commit", "switch", "cleanup"
+	pendingRepo []string // targets captured when the prompt opened
+	input       string
+
+	log     []logEntry
+	running int // actions in flight
+
+	width  int
+	height int
+	ready  bool
+}
+
+// NewDashboardModel creates a dashboard over rows, typically the result of a
+// BulkStatus with the same options.
+func NewDashboardModel(ctx context.Context, client repository.Client, rows []repository.RepositoryStatusResult, opts DashboardOptions) DashboardModel {
+	if opts.StaleStashAfter <= 0 {
+		opts.StaleStashAfter = DefaultStaleStashAfter
+	}
+	m := DashboardModel{
+		ctx:      ctx,
+		client:   client,
+		opts:     opts,
+		rows:     rows,
+		selected: make(map[string]bool),
+	}
+	m.applyFilter()
+	return m
+}
+
+// Messages the dashboard sends itself from tea.Cmds.
+type (
+	// watchEventMsg carries one watcher event; ok is false once the channel
+	// is closed.
+	watchEventMsg struct {
+		event watch.Event
+		ok    bool
+	}
+
+	// repoStatusMsg replaces one row.
+	repoStatusMsg struct {
+		status repository.RepositoryStatusResult
+	}
+
+	// rescanMsg replaces every row.
+	rescanMsg struct {
+		rows []repository.RepositoryStatusResult
+		err  error
+	}
+
+	// actionDoneMsg reports a finished Bulk* call.
+	actionDoneMsg struct {
+		action   string
+		targets  []string
+		outcomes []actionOutcome
+		summary  map[string]int
+		err      error
+	}
+
+	// filesMsg carries the drill-down of one repository.
+	filesMsg struct {
+		path  string
+		files []dashboardFile
+		diff  string
+		err   error
+	}
+)
+
+// Init starts listening for watcher events.
+func (m DashboardModel) Init() tea.Cmd {
+	return m.waitForEvent()
+}
+
+// waitForEvent blocks on the next watcher event. It is re-armed after every
+// event, so exactly one is outstanding at a time.
+func (m DashboardModel) waitForEvent() tea.Cmd {
+	if m.opts.Events == nil {
+		return nil
+	}
+	events := m.opts.Events
+	return func() tea.Msg {
+		event, ok := <-events
+		return watchEventMsg{event: event, ok: ok}
+	}
+}
+
+// Update handles all messages and updates the model state.
+func (m DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.ready = true
+		return m, nil
+
+	case watchEventMsg:
+		if !msg.ok {
+			m.addLog("watcher stopped; press r to refresh by hand", true)
+			return m, nil
+		}
+		return m, tea.Batch(m.checkRepos([]string{msg.event.Path}), m.waitForEvent())
+
+	case repoStatusMsg:
+		m.setRow(msg.status)
+		return m, nil
+
+	case rescanMsg:
+		m.running--
+		if msg.err != nil {
+			m.addLog("refresh failed: "+msg.err.Error(), true)
+			return m, nil
+		}
+		m.rows = msg.rows
+		m.applyFilter()
+		m.addLog(pluralize(len(msg.rows), "repository", "repositories")+" rescanned", false)
+		return m, nil
+
+	case actionDoneMsg:
+		m.running--
+		m.logAction(msg)
+		return m, m.checkRepos(msg.targets)
+
+	case filesMsg:
+		if msg.path != m.filesRepo {
+			return m, nil // the user already left this repository
+		}
+		if msg.err != nil {
+			m.addLog("diff "+m.displayName(msg.path)+": "+msg.err.Error(), true)
+			m.mode = modeRepos
+			return m, nil
+		}
+		m.files = msg.files
+		m.diffText = msg.diff
+		m.fileCursor = 0
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		switch m.mode {
+		case modeFiles:
+			return m.updateFiles(msg)
+		case modeDiff:
+			return m.updateDiff(msg)
+		case modePrompt:
+			return m.updatePrompt(msg)
+		case modeConfirm:
+			return m.updateConfirm(msg)
+		case modeRepos:
+			return m.updateRepos(msg)
+		}
+	}
+	return m, nil
+}
+
+// updateRepos handles keys on the repository list.
+func (m DashboardModel) updateRepos(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.visible)-1 {
+			m.cursor++
+		}
+	case "home", "g":
+		m.cursor = 0
+	case "end", "G":
+		m.cursor = max(len(m.visible)-1, 0)
+
+	case " ":
+		if row, ok := m.current(); ok {
+			m.selected[row.Path] = !m.selected[row.Path]
+			if !m.selected[row.Path] {
+				delete(m.selected, row.Path)
+			}
+		}
+	case "a":
+		for _, i := range m.visible {
+			m.selected[m.rows[i].Path] = true
+		}
+	case "n":
+		m.selected = make(map[string]bool)
+
+	case "enter":
+		row, ok := m.current()
+		if !ok {
+			return m, nil
+		}
+		m.mode = modeFiles
+		m.filesRepo = row.Path
+		m.files = nil
+		m.diffText = ""
+		return m, m.loadFiles(row.Path)
+
+	case "r":
+		m.running++
+		return m, m.rescan()
+
+	case "f":
+		return m.startAction("fetch", "")
+	case "p":
+		return m.startAction("pull", "")
+	case "P":
+		return m.startAction("push", "")
+	case "s":
+		return m.startAction("stash", "")
+	case "c":
+		return m.openPrompt("commit")
+	case "b":
+		return m.openPrompt("switch")
+	case "x":
+		if targets := m.targets(); len(targets) > 0 {
+			m.mode = modeConfirm
+			m.pending = "cleanup"
+			m.pendingRepo = targets
+		}
+
+	case "0":
+		m.setFilter(DashboardAll)
+	case "1":
+		m.toggleFilter(DashboardDirty)
+	case "2":
+		m.toggleFilter(DashboardAhead)
+	case "3":
+		m.toggleFilter(DashboardBehind)
+	case "4":
+		m.toggleFilter(DashboardConflict)
+	case "5":
+		m.toggleFilter(DashboardStaleStash)
+	}
+	return m, nil
+}
+
+// updateFiles handles keys on the changed-file list.
+func (m DashboardModel) updateFiles(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "backspace", "left", "h":
+		m.mode = modeRepos
+		m.filesRepo = ""
+	case "up", "k":
+		if m.fileCursor > 0 {
+			m.fileCursor--
+		}
+	case "down", "j":
+		if m.fileCursor < len(m.files)-1 {
+			m.fileCursor++
+		}
+	case "enter", "right", "l":
+		if m.fileCursor < len(m.files) {
+			m.mode = modeDiff
+			m.diffLines = splitLines(fileDiff(m.diffText, m.files[m.fileCursor].Path))
+			m.diffOffset = 0
+		}
+	}
+	return m, nil
+}
+
+// updateDiff handles keys on the diff view.
+func (m DashboardModel) updateDiff(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	page := m.diffHeight()
+	last := max(len(m.diffLines)-page, 0)
+	switch msg.String() {
+	case "q", "esc", "backspace", "left", "h":
+		m.mode = modeFiles
+	case "up", "k":
+		m.diffOffset = max(m.diffOffset-1, 0)
+	case "down", "j":
+		m.diffOffset = min(m.diffOffset+1, last)
+	case "pgup", "b":
+		m.diffOffset = max(m.diffOffset-page, 0)
+	case "pgdown", "f", " ":
+		m.diffOffset = min(m.diffOffset+page, last)
+	case "home", "g":
+		m.diffOffset = 0
+	case "end", "G":
+		m.diffOffset = last
+	}
+	return m, nil
+}
+
+// updatePrompt handles typing into the commit-message or branch prompt.
+func (m DashboardModel) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.closePrompt()
+	case tea.KeyEnter:
+		if m.input == "" {
+			return m, nil
+		}
+		action, input, targets := m.pending, m.input, m.pendingRepo
+		m.closePrompt()
+		return m.runAction(action, input, targets)
+	case tea.KeyBackspace:
+		if r := []rune(m.input); len(r) > 0 {
+			m.input = string(r[:len(r)-1])
+		}
+	case tea.KeySpace:
+		m.input += " "
+	case tea.KeyRunes:
+		m.input += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// updateConfirm handles the y/n question before a cleanup.
+func (m DashboardModel) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		action, targets := m.pending, m.pendingRepo
+		m.closePrompt()
+		return m.runAction(action, "", targets)
+	default:
+		m.closePrompt()
+	}
+	return m, nil
+}
+
+// openPrompt asks for the commit message or branch name of action.
+func (m DashboardModel) openPrompt(action string) (tea.Model, tea.Cmd) {
+	targets := m.targets()
+	if len(targets) == 0 {
+		return m, nil
+	}
+	m.mode = modePrompt
+	m.pending = action
+	m.pendingRepo = targets
+	m.input = ""
+	return m, nil
+}
+
+func (m *DashboardModel) closePrompt() {
+	m.mode = modeRepos
+	m.pending = ""
+	m.pendingRepo = nil
+	m.input = ""
+}
+
+// startAction runs an action that needs no input on the current targets.
+func (m DashboardModel) startAction(action, input string) (tea.Model, tea.Cmd) {
+	return m.runAction(action, input, m.targets())
+}
+
+// runAction logs the start of action and returns the command that runs it.
+func (m DashboardModel) runAction(action, input string, targets []string) (tea.Model, tea.Cmd) {
+	if len(targets) == 0 {
+		return m, nil
+	}
+	m.running++
+	m.addLog(action+" "+m.describeTargets(targets)+"…", false)
+	return m, m.actionCmd(action, input, targets)
+}
+
+// targets returns the selected repositories, or the one under the cursor
+// when nothing is selected, in scan order.
+func (m DashboardModel) targets() []string {
+	if len(m.selected) == 0 {
+		if row, ok := m.current(); ok {
+			return []string{row.Path}
+		}
+		return nil
+	}
+	paths := make([]string, 0, len(m.selected))
+	for _, row := range m.rows {
+		if m.selected[row.Path] {
+			paths = append(paths, row.Path)
+		}
+	}
+	return paths
+}
+
+// current returns the row under the cursor.
+func (m DashboardModel) current() (repository.RepositoryStatusResult, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return repository.RepositoryStatusResult{}, false
+	}
+	return m.rows[m.visible[m.cursor]], true
+}
+
+// setRow replaces the row for status.Path. A repository the dashboard has
+// not seen (a watcher event from outside the scan) is ignored.
+func (m *DashboardModel) setRow(status repository.RepositoryStatusResult) {
+	for i := range m.rows {
+		if m.rows[i].Path == status.Path {
+			if status.RelativePath == "" {
+				status.RelativePath = m.rows[i].RelativePath
+			}
+			m.rows[i] = status
+			m.applyFilter()
+			return
+		}
+	}
+}
+
+// toggleFilter switches to filter, or back to all when it is already on.
+func (m *DashboardModel) toggleFilter(filter DashboardFilter) {
+	if m.filter == filter {
+		filter = DashboardAll
+	}
+	m.setFilter(filter)
+}
+
+func (m *DashboardModel) setFilter(filter DashboardFilter) {
+	m.filter = filter
+	m.applyFilter()
+}
+
+// applyFilter recomputes the visible rows. The cursor stays on the same
+// repository when it is still visible, so a live update does not move it.
+func (m *DashboardModel) applyFilter() {
+	var keep string
+	if row, ok := m.current(); ok {
+		keep = row.Path
+	}
+
+	now := time.Now()
+	visible := make([]int, 0, len(m.rows))
+	for i, row := range m.rows {
+		if matchesFilter(row, m.filter, now, m.opts.StaleStashAfter) {
+			visible = append(visible, i)
+		}
+	}
+	m.visible = visible
+
+	m.cursor = min(m.cursor, max(len(m.visible)-1, 0))
+	for vi, i := range m.visible {
+		if m.rows[i].Path == keep {
+			m.cursor = vi
+			break
+		}
+	}
+}
+
+// matchesFilter reports whether row belongs in the list under filter.
+func matchesFilter(row repository.RepositoryStatusResult, filter DashboardFilter, now time.Time, staleAfter time.Duration) bool {
+	switch filter {
+	case DashboardDirty:
+		return row.TrackedChangedFiles > 0 || row.UntrackedFiles > 0 || len(row.ConflictFiles) > 0
+	case DashboardAhead:
+		return row.CommitsAhead > 0
+	case DashboardBehind:
+		return row.CommitsBehind > 0
+	case DashboardConflict:
+		return len(row.ConflictFiles) > 0 || row.MergeInProgress || row.RebaseInProgress
+	case DashboardStaleStash:
+		return row.StashCount > 0 && !row.OldestStash.IsZero() && now.Sub(row.OldestStash) >= staleAfter
+	case DashboardAll:
+		return true
+	}
+	return true
+}
+
+// addLog appends to the command log, dropping the oldest entries past
+// maxLogEntries.
+func (m *DashboardModel) addLog(text string, failed bool) {
+	m.log = append(m.log, logEntry{At: time.Now(), Text: text, Failed: failed})
+	if over := len(m.log) - maxLogEntries; over > 0 {
+		m.log = append([]logEntry(nil), m.log[over:]...)
+	}
+}
+
+// displayName is the path shown for a repository: relative to the scan root
+// when known.
+func (m DashboardModel) displayName(path string) string {
+	for _, row := range m.rows {
+		if row.Path == path && row.RelativePath != "" {
+			return row.RelativePath
+		}
+	}
+	return path
+}
+
+// describeTargets names a single target, and counts several.
+func (m DashboardModel) describeTargets(targets []string) string {
+	if len(targets) == 1 {
+		return m.displayName(targets[0])
+	}
+	return pluralize(len(targets), "repository", "repositories")
+}
@@ -0,0 +1,304 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+// actionOutcome is one repository's line of an action's result, flattened
+// from whichever Repository*Result the Bulk* call returned.
+type actionOutcome struct {
+	path    string
+	status  string
+	message string
+	err     error
+}
+
+// failed reports whether the outcome belongs in the log as a failure.
+func (o actionOutcome) failed() bool {
+	return o.err != nil || o.status == repository.StatusError || o.status == repository.StatusBlocked
+}
+
+// selectionPattern is an include pattern matching exactly paths. The bulk
+// scanners match IncludePattern against each repository's path, so this is
+// how a Bulk* call is narrowed to the dashboard's selection without a new
+// option on every one of them.
+func selectionPattern(paths []string) string {
+	quoted := make([]string, len(paths))
+	for i, p := range paths {
+		quoted[i] = regexp.QuoteMeta(p)
+	}
+	return "^(?:" + strings.Join(quoted, "|") + ")$"
+}
+
+// actionCmd runs action over targets. input is the commit message or the
+// branch name for the two actions that prompt for one.
+func (m DashboardModel) actionCmd(action, input string, targets []string) tea.Cmd {
+	ctx, client, o := m.ctx, m.client, m.opts
+	include := selectionPattern(targets)
+
+	return func() tea.Msg {
+		done := actionDoneMsg{action: action, targets: targets}
+		switch action {
+		case "fetch":
+			res, err := client.BulkFetch(ctx, repository.BulkFetchOptions{
+				Directory: o.Directory, Parallel: o.Parallel, MaxDepth: o.MaxDepth,
+				IncludeSubmodules: o.IncludeSubmodules, IncludePattern: include, ExcludePattern: o.ExcludePattern,
+			})
+			done.err = err
+			if res != nil {
+				done.summary = res.Summary
+				for _, r := range res.Repositories {
+					done.outcomes = append(done.outcomes, actionOutcome{r.Path, r.Status, r.Message, r.Error})
+				}
+			}
+
+		case "pull":
+			// ff-only: a dashboard keystroke should never start a merge the
+			// user then has to finish in a shell.
+			res, err := client.BulkPull(ctx, repository.BulkPullOptions{
+				Directory: o.Directory, Parallel: o.Parallel, MaxDepth: o.MaxDepth, Strategy: "ff-only",
+				IncludeSubmodules: o.IncludeSubmodules, IncludePattern: include, ExcludePattern: o.ExcludePattern,
+			})
+			done.err = err
+			if res != nil {
+				done.summary = res.Summary
+				for _, r := range res.Repositories {
+					done.outcomes = append(done.outcomes, actionOutcome{r.Path, r.Status, r.Message, r.Error})
+				}
+			}
+
+		case "push":
+			res, err := client.BulkPush(ctx, repository.BulkPushOptions{
+				Directory: o.Directory, Parallel: o.Parallel, MaxDepth: o.MaxDepth,
+				Policy: o.PushPolicy, Identity: o.Identity,
+				IncludeSubmodules: o.IncludeSubmodules, IncludePattern: include, ExcludePattern: o.ExcludePattern,
+			})
+			done.err = err
+			if res != nil {
+				done.summary = res.Summary
+				for _, r := range res.Repositories {
+					done.outcomes = append(done.outcomes, actionOutcome{r.Path, r.Status, r.Message, r.Error})
+				}
+			}
+
+		case "commit":
+			res, err := client.BulkCommit(ctx, repository.BulkCommitOptions{
+				Directory: o.Directory, Parallel: o.Parallel, MaxDepth: o.MaxDepth, Message: input, Yes: true,
+				IncludeSubmodules: o.IncludeSubmodules, IncludePattern: include, ExcludePattern: o.ExcludePattern,
+			})
+			done.err = err
+			if res != nil {
+				done.summary = res.Summary
+				for _, r := range res.Repositories {
+					msg := r.CommitHash
+					if len(r.ConflictedFiles) > 0 {
+						msg = fmt.Sprintf("%d conflicted files", len(r.ConflictedFiles))
+					}
+					done.outcomes = append(done.outcomes, actionOutcome{r.Path, r.Status, msg, r.Error})
+				}
+			}
+
+		case "stash":
+			res, err := client.BulkStash(ctx, repository.BulkStashOptions{
+				Directory: o.Directory, Parallel: o.Parallel, MaxDepth: o.MaxDepth,
+				Operation: "save", Message: "gz-git tui", OnlyDirty: true,
+				IncludeSubmodules: o.IncludeSubmodules, IncludePattern: include, ExcludePattern: o.ExcludePattern,
+			})
+			done.err = err
+			if res != nil {
+				done.summary = res.Summary
+				for _, r := range res.Repositories {
+					done.outcomes = append(done.outcomes, actionOutcome{r.Path, r.Status, r.Message, r.Error})
+				}
+			}
+
+		case "switch":
+			res, err := client.BulkSwitch(ctx, repository.BulkSwitchOptions{
+				Directory: o.Directory, Parallel: o.Parallel, MaxDepth: o.MaxDepth, Branch: input,
+				IncludeSubmodules: o.IncludeSubmodules, IncludePattern: include, ExcludePattern: o.ExcludePattern,
+			})
+			done.err = err
+			if res != nil {
+				done.summary = res.Summary
+				for _, r := range res.Repositories {
+					done.outcomes = append(done.outcomes, actionOutcome{r.Path, r.Status, r.Message, r.Error})
+				}
+			}
+
+		case "cleanup":
+			// Merged and gone branches only: both are recoverable from the
+			// remote or the base branch, unlike stale ones.
+			res, err := client.BulkCleanup(ctx, repository.BulkCleanupOptions{
+				Directory: o.Directory, Parallel: o.Parallel, MaxDepth: o.MaxDepth,
+				IncludeMerged: true, IncludeGone: true,
+				IncludeSubmodules: o.IncludeSubmodules, IncludePattern: include, ExcludePattern: o.ExcludePattern,
+			})
+			done.err = err
+			if res != nil {
+				done.summary = res.Summary
+				for _, r := range res.Repositories {
+					done.outcomes = append(done.outcomes, actionOutcome{r.Path, r.Status, r.Message, r.Error})
+				}
+			}
+
+		default:
+			done.err = fmt.Errorf("unknown action %q", action)
+		}
+		return done
+	}
+}
+
+// checkRepos re-checks each path and replaces its row.
+func (m DashboardModel) checkRepos(paths []string) tea.Cmd {
+	if len(paths) == 0 {
+		return nil
+	}
+	ctx, client, root := m.ctx, m.client, m.opts.Directory
+	cmds := make([]tea.Cmd, len(paths))
+	for i, path := range paths {
+		cmds[i] = func() tea.Msg {
+			return repoStatusMsg{status: client.RepositoryStatus(ctx, root, path)}
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// rescan repeats the whole scan, picking up added and removed repositories.
+func (m DashboardModel) rescan() tea.Cmd {
+	ctx, client, o := m.ctx, m.client, m.opts
+	return func() tea.Msg {
+		res, err := client.BulkStatus(ctx, repository.BulkStatusOptions{
+			Directory: o.Directory, Parallel: o.Parallel, MaxDepth: o.MaxDepth,
+			IncludeSubmodules: o.IncludeSubmodules, IncludePattern: o.IncludePattern, ExcludePattern: o.ExcludePattern,
+		})
+		if err != nil {
+			return rescanMsg{err: err}
+		}
+		return rescanMsg{rows: res.Repositories}
+	}
+}
+
+// loadFiles fetches the changed files and the full diff of one repository.
+func (m DashboardModel) loadFiles(path string) tea.Cmd {
+	ctx, client, o := m.ctx, m.client, m.opts
+	return func() tea.Msg {
+		res, err := client.BulkDiff(ctx, repository.BulkDiffOptions{
+			Directory: o.Directory, MaxDepth: o.MaxDepth, IncludeUntracked: true,
+			IncludeSubmodules: o.IncludeSubmodules, IncludePattern: selectionPattern([]string{path}),
+		})
+		if err != nil {
+			return filesMsg{path: path, err: err}
+		}
+		if len(res.Repositories) == 0 {
+			return filesMsg{path: path, err: fmt.Errorf("repository not found")}
+		}
+		r := res.Repositories[0]
+		if r.Error != nil {
+			return filesMsg{path: path, err: r.Error}
+		}
+		files := make([]dashboardFile, 0, len(r.ChangedFiles)+len(r.UntrackedFiles))
+		for _, f := range r.ChangedFiles {
+			files = append(files, dashboardFile{Path: f.Path, Status: f.Status})
+		}
+		for _, f := range r.UntrackedFiles {
+			files = append(files, dashboardFile{Path: f, Status: "??"})
+		}
+		return filesMsg{path: path, files: files, diff: r.DiffContent}
+	}
+}
+
+// logAction writes a finished action to the command log: one summary line,
+// then a line for every repository that failed.
+func (m *DashboardModel) logAction(msg actionDoneMsg) {
+	if msg.err != nil {
+		m.addLog(msg.action+" failed: "+msg.err.Error(), true)
+		return
+	}
+
+	failed := 0
+	for _, o := range msg.outcomes {
+		if o.failed() {
+			failed++
+		}
+	}
+	m.addLog(fmt.Sprintf("%s %s: %s", msg.action, m.describeTargets(msg.targets), formatSummary(msg.summary)), failed > 0)
+
+	for _, o := range msg.outcomes {
+		if !o.failed() {
+			continue
+		}
+		detail := o.message
+		if o.err != nil {
+			detail = o.err.Error()
+		}
+		m.addLog(fmt.Sprintf("  %s: %s %s", m.displayName(o.path), o.status, detail), true)
+	}
+}
+
+// formatSummary renders a Summary map as "status=count" pairs in a stable
+// order.
+func formatSummary(summary map[string]int) string {
+	if len(summary) == 0 {
+		return "nothing to do"
+	}
+	keys := make([]string, 0, len(summary))
+	for k := range summary {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s=%d", k, summary[k])
+	}
+	return strings.Join(parts, " ")
+}
+
+// fileDiff cuts the section for path out of a repository's diff. When git
+// quoted the path (unusual characters) the section is not found by name and
+// the whole diff is returned instead, which is still the right content.
+func fileDiff(diff, path string) string {
+	header := "diff --git a/" + path + " b/" + path
+	lines := strings.Split(diff, "\n")
+	start := -1
+	for i, line := range lines {
+		if start < 0 {
+			if line == header {
+				start = i
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "diff --git ") {
+			return strings.Join(lines[start:i], "\n")
+		}
+	}
+	if start < 0 {
+		return diff
+	}
+	return strings.Join(lines[start:], "\n")
+}
+
+// splitLines splits text for the scrolling diff view.
+func splitLines(text string) []string {
+	text = strings.TrimRight(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+func pluralize(n int, one, many string) string {
+	if n == 1 {
+		return "1 " + one
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
@@ -0,0 +1,333 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package tui
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/watch"
+)
+
+func dashboardRows() []repository.RepositoryStatusResult {
+	return []repository.RepositoryStatusResult{
+		{Path: "/ws/clean", RelativePath: "clean", Status: repository.StatusClean},
+		{Path: "/ws/dirty", RelativePath: "dirty", Status: repository.StatusDirty, TrackedChangedFiles: 2},
+		{Path: "/ws/ahead", RelativePath: "ahead", CommitsAhead: 3},
+		{Path: "/ws/behind", RelativePath: "behind", CommitsBehind: 1},
+		{Path: "/ws/conflict", RelativePath: "conflict", ConflictFiles: []string{"a.go"}, MergeInProgress: true},
+		{Path: "/ws/stash", RelativePath: "stash", StashCount: 1, OldestStash: time.Now().Add(-30 * 24 * time.Hour)},
+		{Path: "/ws/fresh", RelativePath: "fresh", StashCount: 1, OldestStash: time.Now().Add(-time.Hour)},
+	}
+}
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case " ":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func press(t *testing.T, m DashboardModel, keys ...string) (DashboardModel, tea.Cmd) {
+	t.Helper()
+	var cmd tea.Cmd
+	for _, k := range keys {
+		var next tea.Model
+		next, cmd = m.Update(key(k))
+		m = next.(DashboardModel)
+	}
+	return m, cmd
+}
+
+func visiblePaths(m DashboardModel) []string {
+	paths := make([]string, len(m.visible))
+	for i, vi := range m.visible {
+		paths[i] = m.rows[vi].RelativePath
+	}
+	return paths
+}
+
+func TestDashboardFilters(t *testing.T) {
+	m := NewDashboardModel(context.Background(), nil, dashboardRows(), DashboardOptions{})
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"1", "dirty,conflict"},
+		{"2", "ahead"},
+		{"3", "behind"},
+		{"4", "conflict"},
+		{"5", "stash"},
+		{"0", "clean,dirty,ahead,behind,conflict,stash,fresh"},
+	}
+	for _, tt := range tests {
+		m, _ = press(t, m, tt.key)
+		if got := strings.Join(visiblePaths(m), ","); got != tt.want {
+			t.Errorf("filter %s: visible = %s, want %s", tt.key, got, tt.want)
+		}
+	}
+
+	// Pressing a filter's key again turns it off.
+	m, _ = press(t, m, "2", "2")
+	if m.filter != DashboardAll {
+		t.Errorf("filter = %q after toggling twice, want all", m.filter)
+	}
+}
+
+func TestDashboardTargets(t *testing.T) {
+	m := NewDashboardModel(context.Background(), nil, dashboardRows(), DashboardOptions{})
+
+	m, _ = press(t, m, "j")
+	if got := m.targets(); len(got) != 1 || got[0] != "/ws/dirty" {
+		t.Errorf("targets without a selection = %v, want the cursor row", got)
+	}
+
+	// Selection is reported in scan order, not selection order.
+	m, _ = press(t, m, "j", " ", "k", "k", " ")
+	if got := strings.Join(m.targets(), ","); got != "/ws/clean,/ws/ahead" {
+		t.Errorf("targets = %s", got)
+	}
+
+	m, _ = press(t, m, "n")
+	if len(m.selected) != 0 {
+		t.Errorf("selected = %v after n", m.selected)
+	}
+}
+
+func TestDashboardLiveUpdateKeepsCursor(t *testing.T) {
+	m := NewDashboardModel(context.Background(), nil, dashboardRows(), DashboardOptions{})
+	m, _ = press(t, m, "j", "j") // ahead
+
+	next, _ := m.Update(repoStatusMsg{status: repository.RepositoryStatusResult{Path: "/ws/clean", TrackedChangedFiles: 1}})
+	m = next.(DashboardModel)
+
+	if row, _ := m.current(); row.Path != "/ws/ahead" {
+		t.Errorf("cursor on %s after an update, want /ws/ahead", row.Path)
+	}
+	if m.rows[0].RelativePath != "clean" || m.rows[0].TrackedChangedFiles != 1 {
+		t.Errorf("row not replaced: %+v", m.rows[0])
+	}
+
+	// A repository outside the scan is ignored.
+	next, _ = m.Update(repoStatusMsg{status: repository.RepositoryStatusResult{Path: "/elsewhere"}})
+	if got := len(next.(DashboardModel).rows); got != len(dashboardRows()) {
+		t.Errorf("rows = %d after an unknown update", got)
+	}
+}
+
+func TestDashboardWatchEventRechecks(t *testing.T) {
+	events := make(chan watch.Event, 1)
+	m := NewDashboardModel(context.Background(), nil, dashboardRows(), DashboardOptions{Events: events})
+
+	events <- watch.Event{Path: "/ws/dirty"}
+	msg := m.Init()()
+	if ev, ok := msg.(watchEventMsg); !ok || ev.event.Path != "/ws/dirty" {
+		t.Fatalf("Init() produced %#v", msg)
+	}
+	if _, cmd := m.Update(msg); cmd == nil {
+		t.Error("a watch event should re-check the repository and wait for the next one")
+	}
+
+	close(events)
+	next, cmd := m.Update(m.Init()())
+	if cmd != nil {
+		t.Error("a closed watcher should not be waited on")
+	}
+	if log := next.(DashboardModel).log; len(log) != 1 || !log[0].Failed {
+		t.Errorf("log = %+v, want a note that live updates stopped", log)
+	}
+}
+
+func TestDashboardPrompt(t *testing.T) {
+	m := NewDashboardModel(context.Background(), nil, dashboardRows(), DashboardOptions{})
+
+	m, _ = press(t, m, "c")
+	if m.mode != modePrompt || m.pending != "commit" {
+		t.Fatalf("mode = %v, pending = %q after c", m.mode, m.pending)
+	}
+	m, _ = press(t, m, "fix", " ", "typo")
+	if m.input != "fix typo" {
+		t.Errorf("input = %q", m.input)
+	}
+
+	// Keys typed into the prompt are not actions.
+	if m.filter != DashboardAll || m.running != 0 {
+		t.Error("prompt input leaked into the list")
+	}
+
+	m, cmd := press(t, m, "enter")
+	if m.mode != modeRepos || cmd == nil || m.running != 1 {
+		t.Errorf("enter: mode = %v, cmd = %v, running = %d", m.mode, cmd != nil, m.running)
+	}
+
+	m, _ = press(t, m, "b", "esc")
+	if m.mode != modeRepos || m.input != "" {
+		t.Error("esc should cancel the prompt")
+	}
+}
+
+func TestDashboardCleanupAsksFirst(t *testing.T) {
+	m := NewDashboardModel(context.Background(), nil, dashboardRows(), DashboardOptions{})
+
+	m, cmd := press(t, m, "x")
+	if m.mode != modeConfirm || cmd != nil {
+		t.Fatalf("x should ask first: mode = %v", m.mode)
+	}
+	m, cmd = press(t, m, "n")
+	if m.mode != modeRepos || cmd != nil || m.running != 0 {
+		t.Error("anything but y should cancel")
+	}
+	m, _ = press(t, m, "x")
+	if _, cmd = press(t, m, "y"); cmd == nil {
+		t.Error("y should run the cleanup")
+	}
+}
+
+func TestDashboardLogAction(t *testing.T) {
+	m := NewDashboardModel(context.Background(), nil, dashboardRows(), DashboardOptions{})
+	next, cmd := m.Update(actionDoneMsg{
+		action:  "pull",
+		targets: []string{"/ws/ahead", "/ws/behind"},
+		summary: map[string]int{"success": 1, "error": 1},
+		outcomes: []actionOutcome{
+			{path: "/ws/ahead", status: "success"},
+			{path: "/ws/behind", status: repository.StatusError, message: "not a fast-forward"},
+		},
+	})
+	m = next.(DashboardModel)
+
+	if cmd == nil {
+		t.Error("a finished action should re-check its targets")
+	}
+	if len(m.log) != 2 {
+		t.Fatalf("log = %+v, want a summary and one failure", m.log)
+	}
+	if m.log[0].Text != "pull 2 repositories: error=1 success=1" || !m.log[0].Failed {
+		t.Errorf("summary line = %+v", m.log[0])
+	}
+	if !strings.Contains(m.log[1].Text, "behind: error not a fast-forward") {
+		t.Errorf("failure line = %q", m.log[1].Text)
+	}
+}
+
+func TestSelectionPattern(t *testing.T) {
+	re := regexp.MustCompile(selectionPattern([]string{"/ws/a.b", "/ws/c"}))
+	for path, want := range map[string]bool{
+		"/ws/a.b":   true,
+		"/ws/c":     true,
+		"/ws/axb":   false, // the dot is literal
+		"/ws/c/sub": false, // anchored at both ends
+		"/x/ws/c":   false,
+	} {
+		if got := re.MatchString(path); got != want {
+			t.Errorf("match %s = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestFileDiff(t *testing.T) {
+	diff := "diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -1 +1 @@\n-x\n+y\n" +
+		"diff --git a/b.go b/b.go\n--- a/b.go\n+++ b/b.go\n@@ -1 +1 @@\n-1\n+2\n"
+
+	got := fileDiff(diff, "a.go")
+	if !strings.HasPrefix(got, "diff --git a/a.go") || strings.Contains(got, "b.go") {
+		t.Errorf("fileDiff(a.go) = %q", got)
+	}
+	if got := fileDiff(diff, "b.go"); !strings.HasSuffix(got, "+2\n") || strings.Contains(got, "a.go") {
+		t.Errorf("fileDiff(b.go) = %q", got)
+	}
+	if got := fileDiff(diff, "missing.go"); got != diff {
+		t.Error("an unknown path should fall back to the whole diff")
+	}
+}
+
+func TestDashboardView(t *testing.T) {
+	m := NewDashboardModel(context.Background(), nil, dashboardRows(), DashboardOptions{Directory: "/ws"})
+	if m.View() != "Initializing..." {
+		t.Error("view before the window size is known")
+	}
+	next, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	view := next.(DashboardModel).View()
+	for _, want := range []string{"gz-git tui", "dirty", "2 changed", "1 conflicted", "f: Fetch", "log"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+}
+
+func TestDashboardIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	root := t.TempDir()
+	repo := filepath.Join(root, "app")
+	for _, args := range [][]string{
+		{"init", "-q", repo},
+		{"-C", repo, "config", "user.name", "Test User"},
+		{"-C", repo, "config", "user.email", "test@example.com"},
+		{"-C", repo, "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil { //nolint:noctx // test setup
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(repo, "new.txt"), []byte("hello\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	client := repository.NewClient()
+	opts := DashboardOptions{Directory: root, MaxDepth: 1}
+	status, err := client.BulkStatus(ctx, repository.BulkStatusOptions{Directory: root, MaxDepth: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewDashboardModel(ctx, client, status.Repositories, opts)
+
+	// Drill down: the untracked file and its diff.
+	m, cmd := press(t, m, "enter")
+	next, _ := m.Update(cmd())
+	m = next.(DashboardModel)
+	if len(m.files) != 1 || m.files[0].Path != "new.txt" || m.files[0].Status != "??" {
+		t.Fatalf("files = %+v", m.files)
+	}
+	m, _ = press(t, m, "enter")
+	if m.mode != modeDiff || !strings.Contains(strings.Join(m.diffLines, "\n"), "+hello") {
+		t.Errorf("diff = %q", m.diffLines)
+	}
+	m, _ = press(t, m, "esc", "esc")
+
+	// Commit from the dashboard, then the re-check shows it clean.
+	m, _ = press(t, m, "c", "add", " ", "file")
+	m, cmd = press(t, m, "enter")
+	next, cmd = m.Update(cmd())
+	m = next.(DashboardModel)
+	if len(m.log) != 2 || m.log[1].Failed {
+		t.Fatalf("log = %+v", m.log)
+	}
+	next, _ = m.Update(cmd()) // one target: tea.Batch returns the command itself
+	m = next.(DashboardModel)
+	if row := m.rows[0]; row.UntrackedFiles != 0 || row.TrackedChangedFiles != 0 {
+		t.Errorf("row after commit = %+v", row)
+	}
+	out, err := exec.Command("git", "-C", repo, "log", "-1", "--format=%s").Output() //nolint:noctx // test check
+	if err != nil || strings.TrimSpace(string(out)) != "add file" {
+		t.Errorf("last commit = %q, %v", out, err)
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+)
+
+// logPaneLines is how many command log lines stay visible under the list.
+const logPaneLines = 6
+
+// View renders the current UI state.
+func (m DashboardModel) View() string {
+	if !m.ready {
+		return "Initializing..."
+	}
+
+	var b strings.Builder
+	b.WriteString(m.renderHeader())
+	b.WriteString("\n\n")
+
+	switch m.mode {
+	case modeFiles:
+		b.WriteString(m.renderFiles())
+	case modeDiff:
+		b.WriteString(m.renderDiff())
+	case modeRepos, modePrompt, modeConfirm:
+		b.WriteString(m.renderRepos())
+	}
+	b.WriteString("\n")
+
+	b.WriteString(m.renderLog())
+	b.WriteString("\n")
+	b.WriteString(m.renderFooter())
+	return b.String()
+}
+
+func (m DashboardModel) renderHeader() string {
+	title := fmt.Sprintf(" gz-git tui  %s", m.opts.Directory)
+	switch m.mode {
+	case modeFiles:
+		title += "  ›  " + m.displayName(m.filesRepo)
+	case modeDiff:
+		if m.fileCursor < len(m.files) {
+			title += "  ›  " + m.displayName(m.filesRepo) + "  ›  " + m.files[m.fileCursor].Path
+		}
+	case modeRepos, modePrompt, modeConfirm:
+		title += fmt.Sprintf("  (%d selected / %d", len(m.selected), len(m.visible))
+		if m.filter != DashboardAll {
+			title += fmt.Sprintf(" of %d", len(m.rows))
+		}
+		title += ")"
+		if m.filter != DashboardAll {
+			title += fmt.Sprintf("  [Filter: %s]", m.filter)
+		}
+	}
+	if m.running > 0 {
+		title += fmt.Sprintf("  ⟳ %d running", m.running)
+	}
+	return HeaderStyle.Render(title + " ")
+}
+
+// listHeight is the number of rows the main pane can use.
+func (m DashboardModel) listHeight() int {
+	// header (2) + log pane and its rule (logPaneLines+1) + footer (2)
+	h := m.height - 5 - logPaneLines
+	if h < 3 {
+		h = 3
+	}
+	return h
+}
+
+// diffHeight is the page size of the diff view.
+func (m DashboardModel) diffHeight() int {
+	return m.listHeight()
+}
+
+// window returns the [start, end) slice of n items to show so that cursor
+// stays in view, the same centring StatusModel uses.
+func window(cursor, n, height int) (start, end int) {
+	start = max(cursor-height/2, 0)
+	end = start + height
+	if end > n {
+		end = n
+		start = max(end-height, 0)
+	}
+	return start, end
+}
+
+func (m DashboardModel) renderRepos() string {
+	if len(m.visible) == 0 {
+		if len(m.rows) == 0 {
+			return SubtleStyle.Render("  No repositories found") + "\n"
+		}
+		return SubtleStyle.Render("  No repositories match the filter (0: show all)") + "\n"
+	}
+
+	var b strings.Builder
+	height := m.listHeight()
+	start, end := window(m.cursor, len(m.visible), height)
+	for vi := start; vi < end; vi++ {
+		row := m.rows[m.visible[vi]]
+		b.WriteString(m.renderRepoRow(row, vi == m.cursor))
+		b.WriteString("\n")
+	}
+	if len(m.visible) > height {
+		b.WriteString(SubtleStyle.Render(fmt.Sprintf("  (%d-%d of %d)", start+1, end, len(m.visible))))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (m DashboardModel) renderRepoRow(row repository.RepositoryStatusResult, isCursor bool) string {
+	checkbox := "[ ]"
+	if m.selected[row.Path] {
+		checkbox = "[✓]"
+	}
+
+	name := row.RelativePath
+	if name == "" || name == "." {
+		name = row.Path
+	}
+	if len(name) > 30 {
+		name = "..." + name[len(name)-27:]
+	}
+
+	branch := row.Branch
+	if branch == "" {
+		branch = "HEAD"
+	}
+	if len(branch) > 15 {
+		branch = branch[:12] + "..."
+	}
+
+	line := fmt.Sprintf("  %s %-30s %-15s ↑%-3d ↓%-3d %s",
+		checkbox, name, branch, row.CommitsAhead, row.CommitsBehind, dashboardState(row))
+
+	switch {
+	case isCursor:
+		return CursorStyle.Render(line)
+	case row.Error != nil || len(row.ConflictFiles) > 0 || row.MergeInProgress || row.RebaseInProgress:
+		return UnhealthyStyle.Render(line)
+	case row.TrackedChangedFiles > 0 || row.UntrackedFiles > 0:
+		return DirtyStyle.Render(line)
+	}
+	return line
+}
+
+// dashboardState is the status column: what needs attention, most urgent
+// first.
+func dashboardState(row repository.RepositoryStatusResult) string {
+	var parts []string
+	switch {
+	case row.Error != nil:
+		return "✗ " + row.Error.Error()
+	case row.RebaseInProgress:
+		parts = append(parts, "✗ rebasing")
+	case row.MergeInProgress:
+		parts = append(parts, "✗ merging")
+	}
+	if n := len(row.ConflictFiles); n > 0 {
+		parts = append(parts, fmt.Sprintf("✗ %d conflicted", n))
+	}
+	if row.TrackedChangedFiles > 0 {
+		parts = append(parts, fmt.Sprintf("%d changed", row.TrackedChangedFiles))
+	}
+	if row.UntrackedFiles > 0 {
+		parts = append(parts, fmt.Sprintf("%d untracked", row.UntrackedFiles))
+	}
+	if row.StashCount > 0 {
+		parts = append(parts, fmt.Sprintf("%d stashed", row.StashCount))
+	}
+	if len(parts) == 0 {
+		return "✓ " + row.Status
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (m DashboardModel) renderFiles() string {
+	if m.files == nil {
+		return SubtleStyle.Render("  Loading changes...") + "\n"
+	}
+	if len(m.files) == 0 {
+		return SubtleStyle.Render("  No changes") + "\n"
+	}
+
+	var b strings.Builder
+	height := m.listHeight()
+	start, end := window(m.fileCursor, len(m.files), height)
+	for i := start; i < end; i++ {
+		f := m.files[i]
+		line := fmt.Sprintf("  %-2s %s", f.Status, f.Path)
+		if i == m.fileCursor {
+			line = CursorStyle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	if len(m.files) > height {
+		b.WriteString(SubtleStyle.Render(fmt.Sprintf("  (%d-%d of %d)", start+1, end, len(m.files))))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (m DashboardModel) renderDiff() string {
+	if len(m.diffLines) == 0 {
+		return SubtleStyle.Render("  No diff (binary, or the change is a mode change only)") + "\n"
+	}
+
+	var b strings.Builder
+	end := min(m.diffOffset+m.diffHeight(), len(m.diffLines))
+	for _, line := range m.diffLines[m.diffOffset:end] {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			line = HeaderLineStyle.Render(line)
+		case strings.HasPrefix(line, "+"):
+			line = AddedStyle.Render(line)
+		case strings.HasPrefix(line, "-"):
+			line = RemovedStyle.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			line = SubtleStyle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	b.WriteString(SubtleStyle.Render(fmt.Sprintf("  (lines %d-%d of %d)", m.diffOffset+1, end, len(m.diffLines))))
+	b.WriteString("\n")
+	return b.String()
+}
+
+func (m DashboardModel) renderLog() string {
+	var b strings.Builder
+	b.WriteString(SubtleStyle.Render("  ── log " + strings.Repeat("─", 40)))
+	b.WriteString("\n")
+
+	start := max(len(m.log)-logPaneLines, 0)
+	for _, e := range m.log[start:] {
+		line := fmt.Sprintf("  %s %s", e.At.Format("15:04:05"), e.Text)
+		if e.Failed {
+			line = UnhealthyStyle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (m DashboardModel) renderFooter() string {
+	var hints []string
+	switch m.mode {
+	case modePrompt:
+		label := "Commit message"
+		if m.pending == "switch" {
+			label = "Switch to branch"
+		}
+		return fmt.Sprintf("  %s (%s): %s█\n  %s", label, m.describeTargets(m.pendingRepo), m.input,
+			SubtleStyle.Render("Enter: Run  │  Esc: Cancel"))
+	case modeConfirm:
+		return fmt.Sprintf("  Delete merged and gone branches in %s? (y/n)", m.describeTargets(m.pendingRepo))
+	case modeFiles:
+		hints = []string{"↑↓/j/k: Navigate", "Enter: Diff", "Esc: Back"}
+	case modeDiff:
+		hints = []string{"↑↓/j/k: Scroll", "PgUp/PgDn: Page", "Esc: Back"}
+	case modeRepos:
+		hints = []string{
+			"Space: Toggle", "a/n: All/None", "Enter: Changes",
+			"f: Fetch", "p: Pull", "P: Push", "c: Commit", "s: Stash", "b: Switch", "x: Cleanup", "r: Rescan",
+			"1-5: Dirty/Ahead/Behind/Conflict/Stale stash", "0: All", "q: Quit",
+		}
+	}
+	return SubtleStyle.Render("  " + strings.Join(hints, "  │  "))
+}
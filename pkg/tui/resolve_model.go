@@ -0,0 +1,671 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/merge"
+)
+
+// resolveScreen is which screen the resolver shows.
+type resolveScreen int
+
+const (
+	screenRepos   resolveScreen = iota // repositories stopped by conflicts
+	screenFiles                        // unmerged paths of one repository
+	screenHunks                        // one file, hunk by hunk
+	screenConfirm                      // y/n before an abort
+)
+
+// ResolveModel is the bubbletea model behind `gz-git conflict resolve`. It
+// walks repositories stopped by conflicts, their unmerged paths, and each
+// path's hunks, and continues or aborts the operation once a repository is
+// done. All git work goes through merge.Resolver.
+type ResolveModel struct {
+	ctx      context.Context
+	resolver merge.Resolver
+	root     string
+
+	repos      []*merge.RepoConflicts
+	repoCursor int
+	fileCursor int
+
+	screen  resolveScreen
+	file    *merge.ConflictFile
+	hunk    int
+	changed bool // file has choices not yet saved
+
+	message string
+	failed  bool
+
+	width  int
+	height int
+	ready  bool
+}
+
+// NewResolveModel creates a resolver UI over repos, as returned by
+// Resolver.Inspect. root is only used to shorten paths for display.
+func NewResolveModel(ctx context.Context, resolver merge.Resolver, root string, repos []*merge.RepoConflicts) ResolveModel {
+	m := ResolveModel{ctx: ctx, resolver: resolver, root: root, repos: repos}
+	if len(repos) == 1 {
+		m.screen = screenFiles
+	}
+	return m
+}
+
+// Messages the resolver sends itself from tea.Cmds.
+type (
+	repoStateMsg struct {
+		index int
+		state *merge.RepoConflicts
+		note  string
+		err   error
+	}
+
+	fileLoadedMsg struct {
+		file *merge.ConflictFile
+		err  error
+	}
+
+	fileSavedMsg struct {
+		staged bool
+		back   bool // return to the file list once saved
+		err    error
+	}
+
+	hunkEditedMsg struct {
+		lines []string
+		err   error
+	}
+)
+
+// Init initializes the model (required by Bubble Tea).
+func (m ResolveModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles all messages and updates the model state.
+func (m ResolveModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height, m.ready = msg.Width, msg.Height, true
+		return m, nil
+
+	case repoStateMsg:
+		if msg.state != nil && msg.index < len(m.repos) {
+			m.repos[msg.index] = msg.state
+			m.fileCursor = min(m.fileCursor, max(len(msg.state.Unmerged)-1, 0))
+		}
+		m.setMessage(msg.note, msg.err)
+		if m.screen == screenFiles && msg.state != nil && !msg.state.Stuck() && len(m.repos) > 1 {
+			m.screen = screenRepos
+		}
+		return m, nil
+
+	case fileLoadedMsg:
+		if msg.err != nil {
+			m.setMessage("", msg.err)
+			return m, nil
+		}
+		m.file, m.hunk, m.changed = msg.file, 0, false
+		m.screen = screenHunks
+		m.setMessage("", nil)
+		return m, nil
+
+	case fileSavedMsg:
+		if msg.err != nil {
+			m.setMessage("", msg.err)
+			return m, nil
+		}
+		m.changed = false
+		note := "saved " + m.file.Path
+		if msg.staged {
+			note = "resolved " + m.file.Path
+		}
+		if msg.staged || msg.back {
+			m.screen, m.file = screenFiles, nil
+		}
+		return m, m.inspect(m.repoCursor, note, nil)
+
+	case hunkEditedMsg:
+		switch {
+		case msg.err != nil:
+			m.setMessage("", msg.err)
+		case m.file != nil && m.hunk < len(m.file.Hunks):
+			if hasMarkers(msg.lines) {
+				m.setMessage("", errors.New("conflict markers left in the edit; hunk still unresolved"))
+				return m, nil
+			}
+			m.file.Hunks[m.hunk].Edit(msg.lines)
+			m.changed = true
+			m.nextUnresolved()
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		switch m.screen {
+		case screenRepos:
+			return m.updateRepoList(msg)
+		case screenFiles:
+			return m.updateFileList(msg)
+		case screenHunks:
+			return m.updateHunks(msg)
+		case screenConfirm:
+			m.screen = screenRepos
+			if len(m.repos) == 1 {
+				m.screen = screenFiles
+			}
+			if msg.String() == "y" || msg.String() == "Y" {
+				return m, m.abort(m.repoCursor)
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m ResolveModel) updateRepoList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		return m, tea.Quit
+	case "up", "k":
+		m.repoCursor = max(m.repoCursor-1, 0)
+	case "down", "j":
+		m.repoCursor = min(m.repoCursor+1, max(len(m.repos)-1, 0))
+	case "enter", "right", "l":
+		if m.repoCursor < len(m.repos) {
+			m.screen, m.fileCursor = screenFiles, 0
+		}
+	default:
+		return m.operationKey(msg)
+	}
+	return m, nil
+}
+
+func (m ResolveModel) updateFileList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	repo := m.currentRepo()
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "esc", "left", "h", "backspace":
+		if len(m.repos) == 1 {
+			return m, tea.Quit
+		}
+		m.screen = screenRepos
+	case "up", "k":
+		m.fileCursor = max(m.fileCursor-1, 0)
+	case "down", "j":
+		if repo != nil {
+			m.fileCursor = min(m.fileCursor+1, max(len(repo.Unmerged)-1, 0))
+		}
+	case "enter", "right", "l":
+		if repo != nil && m.fileCursor < len(repo.Unmerged) {
+			return m, m.load(repo.Path, repo.Unmerged[m.fileCursor])
+		}
+	default:
+		return m.operationKey(msg)
+	}
+	return m, nil
+}
+
+// operationKey handles the keys that act on the current repository from
+// both list screens.
+func (m ResolveModel) operationKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	repo := m.currentRepo()
+	if repo == nil {
+		return m, nil
+	}
+	switch msg.String() {
+	case "c":
+		m.setMessage("continuing "+string(repo.Operation)+"…", nil)
+		return m, m.continueOp(m.repoCursor)
+	case "A":
+		if repo.Operation != merge.OperationNone {
+			m.screen = screenConfirm
+		}
+	case "r":
+		return m, m.inspect(m.repoCursor, "", nil)
+	}
+	return m, nil
+}
+
+func (m ResolveModel) updateHunks(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	f := m.file
+	if f == nil {
+		m.screen = screenFiles
+		return m, nil
+	}
+	repo := m.currentRepo()
+
+	switch msg.String() {
+	case "q", "esc", "left", "backspace":
+		// Leaving saves: unresolved hunks keep their markers, so nothing is
+		// lost, and the working tree always shows the choices made so far.
+		if m.changed {
+			return m, m.save(repo.Path, f, true)
+		}
+		m.screen, m.file = screenFiles, nil
+		return m, nil
+	case "s":
+		return m, m.save(repo.Path, f, false)
+	case "n", "j", "down", "tab":
+		if m.hunk < len(f.Hunks)-1 {
+			m.hunk++
+		}
+		return m, nil
+	case "p", "k", "up", "shift+tab":
+		m.hunk = max(m.hunk-1, 0)
+		return m, nil
+	}
+
+	var res merge.Resolution
+	switch msg.String() {
+	case "o":
+		res = merge.ResolveOurs
+	case "t":
+		res = merge.ResolveTheirs
+	case "b":
+		res = merge.ResolveBoth
+	case "w":
+		res = merge.ResolveWorkingTree
+	case "u":
+		res = merge.Unresolved
+	case "e":
+		if !f.Whole && m.hunk < len(f.Hunks) {
+			return m, editHunk(f.Hunks[m.hunk])
+		}
+		return m, nil
+	default:
+		return m, nil
+	}
+
+	if f.Whole {
+		if res == merge.ResolveBoth {
+			m.setMessage("", fmt.Errorf("%s is %s: take ours (o), theirs (t) or the working tree (w)", f.Path, f.Reason))
+			return m, nil
+		}
+		f.Choice = res
+		m.changed = true
+		return m, nil
+	}
+	if res == merge.ResolveWorkingTree || m.hunk >= len(f.Hunks) {
+		return m, nil
+	}
+	f.Hunks[m.hunk].Resolve(res)
+	m.changed = true
+	if res != merge.Unresolved {
+		m.nextUnresolved()
+	}
+	return m, nil
+}
+
+// nextUnresolved moves to the next hunk still needing a choice, wrapping
+// around; it stays put when none is left.
+func (m *ResolveModel) nextUnresolved() {
+	n := len(m.file.Hunks)
+	for i := 1; i <= n; i++ {
+		j := (m.hunk + i) % n
+		if m.file.Hunks[j].Resolution == merge.Unresolved {
+			m.hunk = j
+			return
+		}
+	}
+}
+
+func (m *ResolveModel) setMessage(note string, err error) {
+	m.message, m.failed = note, false
+	if err != nil {
+		m.message, m.failed = err.Error(), true
+	}
+}
+
+func (m ResolveModel) currentRepo() *merge.RepoConflicts {
+	if m.repoCursor < len(m.repos) {
+		return m.repos[m.repoCursor]
+	}
+	return nil
+}
+
+// inspect re-reads one repository's state, carrying note and err through
+// to the status line.
+func (m ResolveModel) inspect(index int, note string, opErr error) tea.Cmd {
+	ctx, resolver, path := m.ctx, m.resolver, m.repos[index].Path
+	return func() tea.Msg {
+		state, err := resolver.Inspect(ctx, path)
+		if opErr != nil {
+			err = opErr
+		}
+		return repoStateMsg{index: index, state: state, note: note, err: err}
+	}
+}
+
+func (m ResolveModel) load(repoPath, path string) tea.Cmd {
+	ctx, resolver := m.ctx, m.resolver
+	return func() tea.Msg {
+		file, err := resolver.Load(ctx, repoPath, path)
+		return fileLoadedMsg{file: file, err: err}
+	}
+}
+
+func (m ResolveModel) save(repoPath string, file *merge.ConflictFile, back bool) tea.Cmd {
+	ctx, resolver := m.ctx, m.resolver
+	return func() tea.Msg {
+		staged, err := resolver.Save(ctx, repoPath, file)
+		return fileSavedMsg{staged: staged, back: back, err: err}
+	}
+}
+
+func (m ResolveModel) continueOp(index int) tea.Cmd {
+	ctx, resolver, path, op := m.ctx, m.resolver, m.repos[index].Path, string(m.repos[index].Operation)
+	return func() tea.Msg {
+		err := resolver.Continue(ctx, path)
+		note := op + " finished"
+		if errors.Is(err, merge.ErrStoppedAgain) {
+			note, err = op+" stopped on the next conflict", nil
+		}
+		state, inspectErr := resolver.Inspect(ctx, path)
+		if err == nil {
+			err = inspectErr
+		}
+		return repoStateMsg{index: index, state: state, note: note, err: err}
+	}
+}
+
+func (m ResolveModel) abort(index int) tea.Cmd {
+	ctx, resolver, path, op := m.ctx, m.resolver, m.repos[index].Path, string(m.repos[index].Operation)
+	return func() tea.Msg {
+		err := resolver.Abort(ctx, path)
+		state, inspectErr := resolver.Inspect(ctx, path)
+		if err == nil {
+			err = inspectErr
+		}
+		return repoStateMsg{index: index, state: state, note: op + " aborted", err: err}
+	}
+}
+
+// editHunk opens the user's editor on the hunk. The file starts as the
+// current resolution, or the conflict markers when there is none yet.
+func editHunk(h *merge.Hunk) tea.Cmd {
+	tmp, err := os.CreateTemp("", "gz-git-hunk-*.txt")
+	if err != nil {
+		return func() tea.Msg { return hunkEditedMsg{err: err} }
+	}
+	start := h.Lines()
+	if h.Resolution == merge.Unresolved {
+		start = h.Markers()
+	}
+	_, err = tmp.WriteString(strings.Join(start, "\n") + "\n")
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return func() tea.Msg { return hunkEditedMsg{err: err} }
+	}
+
+	return tea.ExecProcess(editorCommand(tmp.Name()), func(runErr error) tea.Msg {
+		defer os.Remove(tmp.Name())
+		if runErr != nil {
+			return hunkEditedMsg{err: fmt.Errorf("editor: %w", runErr)}
+		}
+		data, err := os.ReadFile(tmp.Name())
+		if err != nil {
+			return hunkEditedMsg{err: err}
+		}
+		text := strings.TrimSuffix(string(data), "\n")
+		if text == "" {
+			return hunkEditedMsg{lines: []string{}}
+		}
+		return hunkEditedMsg{lines: strings.Split(text, "\n")}
+	})
+}
+
+// editorCommand runs $VISUAL, then $EDITOR, then vi on path. The variable
+// may carry arguments ("code --wait").
+func editorCommand(path string) *exec.Cmd {
+	editor := "vi"
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if v := strings.TrimSpace(os.Getenv(env)); v != "" {
+			editor = v
+			break
+		}
+	}
+	fields := strings.Fields(editor)
+	return exec.Command(fields[0], append(fields[1:], path)...) //nolint:gosec,noctx // the user's own editor
+}
+
+// hasMarkers reports whether lines still contain a conflict marker line.
+func hasMarkers(lines []string) bool {
+	for _, line := range lines {
+		for _, marker := range []string{"<<<<<<<", "=======", ">>>>>>>"} {
+			if line == marker || strings.HasPrefix(line, marker+" ") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// View renders the current UI state.
+func (m ResolveModel) View() string {
+	if !m.ready {
+		return "Initializing..."
+	}
+
+	var b strings.Builder
+	switch m.screen {
+	case screenRepos:
+		b.WriteString(m.renderRepoList())
+	case screenFiles, screenConfirm:
+		b.WriteString(m.renderFileList())
+	case screenHunks:
+		b.WriteString(m.renderHunk())
+	}
+
+	b.WriteString("\n")
+	if m.message != "" {
+		style := SubtleStyle
+		if m.failed {
+			style = UnhealthyStyle
+		}
+		b.WriteString(style.Render("  " + m.message))
+		b.WriteString("\n")
+	}
+	b.WriteString(m.renderResolveFooter())
+	return b.String()
+}
+
+func (m ResolveModel) repoName(path string) string {
+	if rel, err := filepath.Rel(m.root, path); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
+
+func (m ResolveModel) renderRepoList() string {
+	var b strings.Builder
+	b.WriteString(HeaderStyle.Render(fmt.Sprintf(" gz-git conflict resolve  (%d repositories) ", len(m.repos))))
+	b.WriteString("\n\n")
+	for i, repo := range m.repos {
+		line := fmt.Sprintf("  %-40s %s", m.repoName(repo.Path), describeRepoConflicts(repo))
+		switch {
+		case i == m.repoCursor:
+			line = CursorStyle.Render(line)
+		case !repo.Stuck():
+			line = SubtleStyle.Render(line)
+		case len(repo.Unmerged) > 0:
+			line = UnhealthyStyle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// describeRepoConflicts is the state column of the repository list.
+func describeRepoConflicts(repo *merge.RepoConflicts) string {
+	if !repo.Stuck() {
+		return "✓ done"
+	}
+	op := string(repo.Operation)
+	if op == "" {
+		op = "unmerged paths"
+	}
+	if len(repo.Unmerged) == 0 {
+		return op + ": ready to continue"
+	}
+	return fmt.Sprintf("%s: %d unmerged", op, len(repo.Unmerged))
+}
+
+func (m ResolveModel) renderFileList() string {
+	repo := m.currentRepo()
+	if repo == nil {
+		return ""
+	}
+	var b strings.Builder
+	title := fmt.Sprintf(" %s  %s ", m.repoName(repo.Path), describeRepoConflicts(repo))
+	if repo.Rerere {
+		title += " [rerere] "
+	}
+	b.WriteString(HeaderStyle.Render(title))
+	b.WriteString("\n\n")
+
+	if len(repo.Unmerged) == 0 {
+		if repo.Stuck() {
+			b.WriteString(SubtleStyle.Render("  Every path is resolved. Press c to continue or A to abort."))
+		} else {
+			b.WriteString(SubtleStyle.Render("  Nothing left to resolve."))
+		}
+		b.WriteString("\n")
+	}
+	for i, path := range repo.Unmerged {
+		line := "  " + path
+		if i == m.fileCursor {
+			line = CursorStyle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	if m.screen == screenConfirm {
+		b.WriteString("\n")
+		b.WriteString(UnhealthyStyle.Render(fmt.Sprintf("  Abort the %s in %s and discard every resolution? (y/n)", repo.Operation, m.repoName(repo.Path))))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (m ResolveModel) renderHunk() string {
+	f := m.file
+	var b strings.Builder
+
+	if f.Whole {
+		b.WriteString(HeaderStyle.Render(fmt.Sprintf(" %s  (%s) ", f.Path, f.Reason)))
+		b.WriteString("\n\n")
+		side := func(present bool) string {
+			if present {
+				return "has the file"
+			}
+			return "deleted it"
+		}
+		fmt.Fprintf(&b, "  ours:   %s\n  theirs: %s\n\n", side(f.HasOurs), side(f.HasTheirs))
+		if f.Rerere {
+			b.WriteString("  rerere replayed a recorded resolution into the working tree; w keeps it.\n\n")
+		}
+		fmt.Fprintf(&b, "  choice: %s\n", resolutionText(f.Choice))
+		return b.String()
+	}
+
+	h := f.Hunks[m.hunk]
+	b.WriteString(HeaderStyle.Render(fmt.Sprintf(" %s  hunk %d/%d  (%d unresolved) ", f.Path, m.hunk+1, len(f.Hunks), f.Remaining())))
+	b.WriteString("\n\n")
+
+	sections := 2
+	if h.HasBase {
+		sections++
+	}
+	if h.Resolution != merge.Unresolved {
+		sections++
+	}
+	// header (2) + section titles + message and footer (3)
+	per := max((m.height-5-sections)/sections, 3)
+
+	writeSection(&b, "ours", h.OursLabel, h.Ours, per, AddedStyle)
+	if h.HasBase {
+		writeSection(&b, "base", h.BaseLabel, h.Base, per, SubtleStyle)
+	}
+	writeSection(&b, "theirs", h.TheirsLabel, h.Theirs, per, RemovedStyle)
+	if h.Resolution != merge.Unresolved {
+		writeSection(&b, "result: "+resolutionText(h.Resolution), "", h.Lines(), per, DirtyStyle)
+	}
+	return b.String()
+}
+
+// writeSection renders one side of a hunk, cut to limit lines.
+func writeSection(b *strings.Builder, title, label string, lines []string, limit int, style interface{ Render(...string) string }) {
+	if label != "" {
+		title += " (" + label + ")"
+	}
+	b.WriteString(HeaderLineStyle.Render("── " + title + " "))
+	b.WriteString("\n")
+	if len(lines) == 0 {
+		b.WriteString(SubtleStyle.Render("  (empty)"))
+		b.WriteString("\n")
+	}
+	for i, line := range lines {
+		if i == limit-1 && len(lines) > limit {
+			b.WriteString(SubtleStyle.Render(fmt.Sprintf("  … %d more lines", len(lines)-i)))
+			b.WriteString("\n")
+			break
+		}
+		b.WriteString(style.Render("  " + line))
+		b.WriteString("\n")
+	}
+}
+
+func resolutionText(res merge.Resolution) string {
+	switch res {
+	case merge.Unresolved:
+		return "unresolved"
+	case merge.ResolveBoth:
+		return "both (ours, then theirs)"
+	case merge.ResolveWorkingTree:
+		return "keep the working tree"
+	case merge.ResolveOurs, merge.ResolveTheirs, merge.ResolveEdited:
+	}
+	return string(res)
+}
+
+func (m ResolveModel) renderResolveFooter() string {
+	var hints []string
+	switch m.screen {
+	case screenRepos:
+		hints = []string{"↑↓: Navigate", "Enter: Files", "c: Continue", "A: Abort", "r: Refresh", "q: Quit"}
+	case screenFiles:
+		back := "Esc: Back"
+		if len(m.repos) == 1 {
+			back = "Esc: Quit"
+		}
+		hints = []string{"↑↓: Navigate", "Enter: Resolve", "c: Continue", "A: Abort", "r: Refresh", back}
+	case screenHunks:
+		if m.file != nil && m.file.Whole {
+			hints = []string{"o: Ours", "t: Theirs", "w: Working tree", "u: Undo", "s/Esc: Save"}
+		} else {
+			hints = []string{"o: Ours", "t: Theirs", "b: Both", "e: Edit", "u: Undo", "n/p: Next/Prev hunk", "s: Save", "Esc: Save & back"}
+		}
+	case screenConfirm:
+		return ""
+	}
+	return SubtleStyle.Render("  " + strings.Join(hints, "  │  "))
+}
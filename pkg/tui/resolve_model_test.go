@@ -0,0 +1,247 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/merge"
+)
+
+// fakeResolver serves fixed files and records what the model asks for.
+type fakeResolver struct {
+	states    map[string]*merge.RepoConflicts
+	files     map[string]*merge.ConflictFile
+	saved     []*merge.ConflictFile
+	continued []string
+	aborted   []string
+	stopAgain bool
+}
+
+func (f *fakeResolver) Inspect(_ context.Context, repoPath string) (*merge.RepoConflicts, error) {
+	return f.states[repoPath], nil
+}
+
+func (f *fakeResolver) Load(_ context.Context, _, path string) (*merge.ConflictFile, error) {
+	return f.files[path], nil
+}
+
+func (f *fakeResolver) Save(_ context.Context, repoPath string, file *merge.ConflictFile) (bool, error) {
+	f.saved = append(f.saved, file)
+	if !file.Resolved() {
+		return false, nil
+	}
+	state := f.states[repoPath]
+	state.Unmerged = nil
+	return true, nil
+}
+
+func (f *fakeResolver) Continue(_ context.Context, repoPath string) error {
+	f.continued = append(f.continued, repoPath)
+	if f.stopAgain {
+		f.states[repoPath].Unmerged = []string{"next.txt"}
+		return merge.ErrStoppedAgain
+	}
+	f.states[repoPath].Operation = merge.OperationNone
+	return nil
+}
+
+func (f *fakeResolver) Abort(_ context.Context, repoPath string) error {
+	f.aborted = append(f.aborted, repoPath)
+	f.states[repoPath] = &merge.RepoConflicts{Path: repoPath}
+	return nil
+}
+
+func twoHunkFile() *merge.ConflictFile {
+	return &merge.ConflictFile{
+		Path: "a.txt",
+		Hunks: []*merge.Hunk{
+			{Ours: []string{"ours 1"}, Theirs: []string{"theirs 1"}, Base: []string{"base 1"}, HasBase: true, OursLabel: "HEAD"},
+			{Ours: []string{"ours 2"}, Theirs: []string{"theirs 2"}},
+		},
+	}
+}
+
+func newTestResolve(t *testing.T, fake *fakeResolver, paths ...string) ResolveModel {
+	t.Helper()
+	var repos []*merge.RepoConflicts
+	for _, p := range paths {
+		repos = append(repos, fake.states[p])
+	}
+	m := NewResolveModel(context.Background(), fake, "/src", repos)
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	return model.(ResolveModel)
+}
+
+// resolveKey sends a key and runs the command it returns, feeding the result
+// back, the way the bubbletea runtime would.
+func resolveKey(t *testing.T, m ResolveModel, key string) ResolveModel {
+	t.Helper()
+	var msg tea.KeyMsg
+	switch key {
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	default:
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	}
+	model, cmd := m.Update(msg)
+	for cmd != nil {
+		next := cmd()
+		if _, ok := next.(tea.QuitMsg); ok {
+			break
+		}
+		model, cmd = model.Update(next)
+	}
+	return model.(ResolveModel)
+}
+
+func TestResolveModelHunks(t *testing.T) {
+	fake := &fakeResolver{
+		states: map[string]*merge.RepoConflicts{
+			"/src/app": {Path: "/src/app", Operation: merge.OperationRebase, Unmerged: []string{"a.txt"}},
+		},
+		files: map[string]*merge.ConflictFile{"a.txt": twoHunkFile()},
+	}
+	m := newTestResolve(t, fake, "/src/app")
+	if m.screen != screenFiles {
+		t.Fatalf("a single repository should open on its files, got screen %d", m.screen)
+	}
+
+	m = resolveKey(t, m, "enter")
+	if m.screen != screenHunks {
+		t.Fatalf("screen = %d, want hunks", m.screen)
+	}
+	view := m.View()
+	for _, want := range []string{"hunk 1/2", "ours (HEAD)", "base", "theirs", "base 1"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("hunk view missing %q:\n%s", want, view)
+		}
+	}
+
+	m = resolveKey(t, m, "t")
+	if m.file.Hunks[0].Resolution != merge.ResolveTheirs || m.hunk != 1 {
+		t.Fatalf("after t: resolution %q, hunk %d", m.file.Hunks[0].Resolution, m.hunk)
+	}
+
+	// Leaving half-way saves without staging and stays on the file list.
+	m = resolveKey(t, m, "esc")
+	if len(fake.saved) != 1 || m.screen != screenFiles {
+		t.Fatalf("saved %d, screen %d", len(fake.saved), m.screen)
+	}
+
+	fake.files["a.txt"] = twoHunkFile()
+	m = resolveKey(t, m, "enter")
+	m = resolveKey(t, m, "b")
+	m = resolveKey(t, m, "o")
+	if !m.file.Resolved() {
+		t.Fatal("both hunks should be resolved")
+	}
+	m = resolveKey(t, m, "s")
+	if m.screen != screenFiles || len(m.currentRepo().Unmerged) != 0 {
+		t.Fatalf("a resolved save should go back to an empty file list; screen %d, repo %+v", m.screen, m.currentRepo())
+	}
+	if !strings.Contains(m.View(), "c to continue") {
+		t.Errorf("file list should offer to continue:\n%s", m.View())
+	}
+
+	m = resolveKey(t, m, "c")
+	if len(fake.continued) != 1 || m.currentRepo().Stuck() {
+		t.Errorf("continue: %v, repo %+v", fake.continued, m.currentRepo())
+	}
+	if !strings.Contains(m.message, "rebase finished") {
+		t.Errorf("message = %q", m.message)
+	}
+}
+
+func TestResolveModelContinueStopsAgain(t *testing.T) {
+	fake := &fakeResolver{
+		stopAgain: true,
+		states: map[string]*merge.RepoConflicts{
+			"/src/app": {Path: "/src/app", Operation: merge.OperationRebase},
+		},
+	}
+	m := newTestResolve(t, fake, "/src/app")
+	m = resolveKey(t, m, "c")
+	if m.failed || !strings.Contains(m.message, "stopped on the next conflict") {
+		t.Errorf("message = %q (failed %v)", m.message, m.failed)
+	}
+	if got := m.currentRepo().Unmerged; len(got) != 1 || got[0] != "next.txt" {
+		t.Errorf("unmerged after stopping again = %v", got)
+	}
+}
+
+func TestResolveModelAbortAsks(t *testing.T) {
+	fake := &fakeResolver{
+		states: map[string]*merge.RepoConflicts{
+			"/src/a": {Path: "/src/a", Operation: merge.OperationMerge, Unmerged: []string{"x"}},
+			"/src/b": {Path: "/src/b", Operation: merge.OperationRebase, Unmerged: []string{"y"}},
+		},
+	}
+	m := newTestResolve(t, fake, "/src/a", "/src/b")
+	if m.screen != screenRepos {
+		t.Fatalf("several repositories should open on the repository list")
+	}
+	if view := m.View(); !strings.Contains(view, "merge: 1 unmerged") || !strings.Contains(view, "rebase: 1 unmerged") {
+		t.Errorf("repository list:\n%s", view)
+	}
+
+	m = resolveKey(t, m, "j")
+	m = resolveKey(t, m, "A")
+	if m.screen != screenConfirm || !strings.Contains(m.View(), "Abort the rebase in b") {
+		t.Fatalf("abort should ask first:\n%s", m.View())
+	}
+	m = resolveKey(t, m, "n")
+	if len(fake.aborted) != 0 || m.screen != screenRepos {
+		t.Fatalf("n should cancel; aborted %v", fake.aborted)
+	}
+
+	m = resolveKey(t, m, "A")
+	m = resolveKey(t, m, "y")
+	if len(fake.aborted) != 1 || fake.aborted[0] != "/src/b" {
+		t.Fatalf("aborted = %v", fake.aborted)
+	}
+	if m.repos[1].Stuck() {
+		t.Error("aborted repository should no longer be stuck")
+	}
+}
+
+func TestResolveModelWholeFile(t *testing.T) {
+	fake := &fakeResolver{
+		states: map[string]*merge.RepoConflicts{
+			"/src/app": {Path: "/src/app", Operation: merge.OperationMerge, Unmerged: []string{"gone.txt"}},
+		},
+		files: map[string]*merge.ConflictFile{
+			"gone.txt": {Path: "gone.txt", Whole: true, Reason: "deleted by them", HasOurs: true},
+		},
+	}
+	m := newTestResolve(t, fake, "/src/app")
+	m = resolveKey(t, m, "enter")
+	if !strings.Contains(m.View(), "theirs: deleted it") {
+		t.Errorf("whole-file view:\n%s", m.View())
+	}
+	m = resolveKey(t, m, "b")
+	if !m.failed || m.file.Choice != merge.Unresolved {
+		t.Errorf("both is not a whole-file choice; message %q", m.message)
+	}
+	m = resolveKey(t, m, "t")
+	m = resolveKey(t, m, "s")
+	if len(fake.saved) != 1 || fake.saved[0].Choice != merge.ResolveTheirs {
+		t.Fatalf("saved = %+v", fake.saved)
+	}
+}
+
+func TestHasMarkers(t *testing.T) {
+	if !hasMarkers([]string{"a", "<<<<<<< HEAD", "b"}) || !hasMarkers([]string{"======="}) {
+		t.Error("marker lines should be found")
+	}
+	if hasMarkers([]string{"a", "======== heading", "<<<<<<<<"}) {
+		t.Error("longer runs are text")
+	}
+}
@@ -32,3 +32,18 @@ var (
 	SubtleStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("240"))
 )
+
+// Diff styles for the dashboard's diff view.
+var (
+	// AddedStyle is used for added lines.
+	AddedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("2"))
+
+	// RemovedStyle is used for removed lines.
+	RemovedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("1"))
+
+	// HeaderLineStyle is used for the ---/+++ file header lines.
+	HeaderLineStyle = lipgloss.NewStyle().
+			Bold(true)
+)
@@ -4,19 +4,36 @@
 // Package watch provides repository monitoring and change detection.
 //
 // This package monitors git repositories for changes including file
-// modifications, branch updates, and remote changes.
+// modifications, staging, commits, and branch switches.
 //
-// # Features
+// # How changes are detected
 //
-//   - File system change detection
-//   - Branch change monitoring
-//   - Remote update detection
-//   - Configurable polling intervals
+// Each repository's working tree is registered with the operating system's
+// file notification API (inotify, kqueue, ReadDirectoryChangesW) directory by
+// directory, skipping directories git ignores and nested repositories, along
+// with the git directory's HEAD, index, packed-refs, and refs/. Events are
+// coalesced per repository: once a repository has been quiet for
+// DebounceDuration it is checked with a single `git status`, by one of a
+// bounded pool of workers. A batch of events that touched only ignored files
+// is dropped without a status check.
+//
+// A repository whose directories exceed the notification limit
+// (fs.inotify.max_user_watches, or WatchOptions.MaxWatchesPerRepo) is polled
+// every Interval instead; the others are never polled.
 //
 // # Usage
 //
-//	watcher := watch.NewWatcher(repoPath, watch.Options{
-//	    Interval: 5 * time.Second,
+//	watcher, err := watch.NewWatcher(repoClient, watch.WatchOptions{
+//	    DebounceDuration: 300 * time.Millisecond,
 //	})
-//	events := watcher.Watch(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	defer watcher.Stop()
+//	if err := watcher.Start(ctx, []string{repoPath}); err != nil {
+//	    return err
+//	}
+//	for event := range watcher.Events() {
+//	    fmt.Println(event.Path, event.Type)
+//	}
 package watch
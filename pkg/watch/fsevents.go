@@ -0,0 +1,322 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// routeKind says what a registered directory is to its repository.
+type routeKind int
+
+const (
+	// routeWorkTree is a directory of the working tree.
+	routeWorkTree routeKind = iota
+	// routeGitDir is the git directory itself, where HEAD and index live.
+	routeGitDir
+	// routeRefs is refs/ or a directory below it.
+	routeRefs
+)
+
+// route attributes events in one registered directory.
+type route struct {
+	state *repoState
+	kind  routeKind
+}
+
+// gitDirs locates the git-internal files change detection depends on. They
+// are in one directory except for linked worktrees, whose HEAD and index are
+// per worktree while refs and packed-refs are shared with the main checkout.
+type gitDirs struct {
+	gitDir    string
+	commonDir string
+}
+
+// gitDirFiles are the files directly in the git directory that matter:
+// HEAD (branch switch, detached checkout), index (staging), and packed-refs
+// (ref moves after gc or fetch). Git rewrites each by renaming a .lock file
+// over it, which arrives as a create of the final name.
+var gitDirFiles = map[string]bool{"HEAD": true, "index": true, "packed-refs": true}
+
+// errWatchLimit means a repository needs more watches than it may have.
+var errWatchLimit = errors.New("file watch limit reached")
+
+// resolveGitDirs asks git where the repository's git directories are. A
+// failure falls back to <path>/.git, which is right for every repository
+// that is not a linked worktree.
+func (w *watcher) resolveGitDirs(ctx context.Context, path string) gitDirs {
+	fallback := gitDirs{gitDir: filepath.Join(path, ".git"), commonDir: filepath.Join(path, ".git")}
+	lines, err := w.executor.RunLines(ctx, path, "rev-parse", "--path-format=absolute", "--git-dir", "--git-common-dir")
+	if err != nil || len(lines) != 2 {
+		return fallback
+	}
+	return gitDirs{gitDir: filepath.Clean(lines[0]), commonDir: filepath.Clean(lines[1])}
+}
+
+// loadIgnoredDirs lists the working-tree directories git ignores. One
+// `git ls-files --directory` collapses an ignored directory to a single
+// entry, so node_modules/ costs one line rather than one per file.
+func (w *watcher) loadIgnoredDirs(ctx context.Context, path string) map[string]bool {
+	ignored := make(map[string]bool)
+	out, err := w.executor.RunOutput(ctx, path, "ls-files", "-z", "--others", "--ignored", "--exclude-standard", "--directory")
+	if err != nil {
+		w.logger.Debug("listing ignored directories for %s: %v", path, err)
+		return ignored
+	}
+	for _, entry := range strings.Split(out, "\x00") {
+		if dir, ok := strings.CutSuffix(entry, "/"); ok && dir != "" {
+			ignored[filepath.Join(path, filepath.FromSlash(dir))] = true
+		}
+	}
+	return ignored
+}
+
+// registerRepo registers the working tree and git directories of state. A
+// repository over the watch limit falls back to polling; any other failure
+// is returned. Callers hold w.mu.
+func (w *watcher) registerRepo(state *repoState) error {
+	err := w.addTree(state, state.path, routeWorkTree)
+	if err == nil {
+		err = w.addDir(state, state.dirs.gitDir, routeGitDir)
+	}
+	if err == nil && state.dirs.commonDir != state.dirs.gitDir {
+		err = w.addDir(state, state.dirs.commonDir, routeGitDir)
+	}
+	if err == nil {
+		err = w.addTree(state, filepath.Join(state.dirs.commonDir, "refs"), routeRefs)
+	}
+	if err == nil {
+		return nil
+	}
+	if !isWatchLimit(err) {
+		w.unregisterRepo(state)
+		return err
+	}
+	w.fallBackToPolling(state, err)
+	return nil
+}
+
+// fallBackToPolling drops state's watches and marks it for polling. Callers
+// hold w.mu.
+func (w *watcher) fallBackToPolling(state *repoState, cause error) {
+	w.unregisterRepo(state)
+	state.polling = true
+	w.logger.Warn("%s: %v; polling every %s instead", state.path, cause, w.options.Interval)
+}
+
+// unregisterRepo removes every watch registered for state. Callers hold w.mu.
+func (w *watcher) unregisterRepo(state *repoState) {
+	for _, dir := range state.watched {
+		_ = w.fswatch.Remove(dir)
+		delete(w.routes, dir)
+	}
+	state.watched = nil
+}
+
+// addTree registers root and every directory below it, skipping .git,
+// nested repositories (they are watched on their own or not at all), and
+// directories git ignores. Callers hold w.mu.
+func (w *watcher) addTree(state *repoState, root string, kind routeKind) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			// A directory removed or made unreadable mid-walk is not a
+			// reason to abandon the repository.
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && kind == routeWorkTree {
+			if d.Name() == ".git" || state.ignoredDirs[path] || isNestedRepo(path) {
+				return filepath.SkipDir
+			}
+		}
+		return w.addDir(state, path, kind)
+	})
+}
+
+// addDir registers one directory. Callers hold w.mu.
+func (w *watcher) addDir(state *repoState, dir string, kind routeKind) error {
+	if _, ok := w.routes[dir]; ok {
+		return nil
+	}
+	if limit := w.options.MaxWatchesPerRepo; limit > 0 && len(state.watched) >= limit {
+		return fmt.Errorf("%w: more than %d directories", errWatchLimit, limit)
+	}
+	if err := w.fswatch.Add(dir); err != nil {
+		if isWatchLimit(err) {
+			return fmt.Errorf("%w: %w", errWatchLimit, err)
+		}
+		return fmt.Errorf("watch %s: %w", dir, err)
+	}
+	w.routes[dir] = route{state: state, kind: kind}
+	state.watched = append(state.watched, dir)
+	return nil
+}
+
+// isWatchLimit reports whether err means the watch could not be added for
+// lack of resources rather than because the path is bad: inotify's
+// max_user_watches (ENOSPC) or, with kqueue, the open file limit (EMFILE).
+func isWatchLimit(err error) bool {
+	return errors.Is(err, errWatchLimit) || errors.Is(err, syscall.ENOSPC) || errors.Is(err, syscall.EMFILE)
+}
+
+func isNestedRepo(dir string) bool {
+	_, err := os.Lstat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// handleFSEvent attributes one file system event to its repository and
+// schedules a check.
+func (w *watcher) handleFSEvent(ev fsnotify.Event) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// A registered directory that disappears loses its watch; forget it so
+	// the name can be registered again if it comes back.
+	if ev.Has(fsnotify.Remove) || ev.Has(fsnotify.Rename) {
+		if r, ok := w.routes[ev.Name]; ok {
+			delete(w.routes, ev.Name)
+			r.state.watched = removeString(r.state.watched, ev.Name)
+		}
+	}
+
+	r, ok := w.routes[filepath.Dir(ev.Name)]
+	if !ok || r.state.polling {
+		return
+	}
+	state := r.state
+	name := filepath.Base(ev.Name)
+
+	switch r.kind {
+	case routeGitDir:
+		if gitDirFiles[name] {
+			w.scheduleLocked(state, "", true)
+		}
+	case routeRefs:
+		if strings.HasSuffix(name, ".lock") {
+			return
+		}
+		if ev.Has(fsnotify.Create) && isDir(ev.Name) {
+			// refs/heads/feature/ appears with the first feature/* branch.
+			state.newDirs = append(state.newDirs, ev.Name)
+		}
+		w.scheduleLocked(state, "", true)
+	case routeWorkTree:
+		if name == ".git" || w.underIgnoredDir(state, ev.Name) {
+			return
+		}
+		if ev.Has(fsnotify.Create) && isDir(ev.Name) {
+			state.newDirs = append(state.newDirs, ev.Name)
+		}
+		w.scheduleLocked(state, ev.Name, false)
+	}
+}
+
+// registerNewDir registers a directory created after Start, unless git
+// ignores it. It runs on a worker because asking git is not free.
+func (w *watcher) registerNewDir(ctx context.Context, state *repoState, dir string) {
+	w.mu.RLock()
+	kind := routeWorkTree
+	if r, ok := w.routes[filepath.Dir(dir)]; ok {
+		kind = r.kind
+	}
+	polling := state.polling
+	w.mu.RUnlock()
+	if polling {
+		return
+	}
+
+	if kind == routeWorkTree && w.isIgnored(ctx, state, dir) {
+		w.mu.Lock()
+		state.ignoredDirs[dir] = true
+		w.mu.Unlock()
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.addTree(state, dir, kind); err != nil {
+		if isWatchLimit(err) {
+			w.fallBackToPolling(state, err)
+			return
+		}
+		w.logger.Debug("registering %s: %v", dir, err)
+	}
+}
+
+// underIgnoredDir reports whether path is inside a directory git ignores.
+// Callers hold w.mu.
+func (w *watcher) underIgnoredDir(state *repoState, path string) bool {
+	for dir := path; len(dir) > len(state.path); dir = filepath.Dir(dir) {
+		if state.ignoredDirs[dir] {
+			return true
+		}
+	}
+	return false
+}
+
+// isIgnored asks git whether path is ignored.
+func (w *watcher) isIgnored(ctx context.Context, state *repoState, path string) bool {
+	rel, err := filepath.Rel(state.path, path)
+	if err != nil {
+		return false
+	}
+	ok, err := w.executor.RunQuiet(ctx, state.path, "check-ignore", "-q", "--", filepath.ToSlash(rel))
+	return err == nil && ok
+}
+
+// maxIgnoreBatch bounds the paths passed to one check-ignore. Past it, a
+// plain status check is as cheap as asking.
+const maxIgnoreBatch = 64
+
+// allIgnored reports whether every path in changed is ignored by git, in
+// which case the repository's status cannot have changed. Build output and
+// editor swap files in tracked directories are the common case. Tracked
+// files are never reported as ignored, whatever the patterns say.
+func (w *watcher) allIgnored(ctx context.Context, state *repoState, changed map[string]bool) bool {
+	if len(changed) == 0 || len(changed) > maxIgnoreBatch {
+		return false
+	}
+	args := []string{"check-ignore", "--"}
+	for path := range changed {
+		rel, err := filepath.Rel(state.path, path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return false
+		}
+		args = append(args, filepath.ToSlash(rel))
+	}
+	lines, err := w.executor.RunLines(ctx, state.path, args...)
+	if err != nil {
+		// Exit status 1 means none of the paths is ignored.
+		return false
+	}
+	return len(lines) == len(changed)
+}
+
+func isDir(path string) bool {
+	info, err := os.Lstat(path)
+	return err == nil && info.IsDir()
+}
+
+func removeString(list []string, s string) []string {
+	for i, v := range list {
+		if v == s {
+			return append(list[:i], list[i+1:]...)
+		}
+	}
+	return list
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/identity"
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
 )
 
@@ -42,6 +43,30 @@ type Event struct {
 
 	// Files are the specific files that changed (if available).
 	Files []string
+
+	// Upstream describes the tracking branch for remote events (incoming,
+	// diverged, upstream-gone, foreign-work, fast-forward); nil otherwise.
+	Upstream *UpstreamStatus
+}
+
+// UpstreamStatus is the local branch measured against its upstream after a
+// background fetch.
+type UpstreamStatus struct {
+	// Branch is the local branch; Ref its upstream ("origin/main").
+	Branch string `json:"branch"`
+	Ref    string `json:"ref"`
+
+	// Ahead and Behind count commits only on the local branch and only on
+	// the upstream.
+	Ahead  int `json:"ahead"`
+	Behind int `json:"behind"`
+
+	// Gone is set when the upstream was configured but deleted on the remote.
+	Gone bool `json:"gone,omitempty"`
+
+	// Foreign lists incoming commits signed by another device or agent
+	// (foreign-work events only).
+	Foreign []repository.ForeignCommit `json:"foreign,omitempty"`
 }
 
 // EventType represents the type of change detected.
@@ -68,6 +93,26 @@ const (
 
 	// EventTypeClean indicates the repository became clean.
 	EventTypeClean EventType = "clean"
+
+	// EventTypeIncoming indicates the upstream gained commits the local
+	// branch does not have, and the local branch has none of its own.
+	EventTypeIncoming EventType = "incoming"
+
+	// EventTypeDiverged indicates the upstream gained commits while the local
+	// branch also has unpushed ones, so a pull needs a merge or rebase.
+	EventTypeDiverged EventType = "diverged"
+
+	// EventTypeUpstreamGone indicates the upstream branch was deleted on the
+	// remote.
+	EventTypeUpstreamGone EventType = "upstream-gone"
+
+	// EventTypeForeignWork indicates incoming commits were written by another
+	// device or agent: someone else is working on this branch.
+	EventTypeForeignWork EventType = "foreign-work"
+
+	// EventTypeFastForward indicates a clean repository was fast-forwarded
+	// to its upstream (WatchOptions.AutoFastForward).
+	EventTypeFastForward EventType = "fast-forward"
 )
 
 // String returns the string representation of the event type.
@@ -77,17 +122,52 @@ func (e EventType) String() string {
 
 // WatchOptions configures the watcher behavior.
 type WatchOptions struct {
-	// Interval is the polling interval for checking changes.
+	// Interval is the polling interval for repositories that could not be
+	// watched through file system notifications (see MaxWatchesPerRepo).
+	// Repositories with notifications are not polled.
 	// If not specified, defaults to 2 seconds.
 	Interval time.Duration
 
 	// IncludeClean indicates whether to send events when repository becomes clean.
 	IncludeClean bool
 
-	// DebounceDuration is the minimum time between events for the same path.
-	// This prevents duplicate events in rapid succession.
+	// DebounceDuration is how long a repository must be quiet after a file
+	// system event before it is checked. Every event inside the window is
+	// coalesced into that one check, so a checkout touching a thousand files
+	// costs one `git status`, not a thousand.
 	DebounceDuration time.Duration
 
+	// Workers bounds how many repositories are checked at once.
+	// If not specified, defaults to 4.
+	Workers int
+
+	// MaxWatchesPerRepo caps the directories registered for one repository.
+	// A repository that needs more, or that hits the operating system's
+	// limit (inotify max_user_watches), is polled every Interval instead.
+	// Zero means no cap of gz-git's own.
+	MaxWatchesPerRepo int
+
+	// SkipUnreadable makes Start log and skip a repository it cannot open,
+	// read, or watch instead of failing, so one broken repository does not
+	// stop a long-running watch of many.
+	SkipUnreadable bool
+
+	// FetchInterval enables remote-change detection: every remote of every
+	// repository is fetched about this often, with jitter so many
+	// repositories do not hit one server in lockstep, and with exponential
+	// backoff for a remote whose fetch fails. Zero disables fetching.
+	FetchInterval time.Duration
+
+	// AutoFastForward fast-forwards a repository whose working tree is clean
+	// when its upstream gains commits and the local branch has none of its
+	// own. Repositories with local changes or commits are left alone.
+	AutoFastForward bool
+
+	// Identity is this machine's writer identity. Incoming commits signed by
+	// a different one are reported as foreign-work; an unknown identity
+	// disables that check.
+	Identity identity.Identity
+
 	// Logger is the logger to use for watch operations.
 	Logger Logger
 }
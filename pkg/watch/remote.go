@@ -0,0 +1,387 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package watch
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fetchJitter spreads fetches over ±20% of the interval, so fifty
+// repositories started together do not fetch from one server in lockstep.
+const fetchJitter = 0.2
+
+// maxFetchBackoff caps how far a failing remote's next fetch is pushed out,
+// as a multiple of the interval. An unreachable server is retried every
+// 32 intervals at worst, not abandoned.
+const maxFetchBackoff = 32
+
+// fetchTimeout bounds one `git fetch`; a hung connection must not hold the
+// repository's fetch slot forever.
+const fetchTimeout = 2 * time.Minute
+
+// MinFetchInterval is the shortest FetchInterval the commands accept.
+// Anything shorter fetches every remote almost continuously.
+const MinFetchInterval = time.Second
+
+// minFetchTick keeps the fetch loop's ticker positive for an interval
+// below what the commands accept; time.NewTicker panics on zero.
+const minFetchTick = 10 * time.Millisecond
+
+// remoteState tracks background fetching for one remote.
+type remoteState struct {
+	name     string
+	next     time.Time
+	failures int
+}
+
+// upstreamState holds the fetch schedule and what the last remote check
+// saw. Guarded by watcher.mu.
+type upstreamState struct {
+	remotes  []*remoteState
+	fetching bool
+	// last is the position the last check reported, so a branch that stays
+	// diverged is not reported again on every fetch.
+	last UpstreamStatus
+	// reported holds foreign commit hashes already announced.
+	reported map[string]bool
+}
+
+// Describe summarizes the upstream position in one line, for notifications
+// and terminal output: "main ↔ origin/main: 3 behind, 1 ahead".
+func (u UpstreamStatus) Describe() string {
+	if u.Gone {
+		return fmt.Sprintf("%s: upstream %s is gone", u.Branch, u.Ref)
+	}
+	var parts []string
+	if u.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("%d behind", u.Behind))
+	}
+	if u.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("%d ahead", u.Ahead))
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "up to date")
+	}
+	s := fmt.Sprintf("%s ↔ %s: %s", u.Branch, u.Ref, strings.Join(parts, ", "))
+	if n := len(u.Foreign); n > 0 {
+		s += fmt.Sprintf("; %d commit(s) from %s", n, u.Foreign[0].Identity.Name())
+	}
+	return s
+}
+
+// initRemotes prepares fetching for state and records the current upstream
+// position as the baseline: commits that were already incoming when watch
+// started are not news.
+func (w *watcher) initRemotes(ctx context.Context, state *repoState) {
+	names, err := w.executor.RunLines(ctx, state.path, "remote")
+	if err != nil || len(names) == 0 {
+		return
+	}
+	now := time.Now()
+	up := &upstreamState{reported: make(map[string]bool)}
+	for _, name := range names {
+		// The first fetch lands anywhere in the first interval.
+		offset := time.Duration(rand.Float64() * float64(w.options.FetchInterval)) // #nosec G404 -- scheduling jitter, not security.
+		up.remotes = append(up.remotes, &remoteState{name: name, next: now.Add(offset)})
+	}
+	if status, ok := w.readUpstream(ctx, state.path, state.currentBranch); ok {
+		up.last = status
+	}
+	state.upstream = up
+}
+
+// fetchLoop fetches due remotes until ctx is canceled.
+func (w *watcher) fetchLoop(ctx context.Context) {
+	defer w.wg.Done()
+
+	tick := max(min(time.Second, w.options.FetchInterval/4), minFetchTick)
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	// Fetches share the worker budget with status checks: they are the
+	// expensive part, and a burst should not open a connection per
+	// repository at once.
+	slots := make(chan struct{}, w.options.Workers)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, job := range w.dueFetches(now) {
+				select {
+				case slots <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+				w.wg.Add(1)
+				go func() {
+					defer w.wg.Done()
+					defer func() { <-slots }()
+					w.fetchRepo(ctx, job.state, job.remotes)
+				}()
+			}
+		}
+	}
+}
+
+type fetchJob struct {
+	state   *repoState
+	remotes []*remoteState
+}
+
+// dueFetches claims every repository with a remote due at now. A repository
+// already fetching is skipped: concurrent fetches in one repository contend
+// for the same ref locks.
+func (w *watcher) dueFetches(now time.Time) []fetchJob {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var jobs []fetchJob
+	for _, state := range w.watching {
+		up := state.upstream
+		if up == nil || up.fetching || state.stopping {
+			continue
+		}
+		var due []*remoteState
+		for _, r := range up.remotes {
+			if !now.Before(r.next) {
+				due = append(due, r)
+			}
+		}
+		if len(due) > 0 {
+			up.fetching = true
+			jobs = append(jobs, fetchJob{state: state, remotes: due})
+		}
+	}
+	return jobs
+}
+
+// fetchRepo fetches remotes, reschedules each with jitter or backoff, and
+// reports upstream changes.
+func (w *watcher) fetchRepo(ctx context.Context, state *repoState, remotes []*remoteState) {
+	defer func() {
+		w.mu.Lock()
+		state.upstream.fetching = false
+		w.mu.Unlock()
+	}()
+
+	// Measure from the current HEAD before fetching: a pull, rebase or local
+	// commit since the last check has moved it, and only what the fetch
+	// brings in is news.
+	w.mu.RLock()
+	branch := state.currentBranch
+	w.mu.RUnlock()
+	before, measured := w.readUpstream(ctx, state.path, branch)
+
+	fetched := false
+	for _, r := range remotes {
+		fetchCtx, cancel := context.WithTimeout(ctx, fetchTimeout)
+		_, err := w.executor.RunOutput(fetchCtx, state.path, "fetch", "--quiet", "--prune", "--no-write-fetch-head", r.name)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+
+		w.mu.Lock()
+		if err != nil {
+			r.failures++
+			r.next = time.Now().Add(w.fetchDelay(r.failures))
+		} else {
+			r.failures = 0
+			r.next = time.Now().Add(w.fetchDelay(0))
+			fetched = true
+		}
+		failures, next := r.failures, r.next
+		w.mu.Unlock()
+
+		if err != nil {
+			// Report the first failure of a streak; the rest would repeat it
+			// every interval for as long as the network is down.
+			if failures == 1 {
+				w.sendError(ctx, fmt.Errorf("fetch %s in %s: %w", r.name, state.path, err))
+			}
+			w.logger.Debug("fetch %s in %s failed (%d in a row); next attempt %s", r.name, state.path, failures, next.Format(time.TimeOnly))
+		}
+	}
+	if fetched {
+		w.checkUpstream(ctx, state, before, measured)
+	}
+}
+
+// fetchDelay returns the wait before the next fetch after failures
+// consecutive failures: the interval with jitter, doubled per failure.
+func (w *watcher) fetchDelay(failures int) time.Duration {
+	base := w.options.FetchInterval
+	if failures > 0 {
+		base *= time.Duration(min(1<<min(failures, 6), maxFetchBackoff))
+	}
+	jitter := (rand.Float64()*2 - 1) * fetchJitter // #nosec G404 -- scheduling jitter, not security.
+	return base + time.Duration(float64(base)*jitter)
+}
+
+// checkUpstream compares the branch with its upstream after a fetch and
+// sends the events for what changed. before is the position measured just
+// before the fetch; measured is false when there was none.
+func (w *watcher) checkUpstream(ctx context.Context, state *repoState, before UpstreamStatus, measured bool) {
+	w.mu.RLock()
+	branch := state.currentBranch
+	last := state.upstream.last
+	clean := state.lastStatus != nil && state.lastStatus.IsClean
+	w.mu.RUnlock()
+
+	current, ok := w.readUpstream(ctx, state.path, branch)
+	if !ok {
+		return
+	}
+	if !measured || current.Branch != before.Branch || current.Ref != before.Ref {
+		// A different branch or upstream: start a new baseline.
+		w.setUpstream(state, current)
+		return
+	}
+
+	var events []Event
+	emit := func(typ EventType, status UpstreamStatus) {
+		s := status
+		events = append(events, Event{Path: state.path, Type: typ, Timestamp: time.Now(), Upstream: &s})
+	}
+
+	incoming := !current.Gone && current.Behind > before.Behind
+	// A branch that was already behind diverges when local commits are
+	// added, with nothing new fetched; report that once, not every fetch.
+	diverged := !current.Gone && current.Ahead > 0 && current.Behind > 0
+	wasDiverged := last.Branch == current.Branch && last.Ref == current.Ref &&
+		!last.Gone && last.Ahead > 0 && last.Behind > 0
+
+	switch {
+	case current.Gone && !last.Gone:
+		emit(EventTypeUpstreamGone, current)
+	case current.Gone:
+	case diverged && (incoming || !wasDiverged):
+		emit(EventTypeDiverged, current)
+	case incoming:
+		emit(EventTypeIncoming, current)
+	}
+
+	if incoming {
+		if foreign := w.newForeignWork(ctx, state); len(foreign.Foreign) > 0 {
+			foreign.Branch, foreign.Ref = current.Branch, current.Ref
+			foreign.Ahead, foreign.Behind = current.Ahead, current.Behind
+			emit(EventTypeForeignWork, foreign)
+		}
+		if w.options.AutoFastForward && current.Ahead == 0 && clean {
+			if w.fastForward(ctx, state) {
+				current.Behind = 0
+				emit(EventTypeFastForward, current)
+			}
+		}
+	}
+
+	w.setUpstream(state, current)
+	for _, event := range events {
+		select {
+		case w.events <- event:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (w *watcher) setUpstream(state *repoState, status UpstreamStatus) {
+	w.mu.Lock()
+	state.upstream.last = status
+	w.mu.Unlock()
+}
+
+// newForeignWork returns the incoming foreign commits not reported before.
+func (w *watcher) newForeignWork(ctx context.Context, state *repoState) UpstreamStatus {
+	if !w.options.Identity.Known() {
+		return UpstreamStatus{}
+	}
+	commits, err := w.client.IncomingForeignWork(ctx, state.path, w.options.Identity)
+	if err != nil {
+		w.logger.Debug("foreign work check for %s: %v", state.path, err)
+		return UpstreamStatus{}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var status UpstreamStatus
+	for _, c := range commits {
+		if state.upstream.reported[c.Hash] {
+			continue
+		}
+		state.upstream.reported[c.Hash] = true
+		status.Foreign = append(status.Foreign, c)
+	}
+	return status
+}
+
+// fastForward moves the branch to its upstream. --ff-only refuses anything
+// but a fast-forward, and git refuses to overwrite local changes, so a race
+// with the user editing files fails safely instead of merging.
+func (w *watcher) fastForward(ctx context.Context, state *repoState) bool {
+	if _, err := w.executor.RunOutput(ctx, state.path, "merge", "--ff-only", "--quiet", "@{upstream}"); err != nil {
+		w.logger.Warn("fast-forward %s: %v", state.path, err)
+		return false
+	}
+	// Record the new HEAD so the local check that the ref change triggers
+	// does not report the fast-forward a second time as a commit.
+	branch, head := w.readHead(ctx, state.path)
+	w.mu.Lock()
+	state.currentBranch, state.head = branch, head
+	w.mu.Unlock()
+	return true
+}
+
+// readUpstream measures branch against its upstream with one for-each-ref.
+// ok is false for a detached HEAD or a branch without an upstream.
+func (w *watcher) readUpstream(ctx context.Context, path, branch string) (UpstreamStatus, bool) {
+	if branch == "" {
+		return UpstreamStatus{}, false
+	}
+	out, err := w.executor.RunOutput(ctx, path, "for-each-ref",
+		"--format=%(upstream:short)%00%(upstream:track)", "refs/heads/"+branch)
+	if err != nil {
+		return UpstreamStatus{}, false
+	}
+	ref, track, _ := strings.Cut(out, "\x00")
+	if ref == "" {
+		return UpstreamStatus{}, false
+	}
+	status := UpstreamStatus{Branch: branch, Ref: ref}
+	status.Ahead, status.Behind, status.Gone = parseTrack(track)
+	return status, true
+}
+
+// parseTrack parses %(upstream:track): "", "[gone]", "[ahead 2]",
+// "[behind 3]", or "[ahead 2, behind 3]".
+func parseTrack(track string) (ahead, behind int, gone bool) {
+	track = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(track), "["), "]")
+	if track == "gone" {
+		return 0, 0, true
+	}
+	for part := range strings.SplitSeq(track, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), " ")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+		switch key {
+		case "ahead":
+			ahead = n
+		case "behind":
+			behind = n
+		}
+	}
+	return ahead, behind, false
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package watch
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/identity"
+)
+
+func TestParseTrack(t *testing.T) {
+	tests := []struct {
+		track        string
+		ahead, behnd int
+		gone         bool
+	}{
+		{"", 0, 0, false},
+		{"[gone]", 0, 0, true},
+		{"[ahead 2]", 2, 0, false},
+		{"[behind 3]", 0, 3, false},
+		{"[ahead 2, behind 3]", 2, 3, false},
+	}
+	for _, tt := range tests {
+		ahead, behind, gone := parseTrack(tt.track)
+		if ahead != tt.ahead || behind != tt.behnd || gone != tt.gone {
+			t.Errorf("parseTrack(%q) = %d, %d, %v; want %d, %d, %v", tt.track, ahead, behind, gone, tt.ahead, tt.behnd, tt.gone)
+		}
+	}
+}
+
+func TestUpstreamStatusDescribe(t *testing.T) {
+	tests := []struct {
+		status UpstreamStatus
+		want   string
+	}{
+		{UpstreamStatus{Branch: "main", Ref: "origin/main", Behind: 3, Ahead: 1}, "main ↔ origin/main: 3 behind, 1 ahead"},
+		{UpstreamStatus{Branch: "main", Ref: "origin/main"}, "main ↔ origin/main: up to date"},
+		{UpstreamStatus{Branch: "topic", Ref: "origin/topic", Gone: true}, "topic: upstream origin/topic is gone"},
+	}
+	for _, tt := range tests {
+		if got := tt.status.Describe(); got != tt.want {
+			t.Errorf("Describe() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestFetchDelayBacksOff(t *testing.T) {
+	w := &watcher{options: WatchOptions{FetchInterval: time.Minute}}
+	within := func(d, base time.Duration) bool {
+		return d >= base-base/5 && d <= base+base/5
+	}
+	if d := w.fetchDelay(0); !within(d, time.Minute) {
+		t.Errorf("fetchDelay(0) = %s, want 1m ±20%%", d)
+	}
+	if d := w.fetchDelay(2); !within(d, 4*time.Minute) {
+		t.Errorf("fetchDelay(2) = %s, want 4m ±20%%", d)
+	}
+	if d := w.fetchDelay(20); !within(d, maxFetchBackoff*time.Minute) {
+		t.Errorf("fetchDelay(20) = %s, want the %dm cap ±20%%", d, maxFetchBackoff)
+	}
+}
+
+// remoteFixture is a bare origin with two clones: local is watched, other
+// pushes to origin as another machine would.
+type remoteFixture struct {
+	origin, local, other string
+}
+
+func newRemoteFixture(t *testing.T) remoteFixture {
+	t.Helper()
+	root := t.TempDir()
+	f := remoteFixture{
+		origin: filepath.Join(root, "origin.git"),
+		local:  filepath.Join(root, "local"),
+		other:  filepath.Join(root, "other"),
+	}
+	runGit(t, root, "init", "-q", "--bare", "-b", "main", f.origin)
+	runGit(t, root, "clone", "-q", f.origin, f.other)
+	initIdentity(t, f.other)
+	runGit(t, f.other, "checkout", "-q", "-b", "main")
+	commitFile(t, f.other, "README", "hello")
+	runGit(t, f.other, "push", "-q", "-u", "origin", "main")
+	runGit(t, root, "clone", "-q", f.origin, f.local)
+	initIdentity(t, f.local)
+	return f
+}
+
+func initIdentity(t *testing.T, dir string) {
+	t.Helper()
+	runGit(t, dir, "config", "user.name", "Test User")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+}
+
+func headOf(t *testing.T, dir string) string {
+	t.Helper()
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output() //nolint:noctx // test helper
+	if err != nil {
+		t.Fatalf("rev-parse HEAD in %s: %v", dir, err)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestWatchIntegration_Incoming(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	f := newRemoteFixture(t)
+	w := startTestWatcher(t, f.local, WatchOptions{FetchInterval: 200 * time.Millisecond})
+
+	commitFile(t, f.other, "new.txt", "new")
+	runGit(t, f.other, "push", "-q")
+
+	event := waitForEvent(t, w, EventTypeIncoming)
+	if event.Upstream == nil || event.Upstream.Behind != 1 || event.Upstream.Ref != "origin/main" {
+		t.Errorf("upstream = %+v, want 1 behind origin/main", event.Upstream)
+	}
+}
+
+func TestWatchIntegration_AutoFastForward(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	f := newRemoteFixture(t)
+	w := startTestWatcher(t, f.local, WatchOptions{FetchInterval: 200 * time.Millisecond, AutoFastForward: true})
+
+	commitFile(t, f.other, "new.txt", "new")
+	runGit(t, f.other, "push", "-q")
+
+	waitForEvent(t, w, EventTypeFastForward)
+	if got, want := headOf(t, f.local), headOf(t, f.other); got != want {
+		t.Errorf("local HEAD = %s, want fast-forwarded to %s", got, want)
+	}
+}
+
+func TestWatchIntegration_Diverged(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	f := newRemoteFixture(t)
+	commitFile(t, f.local, "mine.txt", "mine")
+	w := startTestWatcher(t, f.local, WatchOptions{FetchInterval: 200 * time.Millisecond, AutoFastForward: true})
+
+	commitFile(t, f.other, "theirs.txt", "theirs")
+	runGit(t, f.other, "push", "-q")
+
+	event := waitForEvent(t, w, EventTypeDiverged)
+	if event.Upstream == nil || event.Upstream.Ahead != 1 || event.Upstream.Behind != 1 {
+		t.Errorf("upstream = %+v, want 1 ahead, 1 behind", event.Upstream)
+	}
+}
+
+func TestWatchIntegration_DivergedByLocalCommit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	f := newRemoteFixture(t)
+	w := startTestWatcher(t, f.local, WatchOptions{FetchInterval: 200 * time.Millisecond})
+
+	commitFile(t, f.other, "theirs.txt", "theirs")
+	runGit(t, f.other, "push", "-q")
+	waitForEvent(t, w, EventTypeIncoming)
+
+	// Committing while behind diverges with nothing new to fetch.
+	commitFile(t, f.local, "mine.txt", "mine")
+	event := waitForEvent(t, w, EventTypeDiverged)
+	if event.Upstream == nil || event.Upstream.Ahead != 1 || event.Upstream.Behind != 1 {
+		t.Errorf("upstream = %+v, want 1 ahead, 1 behind", event.Upstream)
+	}
+}
+
+func TestWatchIntegration_IncomingAfterPull(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	f := newRemoteFixture(t)
+	commitFile(t, f.other, "one.txt", "one")
+	runGit(t, f.other, "push", "-q")
+	runGit(t, f.local, "fetch", "-q")
+	w := startTestWatcher(t, f.local, WatchOptions{FetchInterval: 200 * time.Millisecond})
+
+	// The baseline is 1 behind; after the pull, one new commit is still
+	// news although the count does not rise above it.
+	runGit(t, f.local, "pull", "-q", "--ff-only")
+	commitFile(t, f.other, "two.txt", "two")
+	runGit(t, f.other, "push", "-q")
+
+	event := waitForEvent(t, w, EventTypeIncoming)
+	if event.Upstream == nil || event.Upstream.Behind != 1 {
+		t.Errorf("upstream = %+v, want 1 behind", event.Upstream)
+	}
+}
+
+func TestWatchIntegration_TinyFetchInterval(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	f := newRemoteFixture(t)
+	// FetchInterval/4 rounds to zero here; the loop must not panic.
+	w := startTestWatcher(t, f.local, WatchOptions{FetchInterval: 3 * time.Nanosecond})
+
+	commitFile(t, f.other, "new.txt", "new")
+	runGit(t, f.other, "push", "-q")
+	waitForEvent(t, w, EventTypeIncoming)
+}
+
+func TestWatchIntegration_UpstreamGone(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	f := newRemoteFixture(t)
+	runGit(t, f.local, "checkout", "-q", "-b", "topic")
+	runGit(t, f.local, "push", "-q", "-u", "origin", "topic")
+	w := startTestWatcher(t, f.local, WatchOptions{FetchInterval: 200 * time.Millisecond})
+
+	runGit(t, f.other, "push", "-q", "origin", "--delete", "topic")
+
+	waitForEvent(t, w, EventTypeUpstreamGone)
+}
+
+func TestWatchIntegration_ForeignWork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	f := newRemoteFixture(t)
+	mine := identity.Identity{Device: "laptop"}
+	w := startTestWatcher(t, f.local, WatchOptions{FetchInterval: 200 * time.Millisecond, Identity: mine})
+
+	writeFile(t, filepath.Join(f.other, "agent.txt"), "agent")
+	gitAdd(t, f.other, "agent.txt")
+	runGit(t, f.other, "commit", "-q", "-m", "agent work\n\nDevice: desktop")
+	runGit(t, f.other, "push", "-q")
+
+	event := waitForEvent(t, w, EventTypeForeignWork)
+	if event.Upstream == nil || len(event.Upstream.Foreign) != 1 {
+		t.Fatalf("upstream = %+v, want one foreign commit", event.Upstream)
+	}
+}
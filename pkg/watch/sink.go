@@ -0,0 +1,550 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Sink types accepted in SinkConfig.Type.
+const (
+	SinkExec    = "exec"
+	SinkFile    = "file"
+	SinkWebhook = "webhook"
+	SinkNotify  = "notify"
+)
+
+// SinkTypes lists the accepted sink types.
+var SinkTypes = []string{SinkExec, SinkFile, SinkWebhook, SinkNotify}
+
+// defaultSinkTimeout bounds one delivery of an exec, webhook, or notify sink,
+// so a hung hook cannot hold its queue forever.
+const defaultSinkTimeout = 10 * time.Second
+
+// sinkQueueSize is how many events may wait for one sink before new ones are
+// dropped. A sink that is slower than the repository changes loses events
+// rather than delaying the terminal output or the other sinks.
+const sinkQueueSize = 64
+
+// EventRecord is the JSON form of an Event. It is what `watch --format json`
+// prints and what every sink receives, so a hook written against one works
+// against the other.
+type EventRecord struct {
+	Timestamp string   `json:"timestamp"`
+	Path      string   `json:"path"`
+	Type      string   `json:"type"`
+	Files     []string `json:"files"`
+	// Upstream is set for remote events only.
+	Upstream *UpstreamStatus `json:"upstream,omitempty"`
+}
+
+// NewEventRecord converts event to its JSON form. Files is never null.
+func NewEventRecord(event Event) EventRecord {
+	files := event.Files
+	if files == nil {
+		files = []string{}
+	}
+	return EventRecord{
+		Timestamp: event.Timestamp.Format(time.RFC3339),
+		Path:      event.Path,
+		Type:      string(event.Type),
+		Files:     files,
+		Upstream:  event.Upstream,
+	}
+}
+
+// Sink receives watch events outside the terminal: a hook command, a log
+// file, a webhook, a desktop notification.
+type Sink interface {
+	// Name identifies the sink in warnings.
+	Name() string
+
+	// Deliver hands one event to the sink. It is called from a single
+	// goroutine per sink, so implementations need no locking of their own.
+	Deliver(ctx context.Context, event Event) error
+}
+
+// SinkConfig declares one sink in the watch section of .gz-git.yaml:
+//
+//	watch:
+//	  sinks:
+//	    - type: exec
+//	      command: [make, test]
+//	      events: [modified, staged]
+//	      limit: 1
+//	      per: 30s
+//	    - type: file
+//	      path: .git/gz-git-watch.ndjson
+//	    - type: webhook
+//	      url: https://hooks.example.com/gz-git
+//	      headers:
+//	        Authorization: Bearer ${WATCH_HOOK_TOKEN}
+//	    - type: notify
+//	      events: [clean]
+//
+// URL and header values may use ${VAR} expansion so secrets stay out of the
+// committed file.
+type SinkConfig struct {
+	// Type is exec, file, webhook, or notify.
+	Type string `yaml:"type"`
+
+	// Name labels the sink in warnings; it defaults to the type and target.
+	Name string `yaml:"name,omitempty"`
+
+	// Command is the argv an exec sink runs for each event. It is not passed
+	// through a shell; the event JSON arrives on stdin, and the event type
+	// and repository path in GZ_GIT_WATCH_EVENT and GZ_GIT_WATCH_PATH.
+	Command []string `yaml:"command,omitempty"`
+
+	// Path is the file a file sink appends NDJSON to. A relative path is
+	// relative to the directory `watch` was started in.
+	Path string `yaml:"path,omitempty"`
+
+	// URL is the endpoint a webhook sink POSTs the event JSON to.
+	URL string `yaml:"url,omitempty"`
+
+	// Headers are added to every webhook request.
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	// Events restricts the sink to these event types. Empty means all.
+	Events []string `yaml:"events,omitempty"`
+
+	// Limit and Per rate-limit the sink to Limit deliveries in any Per window
+	// (for example limit: 1, per: 30s). Events over the limit are dropped,
+	// not queued: a hook that rebuilds the project needs the latest change,
+	// not a backlog of every one. Zero Limit means unlimited.
+	Limit int    `yaml:"limit,omitempty"`
+	Per   string `yaml:"per,omitempty"`
+
+	// Timeout bounds one delivery (default 10s). Ignored by file sinks.
+	Timeout string `yaml:"timeout,omitempty"`
+}
+
+// Validate reports configuration errors without building the sink.
+func (c SinkConfig) Validate() error {
+	switch c.Type {
+	case SinkExec:
+		if len(c.Command) == 0 || strings.TrimSpace(c.Command[0]) == "" {
+			return fmt.Errorf("exec sink requires command")
+		}
+	case SinkFile:
+		if strings.TrimSpace(c.Path) == "" {
+			return fmt.Errorf("file sink requires path")
+		}
+	case SinkWebhook:
+		if strings.TrimSpace(c.URL) == "" {
+			return fmt.Errorf("webhook sink requires url")
+		}
+	case SinkNotify:
+	case "":
+		return fmt.Errorf("sink type is required (want one of: %s)", strings.Join(SinkTypes, ", "))
+	default:
+		return fmt.Errorf("unknown sink type %q (want one of: %s)", c.Type, strings.Join(SinkTypes, ", "))
+	}
+	for _, e := range c.Events {
+		if !isEventType(e) {
+			return fmt.Errorf("unknown event type %q", e)
+		}
+	}
+	if c.Limit < 0 {
+		return fmt.Errorf("limit must not be negative")
+	}
+	if _, err := parseOptionalDuration("per", c.Per); err != nil {
+		return err
+	}
+	if c.Limit > 0 && c.Per == "" {
+		return fmt.Errorf("limit requires per")
+	}
+	if _, err := parseOptionalDuration("timeout", c.Timeout); err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewSink builds the sink c describes, wrapped in its event filter and rate
+// limit.
+func NewSink(c SinkConfig) (Sink, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	timeout, _ := parseOptionalDuration("timeout", c.Timeout)
+	if timeout == 0 {
+		timeout = defaultSinkTimeout
+	}
+
+	var inner Sink
+	switch c.Type {
+	case SinkExec:
+		inner = &execSink{argv: c.Command, timeout: timeout}
+	case SinkFile:
+		inner = &fileSink{path: c.Path}
+	case SinkWebhook:
+		headers := make(map[string]string, len(c.Headers))
+		for k, v := range c.Headers {
+			headers[k] = os.ExpandEnv(v)
+		}
+		inner = &webhookSink{url: os.ExpandEnv(c.URL), headers: headers, client: &http.Client{Timeout: timeout}}
+	case SinkNotify:
+		inner = &notifySink{timeout: timeout}
+	}
+
+	per, _ := parseOptionalDuration("per", c.Per)
+	return &filteredSink{
+		Sink:   inner,
+		name:   c.displayName(),
+		events: c.Events,
+		limit:  newWindowLimiter(c.Limit, per),
+	}, nil
+}
+
+func (c SinkConfig) displayName() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	switch c.Type {
+	case SinkExec:
+		return "exec:" + filepath.Base(c.Command[0])
+	case SinkFile:
+		return "file:" + c.Path
+	case SinkWebhook:
+		// The URL may carry a token in its query; the scheme and host are
+		// enough to tell webhooks apart in a warning.
+		if scheme, rest, ok := strings.Cut(c.URL, "://"); ok {
+			host, _, _ := strings.Cut(rest, "/")
+			return "webhook:" + scheme + "://" + host
+		}
+		return "webhook"
+	default:
+		return c.Type
+	}
+}
+
+func parseOptionalDuration(field, s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", field, s, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%s must be positive", field)
+	}
+	return d, nil
+}
+
+func isEventType(s string) bool {
+	switch EventType(s) {
+	case EventTypeModified, EventTypeStaged, EventTypeUntracked, EventTypeDeleted,
+		EventTypeCommit, EventTypeBranch, EventTypeClean,
+		EventTypeIncoming, EventTypeDiverged, EventTypeUpstreamGone, EventTypeForeignWork, EventTypeFastForward:
+		return true
+	}
+	return false
+}
+
+// errSinkFiltered and errSinkRateLimited are returned by filteredSink for
+// events it did not deliver. Dispatcher treats both as success.
+var (
+	errSinkFiltered    = errors.New("event type not selected")
+	errSinkRateLimited = errors.New("rate limited")
+)
+
+// filteredSink applies a sink's event filter and rate limit before
+// delivering.
+type filteredSink struct {
+	Sink
+	name   string
+	events []string
+	limit  *windowLimiter
+}
+
+func (s *filteredSink) Name() string { return s.name }
+
+func (s *filteredSink) Deliver(ctx context.Context, event Event) error {
+	if len(s.events) > 0 && !containsString(s.events, string(event.Type)) {
+		return errSinkFiltered
+	}
+	if !s.limit.Allow(time.Now()) {
+		return errSinkRateLimited
+	}
+	return s.Sink.Deliver(ctx, event)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// windowLimiter allows at most limit calls in any sliding window of length
+// per. A nil limiter allows everything.
+type windowLimiter struct {
+	limit int
+	per   time.Duration
+	sent  []time.Time
+}
+
+func newWindowLimiter(limit int, per time.Duration) *windowLimiter {
+	if limit <= 0 || per <= 0 {
+		return nil
+	}
+	return &windowLimiter{limit: limit, per: per}
+}
+
+// Allow records a delivery at now and reports whether it is within the limit.
+func (l *windowLimiter) Allow(now time.Time) bool {
+	if l == nil {
+		return true
+	}
+	cutoff := now.Add(-l.per)
+	kept := l.sent[:0]
+	for _, t := range l.sent {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	l.sent = kept
+	if len(l.sent) >= l.limit {
+		return false
+	}
+	l.sent = append(l.sent, now)
+	return true
+}
+
+// execSink runs a command per event with the event JSON on stdin.
+type execSink struct {
+	argv    []string
+	timeout time.Duration
+}
+
+func (s *execSink) Name() string { return "exec" }
+
+func (s *execSink) Deliver(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(NewEventRecord(event))
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, s.argv[0], s.argv[1:]...) // #nosec G204 -- argv comes from the project's own watch config.
+	cmd.Stdin = bytes.NewReader(append(payload, '\n'))
+	cmd.Env = append(os.Environ(),
+		"GZ_GIT_WATCH_EVENT="+string(event.Type),
+		"GZ_GIT_WATCH_PATH="+event.Path,
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s: %w: %s", s.argv[0], err, msg)
+		}
+		return fmt.Errorf("%s: %w", s.argv[0], err)
+	}
+	return nil
+}
+
+// fileSink appends one JSON line per event. The file is opened per event so
+// it can be rotated or removed while watch runs.
+type fileSink struct {
+	path string
+}
+
+func (s *fileSink) Name() string { return "file" }
+
+func (s *fileSink) Deliver(_ context.Context, event Event) error {
+	payload, err := json.Marshal(NewEventRecord(event))
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+	if dir := filepath.Dir(s.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create %s: %w", dir, err)
+		}
+	}
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644) // #nosec G302 G304 -- a log file at a path the project configured.
+	if err != nil {
+		return fmt.Errorf("open %s: %w", s.path, err)
+	}
+	if _, err := f.Write(append(payload, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("write %s: %w", s.path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("write %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// webhookSink POSTs the event JSON to a URL.
+type webhookSink struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+func (s *webhookSink) Name() string { return "webhook" }
+
+func (s *webhookSink) Deliver(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(NewEventRecord(event))
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "gz-git-watch")
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook: %s", resp.Status)
+	}
+	return nil
+}
+
+// notifySink shows a desktop notification through notify-send, which is
+// what freedesktop desktops (GNOME, KDE, XFCE, most tiling setups with a
+// notification daemon) provide.
+type notifySink struct {
+	timeout time.Duration
+	// command is overridden in tests.
+	command string
+}
+
+func (s *notifySink) Name() string { return "notify" }
+
+func (s *notifySink) Deliver(ctx context.Context, event Event) error {
+	command := s.command
+	if command == "" {
+		command = "notify-send"
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	summary, body := NotificationText(event)
+	cmd := exec.CommandContext(ctx, command, "--app-name=gz-git", summary, body) // #nosec G204 -- fixed program; summary and body are single arguments.
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %w: %s", command, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// NotificationText renders event as a short title and body for a desktop
+// notification.
+func NotificationText(event Event) (summary, body string) {
+	summary = fmt.Sprintf("%s: %s", filepath.Base(event.Path), event.Type)
+	if up := event.Upstream; up != nil {
+		return summary, up.Describe()
+	}
+	const maxFiles = 5
+	files := event.Files
+	more := 0
+	if len(files) > maxFiles {
+		more = len(files) - maxFiles
+		files = files[:maxFiles]
+	}
+	body = strings.Join(files, "\n")
+	if more > 0 {
+		body += fmt.Sprintf("\n… and %d more", more)
+	}
+	return summary, body
+}
+
+// Dispatcher fans events out to sinks. Each sink has its own queue and
+// goroutine, so a slow webhook delays neither the terminal output nor the
+// other sinks; a full queue drops the event with a warning.
+type Dispatcher struct {
+	logger Logger
+	sinks  []Sink
+	queues []chan Event
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+}
+
+// NewDispatcher starts one worker per sink. Delivery errors are reported to
+// logger as warnings and never stop the watch.
+func NewDispatcher(ctx context.Context, sinks []Sink, logger Logger) *Dispatcher {
+	if logger == nil {
+		logger = &noopLogger{}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	d := &Dispatcher{logger: logger, sinks: sinks, cancel: cancel}
+	for _, sink := range sinks {
+		queue := make(chan Event, sinkQueueSize)
+		d.queues = append(d.queues, queue)
+		d.wg.Add(1)
+		go d.run(ctx, sink, queue)
+	}
+	return d
+}
+
+func (d *Dispatcher) run(ctx context.Context, sink Sink, queue <-chan Event) {
+	defer d.wg.Done()
+	for event := range queue {
+		err := sink.Deliver(ctx, event)
+		switch {
+		case err == nil, errors.Is(err, errSinkFiltered):
+		case errors.Is(err, errSinkRateLimited):
+			d.logger.Debug("sink %s: dropped %s event for %s: rate limited", sink.Name(), event.Type, event.Path)
+		default:
+			d.logger.Warn("sink %s: %v", sink.Name(), err)
+		}
+	}
+}
+
+// Dispatch queues event for every sink without blocking.
+func (d *Dispatcher) Dispatch(event Event) {
+	for i, queue := range d.queues {
+		select {
+		case queue <- event:
+		default:
+			d.logger.Warn("sink %s: queue full, dropped %s event for %s", d.sinks[i].Name(), event.Type, event.Path)
+		}
+	}
+}
+
+// Close stops accepting events and waits for queued ones to be delivered,
+// up to timeout; deliveries still running after it are canceled.
+func (d *Dispatcher) Close(timeout time.Duration) {
+	for _, queue := range d.queues {
+		close(queue)
+	}
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		d.cancel()
+		<-done
+	}
+	d.cancel()
+}
@@ -0,0 +1,291 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package watch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testEvent(typ EventType) Event {
+	return Event{
+		Path:      "/repos/app",
+		Type:      typ,
+		Timestamp: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+		Files:     []string{"main.go"},
+	}
+}
+
+func TestSinkConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     SinkConfig
+		wantErr string
+	}{
+		{"exec ok", SinkConfig{Type: SinkExec, Command: []string{"true"}}, ""},
+		{"exec without command", SinkConfig{Type: SinkExec}, "requires command"},
+		{"file without path", SinkConfig{Type: SinkFile}, "requires path"},
+		{"webhook without url", SinkConfig{Type: SinkWebhook}, "requires url"},
+		{"notify ok", SinkConfig{Type: SinkNotify}, ""},
+		{"missing type", SinkConfig{}, "type is required"},
+		{"unknown type", SinkConfig{Type: "email"}, "unknown sink type"},
+		{"unknown event", SinkConfig{Type: SinkNotify, Events: []string{"pushed"}}, "unknown event type"},
+		{"limit without per", SinkConfig{Type: SinkNotify, Limit: 2}, "limit requires per"},
+		{"bad per", SinkConfig{Type: SinkNotify, Limit: 1, Per: "soon"}, "invalid per"},
+		{"bad timeout", SinkConfig{Type: SinkNotify, Timeout: "-1s"}, "timeout must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFileSinkAppendsNDJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "watch.ndjson")
+	sink, err := NewSink(SinkConfig{Type: SinkFile, Path: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, typ := range []EventType{EventTypeModified, EventTypeClean} {
+		if err := sink.Deliver(context.Background(), testEvent(typ)); err != nil {
+			t.Fatalf("Deliver: %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var types []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec EventRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		types = append(types, rec.Type)
+	}
+	if strings.Join(types, ",") != "modified,clean" {
+		t.Errorf("types = %v, want [modified clean]", types)
+	}
+}
+
+func TestExecSinkReceivesEventOnStdin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	out := filepath.Join(t.TempDir(), "out")
+	sink, err := NewSink(SinkConfig{
+		Type:    SinkExec,
+		Command: []string{"sh", "-c", `cat > "$1"; echo "$GZ_GIT_WATCH_EVENT" >> "$1"`, "hook", out},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Deliver(context.Background(), testEvent(EventTypeStaged)); err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("hook output = %q, want JSON line and event type", data)
+	}
+	var rec EventRecord
+	if err := json.Unmarshal([]byte(lines[0]), &rec); err != nil {
+		t.Fatalf("stdin was not event JSON: %v", err)
+	}
+	if rec.Path != "/repos/app" || rec.Type != "staged" {
+		t.Errorf("record = %+v", rec)
+	}
+	if lines[1] != "staged" {
+		t.Errorf("GZ_GIT_WATCH_EVENT = %q, want staged", lines[1])
+	}
+}
+
+func TestExecSinkReportsFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	sink, err := NewSink(SinkConfig{Type: SinkExec, Command: []string{"sh", "-c", "echo broken >&2; exit 3"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sink.Deliver(context.Background(), testEvent(EventTypeModified))
+	if err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Fatalf("Deliver() = %v, want error with hook stderr", err)
+	}
+}
+
+func TestWebhookSinkPostsJSON(t *testing.T) {
+	t.Setenv("WATCH_HOOK_TOKEN", "s3cret")
+	var (
+		mu   sync.Mutex
+		auth string
+		rec  EventRecord
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		auth = r.Header.Get("Authorization")
+		_ = json.Unmarshal(body, &rec)
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	sink, err := NewSink(SinkConfig{
+		Type:    SinkWebhook,
+		URL:     srv.URL,
+		Headers: map[string]string{"Authorization": "Bearer ${WATCH_HOOK_TOKEN}"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Deliver(context.Background(), testEvent(EventTypeCommit)); err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if auth != "Bearer s3cret" {
+		t.Errorf("Authorization = %q, want expanded header", auth)
+	}
+	if rec.Type != "commit" {
+		t.Errorf("posted type = %q, want commit", rec.Type)
+	}
+}
+
+func TestWebhookSinkNon2xxIsError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	sink, err := NewSink(SinkConfig{Type: SinkWebhook, URL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Deliver(context.Background(), testEvent(EventTypeModified)); err == nil || !strings.Contains(err.Error(), "503") {
+		t.Fatalf("Deliver() = %v, want 503 error", err)
+	}
+}
+
+func TestFilteredSinkEventsAndRateLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watch.ndjson")
+	sink, err := NewSink(SinkConfig{
+		Type:   SinkFile,
+		Path:   path,
+		Events: []string{"modified"},
+		Limit:  2,
+		Per:    "1h",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sink.Deliver(context.Background(), testEvent(EventTypeClean)); err != errSinkFiltered {
+		t.Errorf("clean event: err = %v, want filtered", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := sink.Deliver(context.Background(), testEvent(EventTypeModified)); err != nil {
+			t.Fatalf("modified event %d: %v", i, err)
+		}
+	}
+	if err := sink.Deliver(context.Background(), testEvent(EventTypeModified)); err != errSinkRateLimited {
+		t.Errorf("third modified event: err = %v, want rate limited", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(data), "\n"); n != 2 {
+		t.Errorf("delivered %d events, want 2", n)
+	}
+}
+
+func TestWindowLimiterSlides(t *testing.T) {
+	l := newWindowLimiter(1, time.Minute)
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	if !l.Allow(start) {
+		t.Fatal("first call should be allowed")
+	}
+	if l.Allow(start.Add(30 * time.Second)) {
+		t.Error("second call inside the window should be denied")
+	}
+	if !l.Allow(start.Add(61 * time.Second)) {
+		t.Error("call after the window should be allowed")
+	}
+	if newWindowLimiter(0, time.Minute) != nil {
+		t.Error("zero limit should mean no limiter")
+	}
+}
+
+func TestNotificationText(t *testing.T) {
+	ev := testEvent(EventTypeModified)
+	ev.Files = []string{"a", "b", "c", "d", "e", "f", "g"}
+	summary, body := NotificationText(ev)
+	if summary != "app: modified" {
+		t.Errorf("summary = %q", summary)
+	}
+	if !strings.HasSuffix(body, "and 2 more") || strings.Count(body, "\n") != 5 {
+		t.Errorf("body = %q, want five files and a remainder line", body)
+	}
+}
+
+// recordingSink collects delivered events for dispatcher tests.
+type recordingSink struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (s *recordingSink) Name() string { return "recording" }
+
+func (s *recordingSink) Deliver(_ context.Context, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func TestDispatcherDeliversToEverySink(t *testing.T) {
+	a, b := &recordingSink{}, &recordingSink{}
+	d := NewDispatcher(context.Background(), []Sink{a, b}, nil)
+	d.Dispatch(testEvent(EventTypeModified))
+	d.Dispatch(testEvent(EventTypeClean))
+	d.Close(time.Second)
+
+	for name, s := range map[string]*recordingSink{"a": a, "b": b} {
+		if len(s.events) != 2 {
+			t.Errorf("sink %s received %d events, want 2", name, len(s.events))
+		}
+	}
+}
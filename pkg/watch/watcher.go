@@ -5,35 +5,83 @@ package watch
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
 
+	"github.com/gizzahub/gzh-cli-gitforge/internal/gitcmd"
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
 )
 
+// defaultWorkers is how many repositories are checked concurrently when
+// WatchOptions.Workers is unset. Each check is a `git status`, which is
+// mostly I/O; a few at a time keeps a burst across many repositories from
+// saturating the disk.
+const defaultWorkers = 4
+
 // watcher implements the Watcher interface using fsnotify.
+//
+// Each repository's working tree is registered directory by directory,
+// skipping what git ignores, together with the parts of its git directory
+// that change when HEAD, the index, or a ref moves. A file system event marks
+// its repository pending; the repository is checked once it has been quiet
+// for DebounceDuration, by one of a bounded pool of workers. Only
+// repositories that cannot be registered (too many directories for the
+// notification limit) are polled.
 type watcher struct {
 	client   repository.Client
+	executor *gitcmd.Executor
 	fswatch  *fsnotify.Watcher
 	options  WatchOptions
 	events   chan Event
 	errors   chan error
+	jobs     chan *repoState
 	cancel   context.CancelFunc
 	wg       sync.WaitGroup
 	mu       sync.RWMutex
 	watching map[string]*repoState
-	logger   Logger
+	// routes maps every registered directory to its repository and to what
+	// the directory is, so an event is attributed without prefix matching.
+	routes map[string]route
+	logger Logger
 }
 
-// repoState tracks the state of a watched repository.
+// repoState tracks the state of a watched repository. Fields below the
+// first group are guarded by watcher.mu.
 type repoState struct {
 	path          string
 	lastStatus    *repository.Status
 	lastEventAt   time.Time
 	currentBranch string
+	head          string
+
+	repo *repository.Repository
+	dirs gitDirs
+	// ignoredDirs holds the absolute paths of working-tree directories git
+	// ignores; nothing below them is registered or reported.
+	ignoredDirs map[string]bool
+	// watched lists the directories registered for this repository.
+	watched []string
+	// polling is set when the repository fell back to polling.
+	polling bool
+	// upstream is set when FetchInterval enables remote-change detection
+	// and the repository has remotes.
+	upstream *upstreamState
+
+	// Scheduling state. pending collects working-tree paths changed since
+	// the last check; full means a git-internal file changed or the tree
+	// must be rescanned, so the check cannot be skipped.
+	pending  map[string]bool
+	newDirs  []string
+	full     bool
+	timer    *time.Timer
+	queued   bool
+	running  bool
+	rerun    bool
+	stopping bool
 }
 
 // NewWatcher creates a new repository watcher with the given options.
@@ -57,6 +105,9 @@ func NewWatcher(client repository.Client, options WatchOptions) (Watcher, error)
 	if options.DebounceDuration == 0 {
 		options.DebounceDuration = 500 * time.Millisecond
 	}
+	if options.Workers <= 0 {
+		options.Workers = defaultWorkers
+	}
 	if options.Logger == nil {
 		options.Logger = &noopLogger{}
 	}
@@ -69,11 +120,13 @@ func NewWatcher(client repository.Client, options WatchOptions) (Watcher, error)
 
 	w := &watcher{
 		client:   client,
+		executor: gitcmd.NewExecutor(),
 		fswatch:  fswatch,
 		options:  options,
 		events:   make(chan Event, 100),
 		errors:   make(chan error, 50), // Increased buffer to prevent blocking
 		watching: make(map[string]*repoState),
+		routes:   make(map[string]route),
 		logger:   options.Logger,
 	}
 
@@ -90,49 +143,79 @@ func (w *watcher) Start(ctx context.Context, paths []string) error {
 
 	// Initialize watch state for each repository
 	for _, path := range paths {
-		// Open repository
-		repo, err := w.client.Open(ctx, path)
-		if err != nil {
-			return fmt.Errorf("failed to open repository %s: %w", path, err)
+		if _, dup := w.watching[path]; dup {
+			continue
 		}
-
-		// Get initial status
-		status, err := w.client.GetStatus(ctx, repo)
-		if err != nil {
-			return fmt.Errorf("failed to get status for %s: %w", path, err)
+		if err := w.addRepo(ctx, path); err != nil {
+			if !w.options.SkipUnreadable {
+				return err
+			}
+			w.logger.Warn("Skipping repository: %v", err)
 		}
+	}
 
-		// Get initial branch
-		info, err := w.client.GetInfo(ctx, repo)
-		if err != nil {
-			w.logger.Warn("failed to get info for %s: %v", path, err)
-		}
+	workers := min(w.options.Workers, len(w.watching))
+	// A repository is in the queue at most once (see enqueue), so a buffer
+	// the size of the repository set never blocks a sender.
+	w.jobs = make(chan *repoState, len(w.watching))
+	for range workers {
+		w.wg.Add(1)
+		go w.worker(ctx)
+	}
 
-		branch := ""
-		if info != nil {
-			branch = info.Branch
-		}
+	// Start event loop
+	w.wg.Add(1)
+	go w.eventLoop(ctx)
 
-		// Initialize state
-		w.watching[path] = &repoState{
-			path:          path,
-			lastStatus:    status,
-			lastEventAt:   time.Time{},
-			currentBranch: branch,
-		}
+	if w.options.FetchInterval > 0 {
+		w.wg.Add(1)
+		go w.fetchLoop(ctx)
+	}
 
-		// Add path to fsnotify
-		if err := w.fswatch.Add(path); err != nil {
-			return fmt.Errorf("failed to watch path %s: %w", path, err)
-		}
+	return nil
+}
 
-		w.logger.Info("Started watching repository: %s", path)
+// addRepo opens path, records its initial state, and registers its watches.
+// Nothing is left behind for a repository that fails. Callers hold w.mu.
+func (w *watcher) addRepo(ctx context.Context, path string) error {
+	// Open repository
+	repo, err := w.client.Open(ctx, path)
+	if err != nil {
+		return fmt.Errorf("failed to open repository %s: %w", path, err)
 	}
 
-	// Start event loop
-	w.wg.Add(1)
-	go w.eventLoop(ctx)
+	// Get initial status
+	status, err := w.client.GetStatus(ctx, repo)
+	if err != nil {
+		return fmt.Errorf("failed to get status for %s: %w", path, err)
+	}
 
+	branch, head := w.readHead(ctx, path)
+
+	state := &repoState{
+		path:          path,
+		lastStatus:    status,
+		currentBranch: branch,
+		head:          head,
+		repo:          repo,
+		dirs:          w.resolveGitDirs(ctx, path),
+		ignoredDirs:   w.loadIgnoredDirs(ctx, path),
+		pending:       make(map[string]bool),
+	}
+	if w.options.FetchInterval > 0 {
+		w.initRemotes(ctx, state)
+	}
+
+	if err := w.registerRepo(state); err != nil {
+		return fmt.Errorf("failed to watch path %s: %w", path, err)
+	}
+	w.watching[path] = state
+
+	if state.polling {
+		w.logger.Info("Started polling repository: %s (every %s)", path, w.options.Interval)
+	} else {
+		w.logger.Info("Started watching repository: %s (%d directories)", path, len(state.watched))
+	}
 	return nil
 }
 
@@ -153,6 +236,13 @@ func (w *watcher) Stop() error {
 	if w.cancel != nil {
 		w.cancel()
 	}
+	for _, state := range w.watching {
+		state.stopping = true
+		if state.timer != nil {
+			state.timer.Stop()
+			state.timer = nil
+		}
+	}
 
 	// Close fsnotify
 	var closeErr error
@@ -163,8 +253,8 @@ func (w *watcher) Stop() error {
 	}
 	w.mu.Unlock()
 
-	// Wait for event loop to finish (without holding lock to avoid deadlock)
-	// The eventLoop may call findRepoForPath which needs RLock
+	// Wait for the event loop and workers to finish (without holding the lock
+	// to avoid deadlock: both take it while handling an event).
 	w.wg.Wait()
 
 	// Close channels (safe after eventLoop is done)
@@ -176,7 +266,8 @@ func (w *watcher) Stop() error {
 	return closeErr
 }
 
-// eventLoop is the main event processing loop.
+// eventLoop turns file system events into scheduled checks and polls the
+// repositories that fell back to polling.
 func (w *watcher) eventLoop(ctx context.Context) {
 	defer w.wg.Done()
 
@@ -189,42 +280,133 @@ func (w *watcher) eventLoop(ctx context.Context) {
 			return
 
 		case <-ticker.C:
-			// Poll all watched repositories
-			w.pollRepositories(ctx)
+			w.pollRepositories()
 
 		case fsEvent, ok := <-w.fswatch.Events:
 			if !ok {
 				return
 			}
 			w.logger.Debug("File system event: %s %s", fsEvent.Op, fsEvent.Name)
-			// Trigger immediate check for affected repository
-			w.checkRepository(ctx, w.findRepoForPath(fsEvent.Name))
+			w.handleFSEvent(fsEvent)
 
 		case err, ok := <-w.fswatch.Errors:
 			if !ok {
 				return
 			}
-			select {
-			case w.errors <- err:
-			case <-ctx.Done():
-				return
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				// The kernel dropped events; which repositories they were
+				// for is unknown, so check every one.
+				w.logger.Warn("file system event queue overflowed; rechecking all repositories")
+				w.scheduleAll()
+				continue
 			}
+			w.sendError(ctx, err)
 		}
 	}
 }
 
-// pollRepositories checks all watched repositories for changes.
-func (w *watcher) pollRepositories(ctx context.Context) {
-	w.mu.RLock()
-	paths := make([]string, 0, len(w.watching))
-	for path := range w.watching {
-		paths = append(paths, path)
+// pollRepositories queues a check for every repository that fell back to
+// polling.
+func (w *watcher) pollRepositories() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, state := range w.watching {
+		if state.polling {
+			state.full = true
+			w.enqueueLocked(state)
+		}
 	}
-	w.mu.RUnlock()
+}
 
-	for _, path := range paths {
-		w.checkRepository(ctx, path)
+// scheduleAll marks every repository for a full check.
+func (w *watcher) scheduleAll() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, state := range w.watching {
+		w.scheduleLocked(state, "", true)
+	}
+}
+
+// scheduleLocked records a change for state and (re)arms its debounce timer.
+// path is a working-tree path to consider for the gitignore filter, or "" for
+// a change that always needs a check. Callers hold w.mu.
+func (w *watcher) scheduleLocked(state *repoState, path string, full bool) {
+	if state.stopping {
+		return
+	}
+	if full || path == "" {
+		state.full = true
+	} else {
+		state.pending[path] = true
+	}
+	if state.timer != nil {
+		state.timer.Reset(w.options.DebounceDuration)
+		return
 	}
+	state.timer = time.AfterFunc(w.options.DebounceDuration, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		state.timer = nil
+		w.enqueueLocked(state)
+	})
+}
+
+// enqueueLocked hands state to the worker pool unless it is already queued.
+// A repository being checked is re-queued when its check finishes, so a
+// change that lands mid-check is never lost and one repository is never
+// checked by two workers at once. Callers hold w.mu.
+func (w *watcher) enqueueLocked(state *repoState) {
+	switch {
+	case state.stopping || state.queued:
+	case state.running:
+		state.rerun = true
+	default:
+		state.queued = true
+		w.jobs <- state
+	}
+}
+
+// worker checks queued repositories until ctx is canceled.
+func (w *watcher) worker(ctx context.Context) {
+	defer w.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case state := <-w.jobs:
+			w.process(ctx, state)
+		}
+	}
+}
+
+// process runs one scheduled check for state.
+func (w *watcher) process(ctx context.Context, state *repoState) {
+	w.mu.Lock()
+	state.queued = false
+	state.running = true
+	pending, newDirs, full := state.pending, state.newDirs, state.full
+	state.pending, state.newDirs, state.full = make(map[string]bool), nil, false
+	w.mu.Unlock()
+
+	defer func() {
+		w.mu.Lock()
+		state.running = false
+		if state.rerun {
+			state.rerun = false
+			w.enqueueLocked(state)
+		}
+		w.mu.Unlock()
+	}()
+
+	for _, dir := range newDirs {
+		w.registerNewDir(ctx, state, dir)
+	}
+
+	if !full && len(newDirs) == 0 && w.allIgnored(ctx, state, pending) {
+		w.logger.Debug("Skipping check for %s: only ignored paths changed", state.path)
+		return
+	}
+	w.checkRepository(ctx, state.path)
 }
 
 // checkRepository checks a single repository for changes.
@@ -241,62 +423,94 @@ func (w *watcher) checkRepository(ctx context.Context, path string) {
 		return
 	}
 
-	// Check debounce
-	if time.Since(state.lastEventAt) < w.options.DebounceDuration {
-		return
-	}
-
-	// Open repository
-	repo, err := w.client.Open(ctx, path)
-	if err != nil {
-		select {
-		case w.errors <- fmt.Errorf("failed to open repository %s: %w", path, err):
-		case <-ctx.Done():
-		default:
-			// Error channel full, log and continue
-			w.logger.Warn("Error channel full, dropping error: failed to open repository %s: %v", path, err)
+	repo := state.repo
+	if repo == nil {
+		opened, err := w.client.Open(ctx, path)
+		if err != nil {
+			w.sendError(ctx, fmt.Errorf("failed to open repository %s: %w", path, err))
+			return
 		}
-		return
+		repo = opened
 	}
 
 	// Get current status
 	status, err := w.client.GetStatus(ctx, repo)
 	if err != nil {
-		select {
-		case w.errors <- fmt.Errorf("failed to get status for %s: %w", path, err):
-		case <-ctx.Done():
-		default:
-			// Error channel full, log and continue
-			w.logger.Warn("Error channel full, dropping error: failed to get status for %s: %v", path, err)
-		}
+		w.sendError(ctx, fmt.Errorf("failed to get status for %s: %w", path, err))
 		return
 	}
 
-	// Get current branch
-	info, err := w.client.GetInfo(ctx, repo)
-	currentBranch := state.currentBranch
-	if err == nil && info != nil {
-		currentBranch = info.Branch
-	}
+	currentBranch, head := w.readHead(ctx, path)
 
-	// Detect changes
-	events := w.detectChanges(state, status, currentBranch)
+	// Detect changes and update state in one critical section: a
+	// fast-forward (see remote.go) records the HEAD it moved to, and must not
+	// be reported again as a commit by a check that read the old HEAD.
+	w.mu.Lock()
+	if currentBranch == "" && head == "" {
+		// HEAD could not be read (a ref update in flight); keep the last
+		// known values rather than reporting a spurious branch change.
+		currentBranch, head = state.currentBranch, state.head
+	}
+	var events []Event
+	if commit := w.detectCommit(state, currentBranch, head, status); commit != nil {
+		events = append(events, *commit)
+	}
+	events = append(events, w.detectChanges(state, status, currentBranch)...)
+	if len(events) > 0 {
+		state.lastEventAt = time.Now()
+	}
+	state.lastStatus = status
+	state.currentBranch = currentBranch
+	state.head = head
+	w.mu.Unlock()
 
 	// Send events
 	for _, event := range events {
 		select {
 		case w.events <- event:
-			state.lastEventAt = time.Now()
 		case <-ctx.Done():
 			return
 		}
 	}
+}
 
-	// Update state
-	w.mu.Lock()
-	state.lastStatus = status
-	state.currentBranch = currentBranch
-	w.mu.Unlock()
+// sendError reports err without blocking the caller.
+func (w *watcher) sendError(ctx context.Context, err error) {
+	select {
+	case w.errors <- err:
+	case <-ctx.Done():
+	default:
+		// Error channel full, log and continue
+		w.logger.Warn("Error channel full, dropping error: %v", err)
+	}
+}
+
+// readHead returns the current branch ("" when detached) and the commit HEAD
+// points to ("" on an unborn branch). It costs two small git calls instead
+// of the half-dozen GetInfo makes for remotes and upstream tracking that
+// change detection does not use.
+func (w *watcher) readHead(ctx context.Context, path string) (branch, head string) {
+	if out, err := w.executor.RunOutput(ctx, path, "symbolic-ref", "-q", "--short", "HEAD"); err == nil {
+		branch = out
+	}
+	if out, err := w.executor.RunOutput(ctx, path, "rev-parse", "-q", "--verify", "HEAD"); err == nil {
+		head = out
+	}
+	return branch, head
+}
+
+// detectCommit reports a new commit on the same branch: HEAD moved while the
+// branch did not. A branch switch is reported by detectChanges instead.
+func (w *watcher) detectCommit(state *repoState, branch, head string, status *repository.Status) *Event {
+	if head == "" || head == state.head || branch != state.currentBranch {
+		return nil
+	}
+	return &Event{
+		Path:      state.path,
+		Type:      EventTypeCommit,
+		Timestamp: time.Now(),
+		Status:    status,
+	}
 }
 
 // detectChanges compares old and new status to detect change events.
@@ -376,22 +590,6 @@ func (w *watcher) detectChanges(state *repoState, newStatus *repository.Status,
 	return events
 }
 
-// findRepoForPath finds which repository a file path belongs to.
-func (w *watcher) findRepoForPath(filePath string) string {
-	w.mu.RLock()
-	defer w.mu.RUnlock()
-
-	// Simple prefix match for now
-	// TODO: More sophisticated path matching
-	for repoPath := range w.watching {
-		if len(filePath) >= len(repoPath) && filePath[:len(repoPath)] == repoPath {
-			return repoPath
-		}
-	}
-
-	return ""
-}
-
 // equalStringSlices compares two string slices for equality.
 func equalStringSlices(a, b []string) bool {
 	if len(a) != len(b) {
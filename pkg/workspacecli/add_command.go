@@ -13,6 +13,7 @@ import (
 
 	"github.com/gizzahub/gzh-cli-gitforge/internal/gitcmd"
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
 )
 
@@ -100,19 +101,19 @@ func (f CommandFactory) runAdd(cmd *cobra.Command, opts *AddOptions) error {
 	}
 
 	// Load existing config or create new
-	config, err := loadOrCreateConfig(configPath)
+	doc, err := loadOrCreateConfig(configPath)
 	if err != nil {
 		return err
 	}
 
 	// Validate and add the repository. appendRepoEntry rejects empty/invalid
 	// URLs and duplicates before anything is written to disk.
-	if err := appendRepoEntry(config, name, opts.URL, targetPath); err != nil {
+	if err := appendRepoEntry(doc, name, opts.URL, targetPath); err != nil {
 		return err
 	}
 
 	// Write config
-	if err := writeConfig(configPath, config); err != nil {
+	if err := writeConfig(configPath, doc); err != nil {
 		return err
 	}
 
@@ -149,17 +150,17 @@ func (f CommandFactory) addFromCurrent(cmd *cobra.Command, configPath string) er
 		return fmt.Errorf("current repository has no 'origin' remote; add one with 'git remote add origin <url>' or run 'workspace add <url>'")
 	}
 
-	config, err := loadOrCreateConfig(configPath)
+	doc, err := loadOrCreateConfig(configPath)
 	if err != nil {
 		return err
 	}
 
 	// appendRepoEntry validates the resolved URL and rejects duplicates.
-	if err := appendRepoEntry(config, name, info.RemoteURL, absPath); err != nil {
+	if err := appendRepoEntry(doc, name, info.RemoteURL, absPath); err != nil {
 		return err
 	}
 
-	if err := writeConfig(configPath, config); err != nil {
+	if err := writeConfig(configPath, doc); err != nil {
 		return err
 	}
 
@@ -173,64 +174,66 @@ func (f CommandFactory) addFromCurrent(cmd *cobra.Command, configPath string) er
 // "targetPath" key on existing entries) — so `workspace add` never writes an
 // unusable entry to disk. New entries use the canonical "path" key that every
 // config loader reads (the old code wrote "targetPath", which loaders ignore).
-func appendRepoEntry(config map[string]any, name, url, path string) error {
+func appendRepoEntry(doc *config.ConfigEditor, name, url, path string) error {
 	if err := gitcmd.SanitizeURL(url); err != nil {
 		return fmt.Errorf("invalid repository URL %q: %w", url, err)
 	}
 
-	repos, ok := config["repositories"].([]any)
-	if !ok {
-		repos = []any{}
+	count := 0
+	if repos, _ := doc.Get("repositories"); repos != nil && repos.Kind == yaml.SequenceNode {
+		count = len(repos.Content)
 	}
-
-	for _, r := range repos {
-		rm, ok := r.(map[string]any)
-		if !ok {
-			continue
-		}
-		if rm["url"] == url {
+	for i := range count {
+		entry := fmt.Sprintf("repositories[%d]", i)
+		if v, ok := doc.Value(entry + ".url"); ok && v == url {
 			return fmt.Errorf("repository with URL %q already exists", url)
 		}
-		if rm["path"] == path || rm["targetPath"] == path {
-			return fmt.Errorf("repository with path %q already exists", path)
+		for _, key := range []string{".path", ".targetPath"} {
+			if v, ok := doc.Value(entry + key); ok && v == path {
+				return fmt.Errorf("repository with path %q already exists", path)
+			}
 		}
 	}
 
-	config["repositories"] = append(repos, map[string]any{
-		"name": name,
-		"url":  url,
-		"path": path,
-	})
-	return nil
+	var entry yaml.Node
+	if err := entry.Encode(struct {
+		Name string `yaml:"name"`
+		URL  string `yaml:"url"`
+		Path string `yaml:"path"`
+	}{name, url, path}); err != nil {
+		return fmt.Errorf("encode repository entry: %w", err)
+	}
+	return doc.Set(fmt.Sprintf("repositories[%d]", count), &entry)
 }
 
-func loadOrCreateConfig(path string) (map[string]any, error) {
+// newWorkspaceConfig is the config `workspace add` starts when there is none.
+const newWorkspaceConfig = `strategy: reset
+parallel: 4
+maxRetries: 3
+cloneProto: ssh
+sshPort: 0
+repositories: []
+`
+
+// loadOrCreateConfig opens the config for editing. It is edited as YAML
+// nodes, so the comments and key order of an existing file survive the add.
+func loadOrCreateConfig(path string) (*config.ConfigEditor, error) {
 	data, err := os.ReadFile(path) // #nosec G304 -- path is the explicit workspace config selected by the caller.
 	if os.IsNotExist(err) {
-		// Create default config structure
-		return map[string]any{
-			"strategy":     "reset",
-			"parallel":     4,
-			"maxRetries":   3,
-			"cloneProto":   "ssh",
-			"sshPort":      0,
-			"repositories": []any{},
-		}, nil
-	}
-	if err != nil {
+		data = []byte(newWorkspaceConfig)
+	} else if err != nil {
 		return nil, fmt.Errorf("read config: %w", err)
 	}
 
-	var config map[string]any
-	if err := yaml.Unmarshal(data, &config); err != nil {
+	doc, err := config.NewConfigEditor(data)
+	if err != nil {
 		return nil, fmt.Errorf("parse config: %w", err)
 	}
-
-	return config, nil
+	return doc, nil
 }
 
-func writeConfig(path string, config map[string]any) error {
-	data, err := yaml.Marshal(config)
+func writeConfig(path string, doc *config.ConfigEditor) error {
+	data, err := doc.Bytes()
 	if err != nil {
 		return fmt.Errorf("marshal config: %w", err)
 	}
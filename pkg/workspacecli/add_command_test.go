@@ -6,6 +6,7 @@ package workspacecli
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"gopkg.in/yaml.v3"
@@ -40,27 +41,27 @@ func TestLoadOrCreateConfig_NewFile(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "new-config.yaml")
 
-	config, err := loadOrCreateConfig(configPath)
+	doc, err := loadOrCreateConfig(configPath)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
 	// Check default values
-	if config["strategy"] != "reset" {
-		t.Errorf("strategy = %v, want reset", config["strategy"])
+	if v, _ := doc.Value("strategy"); v != "reset" {
+		t.Errorf("strategy = %v, want reset", v)
 	}
 
-	if config["parallel"] != 4 {
-		t.Errorf("parallel = %v, want 4", config["parallel"])
+	if v, _ := doc.Value("parallel"); v != "4" {
+		t.Errorf("parallel = %v, want 4", v)
 	}
 
-	repos, ok := config["repositories"].([]any)
-	if !ok {
-		t.Error("repositories should be a slice")
+	repos, err := doc.Get("repositories")
+	if err != nil || repos == nil || repos.Kind != yaml.SequenceNode {
+		t.Fatal("repositories should be a list")
 	}
 
-	if len(repos) != 0 {
-		t.Errorf("new config should have empty repositories, got %d", len(repos))
+	if len(repos.Content) != 0 {
+		t.Errorf("new config should have empty repositories, got %d", len(repos.Content))
 	}
 }
 
@@ -80,17 +81,17 @@ repositories:
 		t.Fatal(err)
 	}
 
-	config, err := loadOrCreateConfig(configPath)
+	doc, err := loadOrCreateConfig(configPath)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if config["strategy"] != "pull" {
-		t.Errorf("strategy = %v, want pull", config["strategy"])
+	if v, _ := doc.Value("strategy"); v != "pull" {
+		t.Errorf("strategy = %v, want pull", v)
 	}
 
-	if config["parallel"] != 8 {
-		t.Errorf("parallel = %v, want 8", config["parallel"])
+	if v, _ := doc.Value("parallel"); v != "8" {
+		t.Errorf("parallel = %v, want 8", v)
 	}
 }
 
@@ -112,19 +113,15 @@ func TestWriteConfig(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "output.yaml")
 
-	config := map[string]any{
-		"strategy": "reset",
-		"parallel": 4,
-		"repositories": []any{
-			map[string]any{
-				"name":       "test-repo",
-				"url":        "https://github.com/test/repo.git",
-				"targetPath": "./repos/test",
-			},
-		},
+	doc, err := loadOrCreateConfig(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := appendRepoEntry(doc, "test-repo", "https://github.com/test/repo.git", "./repos/test"); err != nil {
+		t.Fatal(err)
 	}
 
-	if err := writeConfig(configPath, config); err != nil {
+	if err := writeConfig(configPath, doc); err != nil {
 		t.Fatalf("writeConfig failed: %v", err)
 	}
 
@@ -142,50 +139,72 @@ func TestWriteConfig(t *testing.T) {
 	if loaded["strategy"] != "reset" {
 		t.Errorf("strategy = %v, want reset", loaded["strategy"])
 	}
+	if !strings.Contains(string(data), "repositories:\n  - name: test-repo\n    url: https://github.com/test/repo.git\n    path: ./repos/test\n") {
+		t.Errorf("repositories not written in block style:\n%s", data)
+	}
 }
 
-func TestAddOptions_DuplicateDetection(t *testing.T) {
+func TestAppendRepoEntry_KeepsComments(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "config.yaml")
-
-	// Create config with existing repo
-	config := map[string]any{
-		"strategy": "reset",
-		"parallel": 4,
-		"repositories": []any{
-			map[string]any{
-				"name":       "existing",
-				"url":        "https://github.com/test/existing.git",
-				"targetPath": "./repos/existing",
-			},
-		},
+	existing := `# Team devbox
+strategy: pull # never reset local work
+repositories:
+  # the API
+  - url: https://github.com/test/api.git
+    path: ./api
+`
+	if err := os.WriteFile(configPath, []byte(existing), 0o644); err != nil {
+		t.Fatal(err)
 	}
 
-	if err := writeConfig(configPath, config); err != nil {
+	doc, err := loadOrCreateConfig(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := appendRepoEntry(doc, "web", "https://github.com/test/web.git", "./web"); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeConfig(configPath, doc); err != nil {
 		t.Fatal(err)
 	}
 
-	// Load and check for duplicate
-	loaded, err := loadOrCreateConfig(configPath)
+	data, err := os.ReadFile(configPath)
 	if err != nil {
 		t.Fatal(err)
 	}
+	want := existing + "  - name: web\n    url: https://github.com/test/web.git\n    path: ./web\n"
+	if string(data) != want {
+		t.Errorf("config:\n%s\nwant:\n%s", data, want)
+	}
+}
+
+func TestAddOptions_DuplicateDetection(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
 
-	repos, ok := loaded["repositories"].([]any)
-	if !ok {
-		t.Fatal("repositories field is not []interface{}")
+	// Create config with existing repo, written with the legacy targetPath key
+	existing := `strategy: reset
+repositories:
+  - name: existing
+    url: https://github.com/test/existing.git
+    targetPath: ./repos/existing
+`
+	if err := os.WriteFile(configPath, []byte(existing), 0o644); err != nil {
+		t.Fatal(err)
 	}
-	newTargetPath := "./repos/existing"
 
-	// Check duplicate detection logic
-	for _, r := range repos {
-		if rm, ok := r.(map[string]any); ok {
-			if rm["targetPath"] == newTargetPath {
-				// This is the expected behavior
-				return
-			}
-		}
+	doc, err := loadOrCreateConfig(configPath)
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	t.Error("should have detected duplicate targetPath")
+	err = appendRepoEntry(doc, "other", "https://github.com/test/other.git", "./repos/existing")
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("should have detected duplicate targetPath, err = %v", err)
+	}
+	err = appendRepoEntry(doc, "again", "https://github.com/test/existing.git", "./elsewhere")
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("should have detected duplicate URL, err = %v", err)
+	}
 }
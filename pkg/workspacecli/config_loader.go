@@ -36,35 +36,7 @@ func (l FileSpecLoader) Load(ctx context.Context, path string) (*ConfigData, err
 		return nil, fmt.Errorf("read config file: %w", err)
 	}
 
-	var raw struct {
-		// Meta information
-		Version  int              `yaml:"version,omitempty"`
-		Kind     string           `yaml:"kind,omitempty"`
-		Metadata *config.Metadata `yaml:"metadata,omitempty"`
-
-		// Sync settings
-		Strategy       string            `yaml:"strategy"`
-		Parallel       int               `yaml:"parallel"`
-		MaxRetries     int               `yaml:"maxRetries"`
-		CleanupOrphans bool              `yaml:"cleanupOrphans"`
-		CloneProto     string            `yaml:"cloneProto"`
-		SSHPort        int               `yaml:"sshPort"`
-		Branch         config.FlexBranch `yaml:"branch"`
-		Roots          []string          `yaml:"roots"`
-		Repositories   []struct {
-			Name              string            `yaml:"name"`
-			Description       string            `yaml:"description"` // optional: human-readable description
-			URL               string            `yaml:"url"`
-			AdditionalRemotes map[string]string `yaml:"additionalRemotes"` // Additional git remotes (name: url)
-			Path              string            `yaml:"path"`
-			Strategy          string            `yaml:"strategy"`
-			CloneProto        string            `yaml:"cloneProto"`
-			Branch            config.FlexBranch `yaml:"branch"`
-			Enabled           *bool             `yaml:"enabled"`       // optional: if false, exclude from sync (default: true)
-			AssumePresent     bool              `yaml:"assumePresent"` // if true, skip clone check
-		} `yaml:"repositories"`
-	}
-
+	var raw config.RepositoriesConfig
 	if err := yaml.Unmarshal(data, &raw); err != nil {
 		return nil, fmt.Errorf("parse YAML: %w", err)
 	}
@@ -70,11 +70,19 @@ Examples:
 	generateCmd.GroupID = mgmtGroup.ID
 	root.AddCommand(generateCmd)
 
+	lockCmd := f.newLockCmd()
+	lockCmd.GroupID = mgmtGroup.ID
+	root.AddCommand(lockCmd)
+
 	// Operations
 	syncCmd := f.newSyncCmd()
 	syncCmd.GroupID = opsGroup.ID
 	root.AddCommand(syncCmd)
 
+	snapshotCmd := f.newSnapshotCmd()
+	snapshotCmd.GroupID = opsGroup.ID
+	root.AddCommand(snapshotCmd)
+
 	// Diagnostics
 	statusCmd := f.newStatusCmd()
 	statusCmd.GroupID = diagGroup.ID
@@ -84,5 +92,9 @@ Examples:
 	validateCmd.GroupID = diagGroup.ID
 	root.AddCommand(validateCmd)
 
+	groupsCmd := f.newGroupsCmd()
+	groupsCmd.GroupID = diagGroup.ID
+	root.AddCommand(groupsCmd)
+
 	return root
 }
@@ -26,6 +26,9 @@ func TestCommandFactory_NewRootCmd(t *testing.T) {
 		"status":   false,
 		"add":      false,
 		"validate": false,
+		"lock":     false,
+		"snapshot": false,
+		"groups":   false,
 	}
 
 	for _, sub := range cmd.Commands() {
@@ -0,0 +1,194 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package workspacecli
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/reposync"
+)
+
+func (f CommandFactory) newGroupsCmd() *cobra.Command {
+	var (
+		configPath string
+		scanDepth  int
+		selectExpr string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "groups [group...]",
+		Short: "List the groups and labels of the workspace and their repositories",
+		Long: cliutil.QuickStartHelp(`  # Every group and label, with its repositories
+  gz-git workspace groups
+
+  # The repositories of one group
+  gz-git workspace groups core
+
+  # Try out a selector before using it with --select
+  gz-git workspace groups --select 'label=backend,!archived'`) + `
+Labels are set on repositories entries and on workspaces (a workspace's
+labels apply to every repository in it); groups are named lists of selectors
+under groups: in the config:
+
+  groups:
+    core: [api, web]                 # by name or label
+    services: ["label=backend,!archived", "path=services/*"]
+
+The repositories listed are those the config names plus those found on disk
+under the config's directory. Bulk commands and 'workspace sync' take the
+same --select and --group.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+
+			if configPath == "" {
+				detected, err := detectConfigFile(".")
+				if err != nil {
+					return fmt.Errorf("no config file specified and auto-detection failed: %w", err)
+				}
+				configPath = detected
+			}
+			selection, err := config.LoadSelection(configPath)
+			if err != nil {
+				return err
+			}
+
+			scan, err := repository.NewClient().ScanRepositories(cmd.Context(), repository.ScanOptions{
+				Directory: selection.BaseDir,
+				MaxDepth:  scanDepth,
+			})
+			if err != nil {
+				return err
+			}
+			targets := selection.Targets(scan.Paths)
+
+			if selectExpr != "" {
+				sel, err := config.ParseSelector(selectExpr)
+				if err != nil {
+					return err
+				}
+				members, err := selectTargets(targets, func(t config.SelectTarget) (bool, error) { return selection.Match(sel, t) })
+				if err != nil {
+					return err
+				}
+				printMembers(cmd, fmt.Sprintf("--select %s", sel), members)
+				return nil
+			}
+
+			groups := args
+			if len(groups) == 0 {
+				groups = selection.GroupNames()
+			}
+			for _, g := range groups {
+				members, err := selectTargets(targets, func(t config.SelectTarget) (bool, error) { return selection.InGroup(g, t) })
+				if err != nil {
+					return err
+				}
+				printMembers(cmd, "group "+g, members)
+			}
+			if len(args) > 0 {
+				return nil
+			}
+
+			byLabel := map[string][]config.SelectTarget{}
+			for _, t := range targets {
+				for _, l := range t.Labels {
+					byLabel[l] = append(byLabel[l], t)
+				}
+			}
+			labels := make([]string, 0, len(byLabel))
+			for l := range byLabel {
+				labels = append(labels, l)
+			}
+			sort.Strings(labels)
+			for _, l := range labels {
+				printMembers(cmd, "label "+l, byLabel[l])
+			}
+
+			if len(groups) == 0 && len(labels) == 0 {
+				fmt.Fprintf(out, "No groups or labels in %s\n", configPath)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&configPath, "config", "c", "", "Path to config file (auto-detects "+DefaultConfigFile+")")
+	cmd.Flags().IntVarP(&scanDepth, "scan-depth", "d", 2, "Directory depth to scan for repositories")
+	cmd.Flags().StringVar(&selectExpr, "select", "", "List the repositories matching a selector instead")
+	return cmd
+}
+
+func selectTargets(targets []config.SelectTarget, match func(config.SelectTarget) (bool, error)) ([]config.SelectTarget, error) {
+	var kept []config.SelectTarget
+	for _, t := range targets {
+		ok, err := match(t)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			kept = append(kept, t)
+		}
+	}
+	return kept, nil
+}
+
+func printMembers(cmd *cobra.Command, title string, members []config.SelectTarget) {
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "%s (%d)\n", title, len(members))
+	for _, t := range members {
+		if t.Name != filepath.Base(t.Path) {
+			fmt.Fprintf(out, "  %s (%s)\n", t.Path, t.Name)
+			continue
+		}
+		fmt.Fprintf(out, "  %s\n", t.Path)
+	}
+}
+
+// selectActions keeps the planned actions whose repository matches
+// selectExpr, when set, and is in one of groups, when any are given.
+func selectActions(actions []reposync.Action, configPath, selectExpr string, groups []string) ([]reposync.Action, error) {
+	var sel *config.Selector
+	if selectExpr != "" {
+		var err error
+		if sel, err = config.ParseSelector(selectExpr); err != nil {
+			return nil, err
+		}
+	}
+	selection, err := config.LoadSelection(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, len(actions))
+	for i, a := range actions {
+		abs, err := filepath.Abs(a.Repo.TargetPath)
+		if err != nil {
+			abs = a.Repo.TargetPath
+		}
+		paths[i] = abs
+	}
+	kept, err := selection.Filter(paths, sel, groups)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := make(map[string]bool, len(kept))
+	for _, p := range kept {
+		keep[p] = true
+	}
+	selected := make([]reposync.Action, 0, len(kept))
+	for i, a := range actions {
+		if keep[paths[i]] {
+			selected = append(selected, a)
+		}
+	}
+	return selected, nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package workspacecli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/reposync"
+)
+
+const groupsTestConfig = `repositories:
+  - url: https://github.com/org/api.git
+    labels: [backend]
+  - url: https://github.com/org/web.git
+    labels: [frontend]
+  - url: https://github.com/org/legacy.git
+    labels: [backend, archived]
+groups:
+  core: [api, web]
+`
+
+func writeGroupsConfig(t *testing.T) (dir, configPath string) {
+	t.Helper()
+	dir = t.TempDir()
+	configPath = filepath.Join(dir, DefaultConfigFile)
+	if err := os.WriteFile(configPath, []byte(groupsTestConfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return dir, configPath
+}
+
+func TestWorkspaceGroupsCommand(t *testing.T) {
+	dir, configPath := writeGroupsConfig(t)
+	gitIn(t, dir, "init", "--quiet", "scratch")
+
+	f := CommandFactory{}
+	out, err := runWorkspaceCmd(t, f.newGroupsCmd, "-c", configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"group core (2)\n  api\n  web\n",
+		"label archived (1)\n  legacy\n",
+		"label backend (2)\n  api\n  legacy\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+
+	out, err = runWorkspaceCmd(t, f.newGroupsCmd, "-c", configPath, "--select", "!backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "--select !backend (2)\n  scratch\n  web\n"; out != want {
+		t.Errorf("--select output = %q, want %q", out, want)
+	}
+
+	if _, err := runWorkspaceCmd(t, f.newGroupsCmd, "-c", configPath, "nope"); err == nil || !strings.Contains(err.Error(), `unknown group "nope"`) {
+		t.Errorf("unknown group: err = %v", err)
+	}
+}
+
+func TestSelectActions(t *testing.T) {
+	dir, configPath := writeGroupsConfig(t)
+	var actions []reposync.Action
+	for _, name := range []string{"api", "web", "legacy"} {
+		actions = append(actions, reposync.Action{Type: reposync.ActionUpdate, Repo: reposync.RepoSpec{Name: name, TargetPath: filepath.Join(dir, name)}})
+	}
+
+	got, err := selectActions(actions, configPath, "backend,!archived", []string{"core"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Repo.Name != "api" {
+		t.Errorf("selected %+v, want api", got)
+	}
+	if _, err := selectActions(actions, configPath, "owner=me", nil); err == nil {
+		t.Error("expected an invalid selector to be rejected")
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package workspacecli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/reposync"
+)
+
+func (f CommandFactory) newLockCmd() *cobra.Command {
+	var (
+		configPath string
+		update     bool
+		diffOnly   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "lock [repo...]",
+		Short: "Record the exact commit of every repository in " + reposync.LockfileName,
+		Long: cliutil.QuickStartHelp(`  # Lock the current checkouts (new repositories are added, locked ones kept)
+  gz-git workspace lock
+
+  # Re-lock everything at the commits now checked out
+  gz-git workspace lock --update
+
+  # Re-lock only some repositories (by name or path)
+  gz-git workspace lock --update api-server libs/common
+
+  # Show what would change without writing the lockfile
+  gz-git workspace lock --update --diff
+
+  # Reproduce the locked tree elsewhere
+  gz-git workspace sync --locked`) + `
+The lockfile is written next to the config and records, for every repository
+the config plans, its path, URL, branch and the commit checked out there.
+Commit it with the config: 'workspace sync --locked' then checks out exactly
+those commits instead of the branch tips.
+
+Repositories must be cloned, and their HEAD must be on a remote branch, since
+nobody else could fetch an unpushed commit. Without --update, repositories
+already in the lockfile keep their commit; new ones are added and ones the
+config no longer lists are dropped.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			out := cmd.OutOrStdout()
+
+			if len(args) > 0 && !update {
+				return fmt.Errorf("naming repositories requires --update")
+			}
+
+			// Auto-detect config file if not specified
+			if configPath == "" {
+				detected, err := detectConfigFile(".")
+				if err != nil {
+					return fmt.Errorf("no config file specified and auto-detection failed: %w", err)
+				}
+				configPath = detected
+				fmt.Fprintf(out, "Using config: %s\n", configPath)
+			}
+
+			plan, err := planWorkspaceSync(ctx, configPath, cmd.ErrOrStderr(), "", false)
+			if err != nil {
+				return err
+			}
+			lockPath := filepath.Join(plan.ConfigDir, reposync.LockfileName)
+
+			var prev *reposync.Lockfile
+			if _, err := os.Stat(lockPath); err == nil {
+				if prev, err = reposync.LoadLockfile(lockPath); err != nil {
+					return err
+				}
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+
+			next, err := buildLockfile(cmd, plan, prev, update, args)
+			if err != nil {
+				return err
+			}
+
+			changes := reposync.DiffLockfiles(prev, next)
+			for _, c := range changes {
+				fmt.Fprintln(out, c)
+			}
+			switch {
+			case diffOnly:
+				if len(changes) == 0 {
+					fmt.Fprintf(out, "%s is up to date\n", lockPath)
+				}
+				return nil
+			case prev != nil && len(changes) == 0:
+				fmt.Fprintf(out, "✓ %s is up to date\n", lockPath)
+				return nil
+			}
+
+			if err := next.Save(lockPath); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "✓ Wrote %s (%d repositories)\n", lockPath, len(next.Repositories))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&configPath, "config", "c", "", "Path to config file (auto-detects "+DefaultConfigFile+")")
+	cmd.Flags().BoolVar(&update, "update", false, "Re-lock repositories at their current commits (all, or the named ones)")
+	cmd.Flags().BoolVar(&diffOnly, "diff", false, "Show how the lockfile would change without writing it")
+
+	return cmd
+}
+
+// buildLockfile records every repository of the plan. Entries of prev are
+// kept unless update selects them: all with no names, else those whose name
+// or path is listed.
+func buildLockfile(cmd *cobra.Command, plan *syncPlan, prev *reposync.Lockfile, update bool, names []string) (*reposync.Lockfile, error) {
+	wanted := make(map[string]bool, len(names))
+	for _, n := range names {
+		wanted[filepath.ToSlash(filepath.Clean(n))] = false
+	}
+
+	next := &reposync.Lockfile{}
+	var problems []string
+	for _, a := range plan.Actions {
+		if a.Type != reposync.ActionClone && a.Type != reposync.ActionUpdate {
+			continue
+		}
+		rel := reposync.LockPath(plan.ConfigDir, a.Repo.TargetPath)
+
+		refresh := update && len(names) == 0
+		for _, key := range []string{a.Repo.Name, rel} {
+			if _, ok := wanted[key]; ok {
+				wanted[key] = true
+				refresh = true
+			}
+		}
+
+		var old *reposync.LockedRepo
+		if prev != nil {
+			old = prev.Lookup(rel)
+		}
+		if old != nil && !refresh {
+			next.Repositories = append(next.Repositories, *old)
+			continue
+		}
+
+		entry, err := reposync.ReadLockedRepo(cmd.Context(), a, plan.ConfigDir)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		if entry.Branch == "" && old != nil {
+			entry.Branch = old.Branch
+		}
+		next.Repositories = append(next.Repositories, entry)
+	}
+
+	for _, n := range names {
+		if !wanted[filepath.ToSlash(filepath.Clean(n))] {
+			problems = append(problems, fmt.Sprintf("%s: no such repository in the config", n))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("cannot lock the workspace:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return next, nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package workspacecli
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/internal/testutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/reposync"
+)
+
+func gitIn(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...) //nolint:noctx // test helper; no context.Context available in *testing.T API
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func runWorkspaceCmd(t *testing.T, cmd func() *cobra.Command, args ...string) (string, error) {
+	t.Helper()
+	c := cmd()
+	var buf bytes.Buffer
+	c.SetOut(&buf)
+	c.SetErr(&buf)
+	c.SetArgs(args)
+	err := c.Execute()
+	return buf.String(), err
+}
+
+func TestWorkspaceLockAndLockedSync(t *testing.T) {
+	fx := testutil.TempWorktreeWithBareOrigin(t)
+	branch := gitIn(t, fx.Clone, "rev-parse", "--abbrev-ref", "HEAD")
+	gitIn(t, fx.Clone, "commit", "--allow-empty", "-m", "second")
+	gitIn(t, fx.Clone, "push", "origin", branch)
+	locked := gitIn(t, fx.Clone, "rev-parse", "HEAD")
+
+	ws := t.TempDir()
+	gitIn(t, ws, "clone", "--quiet", fx.Origin, "api")
+	cfg := filepath.Join(ws, DefaultConfigFile)
+	if err := os.WriteFile(cfg, []byte("repositories:\n  - name: api\n    url: "+fx.Origin+"\n    path: api\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(ws)
+	lockPath := filepath.Join(ws, reposync.LockfileName)
+	f := CommandFactory{}
+
+	out, err := runWorkspaceCmd(t, f.newLockCmd, "-c", cfg)
+	if err != nil {
+		t.Fatalf("lock: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "+ api "+locked[:12]) {
+		t.Errorf("lock output:\n%s", out)
+	}
+	lock, err := reposync.LoadLockfile(lockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := lock.Lookup("api"); e == nil || e.Commit != locked || e.Branch != branch || e.URL != fx.Origin {
+		t.Fatalf("lockfile entry = %+v", e)
+	}
+
+	// The branch moves on upstream; a locked sync stays on the locked commit.
+	gitIn(t, fx.Clone, "commit", "--allow-empty", "-m", "third")
+	gitIn(t, fx.Clone, "push", "origin", branch)
+	tip := gitIn(t, fx.Clone, "rev-parse", "HEAD")
+
+	if out, err := runWorkspaceCmd(t, f.newSyncCmd, "-c", cfg, "--locked", "--format", "json"); err != nil {
+		t.Fatalf("sync --locked: %v\n%s", err, out)
+	}
+	if head := gitIn(t, filepath.Join(ws, "api"), "rev-parse", "HEAD"); head != locked {
+		t.Errorf("HEAD after locked sync = %s, want %s", head, locked)
+	}
+
+	// Without --update the lock keeps its commit; --diff previews a re-lock.
+	gitIn(t, filepath.Join(ws, "api"), "checkout", "--quiet", "-B", branch, "origin/"+branch)
+	if out, err := runWorkspaceCmd(t, f.newLockCmd, "-c", cfg); err != nil || !strings.Contains(out, "is up to date") {
+		t.Errorf("lock without --update: %v\n%s", err, out)
+	}
+	out, err = runWorkspaceCmd(t, f.newLockCmd, "-c", cfg, "--update", "--diff", "api")
+	if err != nil || !strings.Contains(out, "~ api "+locked[:12]+" → "+tip[:12]) {
+		t.Errorf("lock --update --diff: %v\n%s", err, out)
+	}
+	if lock, _ := reposync.LoadLockfile(lockPath); lock.Lookup("api").Commit != locked {
+		t.Error("--diff wrote the lockfile")
+	}
+	if out, err := runWorkspaceCmd(t, f.newLockCmd, "-c", cfg, "--update"); err != nil {
+		t.Fatalf("lock --update: %v\n%s", err, out)
+	}
+	if lock, _ := reposync.LoadLockfile(lockPath); lock.Lookup("api").Commit != tip {
+		t.Error("--update did not re-lock api")
+	}
+
+	if _, err := runWorkspaceCmd(t, f.newLockCmd, "-c", cfg, "--update", "web"); err == nil || !strings.Contains(err.Error(), "web: no such repository") {
+		t.Errorf("unknown repo: err = %v", err)
+	}
+	if _, err := runWorkspaceCmd(t, f.newLockCmd, "-c", cfg, "api"); err == nil || !strings.Contains(err.Error(), "requires --update") {
+		t.Errorf("name without --update: err = %v", err)
+	}
+}
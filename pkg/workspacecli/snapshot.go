@@ -0,0 +1,465 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package workspacecli
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/repository"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/reposync"
+)
+
+// workspaceSnapshot is the saved state of every repository under a
+// workspace root, as 'workspace snapshot save' records it.
+type workspaceSnapshot struct {
+	Name      string         `json:"name"`
+	Root      string         `json:"root"`
+	CreatedAt time.Time      `json:"createdAt"`
+	Repos     []repoSnapshot `json:"repositories"`
+}
+
+// repoSnapshot is the state of one repository. Patch is a binary diff of
+// the working tree against Head, untracked files included, so applying it to
+// a clean checkout of Head recreates the tree.
+type repoSnapshot struct {
+	Path      string          `json:"path"` // relative to the snapshot root
+	Branch    string          `json:"branch,omitempty"`
+	Head      string          `json:"head"`
+	Upstream  string          `json:"upstream,omitempty"`
+	RemoteURL string          `json:"remoteURL,omitempty"`
+	Patch     string          `json:"patch,omitempty"`
+	Stashes   []snapshotStash `json:"stashes,omitempty"`
+}
+
+// snapshotStash is a stash entry, newest first as 'git stash list' prints.
+type snapshotStash struct {
+	Commit  string `json:"commit"`
+	Message string `json:"message"`
+}
+
+var snapshotNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// snapshotStore keeps the snapshots of one workspace root as JSON files.
+// They live in the state directory rather than the workspace, since patches
+// may hold work nobody meant to commit.
+type snapshotStore struct {
+	dir string
+}
+
+// newSnapshotStore returns the store for root, under
+// ~/.config/gz-git/state/snapshots/<root name>-<hash of root>.
+func newSnapshotStore(root string) (*snapshotStore, error) {
+	paths, err := config.NewPaths()
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256([]byte(root))
+	key := filepath.Base(root) + "-" + hex.EncodeToString(sum[:])[:12]
+	return &snapshotStore{dir: filepath.Join(paths.StateDir, "snapshots", key)}, nil
+}
+
+func (s *snapshotStore) path(name string) (string, error) {
+	if !snapshotNameRe.MatchString(name) {
+		return "", fmt.Errorf("invalid snapshot name %q: use letters, digits, '.', '_' and '-'", name)
+	}
+	return filepath.Join(s.dir, name+".json"), nil
+}
+
+// Save writes snap; an existing snapshot of the same name needs overwrite.
+func (s *snapshotStore) Save(snap *workspaceSnapshot, overwrite bool) error {
+	path, err := s.path(snap.Name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil && !overwrite {
+		return fmt.Errorf("snapshot %q already exists: pass --force to replace it", snap.Name)
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("create snapshot directory: %w", err)
+	}
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal snapshot: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write snapshot: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("move snapshot: %w", err)
+	}
+	return nil
+}
+
+// Load reads the snapshot called name.
+func (s *snapshotStore) Load(name string) (*workspaceSnapshot, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- path is built from a validated snapshot name.
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("no snapshot %q for this workspace: see 'gz-git workspace snapshot list'", name)
+		}
+		return nil, fmt.Errorf("read snapshot: %w", err)
+	}
+	var snap workspaceSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("parse snapshot %s: %w", path, err)
+	}
+	return &snap, nil
+}
+
+// List returns the workspace's snapshots, oldest first.
+func (s *snapshotStore) List() ([]*workspaceSnapshot, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read snapshot directory: %w", err)
+	}
+	var snaps []*workspaceSnapshot
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok || e.IsDir() {
+			continue
+		}
+		snap, err := s.Load(name)
+		if err != nil {
+			return nil, err
+		}
+		snaps = append(snaps, snap)
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].CreatedAt.Before(snaps[j].CreatedAt) })
+	return snaps, nil
+}
+
+// captureWorkspace records every repository under root. BulkStatus finds the
+// repositories and reads branch, upstream and remote; the full HEAD, the
+// working tree patch and the stash list are read per repository.
+func captureWorkspace(ctx context.Context, name, root string, depth, parallel int) (*workspaceSnapshot, error) {
+	status, err := repository.NewClient().BulkStatus(ctx, repository.BulkStatusOptions{
+		Directory: root,
+		MaxDepth:  depth,
+		Parallel:  parallel,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan %s: %w", root, err)
+	}
+
+	snap := &workspaceSnapshot{Name: name, Root: root, CreatedAt: time.Now().UTC()}
+	var problems []string
+	for _, r := range status.Repositories {
+		rel := filepath.ToSlash(r.RelativePath)
+		switch r.Status {
+		case repository.StatusError:
+			problems = append(problems, fmt.Sprintf("%s: %s: %v", rel, r.Message, r.Error))
+			continue
+		case repository.StatusConflict, repository.StatusRebaseInProgress, repository.StatusMergeInProgress:
+			problems = append(problems, fmt.Sprintf("%s: %s; finish or abort it first", rel, strings.ToLower(r.Message)))
+			continue
+		}
+
+		repo := repoSnapshot{
+			Path:      rel,
+			Branch:    r.Branch,
+			Upstream:  r.Upstream,
+			RemoteURL: reposync.StripURLCredentials(r.RemoteURL),
+		}
+		if repo.Head, err = snapshotGit(ctx, r.Path, nil, "rev-parse", "--verify", "HEAD^{commit}"); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: no commit to snapshot: %v", rel, err))
+			continue
+		}
+		if r.TrackedChangedFiles+r.UntrackedFiles > 0 {
+			if repo.Patch, err = worktreePatch(ctx, r.Path); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", rel, err))
+				continue
+			}
+		}
+		if r.StashCount > 0 {
+			if repo.Stashes, err = stashList(ctx, r.Path); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", rel, err))
+				continue
+			}
+		}
+		snap.Repos = append(snap.Repos, repo)
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("cannot snapshot the workspace:\n  %s", strings.Join(problems, "\n  "))
+	}
+	sort.Slice(snap.Repos, func(i, j int) bool { return snap.Repos[i].Path < snap.Repos[j].Path })
+	return snap, nil
+}
+
+// worktreePatch diffs the working tree, untracked files included, against
+// HEAD. It stages into a throwaway index so the real one is left alone.
+func worktreePatch(ctx context.Context, repoPath string) (string, error) {
+	tmp, err := os.MkdirTemp("", "gz-git-snapshot-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+
+	env := []string{"GIT_INDEX_FILE=" + filepath.Join(tmp, "index")}
+	if _, err := snapshotGit(ctx, repoPath, env, "read-tree", "HEAD"); err != nil {
+		return "", fmt.Errorf("diff working tree: %w", err)
+	}
+	if _, err := snapshotGit(ctx, repoPath, env, "add", "--all"); err != nil {
+		return "", fmt.Errorf("diff working tree: %w", err)
+	}
+	patch, err := snapshotGitRaw(ctx, repoPath, env, nil, "diff", "--cached", "--binary", "--full-index", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("diff working tree: %w", err)
+	}
+	return patch, nil
+}
+
+func stashList(ctx context.Context, repoPath string) ([]snapshotStash, error) {
+	out, err := snapshotGit(ctx, repoPath, nil, "stash", "list", "--format=%H %gs")
+	if err != nil {
+		return nil, fmt.Errorf("list stashes: %w", err)
+	}
+	var stashes []snapshotStash
+	for line := range strings.SplitSeq(out, "\n") {
+		if commit, msg, ok := strings.Cut(line, " "); ok {
+			stashes = append(stashes, snapshotStash{Commit: commit, Message: msg})
+		}
+	}
+	return stashes, nil
+}
+
+// restoreOptions controls restoreRepo.
+type restoreOptions struct {
+	// StashChanges stashes uncommitted changes instead of refusing to
+	// restore over them.
+	StashChanges bool
+}
+
+// restoreRepo recreates repo under root: it clones a missing repository,
+// checks out Head (on Branch when the branch still points there, detached
+// otherwise), applies Patch and stores stash entries that are gone. It
+// returns what it did, and notes on what it could not do exactly.
+func restoreRepo(ctx context.Context, root string, repo repoSnapshot, opts restoreOptions) (string, []string, error) {
+	path := filepath.Join(root, filepath.FromSlash(repo.Path))
+	var notes []string
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		if repo.RemoteURL == "" {
+			return "", nil, errors.New("missing, and the snapshot has no remote to clone it from")
+		}
+		if _, err := snapshotGit(ctx, root, nil, "clone", "--quiet", "--", repo.RemoteURL, path); err != nil {
+			return "", nil, err
+		}
+		notes = append(notes, "cloned from "+repo.RemoteURL)
+	}
+
+	dirty, err := snapshotGit(ctx, path, nil, "status", "--porcelain")
+	if err != nil {
+		return "", nil, err
+	}
+	if dirty != "" {
+		if !opts.StashChanges {
+			return "", nil, errors.New("has uncommitted changes: commit them, or pass --stash to stash them first")
+		}
+		if _, err := snapshotGit(ctx, path, nil, "stash", "push", "--include-untracked", "--message", "gz-git snapshot restore"); err != nil {
+			return "", nil, err
+		}
+		notes = append(notes, "stashed uncommitted changes")
+	}
+
+	if !commitPresent(ctx, path, repo.Head) {
+		if _, err := snapshotGit(ctx, path, repository.NonInteractiveEnv(), "fetch", "--quiet", "origin"); err != nil || !commitPresent(ctx, path, repo.Head) {
+			return "", nil, fmt.Errorf("commit %s is gone (not here and not on origin)", shortCommit(repo.Head))
+		}
+	}
+
+	done := "detached at " + shortCommit(repo.Head)
+	switch tip, tipErr := snapshotGit(ctx, path, nil, "rev-parse", "--verify", "--quiet", "refs/heads/"+repo.Branch); {
+	case repo.Branch == "":
+		_, err = snapshotGit(ctx, path, nil, "checkout", "--quiet", "--detach", repo.Head)
+	case tipErr != nil:
+		// The branch is gone; bring it back where it was.
+		if _, err = snapshotGit(ctx, path, nil, "checkout", "--quiet", "-b", repo.Branch, repo.Head); err == nil && repo.Upstream != "" {
+			if _, upErr := snapshotGit(ctx, path, nil, "branch", "--quiet", "--set-upstream-to", repo.Upstream); upErr != nil {
+				notes = append(notes, "upstream "+repo.Upstream+" not set: "+upErr.Error())
+			}
+		}
+		done = fmt.Sprintf("%s at %s (recreated)", repo.Branch, shortCommit(repo.Head))
+	case tip == repo.Head:
+		_, err = snapshotGit(ctx, path, nil, "checkout", "--quiet", repo.Branch)
+		done = fmt.Sprintf("%s at %s", repo.Branch, shortCommit(repo.Head))
+	default:
+		// Moving the branch back could drop commits made since the snapshot.
+		_, err = snapshotGit(ctx, path, nil, "checkout", "--quiet", "--detach", repo.Head)
+		notes = append(notes, fmt.Sprintf("%s has moved on to %s; left HEAD detached", repo.Branch, shortCommit(tip)))
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	if repo.Patch != "" {
+		if _, err := snapshotGitRaw(ctx, path, nil, strings.NewReader(repo.Patch), "apply", "--binary", "--whitespace=nowarn"); err != nil {
+			return "", nil, fmt.Errorf("apply working tree patch: %w", err)
+		}
+		done += " + uncommitted changes"
+	}
+
+	current, err := stashList(ctx, path)
+	if err != nil {
+		return "", nil, err
+	}
+	have := make(map[string]bool, len(current))
+	for _, s := range current {
+		have[s.Commit] = true
+	}
+	// 'stash store' pushes on top, so store the oldest missing entry first.
+	for _, s := range slices.Backward(repo.Stashes) {
+		if have[s.Commit] {
+			continue
+		}
+		if !commitPresent(ctx, path, s.Commit) {
+			notes = append(notes, fmt.Sprintf("stash %q is gone", s.Message))
+			continue
+		}
+		if _, err := snapshotGit(ctx, path, nil, "stash", "store", "--message", s.Message, s.Commit); err != nil {
+			return "", nil, err
+		}
+		done += fmt.Sprintf(", stash %q restored", s.Message)
+	}
+	return done, notes, nil
+}
+
+// diffSnapshots describes how repositories changed from before to after, one
+// line per repository that differs.
+func diffSnapshots(before, after *workspaceSnapshot) []string {
+	index := func(s *workspaceSnapshot) map[string]repoSnapshot {
+		m := make(map[string]repoSnapshot, len(s.Repos))
+		for _, r := range s.Repos {
+			m[r.Path] = r
+		}
+		return m
+	}
+	oldRepos, newRepos := index(before), index(after)
+
+	paths := make([]string, 0, len(oldRepos)+len(newRepos))
+	for p := range oldRepos {
+		paths = append(paths, p)
+	}
+	for p := range newRepos {
+		if _, ok := oldRepos[p]; !ok {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	var lines []string
+	for _, p := range paths {
+		o, inOld := oldRepos[p]
+		n, inNew := newRepos[p]
+		switch {
+		case !inOld:
+			lines = append(lines, fmt.Sprintf("+ %s %s", p, describeRepoState(n)))
+			continue
+		case !inNew:
+			lines = append(lines, fmt.Sprintf("- %s %s", p, describeRepoState(o)))
+			continue
+		}
+
+		var parts []string
+		if o.Branch != n.Branch {
+			parts = append(parts, fmt.Sprintf("branch %s → %s", branchLabel(o.Branch), branchLabel(n.Branch)))
+		}
+		if o.Head != n.Head {
+			parts = append(parts, fmt.Sprintf("head %s → %s", shortCommit(o.Head), shortCommit(n.Head)))
+		}
+		if o.Patch != n.Patch {
+			parts = append(parts, fmt.Sprintf("uncommitted %d → %d file(s)", patchFileCount(o.Patch), patchFileCount(n.Patch)))
+		}
+		if len(o.Stashes) != len(n.Stashes) {
+			parts = append(parts, fmt.Sprintf("stashes %d → %d", len(o.Stashes), len(n.Stashes)))
+		}
+		if len(parts) > 0 {
+			lines = append(lines, fmt.Sprintf("~ %s: %s", p, strings.Join(parts, ", ")))
+		}
+	}
+	return lines
+}
+
+func describeRepoState(r repoSnapshot) string {
+	s := fmt.Sprintf("(%s at %s", branchLabel(r.Branch), shortCommit(r.Head))
+	if r.Patch != "" {
+		s += fmt.Sprintf(", %d uncommitted file(s)", patchFileCount(r.Patch))
+	}
+	return s + ")"
+}
+
+func branchLabel(branch string) string {
+	if branch == "" {
+		return "detached"
+	}
+	return branch
+}
+
+// patchFileCount counts the files a 'git diff' patch touches.
+func patchFileCount(patch string) int {
+	n := strings.Count(patch, "\ndiff --git ")
+	if strings.HasPrefix(patch, "diff --git ") {
+		n++
+	}
+	return n
+}
+
+func shortCommit(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
+
+func commitPresent(ctx context.Context, repoPath, sha string) bool {
+	_, err := snapshotGit(ctx, repoPath, nil, "cat-file", "-e", sha+"^{commit}")
+	return err == nil
+}
+
+// snapshotGit runs git in repoPath and returns its trimmed output.
+func snapshotGit(ctx context.Context, repoPath string, env []string, args ...string) (string, error) {
+	out, err := snapshotGitRaw(ctx, repoPath, env, nil, args...)
+	return strings.TrimSpace(out), err
+}
+
+func snapshotGitRaw(ctx context.Context, repoPath string, env []string, stdin *strings.Reader, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", repoPath}, args...)...) // #nosec G204 -- fixed git subcommands; SHAs and paths come from git or the snapshot, passed as argv.
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,272 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package workspacecli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+)
+
+// snapshotFlags are shared by the snapshot subcommands.
+type snapshotFlags struct {
+	path      string
+	scanDepth int
+	parallel  int
+}
+
+// root returns the absolute workspace root: --path, else the directory of
+// the nearest config, else the current directory.
+func (f *snapshotFlags) root() (string, error) {
+	dir := f.path
+	if dir == "" {
+		dir = "."
+		if detected, err := detectConfigFile("."); err == nil {
+			dir = filepath.Dir(detected)
+		}
+	}
+	return filepath.Abs(dir)
+}
+
+func (f CommandFactory) newSnapshotCmd() *cobra.Command {
+	flags := &snapshotFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Save and restore the state of every repository in the workspace",
+		Long: cliutil.QuickStartHelp(`  # Save branch, HEAD, uncommitted changes and stashes of every repo
+  gz-git workspace snapshot save before-upgrade
+
+  # What changed since then?
+  gz-git workspace snapshot diff before-upgrade
+
+  # Go back
+  gz-git workspace snapshot restore before-upgrade --stash
+
+  # Saved snapshots
+  gz-git workspace snapshot list`) + `
+A snapshot records, for every repository under the workspace root, the
+current branch, the full HEAD commit, its upstream, the uncommitted changes
+(untracked files included) as a binary patch, and the stash list. Snapshots
+are kept in ~/.config/gz-git/state/snapshots, not in the workspace, since
+patches can hold work nobody meant to commit.
+
+The workspace root is the directory of the nearest ` + DefaultConfigFile + `, or the
+current directory; --path picks another.
+`,
+	}
+
+	cmd.PersistentFlags().StringVar(&flags.path, "path", "", "Workspace root (default: directory of the nearest "+DefaultConfigFile+")")
+	cmd.PersistentFlags().IntVarP(&flags.scanDepth, "scan-depth", "d", 2, "Directory depth to scan for repositories")
+	cmd.PersistentFlags().IntVarP(&flags.parallel, "parallel", "j", 4, "Repositories read in parallel")
+
+	cmd.AddCommand(
+		newSnapshotSaveCmd(flags),
+		newSnapshotRestoreCmd(flags),
+		newSnapshotListCmd(flags),
+		newSnapshotDiffCmd(flags),
+	)
+	return cmd
+}
+
+func newSnapshotSaveCmd(flags *snapshotFlags) *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "save <name>",
+		Short: "Record the state of every repository",
+		Long: `Record the state of every repository under the workspace root.
+
+Repositories with a merge or rebase in progress, or with conflicts, cannot be
+snapshotted: finish or abort the operation first. Nothing in the workspace is
+changed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := flags.root()
+			if err != nil {
+				return err
+			}
+			store, err := newSnapshotStore(root)
+			if err != nil {
+				return err
+			}
+			if _, err := store.path(args[0]); err != nil {
+				return err
+			}
+
+			snap, err := captureWorkspace(cmd.Context(), args[0], root, flags.scanDepth, flags.parallel)
+			if err != nil {
+				return err
+			}
+			if len(snap.Repos) == 0 {
+				return fmt.Errorf("no repositories found under %s", root)
+			}
+			if err := store.Save(snap, force); err != nil {
+				return err
+			}
+
+			dirty := 0
+			for _, r := range snap.Repos {
+				if r.Patch != "" {
+					dirty++
+				}
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Saved snapshot %q: %d repositories (%d with uncommitted changes)\n", snap.Name, len(snap.Repos), dirty)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Replace an existing snapshot of the same name")
+	return cmd
+}
+
+func newSnapshotRestoreCmd(flags *snapshotFlags) *cobra.Command {
+	var opts restoreOptions
+
+	cmd := &cobra.Command{
+		Use:   "restore <name>",
+		Short: "Recreate a saved state across the workspace",
+		Long: `Recreate a saved state across the workspace.
+
+For every repository in the snapshot: clone it if it is missing, check out
+the recorded commit, apply the recorded uncommitted changes (unstaged) and
+store stash entries that have since been dropped.
+
+The recorded branch is checked out when it still points at the recorded
+commit, and recreated when it was deleted. A branch that has moved on is left
+alone and HEAD is detached at the recorded commit, so no commit made since is
+lost. Repositories with uncommitted changes are refused unless --stash
+stashes those changes first. Repositories not in the snapshot are untouched.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			root, err := flags.root()
+			if err != nil {
+				return err
+			}
+			store, err := newSnapshotStore(root)
+			if err != nil {
+				return err
+			}
+			snap, err := store.Load(args[0])
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(root, 0o750); err != nil {
+				return err
+			}
+
+			failed := 0
+			for _, repo := range snap.Repos {
+				done, notes, err := restoreRepo(cmd.Context(), root, repo, opts)
+				if err != nil {
+					failed++
+					fmt.Fprintf(out, "✗ %s: %v\n", repo.Path, err)
+					continue
+				}
+				fmt.Fprintf(out, "✓ %s: %s\n", repo.Path, done)
+				for _, n := range notes {
+					fmt.Fprintf(out, "  ⚠️  %s\n", n)
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d repositories were not restored", failed, len(snap.Repos))
+			}
+			fmt.Fprintf(out, "Restored snapshot %q (%d repositories)\n", snap.Name, len(snap.Repos))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.StashChanges, "stash", false, "Stash uncommitted changes instead of refusing to restore over them")
+	return cmd
+}
+
+func newSnapshotListCmd(flags *snapshotFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the workspace's snapshots",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			out := cmd.OutOrStdout()
+			root, err := flags.root()
+			if err != nil {
+				return err
+			}
+			store, err := newSnapshotStore(root)
+			if err != nil {
+				return err
+			}
+			snaps, err := store.List()
+			if err != nil {
+				return err
+			}
+			if len(snaps) == 0 {
+				fmt.Fprintf(out, "No snapshots for %s\n", root)
+				return nil
+			}
+			for _, s := range snaps {
+				dirty := 0
+				for _, r := range s.Repos {
+					if r.Patch != "" {
+						dirty++
+					}
+				}
+				fmt.Fprintf(out, "%-24s %s  %3d repos  %3d with uncommitted changes\n",
+					s.Name, s.CreatedAt.Local().Format("2006-01-02 15:04"), len(s.Repos), dirty)
+			}
+			return nil
+		},
+	}
+}
+
+func newSnapshotDiffCmd(flags *snapshotFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff <name> [other]",
+		Short: "Compare a snapshot with the workspace now, or with another snapshot",
+		Long: `Compare a snapshot with the current state of the workspace, or with another
+snapshot. Prints one line per repository that differs: "+" for one that is
+new, "-" for one that is gone and "~" with what changed (branch, HEAD,
+uncommitted changes, stash count).`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			root, err := flags.root()
+			if err != nil {
+				return err
+			}
+			store, err := newSnapshotStore(root)
+			if err != nil {
+				return err
+			}
+			before, err := store.Load(args[0])
+			if err != nil {
+				return err
+			}
+
+			var after *workspaceSnapshot
+			if len(args) == 2 {
+				after, err = store.Load(args[1])
+			} else {
+				after, err = captureWorkspace(cmd.Context(), "", root, flags.scanDepth, flags.parallel)
+			}
+			if err != nil {
+				return err
+			}
+
+			lines := diffSnapshots(before, after)
+			if len(lines) == 0 {
+				fmt.Fprintln(out, "No differences")
+				return nil
+			}
+			for _, l := range lines {
+				fmt.Fprintln(out, l)
+			}
+			return nil
+		},
+	}
+}
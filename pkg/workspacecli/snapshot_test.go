@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package workspacecli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitforge/internal/testutil"
+)
+
+func TestWorkspaceSnapshotSaveDiffRestore(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	fx := testutil.TempWorktreeWithBareOrigin(t)
+	ws := t.TempDir()
+	api, web := filepath.Join(ws, "api"), filepath.Join(ws, "web")
+	for _, dir := range []string{"api", "web"} {
+		gitIn(t, ws, "clone", "--quiet", fx.Origin, dir)
+		for _, kv := range [][2]string{{"user.email", "test@test.com"}, {"user.name", "Test"}, {"commit.gpgsign", "false"}} {
+			gitIn(t, filepath.Join(ws, dir), "config", kv[0], kv[1])
+		}
+	}
+	write := func(dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// api: on a feature branch, with a stash and uncommitted, untracked and
+	// binary changes.
+	gitIn(t, api, "checkout", "--quiet", "-b", "feature")
+	gitIn(t, api, "commit", "--quiet", "--allow-empty", "-m", "feature work")
+	head := gitIn(t, api, "rev-parse", "HEAD")
+	write(api, "wip.txt", "stashed\n")
+	gitIn(t, api, "stash", "push", "--quiet", "--include-untracked", "--message", "wip")
+	write(api, "README.md", "# Changed\n")
+	write(api, "notes.txt", "untracked\n")
+	write(api, "blob.bin", "\x00\x01\x02binary")
+
+	f := CommandFactory{}
+	snapshot := func(args ...string) (string, error) {
+		return runWorkspaceCmd(t, f.newSnapshotCmd, append(args, "--path", ws)...)
+	}
+
+	if out, err := snapshot("save", "before"); err != nil || !strings.Contains(out, "2 repositories (1 with uncommitted changes)") {
+		t.Fatalf("save: %v\n%s", err, out)
+	}
+	if _, err := snapshot("save", "before"); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("second save: err = %v", err)
+	}
+	if _, err := snapshot("save", "../escape"); err == nil || !strings.Contains(err.Error(), "invalid snapshot name") {
+		t.Errorf("bad name: err = %v", err)
+	}
+	if out, err := snapshot("list"); err != nil || !strings.Contains(out, "before") {
+		t.Errorf("list: %v\n%s", err, out)
+	}
+	if out, err := snapshot("diff", "before"); err != nil || !strings.Contains(out, "No differences") {
+		t.Errorf("diff of an unchanged workspace: %v\n%s", err, out)
+	}
+
+	// Things move on: the branch gets a commit, the changes and the stash are
+	// dropped, and web is deleted.
+	gitIn(t, api, "checkout", "--quiet", "--", ".")
+	gitIn(t, api, "clean", "--quiet", "-fd")
+	gitIn(t, api, "commit", "--quiet", "--allow-empty", "-m", "later")
+	gitIn(t, api, "stash", "drop", "--quiet")
+	if err := os.RemoveAll(web); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := snapshot("diff", "before")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"~ api: head " + head[:12] + " → ", "uncommitted 3 → 0 file(s)", "stashes 1 → 0", "- web ("} {
+		if !strings.Contains(out, want) {
+			t.Errorf("diff output lacks %q:\n%s", want, out)
+		}
+	}
+
+	// Restore refuses to overwrite new uncommitted work unless asked to stash it.
+	write(api, "new-work.txt", "keep me\n")
+	out, err = snapshot("restore", "before")
+	if err == nil || !strings.Contains(out, "✗ api: has uncommitted changes") || !strings.Contains(out, "✓ web: ") {
+		t.Fatalf("restore over changes: %v\n%s", err, out)
+	}
+	if out, err = snapshot("restore", "before", "--stash"); err != nil {
+		t.Fatalf("restore --stash: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "feature has moved on") {
+		t.Errorf("restore output:\n%s", out)
+	}
+
+	if got := gitIn(t, api, "rev-parse", "HEAD"); got != head {
+		t.Errorf("api HEAD = %s, want %s", got, head)
+	}
+	for name, want := range map[string]string{"README.md": "# Changed\n", "notes.txt": "untracked\n", "blob.bin": "\x00\x01\x02binary"} {
+		if data, err := os.ReadFile(filepath.Join(api, name)); err != nil || string(data) != want {
+			t.Errorf("%s = %q, %v; want %q", name, data, err, want)
+		}
+	}
+	stashes := gitIn(t, api, "stash", "list", "--format=%gs")
+	if !strings.Contains(stashes, "wip") || !strings.Contains(stashes, "gz-git snapshot restore") {
+		t.Errorf("stash list after restore:\n%s", stashes)
+	}
+	if _, err := os.Stat(filepath.Join(web, ".git")); err != nil {
+		t.Errorf("web was not cloned back: %v", err)
+	}
+}
+
+func TestCaptureWorkspaceStripsRemoteCredentials(t *testing.T) {
+	fx := testutil.TempWorktreeWithBareOrigin(t)
+	ws := t.TempDir()
+	gitIn(t, ws, "clone", "--quiet", fx.Origin, "api")
+	// A token-only userinfo, as the Gitea token injection writes it.
+	gitIn(t, filepath.Join(ws, "api"), "remote", "set-url", "origin", "https://TOKEN@example.com/org/api.git")
+
+	snap, err := captureWorkspace(t.Context(), "s", ws, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snap.Repos) != 1 || snap.Repos[0].RemoteURL != "https://example.com/org/api.git" {
+		t.Errorf("repos = %+v, want remote https://example.com/org/api.git", snap.Repos)
+	}
+}
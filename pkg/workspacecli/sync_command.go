@@ -47,6 +47,10 @@ func (f CommandFactory) newSyncCmd() *cobra.Command { //nolint:gocognit,gocyclo
 		recursiveDepth int
 		format         string
 		pushAfterSync  bool
+		locked         bool
+		lockCheckout   string
+		selectExpr     string
+		groups         []string
 	)
 
 	cmd := &cobra.Command{
@@ -67,6 +71,10 @@ func (f CommandFactory) newSyncCmd() *cobra.Command { //nolint:gocognit,gocyclo
   # Override strategy for all repos
   gz-git workspace sync --strategy pull
 
+  # Sync only the repositories labelled backend, or in the group core
+  gz-git workspace sync --select 'label=backend,!archived'
+  gz-git workspace sync --group core
+
   # Resume interrupted sync
   gz-git workspace sync --resume --state-file state.json
 
@@ -117,29 +125,6 @@ Config File Structure (Reference):
 				fmt.Fprintf(cmd.OutOrStdout(), "Using config: %s\n", configPath)
 			}
 
-			// Get config directory for path resolution
-			absConfigPath, err := filepath.Abs(configPath)
-			if err != nil {
-				return fmt.Errorf("failed to resolve config path %s: %w", configPath, err)
-			}
-			configDir := filepath.Dir(absConfigPath)
-			configFile := filepath.Base(absConfigPath)
-
-			// Load config to check for "repositories" list (flat config format)
-			loader := FileSpecLoader{}
-			cfgData, err := loader.Load(ctx, configPath)
-			if err != nil {
-				return err
-			}
-
-			// Plan from repositories list (flat config format)
-			var allActions []reposync.Action
-			if len(cfgData.Plan.Input.Repos) > 0 {
-				// CRITICAL: Filter out the devbox directory itself to prevent self-reset
-				flatActions := createActionsFromFlatConfig(cfgData.Plan, configDir)
-				allActions = append(allActions, flatActions...)
-			}
-
 			// Validate format early so we can suppress planning output for machine formats
 			if err := cliutil.ValidateFormat(format, cliutil.CoreFormats); err != nil {
 				return err
@@ -161,72 +146,42 @@ Config File Structure (Reference):
 				go planSpinnerProgram.Run() //nolint:errcheck // spinner error is non-critical and only logged by Bubble Tea internally
 			}
 
-			// Load Recursive Config (File 4 style)
-			// We check if there are nested workspaces or forge sources defined
+			plan, planErr := planWorkspaceSync(ctx, configPath, planOut, strategy, fullOutput)
 
-			recursiveCfg, recursiveErr := config.LoadConfigRecursive(configDir, configFile)
-			if recursiveErr != nil {
-				if !os.IsNotExist(recursiveErr) {
-					fmt.Fprintf(planOut, "⚠️  Config warning: %v\n", recursiveErr)
-				}
-				recursiveCfg = nil
+			// Stop planning spinner
+			if planSpinnerProgram != nil {
+				planSpinnerProgram.Send(planDoneMsg{})
+				planSpinnerProgram.Wait()
 			}
+			if planErr != nil {
+				return planErr
+			}
+			cfgData, recursiveCfg, configDir, allActions := plan.Data, plan.Config, plan.ConfigDir, plan.Actions
 
-			if recursiveCfg != nil {
-				// Auto-create child configs if missing
-				// This handles bootstrapping where child directories don't exist yet
-				if err := ensureChildConfigs(planOut, recursiveCfg); err != nil {
-					if planSpinnerProgram != nil {
-						planSpinnerProgram.Send(planDoneMsg{})
-						planSpinnerProgram.Wait()
-					}
-					return fmt.Errorf("failed to ensure child configs: %w", err)
-				}
-
-				// Discover workspaces (Hybrid mode)
-				if err := config.LoadWorkspaces(configDir, recursiveCfg, config.HybridMode); err == nil {
-					// Get forge actions
-					forgeActions, err := planForgeWorkspaces(ctx, recursiveCfg, planOut, strategy, fullOutput)
-					if err != nil {
-						if planSpinnerProgram != nil {
-							planSpinnerProgram.Send(planDoneMsg{})
-							planSpinnerProgram.Wait()
-						}
-						return err
-					}
-					allActions = append(allActions, forgeActions...)
-
-					// Get git workspace actions (type=git with URL)
-					gitActions, err := planGitWorkspaces(ctx, recursiveCfg, configDir, planOut, strategy)
-					if err != nil {
-						if planSpinnerProgram != nil {
-							planSpinnerProgram.Send(planDoneMsg{})
-							planSpinnerProgram.Wait()
-						}
-						return err
-					}
-					allActions = append(allActions, gitActions...)
-
-					// Get config workspace actions (type=config with sync.recursive=true)
-					cfgWsActions, err := planConfigWorkspaces(ctx, recursiveCfg, configDir, planOut, strategy)
-					if err != nil {
-						if planSpinnerProgram != nil {
-							planSpinnerProgram.Send(planDoneMsg{})
-							planSpinnerProgram.Wait()
-						}
-						return err
-					}
-					allActions = append(allActions, cfgWsActions...)
+			// Keep only the repositories picked by --select and --group
+			if selectExpr != "" || len(groups) > 0 {
+				selected, err := selectActions(allActions, configPath, selectExpr, groups)
+				if err != nil {
+					return err
 				}
-
-				// Deduplicate actions by target path (forge + config planners may overlap)
-				allActions = deduplicateActions(allActions)
+				fmt.Fprintf(planOut, "Selected %d of %d repositories\n", len(selected), len(allActions))
+				allActions = selected
 			}
 
-			// Stop planning spinner
-			if planSpinnerProgram != nil {
-				planSpinnerProgram.Send(planDoneMsg{})
-				planSpinnerProgram.Wait()
+			// Pin every repository to the commit recorded in the lockfile
+			if locked {
+				mode := reposync.CommitCheckout(lockCheckout)
+				if err := mode.Validate(); err != nil {
+					return err
+				}
+				lockPath := filepath.Join(configDir, reposync.LockfileName)
+				lock, err := reposync.LoadLockfile(lockPath)
+				if err != nil {
+					return fmt.Errorf("--locked: %w", err)
+				}
+				if err := lock.Apply(allActions, configDir, mode); err != nil {
+					return fmt.Errorf("--locked: %s: %w", lockPath, err)
+				}
 			}
 
 			// Execute self-sync first (sync config directory itself)
@@ -445,10 +400,98 @@ Config File Structure (Reference):
 	cmd.Flags().IntVar(&recursiveDepth, "recursive-depth", 3, "Maximum recursion depth for --recurse-workspaces")
 	cmd.Flags().StringVar(&format, "format", "default", "Output format (default, compact, json, llm)")
 	cmd.Flags().BoolVar(&pushAfterSync, "push", false, "Push after successful sync (only repos with local commits ahead)")
+	cmd.Flags().BoolVar(&locked, "locked", false, "Check out the commits recorded in "+reposync.LockfileName+" instead of branch tips")
+	cmd.Flags().StringVar(&lockCheckout, "lock-checkout", string(reposync.CommitCheckoutDetach), "How --locked checks out a commit (detach|branch)")
+	cmd.Flags().StringVar(&selectExpr, "select", "", "Sync only repositories matching a selector (e.g. 'label=backend,!archived')")
+	cmd.Flags().StringSliceVar(&groups, "group", nil, "Sync only repositories in these groups")
 
 	return cmd
 }
 
+// syncPlan is the result of planning a workspace config: the actions to run
+// and the config they came from.
+type syncPlan struct {
+	ConfigDir string
+	Data      *ConfigData
+	Config    *config.Config // nil unless the config loads as a workspace tree
+	Actions   []reposync.Action
+}
+
+// planWorkspaceSync loads configPath and plans its flat repositories list and
+// its forge, git and config workspaces, writing planning progress to out.
+func planWorkspaceSync(ctx context.Context, configPath string, out io.Writer, strategy string, fullOutput bool) (*syncPlan, error) {
+	// Get config directory for path resolution
+	absConfigPath, err := filepath.Abs(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve config path %s: %w", configPath, err)
+	}
+	configDir := filepath.Dir(absConfigPath)
+	configFile := filepath.Base(absConfigPath)
+
+	// Load config to check for "repositories" list (flat config format)
+	loader := FileSpecLoader{}
+	cfgData, err := loader.Load(ctx, configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &syncPlan{ConfigDir: configDir, Data: cfgData}
+
+	// Plan from repositories list (flat config format)
+	if len(cfgData.Plan.Input.Repos) > 0 {
+		// CRITICAL: Filter out the devbox directory itself to prevent self-reset
+		plan.Actions = append(plan.Actions, createActionsFromFlatConfig(cfgData.Plan, configDir)...)
+	}
+
+	// Load Recursive Config (File 4 style)
+	// We check if there are nested workspaces or forge sources defined
+	recursiveCfg, err := config.LoadConfigRecursive(configDir, configFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			fmt.Fprintf(out, "⚠️  Config warning: %v\n", err)
+		}
+		return plan, nil
+	}
+	if recursiveCfg == nil {
+		return plan, nil
+	}
+	plan.Config = recursiveCfg
+
+	// Auto-create child configs if missing
+	// This handles bootstrapping where child directories don't exist yet
+	if err := ensureChildConfigs(out, recursiveCfg); err != nil {
+		return nil, fmt.Errorf("failed to ensure child configs: %w", err)
+	}
+
+	// Discover workspaces (Hybrid mode)
+	if err := config.LoadWorkspaces(configDir, recursiveCfg, config.HybridMode); err == nil {
+		// Get forge actions
+		forgeActions, err := planForgeWorkspaces(ctx, recursiveCfg, out, strategy, fullOutput)
+		if err != nil {
+			return nil, err
+		}
+		plan.Actions = append(plan.Actions, forgeActions...)
+
+		// Get git workspace actions (type=git with URL)
+		gitActions, err := planGitWorkspaces(ctx, recursiveCfg, configDir, out, strategy)
+		if err != nil {
+			return nil, err
+		}
+		plan.Actions = append(plan.Actions, gitActions...)
+
+		// Get config workspace actions (type=config with sync.recursive=true)
+		cfgWsActions, err := planConfigWorkspaces(ctx, recursiveCfg, configDir, out, strategy)
+		if err != nil {
+			return nil, err
+		}
+		plan.Actions = append(plan.Actions, cfgWsActions...)
+	}
+
+	// Deduplicate actions by target path (forge + config planners may overlap)
+	plan.Actions = deduplicateActions(plan.Actions)
+	return plan, nil
+}
+
 // createActionsFromFlatConfig converts flat config PlanRequest to Actions.
 // configDir is the directory containing the config file, used to filter out devbox itself.
 func createActionsFromFlatConfig(req reposync.PlanRequest, configDir string) []reposync.Action {
@@ -563,6 +606,7 @@ func planForgeWorkspaces(ctx context.Context, cfg *config.Config, out io.Writer,
 		cloneProto := ws.CloneProto
 		sshPort := ws.SSHPort
 		token := ws.Source.Token
+		var sshKeyPath, sshKeyContent string
 
 		// Fallback to profile values if not set
 		if ws.Profile != "" && cfg != nil {
@@ -577,6 +621,9 @@ func planForgeWorkspaces(ctx context.Context, cfg *config.Config, out io.Writer,
 				if token == "" {
 					token = profile.Token
 				}
+				if sshKeyPath == "" && sshKeyContent == "" {
+					sshKeyPath, sshKeyContent = profile.SSHKeyPath, profile.SSHKeyContent
+				}
 			}
 		}
 
@@ -593,6 +640,9 @@ func planForgeWorkspaces(ctx context.Context, cfg *config.Config, out io.Writer,
 				if token == "" {
 					token = profile.Token
 				}
+				if sshKeyPath == "" && sshKeyContent == "" {
+					sshKeyPath, sshKeyContent = profile.SSHKeyPath, profile.SSHKeyContent
+				}
 			}
 		}
 
@@ -606,6 +656,18 @@ func planForgeWorkspaces(ctx context.Context, cfg *config.Config, out io.Writer,
 		if token == "" && cfg != nil {
 			token = cfg.Token
 		}
+		if sshKeyPath == "" && sshKeyContent == "" && cfg != nil {
+			sshKeyPath, sshKeyContent = cfg.GetSSHKeyPath(), cfg.GetSSHKeyContent()
+		}
+
+		// Secret references (${keyring:...}, ${cmd:...}) are resolved only now,
+		// for a workspace that is actually being synced.
+		if token, err = config.ResolveSecret(token); err != nil {
+			return nil, fmt.Errorf("workspace '%s': token: %w", name, err)
+		}
+		if sshKeyContent, err = config.ResolveSecret(sshKeyContent); err != nil {
+			return nil, fmt.Errorf("workspace '%s': sshKeyContent: %w", name, err)
+		}
 
 		// Extract branch from workspace config, falling back to root config
 		branch := ""
@@ -627,9 +689,11 @@ func planForgeWorkspaces(ctx context.Context, cfg *config.Config, out io.Writer,
 			FilterIncludePatterns: includePatterns,
 			FilterExcludePatterns: excludePatterns,
 			Auth: reposync.AuthConfig{
-				Token:    token,
-				Provider: ws.Source.Provider,
-				SSHPort:  sshPort,
+				Token:         token,
+				Provider:      ws.Source.Provider,
+				SSHKeyPath:    sshKeyPath,
+				SSHKeyContent: sshKeyContent,
+				SSHPort:       sshPort,
 			},
 			CloneProto: cloneProto,
 			SSHPort:    sshPort,
@@ -1143,7 +1207,7 @@ func (p *consoleProgress) OnProgress(action reposync.Action, message string, pro
 // OnComplete implements reposync.ProgressSink.
 func (p *consoleProgress) OnComplete(result reposync.ActionResult) {
 	if result.Error != nil {
-		fmt.Fprintf(p.out, "✗ [%s] %s: %v\n", result.Action.Type, result.Action.Repo.Name, result.Error)
+		fmt.Fprintf(p.out, "✗ [%s] %s: %s\n", result.Action.Type, result.Action.Repo.Name, config.RedactSecrets(result.Error.Error()))
 		return
 	}
 	msg := result.Message
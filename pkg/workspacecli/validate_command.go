@@ -14,6 +14,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/gizzahub/gzh-cli-gitforge/pkg/cliutil"
+	"github.com/gizzahub/gzh-cli-gitforge/pkg/config"
 )
 
 // ConfigType represents the detected configuration type.
@@ -143,8 +144,11 @@ func validateConfigFile(path string) (*ValidationResult, error) {
 	switch configType {
 	case ConfigTypeClone:
 		validateCloneConfig(rawConfig, result)
+		validateMigrations(path, content, result)
 	case ConfigTypeWorkspace:
 		validateWorkspaceConfig(rawConfig, result)
+		validateAgainstSchema(path, content, result)
+		validateMigrations(path, content, result)
 	case ConfigTypeUnknown:
 		// Try to determine what the user intended
 		result.Errors = append(result.Errors,
@@ -158,6 +162,29 @@ func validateConfigFile(path string) (*ValidationResult, error) {
 	return result, nil
 }
 
+// validateAgainstSchema checks the file against the generated JSON Schema of
+// .gz-git.yaml, which knows every key and the values each takes, and reports
+// each violation at its line and column. Unknown keys are warnings: gz-git
+// ignores them, so the file still loads, but they are usually typos.
+func validateAgainstSchema(path string, content []byte, result *ValidationResult) {
+	schema, err := config.GenerateSchema(config.SchemaKindConfig)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("schema: %s", err))
+		return
+	}
+	violations, err := schema.ValidateYAML(content)
+	if err != nil {
+		return // the caller has already reported the parse error
+	}
+	for _, v := range violations {
+		if v.Unknown {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s:%s (ignored)", path, v))
+			continue
+		}
+		result.Errors = append(result.Errors, fmt.Sprintf("%s:%s", path, v))
+	}
+}
+
 // detectConfigType determines whether the config is workspace or clone type.
 func detectConfigType(config map[string]any) ConfigType {
 	kind, hasKind := config["kind"].(string)
@@ -443,15 +470,9 @@ func validateKind(config map[string]any, result *ValidationResult) {
 		return
 	}
 
-	kindStr, ok := kind.(string)
-	if !ok {
-		result.Errors = append(result.Errors, "'kind' must be a string")
-		return
-	}
-
+	// The schema reports a kind that is not a string or not a known kind.
+	kindStr, _ := kind.(string)
 	switch kindStr {
-	case "workspace", "repositories":
-		// Valid kinds - OK
 	case "workspaces":
 		// Deprecated alias - warn but accept
 		result.Warnings = append(result.Warnings,
@@ -460,35 +481,43 @@ func validateKind(config map[string]any, result *ValidationResult) {
 		// Deprecated alias - warn but accept
 		result.Warnings = append(result.Warnings,
 			"'kind: repository' is deprecated, use 'kind: repositories' instead")
-	default:
-		result.Errors = append(result.Errors,
-			fmt.Sprintf("invalid kind '%s': must be 'workspace' or 'repositories'", kindStr))
 	}
 }
 
 // validateVersion checks the version field.
-func validateVersion(config map[string]any, result *ValidationResult) {
-	version, ok := config["version"]
+func validateVersion(raw map[string]any, result *ValidationResult) {
+	version, ok := raw["version"]
 	if !ok {
 		result.Suggestions = append(result.Suggestions,
-			"Add 'version: 1' for future compatibility")
+			fmt.Sprintf("Add 'version: %d' for future compatibility", config.CurrentConfigVersion))
 		return
 	}
 
-	switch v := version.(type) {
-	case int:
-		if v != 1 {
-			result.Warnings = append(result.Warnings,
-				fmt.Sprintf("unknown version %d, only version 1 is supported", v))
-		}
-	case float64:
-		if v != 1.0 {
-			result.Warnings = append(result.Warnings,
-				fmt.Sprintf("unknown version %.0f, only version 1 is supported", v))
-		}
-	default:
+	v, ok := version.(int)
+	if !ok {
 		result.Warnings = append(result.Warnings, "'version' should be an integer")
+		return
 	}
+	switch {
+	case v > config.CurrentConfigVersion:
+		result.Warnings = append(result.Warnings,
+			fmt.Sprintf("version %d is newer than this gz-git supports (%d): upgrade gz-git", v, config.CurrentConfigVersion))
+	case v < config.CurrentConfigVersion:
+		result.Warnings = append(result.Warnings,
+			fmt.Sprintf("version %d is outdated (current: %d)", v, config.CurrentConfigVersion))
+	}
+}
+
+// validateMigrations warns when the file still uses a format 'config migrate'
+// would upgrade, and names the command that does it.
+func validateMigrations(path string, content []byte, result *ValidationResult) {
+	migration, err := config.MigrateConfig(content)
+	if err != nil || len(migration.Changes) == 0 {
+		return // validateVersion reports the version itself
+	}
+	result.Warnings = append(result.Warnings,
+		fmt.Sprintf("outdated config format (%d change(s) to version %d): run 'gz-git config migrate --write %s'",
+			len(migration.Changes), migration.ToVersion, path))
 }
 
 // validateBranchConfig checks the branch configuration.
@@ -517,24 +546,12 @@ func validateBranchConfig(config map[string]any, result *ValidationResult) {
 	}
 }
 
-// validateStrategy checks the strategy field.
+// validateStrategy suggests a strategy when there is none. The schema checks
+// the value of one that is set.
 func validateStrategy(config map[string]any, result *ValidationResult) {
-	strategy, ok := config["strategy"]
-	if !ok {
+	if _, ok := config["strategy"]; !ok {
 		result.Suggestions = append(result.Suggestions,
 			"Add 'strategy: pull' (or reset, rebase, fetch, skip) to specify sync behavior")
-		return
-	}
-
-	strategyStr, ok := strategy.(string)
-	if !ok {
-		result.Errors = append(result.Errors, "'strategy' must be a string")
-		return
-	}
-
-	if !isValidStrategy(strategyStr) {
-		result.Errors = append(result.Errors,
-			fmt.Sprintf("invalid strategy '%s': must be one of %v", strategyStr, ValidStrategies))
 	}
 }
 
@@ -590,16 +607,9 @@ func validateEntries(config map[string]any, result *ValidationResult) {
 
 // validateRepoEntry validates a single repository entry.
 func validateRepoEntry(index int, entry any, result *ValidationResult) {
-	// String format (just URL)
-	if _, ok := entry.(string); ok {
-		return // Valid simple format
-	}
-
-	// Map format
+	// The schema reports an entry that is not a map.
 	repoMap, ok := entry.(map[string]any)
 	if !ok {
-		result.Errors = append(result.Errors,
-			fmt.Sprintf("repositories[%d]: invalid format, must be string or map", index))
 		return
 	}
 
@@ -615,30 +625,20 @@ func validateRepoEntry(index int, entry any, result *ValidationResult) {
 
 // validateWorkspaceEntry validates a single workspace entry.
 func validateWorkspaceEntry(name string, entry any, result *ValidationResult) {
+	// The schema reports an entry that is not a map, and its type.
 	wsMap, ok := entry.(map[string]any)
 	if !ok {
-		result.Errors = append(result.Errors,
-			fmt.Sprintf("workspaces.%s: invalid format, must be a map", name))
 		return
 	}
 
-	// Check for path or url
+	// Check for path or url (a template may provide them)
 	_, hasPath := wsMap["path"]
 	_, hasURL := wsMap["url"]
-	if !hasPath && !hasURL {
+	_, hasTemplate := wsMap["template"]
+	if !hasPath && !hasURL && !hasTemplate {
 		result.Suggestions = append(result.Suggestions,
 			fmt.Sprintf("workspaces.%s: consider adding 'path' or 'url' field", name))
 	}
-
-	// Check type field
-	if wsType, ok := wsMap["type"].(string); ok {
-		validTypes := []string{"git", "config", "forge"}
-		isValid := slices.Contains(validTypes, wsType)
-		if !isValid {
-			result.Warnings = append(result.Warnings,
-				fmt.Sprintf("workspaces.%s: unknown type '%s', expected git, config, or forge", name, wsType))
-		}
-	}
 }
 
 // printValidationResult prints the validation result to the writer.
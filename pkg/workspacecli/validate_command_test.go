@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -572,6 +573,47 @@ core:
 	}
 }
 
+// TestValidateCmd_SchemaPositions tests that schema violations are reported
+// at their line and column, and unknown keys only as warnings.
+func TestValidateCmd_SchemaPositions(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".gz-git.yaml")
+
+	config := `version: 1
+kind: workspace
+parallell: 4
+workspaces:
+  devbox:
+    path: ~/devbox
+    type: svn
+`
+	if err := os.WriteFile(configPath, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	factory := CommandFactory{}
+	cmd := factory.newValidateCmd()
+
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+
+	cmd.SetArgs([]string{"-c", configPath})
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected error for invalid workspace type")
+	}
+
+	output := buf.String()
+	errors, warnings, _ := strings.Cut(output, "Warnings:")
+	wantError := configPath + `:7:11: workspaces.devbox.type: "svn" is not one of: forge, git, config`
+	if !strings.Contains(errors, wantError) {
+		t.Errorf("expected error %q, output: %s", wantError, output)
+	}
+	wantWarning := configPath + ":3:1: parallell: unknown key (ignored)"
+	if !strings.Contains(warnings, wantWarning) {
+		t.Errorf("expected warning %q, output: %s", wantWarning, output)
+	}
+}
+
 // TestValidateCmd_CloneConfigInvalidStrategy tests invalid strategy in clone config.
 func TestValidateCmd_CloneConfigInvalidStrategy(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -821,3 +863,32 @@ func TestValidationResult(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateConfigFile_OutdatedFormat(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".gz-git.yaml")
+	legacy := "kind: repository\nrepositories:\n  - url: https://github.com/org/api.git\n    targetPath: ./api\n"
+	if err := os.WriteFile(path, []byte(legacy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := validateConfigFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "outdated config format (2 change(s) to version 1): run 'gz-git config migrate --write " + path + "'"
+	if !slices.Contains(result.Warnings, want) {
+		t.Errorf("warnings = %q, want %q", result.Warnings, want)
+	}
+
+	if err := os.WriteFile(path, []byte("version: 0\nkind: repositories\nrepositories: []\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	result, err = validateConfigFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(result.Warnings, "version 0 is outdated (current: 1)") {
+		t.Errorf("warnings = %q", result.Warnings)
+	}
+}